@@ -60,6 +60,14 @@ type AddressManager struct {
 	subnetworkNewAddressCounts        map[subnetworkid.SubnetworkID]int
 	subnetworkTriedAddresBucketArrays map[subnetworkid.SubnetworkID]*triedAddressBucketArray
 	subnetworkTriedAddressCounts      map[subnetworkid.SubnetworkID]int
+
+	netGrouper NetGrouper
+
+	// dirtyAddresses holds the keys of all the addresses that were
+	// added, changed or removed since they were last saved to the
+	// database
+	dirtyAddresses      map[AddressKey]struct{}
+	isLegacyStateLoaded bool
 }
 
 type serializedKnownAddress struct {
@@ -75,6 +83,15 @@ type serializedKnownAddress struct {
 	// no refcount or tried, that is available from context.
 }
 
+// storedKnownAddress is the data model that is used to store a single
+// known address in the database. Unlike in PeersStateForSerialization,
+// bucket membership isn't stored. It is instead recomputed on load,
+// which also rebuckets all addresses whenever the NetGrouper changes.
+type storedKnownAddress struct {
+	KnownAddress *serializedKnownAddress
+	Tried        bool
+}
+
 type serializedNewAddressBucketArray [NewBucketCount][]AddressKey
 type serializedTriedAddressBucketArray [TriedBucketCount][]AddressKey
 
@@ -192,7 +209,17 @@ const (
 var ErrAddressNotFound = errors.New("address not found")
 
 // New returns a new Kaspa address manager.
-func New(cfg *config.Config, databaseContext *dbaccess.DatabaseContext) *AddressManager {
+func New(cfg *config.Config, databaseContext *dbaccess.DatabaseContext) (*AddressManager, error) {
+	var netGrouper NetGrouper = PrefixNetGrouper{}
+	if cfg.ASMap != "" {
+		asMap, err := LoadASMap(cfg.ASMap)
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Loaded %d prefixes from asmap file %s", asMap.Len(), cfg.ASMap)
+		netGrouper = NewASNNetGrouper(asMap, netGrouper)
+	}
+
 	addressManager := AddressManager{
 		cfg:               cfg,
 		databaseContext:   databaseContext,
@@ -201,9 +228,16 @@ func New(cfg *config.Config, databaseContext *dbaccess.DatabaseContext) *Address
 		quit:              make(chan struct{}),
 		localAddresses:    make(map[AddressKey]*localAddress),
 		localSubnetworkID: cfg.SubnetworkID,
+		netGrouper:        netGrouper,
 	}
 	addressManager.reset()
-	return &addressManager
+	return &addressManager, nil
+}
+
+// markDirty marks the given address to be saved to the
+// database the next time peers are saved
+func (am *AddressManager) markDirty(addressKey AddressKey) {
+	am.dirtyAddresses[addressKey] = struct{}{}
 }
 
 // updateAddress is a helper function to either update an address already known
@@ -218,6 +252,8 @@ func (am *AddressManager) updateAddress(netAddress, sourceAddress *domainmessage
 	addressKey := NetAddressKey(netAddress)
 	knownAddress := am.knownAddress(netAddress)
 	if knownAddress != nil {
+		am.markDirty(addressKey)
+
 		// TODO: only update addresses periodically.
 		// Update the last seen time and services.
 		// note that to prevent causing excess garbage on getaddr
@@ -258,6 +294,7 @@ func (am *AddressManager) updateAddress(netAddress, sourceAddress *domainmessage
 		knownAddress = &KnownAddress{netAddress: &netAddressCopy, sourceAddress: sourceAddress, subnetworkID: subnetworkID}
 		am.addressIndex[addressKey] = knownAddress
 		am.incrementNewAddressCount(subnetworkID)
+		am.markDirty(addressKey)
 	}
 
 	// Already exists?
@@ -331,6 +368,7 @@ func (am *AddressManager) expireNew(subnetworkID *subnetworkid.SubnetworkID, buc
 			if knownAddress.referenceCount == 0 {
 				am.decrementNewAddressCount(subnetworkID)
 				delete(am.addressIndex, addressKey)
+				am.markDirty(addressKey)
 			}
 			continue
 		}
@@ -350,6 +388,7 @@ func (am *AddressManager) expireNew(subnetworkID *subnetworkid.SubnetworkID, buc
 		if oldest.referenceCount == 0 {
 			am.decrementNewAddressCount(subnetworkID)
 			delete(am.addressIndex, addressKey)
+			am.markDirty(addressKey)
 		}
 	}
 }
@@ -438,33 +477,136 @@ out:
 	log.Trace("Address handler done")
 }
 
-// savePeers saves all the known addresses to the database so they can be read back
-// in at next run.
+// savePeers saves all the known addresses that changed since the last save
+// to the database so they can be read back in at next run.
 func (am *AddressManager) savePeers() error {
-	serializedPeersState, err := am.serializePeersState()
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	if len(am.dirtyAddresses) == 0 && !am.isLegacyStateLoaded {
+		return nil
+	}
+
+	dbTx, err := am.databaseContext.NewTx()
 	if err != nil {
 		return err
 	}
+	defer dbTx.RollbackUnlessClosed()
 
-	return dbaccess.StorePeersState(am.databaseContext, serializedPeersState)
-}
+	if am.isLegacyStateLoaded {
+		err := dbaccess.StorePeersBucketingKey(dbTx, am.key[:])
+		if err != nil {
+			return err
+		}
+		err = dbaccess.RemovePeersState(dbTx)
+		if err != nil {
+			return err
+		}
+	}
+
+	for addressKey := range am.dirtyAddresses {
+		knownAddress, ok := am.addressIndex[addressKey]
+		if !ok {
+			err := dbaccess.RemovePeerAddress(dbTx, []byte(addressKey))
+			if err != nil {
+				return err
+			}
+			continue
+		}
 
-func (am *AddressManager) serializePeersState() ([]byte, error) {
-	peersState, err := am.PeersStateForSerialization()
+		serializedAddress, err := serializeStoredKnownAddress(addressKey, knownAddress)
+		if err != nil {
+			return err
+		}
+		err = dbaccess.StorePeerAddress(dbTx, []byte(addressKey), serializedAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = dbTx.Commit()
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	log.Debugf("Saved %d changed addresses to the database", len(am.dirtyAddresses))
+	am.dirtyAddresses = make(map[AddressKey]struct{})
+	am.isLegacyStateLoaded = false
+	return nil
+}
+
+func serializeStoredKnownAddress(addressKey AddressKey, knownAddress *KnownAddress) ([]byte, error) {
+	storedAddress := &storedKnownAddress{
+		KnownAddress: serializeKnownAddress(addressKey, knownAddress),
+		Tried:        knownAddress.tried,
 	}
 
 	buffer := &bytes.Buffer{}
 	encoder := gob.NewEncoder(buffer)
-	err = encoder.Encode(&peersState)
+	err := encoder.Encode(storedAddress)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to encode peers state")
+		return nil, errors.Wrapf(err, "failed to encode address %s", addressKey)
 	}
-
 	return buffer.Bytes(), nil
 }
 
+func deserializeStoredKnownAddress(serializedAddress []byte) (*storedKnownAddress, error) {
+	storedAddress := &storedKnownAddress{}
+	decoder := gob.NewDecoder(bytes.NewReader(serializedAddress))
+	err := decoder.Decode(storedAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode stored address")
+	}
+	return storedAddress, nil
+}
+
+func serializeKnownAddress(addressKey AddressKey, knownAddress *KnownAddress) *serializedKnownAddress {
+	serializedAddress := new(serializedKnownAddress)
+	serializedAddress.Address = addressKey
+	if knownAddress.subnetworkID == nil {
+		serializedAddress.SubnetworkID = ""
+	} else {
+		serializedAddress.SubnetworkID = knownAddress.subnetworkID.String()
+	}
+	serializedAddress.TimeStamp = knownAddress.netAddress.Timestamp.UnixMilliseconds()
+	serializedAddress.SourceAddress = NetAddressKey(knownAddress.sourceAddress)
+	serializedAddress.Attempts = knownAddress.attempts
+	serializedAddress.LastAttempt = knownAddress.lastAttempt.UnixMilliseconds()
+	serializedAddress.LastSuccess = knownAddress.lastSuccess.UnixMilliseconds()
+	serializedAddress.IsBanned = knownAddress.isBanned
+	serializedAddress.BannedTime = knownAddress.bannedTime.UnixMilliseconds()
+	return serializedAddress
+}
+
+func (am *AddressManager) deserializeKnownAddress(serializedAddress *serializedKnownAddress) (*KnownAddress, error) {
+	var err error
+	knownAddress := new(KnownAddress)
+	knownAddress.netAddress, err = am.DeserializeNetAddress(serializedAddress.Address)
+	if err != nil {
+		return nil, errors.Errorf("failed to deserialize netaddress "+
+			"%s: %s", serializedAddress.Address, err)
+	}
+	knownAddress.netAddress.Timestamp = mstime.UnixMilliseconds(serializedAddress.TimeStamp)
+	knownAddress.sourceAddress, err = am.DeserializeNetAddress(serializedAddress.SourceAddress)
+	if err != nil {
+		return nil, errors.Errorf("failed to deserialize netaddress "+
+			"%s: %s", serializedAddress.SourceAddress, err)
+	}
+	if serializedAddress.SubnetworkID != "" {
+		knownAddress.subnetworkID, err = subnetworkid.NewFromStr(serializedAddress.SubnetworkID)
+		if err != nil {
+			return nil, errors.Errorf("failed to deserialize subnetwork id "+
+				"%s: %s", serializedAddress.SubnetworkID, err)
+		}
+	}
+	knownAddress.attempts = serializedAddress.Attempts
+	knownAddress.lastAttempt = mstime.UnixMilliseconds(serializedAddress.LastAttempt)
+	knownAddress.lastSuccess = mstime.UnixMilliseconds(serializedAddress.LastSuccess)
+	knownAddress.isBanned = serializedAddress.IsBanned
+	knownAddress.bannedTime = mstime.UnixMilliseconds(serializedAddress.BannedTime)
+	return knownAddress, nil
+}
+
 // PeersStateForSerialization returns the data model that is used to serialize the peers state to any encoding.
 func (am *AddressManager) PeersStateForSerialization() (*PeersStateForSerialization, error) {
 	am.mutex.Lock()
@@ -479,23 +621,9 @@ func (am *AddressManager) PeersStateForSerialization() (*PeersStateForSerializat
 	peersState.Addresses = make([]*serializedKnownAddress, len(am.addressIndex))
 	i := 0
 	for addressKey, knownAddress := range am.addressIndex {
-		serializedAddress := new(serializedKnownAddress)
-		serializedAddress.Address = addressKey
-		if knownAddress.subnetworkID == nil {
-			serializedAddress.SubnetworkID = ""
-		} else {
-			serializedAddress.SubnetworkID = knownAddress.subnetworkID.String()
-		}
-		serializedAddress.TimeStamp = knownAddress.netAddress.Timestamp.UnixMilliseconds()
-		serializedAddress.SourceAddress = NetAddressKey(knownAddress.sourceAddress)
-		serializedAddress.Attempts = knownAddress.attempts
-		serializedAddress.LastAttempt = knownAddress.lastAttempt.UnixMilliseconds()
-		serializedAddress.LastSuccess = knownAddress.lastSuccess.UnixMilliseconds()
-		serializedAddress.IsBanned = knownAddress.isBanned
-		serializedAddress.BannedTime = knownAddress.bannedTime.UnixMilliseconds()
 		// Tried and referenceCount are implicit in the rest of the structure
 		// and will be worked out from context on unserialisation.
-		peersState.Addresses[i] = serializedAddress
+		peersState.Addresses[i] = serializeKnownAddress(addressKey, knownAddress)
 		i++
 	}
 
@@ -556,10 +684,80 @@ func (am *AddressManager) loadPeers() error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
+	bucketingKey, err := dbaccess.FetchPeersBucketingKey(am.databaseContext)
+	if dbaccess.IsNotFoundError(err) {
+		return am.loadLegacyPeersState()
+	}
+	if err != nil {
+		return err
+	}
+	copy(am.key[:], bucketingKey)
+
+	cursor, err := dbaccess.PeerAddressesCursor(am.databaseContext)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		serializedAddress, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		storedAddress, err := deserializeStoredKnownAddress(serializedAddress)
+		if err != nil {
+			return err
+		}
+		knownAddress, err := am.deserializeKnownAddress(storedAddress.KnownAddress)
+		if err != nil {
+			return err
+		}
+		am.addLoadedAddress(knownAddress, storedAddress.Tried)
+	}
+
+	log.Infof("Loaded %d addresses from database", am.totalNumAddresses())
+	return nil
+}
+
+// addLoadedAddress puts an address that was loaded from the database
+// into the bucket it belongs to under the current NetGrouper
+func (am *AddressManager) addLoadedAddress(knownAddress *KnownAddress, tried bool) {
+	addressKey := NetAddressKey(knownAddress.netAddress)
+	am.addressIndex[addressKey] = knownAddress
+
+	if tried {
+		triedAddressBucketIndex := am.triedAddressBucketIndex(knownAddress.netAddress)
+		triedAddressBucketArray := am.triedAddressBucketArray(knownAddress.subnetworkID)
+		if triedAddressBucketArray == nil || len(triedAddressBucketArray[triedAddressBucketIndex]) < triedBucketSize {
+			knownAddress.tried = true
+			am.updateAddrTried(triedAddressBucketIndex, knownAddress)
+			am.incrementTriedAddressCount(knownAddress.subnetworkID)
+			return
+		}
+		// The tried bucket is already full, which can happen if
+		// addresses were rebucketed. Demote the address to new.
+		am.markDirty(addressKey)
+	}
+
+	knownAddress.referenceCount = 1
+	am.updateAddrNew(am.newAddressBucketIndex(knownAddress.netAddress, knownAddress.sourceAddress),
+		addressKey, knownAddress)
+	am.incrementNewAddressCount(knownAddress.subnetworkID)
+}
+
+// loadLegacyPeersState loads the known addresses from the peers state
+// blob that was used before addresses were stored separately. The
+// addresses are moved to their new location the next time peers are
+// saved. If there's no such blob, just start fresh.
+func (am *AddressManager) loadLegacyPeersState() error {
 	serializedPeerState, err := dbaccess.FetchPeersState(am.databaseContext)
 	if dbaccess.IsNotFoundError(err) {
 		am.reset()
-		log.Info("No peers state was found in the database. Created a new one", am.totalNumAddresses())
+		err := dbaccess.StorePeersBucketingKey(am.databaseContext, am.key[:])
+		if err != nil {
+			return err
+		}
+		log.Info("No peers state was found in the database. Created a new one")
 		return nil
 	}
 	if err != nil {
@@ -570,8 +768,12 @@ func (am *AddressManager) loadPeers() error {
 	if err != nil {
 		return err
 	}
+	for addressKey := range am.addressIndex {
+		am.markDirty(addressKey)
+	}
+	am.isLegacyStateLoaded = true
 
-	log.Infof("Loaded %d addresses from database", am.totalNumAddresses())
+	log.Infof("Loaded %d addresses from the legacy peers state", am.totalNumAddresses())
 	return nil
 }
 
@@ -591,29 +793,10 @@ func (am *AddressManager) deserializePeersState(serializedPeerState []byte) erro
 	copy(am.key[:], peersState.Key[:])
 
 	for _, serializedKnownAddress := range peersState.Addresses {
-		knownAddress := new(KnownAddress)
-		knownAddress.netAddress, err = am.DeserializeNetAddress(serializedKnownAddress.Address)
-		if err != nil {
-			return errors.Errorf("failed to deserialize netaddress "+
-				"%s: %s", serializedKnownAddress.Address, err)
-		}
-		knownAddress.sourceAddress, err = am.DeserializeNetAddress(serializedKnownAddress.SourceAddress)
+		knownAddress, err := am.deserializeKnownAddress(serializedKnownAddress)
 		if err != nil {
-			return errors.Errorf("failed to deserialize netaddress "+
-				"%s: %s", serializedKnownAddress.SourceAddress, err)
-		}
-		if serializedKnownAddress.SubnetworkID != "" {
-			knownAddress.subnetworkID, err = subnetworkid.NewFromStr(serializedKnownAddress.SubnetworkID)
-			if err != nil {
-				return errors.Errorf("failed to deserialize subnetwork id "+
-					"%s: %s", serializedKnownAddress.SubnetworkID, err)
-			}
+			return err
 		}
-		knownAddress.attempts = serializedKnownAddress.Attempts
-		knownAddress.lastAttempt = mstime.UnixMilliseconds(serializedKnownAddress.LastAttempt)
-		knownAddress.lastSuccess = mstime.UnixMilliseconds(serializedKnownAddress.LastSuccess)
-		knownAddress.isBanned = serializedKnownAddress.IsBanned
-		knownAddress.bannedTime = mstime.UnixMilliseconds(serializedKnownAddress.BannedTime)
 		am.addressIndex[NetAddressKey(knownAddress.netAddress)] = knownAddress
 	}
 
@@ -885,7 +1068,13 @@ func (am *AddressManager) AddressCache(includeAllSubnetworks bool, subnetworkID
 // reset resets the address manager by reinitialising the random source
 // and allocating fresh empty bucket storage.
 func (am *AddressManager) reset() {
+	for addressKey := range am.addressIndex {
+		am.markDirty(addressKey)
+	}
 	am.addressIndex = make(map[AddressKey]*KnownAddress)
+	if am.dirtyAddresses == nil {
+		am.dirtyAddresses = make(map[AddressKey]struct{})
+	}
 
 	// fill key with bytes from a good random source.
 	io.ReadFull(crand.Reader, am.key[:])
@@ -1050,6 +1239,7 @@ func (am *AddressManager) Attempt(address *domainmessage.NetAddress) {
 	// set last tried time to now
 	knownAddress.attempts++
 	knownAddress.lastAttempt = mstime.Now()
+	am.markDirty(NetAddressKey(address))
 }
 
 // Connected Marks the given address as currently connected and working at the
@@ -1072,6 +1262,7 @@ func (am *AddressManager) Connected(address *domainmessage.NetAddress) {
 		netAddressCopy := *knownAddress.netAddress
 		netAddressCopy.Timestamp = mstime.Now()
 		knownAddress.netAddress = &netAddressCopy
+		am.markDirty(NetAddressKey(address))
 	}
 }
 
@@ -1097,6 +1288,7 @@ func (am *AddressManager) Good(address *domainmessage.NetAddress, subnetworkID *
 	knownAddress.subnetworkID = subnetworkID
 
 	addressKey := NetAddressKey(address)
+	am.markDirty(addressKey)
 	triedAddressBucketIndex := am.triedAddressBucketIndex(knownAddress.netAddress)
 
 	if knownAddress.tried {
@@ -1194,6 +1386,7 @@ func (am *AddressManager) Good(address *domainmessage.NetAddress, subnetworkID *
 	am.incrementNewAddressCount(knownAddress.subnetworkID)
 
 	knownAddressToRemoveKey := NetAddressKey(knownAddressToRemove.netAddress)
+	am.markDirty(knownAddressToRemoveKey)
 	log.Tracef("Replacing %s with %s in tried", knownAddressToRemoveKey, addressKey)
 
 	// We made sure there is space here just above.
@@ -1394,8 +1587,8 @@ func (am *AddressManager) Unban(address *domainmessage.NetAddress) error {
 }
 
 func (am *AddressManager) setBanned(address *domainmessage.NetAddress, isBanned bool, bannedTime mstime.Time) error {
-	am.localAddressesLock.Lock()
-	defer am.localAddressesLock.Unlock()
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
 
 	knownAddress := am.knownAddress(address)
 	if knownAddress == nil {
@@ -1404,13 +1597,14 @@ func (am *AddressManager) setBanned(address *domainmessage.NetAddress, isBanned
 	}
 	knownAddress.isBanned = isBanned
 	knownAddress.bannedTime = bannedTime
+	am.markDirty(NetAddressKey(address))
 	return nil
 }
 
 // IsBanned returns whether the given address is banned
 func (am *AddressManager) IsBanned(address *domainmessage.NetAddress) (bool, error) {
-	am.localAddressesLock.Lock()
-	defer am.localAddressesLock.Unlock()
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
 
 	knownAddress := am.knownAddress(address)
 	if knownAddress == nil {
@@ -124,7 +124,10 @@ func newAddrManagerForTest(t *testing.T, testName string,
 		t.Fatalf("error creating db: %s", err)
 	}
 
-	addressManager = New(cfg, databaseContext)
+	addressManager, err = New(cfg, databaseContext)
+	if err != nil {
+		t.Fatalf("error creating address manager: %s", err)
+	}
 
 	return addressManager, func() {
 		err := databaseContext.Close()
@@ -147,6 +150,83 @@ func TestStartStop(t *testing.T) {
 	}
 }
 
+func TestSaveAndLoadPeers(t *testing.T) {
+	amgr, teardown := newAddrManagerForTest(t, "TestSaveAndLoadPeers", nil)
+	defer teardown()
+	err := amgr.Start()
+	if err != nil {
+		t.Fatalf("Address Manager failed to start: %v", err)
+	}
+
+	srcAddr := domainmessage.NewNetAddressIPPort(net.IPv4(173, 144, 173, 111), 8333, 0)
+	newAddr := domainmessage.NewNetAddressIPPort(net.IPv4(173, 144, 173, 112), 8333, 0)
+	triedAddr := domainmessage.NewNetAddressIPPort(net.IPv4(174, 144, 173, 113), 8333, 0)
+	bannedAddr := domainmessage.NewNetAddressIPPort(net.IPv4(175, 144, 173, 114), 8333, 0)
+	amgr.AddAddresses([]*domainmessage.NetAddress{newAddr, triedAddr, bannedAddr}, srcAddr, nil)
+	amgr.Good(triedAddr, nil)
+	err = amgr.Ban(bannedAddr)
+	if err != nil {
+		t.Fatalf("Ban: unexpected error: %s", err)
+	}
+
+	err = amgr.savePeers()
+	if err != nil {
+		t.Fatalf("savePeers: unexpected error: %s", err)
+	}
+	if len(amgr.dirtyAddresses) != 0 {
+		t.Fatalf("savePeers: expected no dirty addresses after saving, got %d", len(amgr.dirtyAddresses))
+	}
+
+	// Only changed addresses are marked to be saved
+	amgr.Attempt(newAddr)
+	if _, ok := amgr.dirtyAddresses[NetAddressKey(newAddr)]; !ok || len(amgr.dirtyAddresses) != 1 {
+		t.Fatalf("Attempt: expected exactly the attempted address to be dirty, got %v", amgr.dirtyAddresses)
+	}
+	err = amgr.savePeers()
+	if err != nil {
+		t.Fatalf("savePeers: unexpected error: %s", err)
+	}
+
+	loadedAmgr, err := New(amgr.cfg, amgr.databaseContext)
+	if err != nil {
+		t.Fatalf("New: unexpected error: %s", err)
+	}
+	err = loadedAmgr.loadPeers()
+	if err != nil {
+		t.Fatalf("loadPeers: unexpected error: %s", err)
+	}
+
+	if loadedAmgr.key != amgr.key {
+		t.Errorf("loadPeers: expected the bucketing key to be loaded")
+	}
+	if loadedAmgr.TotalNumAddresses() != 3 {
+		t.Fatalf("loadPeers: expected 3 addresses, got %d", loadedAmgr.TotalNumAddresses())
+	}
+	if loadedAmgr.fullNodeTriedAddressCount != 1 || loadedAmgr.fullNodeNewAddressCount != 2 {
+		t.Errorf("loadPeers: expected 1 tried and 2 new addresses, got %d and %d",
+			loadedAmgr.fullNodeTriedAddressCount, loadedAmgr.fullNodeNewAddressCount)
+	}
+	if !loadedAmgr.knownAddress(triedAddr).tried {
+		t.Errorf("loadPeers: expected %s to be tried", triedAddr.IP)
+	}
+	if loadedAmgr.knownAddress(newAddr).attempts != 1 {
+		t.Errorf("loadPeers: expected %s to have 1 attempt, got %d",
+			newAddr.IP, loadedAmgr.knownAddress(newAddr).attempts)
+	}
+	isBanned, err := loadedAmgr.IsBanned(bannedAddr)
+	if err != nil {
+		t.Fatalf("IsBanned: unexpected error: %s", err)
+	}
+	if !isBanned {
+		t.Errorf("loadPeers: expected %s to be banned", bannedAddr.IP)
+	}
+
+	err = amgr.Stop()
+	if err != nil {
+		t.Fatalf("Address Manager failed to stop: %v", err)
+	}
+}
+
 func TestAddAddressByIP(t *testing.T) {
 	fmtErr := errors.Errorf("")
 	addrErr := &net.AddrError{}
@@ -0,0 +1,125 @@
+package addressmanager
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ASMap maps IP prefixes to the numbers of the autonomous systems that
+// announce them.
+//
+// An asmap file is a text file with one mapping per line, of the form
+// <prefix> <ASN>, for example:
+//
+//	1.0.0.0/24 AS13335
+//	2001:200::/32 2500
+//
+// Empty lines and lines starting with '#' are ignored. Prefixes may
+// overlap, in which case the longest matching prefix wins.
+type ASMap struct {
+	// prefixLengths holds every prefix length in the map, in
+	// descending order. All prefixes are kept in their 16-byte
+	// IPv6 form, so IPv4 prefix lengths are offset by 96.
+	prefixLengths []int
+	prefixes      map[int]map[string]uint32
+}
+
+// LoadASMap loads an ASMap from the given asmap file
+func LoadASMap(path string) (*ASMap, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer file.Close()
+
+	asMap, err := ParseASMap(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing asmap file %s", path)
+	}
+	return asMap, nil
+}
+
+// ParseASMap parses an ASMap in the asmap file format out of reader
+func ParseASMap(reader io.Reader) (*ASMap, error) {
+	asMap := &ASMap{prefixes: make(map[int]map[string]uint32)}
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("line %d: expected <prefix> <ASN>, got '%s'", lineNumber, line)
+		}
+		_, ipNet, err := net.ParseCIDR(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		asnString := strings.TrimPrefix(strings.ToUpper(fields[1]), "AS")
+		asn, err := strconv.ParseUint(asnString, 10, 32)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: invalid ASN '%s'", lineNumber, fields[1])
+		}
+
+		asMap.add(ipNet, uint32(asn))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return asMap, nil
+}
+
+func (m *ASMap) add(ipNet *net.IPNet, asn uint32) {
+	prefixLength, bits := ipNet.Mask.Size()
+	if bits == net.IPv4len*8 {
+		prefixLength += (net.IPv6len - net.IPv4len) * 8
+	}
+
+	prefixesOfLength, ok := m.prefixes[prefixLength]
+	if !ok {
+		prefixesOfLength = make(map[string]uint32)
+		m.prefixes[prefixLength] = prefixesOfLength
+
+		m.prefixLengths = append(m.prefixLengths, prefixLength)
+		sort.Sort(sort.Reverse(sort.IntSlice(m.prefixLengths)))
+	}
+	prefixesOfLength[string(maskIPv6(ipNet.IP, prefixLength))] = asn
+}
+
+// ASN returns the number of the autonomous system that announces the
+// given IP address, and false if the address is not in the map
+func (m *ASMap) ASN(ip net.IP) (uint32, bool) {
+	for _, prefixLength := range m.prefixLengths {
+		asn, ok := m.prefixes[prefixLength][string(maskIPv6(ip, prefixLength))]
+		if ok {
+			return asn, true
+		}
+	}
+	return 0, false
+}
+
+// Len returns the number of prefixes in the map
+func (m *ASMap) Len() int {
+	length := 0
+	for _, prefixesOfLength := range m.prefixes {
+		length += len(prefixesOfLength)
+	}
+	return length
+}
+
+func maskIPv6(ip net.IP, prefixLength int) net.IP {
+	return ip.To16().Mask(net.CIDRMask(prefixLength, net.IPv6len*8))
+}
@@ -0,0 +1,71 @@
+package addressmanager
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestParseASMap(t *testing.T) {
+	asMapString := `
+# A comment
+1.2.0.0/16 AS100
+1.2.3.0/24 AS200
+2001:db8::/32 300
+::ffff:5.6.0.0/112 400
+`
+	asMap, err := ParseASMap(strings.NewReader(asMapString))
+	if err != nil {
+		t.Fatalf("ParseASMap: unexpected error: %s", err)
+	}
+	if asMap.Len() != 4 {
+		t.Fatalf("ParseASMap: expected 4 prefixes, got %d", asMap.Len())
+	}
+
+	tests := []struct {
+		ip          string
+		expectedASN uint32
+		expectedOK  bool
+	}{
+		{ip: "1.2.4.5", expectedASN: 100, expectedOK: true},
+		{ip: "1.2.3.4", expectedASN: 200, expectedOK: true},
+		{ip: "::ffff:1.2.3.4", expectedASN: 200, expectedOK: true},
+		{ip: "2001:db8:1::1", expectedASN: 300, expectedOK: true},
+		{ip: "5.6.7.8", expectedASN: 400, expectedOK: true},
+		{ip: "1.3.0.1", expectedOK: false},
+		{ip: "2001:db9::1", expectedOK: false},
+	}
+	for _, test := range tests {
+		asn, ok := asMap.ASN(net.ParseIP(test.ip))
+		if ok != test.expectedOK {
+			t.Errorf("ASN(%s): expected ok to be %t, got %t", test.ip, test.expectedOK, ok)
+			continue
+		}
+		if asn != test.expectedASN {
+			t.Errorf("ASN(%s): expected %d, got %d", test.ip, test.expectedASN, asn)
+		}
+	}
+}
+
+func TestParseASMapErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		asMap    string
+		errorMsg string
+	}{
+		{name: "missing ASN", asMap: "1.2.0.0/16", errorMsg: "line 1: expected <prefix> <ASN>"},
+		{name: "invalid prefix", asMap: "1.2.0.0 AS100", errorMsg: "line 1"},
+		{name: "invalid ASN", asMap: "\n1.2.0.0/16 ASX", errorMsg: "line 2: invalid ASN"},
+		{name: "ASN overflow", asMap: "1.2.0.0/16 AS4294967296", errorMsg: "line 1: invalid ASN"},
+	}
+	for _, test := range tests {
+		_, err := ParseASMap(strings.NewReader(test.asMap))
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.errorMsg) {
+			t.Errorf("%s: expected error to contain '%s', got '%s'", test.name, test.errorMsg, err)
+		}
+	}
+}
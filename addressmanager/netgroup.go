@@ -0,0 +1,67 @@
+package addressmanager
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+)
+
+// NetGrouper splits routable addresses into network groups. Addresses
+// in the same group are assumed to be possibly controlled by the same
+// entity, so the address manager spreads them over few buckets and the
+// connection manager avoids connecting to many peers of the same group.
+type NetGrouper interface {
+	// NetGroup returns a key identifying the network group of
+	// the given routable address
+	NetGroup(na *domainmessage.NetAddress) string
+}
+
+// PrefixNetGrouper groups addresses by their routing prefix: the /16 for
+// IPv4 and the /32 (/36 for he.net) for IPv6.
+type PrefixNetGrouper struct{}
+
+// NetGroup returns the routing prefix of the given address.
+// This is part of the NetGrouper interface.
+func (PrefixNetGrouper) NetGroup(na *domainmessage.NetAddress) string {
+	ip := routedIP(na)
+	if len(ip) == net.IPv4len {
+		return ip.Mask(net.CIDRMask(16, 32)).String()
+	}
+
+	// OK, so now we know ourselves to be a IPv6 address.
+	// We use /32 for everything, except for Hurricane Electric's
+	// (he.net) IP range, which we use /36 for.
+	bits := 32
+	if heNet.Contains(ip) {
+		bits = 36
+	}
+
+	return ip.Mask(net.CIDRMask(bits, 128)).String()
+}
+
+// ASNNetGrouper groups addresses by the autonomous system that announces
+// them. This makes it expensive for an attacker to fill our buckets and
+// connection slots, since IP ranges in many different autonomous systems
+// are much harder to come by than ranges under many different prefixes.
+//
+// Addresses missing from the ASMap are grouped by the fallback NetGrouper.
+type ASNNetGrouper struct {
+	asMap    *ASMap
+	fallback NetGrouper
+}
+
+// NewASNNetGrouper returns a new ASNNetGrouper over the given ASMap
+func NewASNNetGrouper(asMap *ASMap, fallback NetGrouper) *ASNNetGrouper {
+	return &ASNNetGrouper{asMap: asMap, fallback: fallback}
+}
+
+// NetGroup returns the autonomous system of the given address.
+// This is part of the NetGrouper interface.
+func (g *ASNNetGrouper) NetGroup(na *domainmessage.NetAddress) string {
+	asn, ok := g.asMap.ASN(routedIP(na))
+	if !ok {
+		return g.fallback.NetGroup(na)
+	}
+	return fmt.Sprintf("AS%d", asn)
+}
@@ -0,0 +1,38 @@
+package addressmanager
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+)
+
+func TestASNNetGrouper(t *testing.T) {
+	asMap, err := ParseASMap(strings.NewReader("12.1.0.0/16 AS100\n173.0.0.0/8 AS100\n2602:100::/24 AS200"))
+	if err != nil {
+		t.Fatalf("ParseASMap: unexpected error: %s", err)
+	}
+	grouper := NewASNNetGrouper(asMap, PrefixNetGrouper{})
+
+	tests := []struct {
+		name     string
+		ip       string
+		expected string
+	}{
+		{name: "ipv4 in asmap", ip: "12.1.2.3", expected: "AS100"},
+		{name: "ipv4 in same AS, other prefix", ip: "173.1.2.3", expected: "AS100"},
+		{name: "ipv4 missing from asmap", ip: "196.1.2.3", expected: "196.1.0.0"},
+		{name: "ipv6 rfc3964 with ipv4 encap", ip: "2002:0c01:0203::", expected: "AS100"},
+		{name: "ipv6 rfc4380 toredo ipv4", ip: "2001:0:1234::f3fe:fdfc", expected: "AS100"},
+		{name: "ipv6 in asmap", ip: "2602:100::1", expected: "AS200"},
+		{name: "ipv6 missing from asmap", ip: "2001:470:1f10:a1::2", expected: "2001:470:1000::"},
+	}
+
+	for _, test := range tests {
+		netAddress := domainmessage.NewNetAddressIPPort(net.ParseIP(test.ip), 16111, domainmessage.SFNodeNetwork)
+		if group := grouper.NetGroup(netAddress); group != test.expected {
+			t.Errorf("%s: expected group %s, got %s", test.name, test.expected, group)
+		}
+	}
+}
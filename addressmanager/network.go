@@ -212,8 +212,8 @@ func (am *AddressManager) IsRoutable(na *domainmessage.NetAddress) bool {
 }
 
 // GroupKey returns a string representing the network group an address is part
-// of. This is the /16 for IPv4, the /32 (/36 for he.net) for IPv6, the string
-// "local" for a local address, and the string "unroutable" for an unroutable
+// of, as decided by the address manager's NetGrouper. The string "local" is
+// returned for a local address, and the string "unroutable" for an unroutable
 // address.
 func (am *AddressManager) GroupKey(na *domainmessage.NetAddress) string {
 	if IsLocal(na) {
@@ -222,19 +222,22 @@ func (am *AddressManager) GroupKey(na *domainmessage.NetAddress) string {
 	if !am.IsRoutable(na) {
 		return "unroutable"
 	}
+	return am.netGrouper.NetGroup(na)
+}
+
+// routedIP returns the IP address through which the given address is
+// actually routed. For IPv4 addresses, including ones embedded in IPv6
+// translation and tunneling addresses, this is the 4-byte IPv4 address.
+func routedIP(na *domainmessage.NetAddress) net.IP {
 	if IsIPv4(na) {
-		return na.IP.Mask(net.CIDRMask(16, 32)).String()
+		return na.IP.To4()
 	}
 	if IsRFC6145(na) || IsRFC6052(na) {
 		// last four bytes are the ip address
-		ip := na.IP[12:16]
-		return ip.Mask(net.CIDRMask(16, 32)).String()
+		return na.IP[12:16]
 	}
-
 	if IsRFC3964(na) {
-		ip := na.IP[2:6]
-		return ip.Mask(net.CIDRMask(16, 32)).String()
-
+		return na.IP[2:6]
 	}
 	if IsRFC4380(na) {
 		// teredo tunnels have the last 4 bytes as the v4 address XOR
@@ -243,16 +246,7 @@ func (am *AddressManager) GroupKey(na *domainmessage.NetAddress) string {
 		for i, byte := range na.IP[12:16] {
 			ip[i] = byte ^ 0xff
 		}
-		return ip.Mask(net.CIDRMask(16, 32)).String()
+		return ip
 	}
-
-	// OK, so now we know ourselves to be a IPv6 address.
-	// We use /32 for everything, except for Hurricane Electric's
-	// (he.net) IP range, which we use /36 for.
-	bits := 32
-	if heNet.Contains(na.IP) {
-		bits = 36
-	}
-
-	return na.IP.Mask(net.CIDRMask(bits, 128)).String()
+	return na.IP
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/kaspanet/kaspad/protocol"
 	"github.com/kaspanet/kaspad/rpc"
 	"github.com/kaspanet/kaspad/signal"
+	"github.com/kaspanet/kaspad/stratum"
 	"github.com/kaspanet/kaspad/txscript"
 	"github.com/kaspanet/kaspad/util"
 	"github.com/kaspanet/kaspad/util/panics"
@@ -30,6 +31,7 @@ import (
 type App struct {
 	cfg               *config.Config
 	rpcServer         *rpc.Server
+	stratumServer     *stratum.Server
 	addressManager    *addressmanager.AddressManager
 	protocolManager   *protocol.Manager
 	connectionManager *connmanager.ConnectionManager
@@ -59,6 +61,10 @@ func (a *App) Start() {
 	if !a.cfg.DisableRPC {
 		a.rpcServer.Start()
 	}
+
+	if a.stratumServer != nil {
+		a.stratumServer.Start()
+	}
 }
 
 // Stop gracefully shuts down all the kaspad services.
@@ -78,6 +84,13 @@ func (a *App) Stop() error {
 		log.Errorf("Error stopping the p2p protocol: %+v", err)
 	}
 
+	if a.stratumServer != nil {
+		err := a.stratumServer.Stop()
+		if err != nil {
+			log.Errorf("Error stopping the Stratum server: %+v", err)
+		}
+	}
+
 	// Shutdown the RPC server if it's not disabled.
 	if !a.cfg.DisableRPC {
 		err := a.rpcServer.Stop()
@@ -103,13 +116,19 @@ func New(cfg *config.Config, databaseContext *dbaccess.DatabaseContext, interrup
 		return nil, err
 	}
 
-	txMempool := setupMempool(cfg, dag, sigCache)
+	txMempool, err := setupMempool(cfg, databaseContext, dag, sigCache)
+	if err != nil {
+		return nil, err
+	}
 
 	netAdapter, err := netadapter.NewNetAdapter(cfg)
 	if err != nil {
 		return nil, err
 	}
-	addressManager := addressmanager.New(cfg, databaseContext)
+	addressManager, err := addressmanager.New(cfg, databaseContext)
+	if err != nil {
+		return nil, err
+	}
 
 	connectionManager, err := connmanager.New(cfg, netAdapter, addressManager)
 	if err != nil {
@@ -120,8 +139,13 @@ func New(cfg *config.Config, databaseContext *dbaccess.DatabaseContext, interrup
 	if err != nil {
 		return nil, err
 	}
-	rpcServer, err := setupRPC(
-		cfg, dag, txMempool, sigCache, acceptanceIndex, connectionManager, addressManager, protocolManager)
+	blockTemplateGenerator := setupBlockTemplateGenerator(cfg, txMempool, dag, sigCache)
+	rpcServer, err := setupRPC(cfg, databaseContext, dag, txMempool, blockTemplateGenerator, acceptanceIndex,
+		connectionManager, addressManager, protocolManager)
+	if err != nil {
+		return nil, err
+	}
+	stratumServer, err := setupStratum(cfg, dag, blockTemplateGenerator, protocolManager)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +153,7 @@ func New(cfg *config.Config, databaseContext *dbaccess.DatabaseContext, interrup
 	return &App{
 		cfg:               cfg,
 		rpcServer:         rpcServer,
+		stratumServer:     stratumServer,
 		protocolManager:   protocolManager,
 		connectionManager: connectionManager,
 		netAdapter:        netAdapter,
@@ -158,6 +183,7 @@ func setupDAG(cfg *config.Config, databaseContext *dbaccess.DatabaseContext, int
 		SigCache:        sigCache,
 		IndexManager:    indexManager,
 		SubnetworkID:    cfg.SubnetworkID,
+		AssumeValid:     cfg.AssumeValid,
 	})
 	return dag, err
 }
@@ -180,7 +206,9 @@ func setupIndexes(cfg *config.Config) (blockdag.IndexManager, *indexers.Acceptan
 	return indexManager, acceptanceIndex
 }
 
-func setupMempool(cfg *config.Config, dag *blockdag.BlockDAG, sigCache *txscript.SigCache) *mempool.TxPool {
+func setupMempool(cfg *config.Config, databaseContext *dbaccess.DatabaseContext, dag *blockdag.BlockDAG,
+	sigCache *txscript.SigCache) (*mempool.TxPool, error) {
+
 	mempoolConfig := mempool.Config{
 		Policy: mempool.Policy{
 			AcceptNonStd:    cfg.RelayNonStd,
@@ -188,6 +216,11 @@ func setupMempool(cfg *config.Config, dag *blockdag.BlockDAG, sigCache *txscript
 			MaxOrphanTxSize: config.DefaultMaxOrphanTxSize,
 			MinRelayTxFee:   cfg.MinRelayTxFee,
 			MaxTxVersion:    1,
+
+			MaxAncestorCount:   cfg.MaxAncestors,
+			MaxAncestorMass:    cfg.MaxAncestorMass,
+			MaxDescendantCount: cfg.MaxDescendants,
+			MaxDescendantMass:  cfg.MaxDescendantMass,
 		},
 		CalcSequenceLockNoLock: func(tx *util.Tx, utxoSet blockdag.UTXOSet) (*blockdag.SequenceLock, error) {
 			return dag.CalcSequenceLockNoLock(tx, utxoSet, true)
@@ -195,27 +228,40 @@ func setupMempool(cfg *config.Config, dag *blockdag.BlockDAG, sigCache *txscript
 		IsDeploymentActive: dag.IsDeploymentActive,
 		SigCache:           sigCache,
 		DAG:                dag,
+		DatabaseContext:    databaseContext,
+	}
+
+	txMempool := mempool.New(&mempoolConfig)
+	err := txMempool.LoadFeeDeltas()
+	if err != nil {
+		return nil, err
 	}
+	return txMempool, nil
+}
 
-	return mempool.New(&mempoolConfig)
+func setupBlockTemplateGenerator(cfg *config.Config, txMempool *mempool.TxPool, dag *blockdag.BlockDAG,
+	sigCache *txscript.SigCache) *mining.BlkTmplGenerator {
+
+	policy := mining.Policy{
+		BlockMaxMass:      cfg.BlockMaxMass,
+		BlockVersion:      cfg.BlockVersion,
+		SignalDeployments: cfg.SignalDeployments,
+	}
+	return mining.NewBlkTmplGenerator(&policy, txMempool, dag, sigCache)
 }
 
 func setupRPC(cfg *config.Config,
+	databaseContext *dbaccess.DatabaseContext,
 	dag *blockdag.BlockDAG,
 	txMempool *mempool.TxPool,
-	sigCache *txscript.SigCache,
+	blockTemplateGenerator *mining.BlkTmplGenerator,
 	acceptanceIndex *indexers.AcceptanceIndex,
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	protocolManager *protocol.Manager) (*rpc.Server, error) {
 
 	if !cfg.DisableRPC {
-		policy := mining.Policy{
-			BlockMaxMass: cfg.BlockMaxMass,
-		}
-		blockTemplateGenerator := mining.NewBlkTmplGenerator(&policy, txMempool, dag, sigCache)
-
-		rpcServer, err := rpc.NewRPCServer(cfg, dag, txMempool, acceptanceIndex, blockTemplateGenerator,
+		rpcServer, err := rpc.NewRPCServer(cfg, databaseContext, dag, txMempool, acceptanceIndex, blockTemplateGenerator,
 			connectionManager, addressManager, protocolManager)
 		if err != nil {
 			return nil, err
@@ -232,6 +278,15 @@ func setupRPC(cfg *config.Config,
 	return nil, nil
 }
 
+func setupStratum(cfg *config.Config, dag *blockdag.BlockDAG, blockTemplateGenerator *mining.BlkTmplGenerator,
+	protocolManager *protocol.Manager) (*stratum.Server, error) {
+
+	if len(cfg.StratumListeners) == 0 {
+		return nil, nil
+	}
+	return stratum.NewServer(cfg, dag, blockTemplateGenerator, protocolManager)
+}
+
 // P2PNodeID returns the network ID associated with this App
 func (a *App) P2PNodeID() *id.ID {
 	return a.netAdapter.ID()
@@ -8,28 +8,93 @@ import (
 	"fmt"
 
 	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/domainmessage"
 	"github.com/kaspanet/kaspad/util"
 	"github.com/pkg/errors"
 )
 
+// addNodeToIndexWithInvalidAncestor adds a block that has an invalid parent
+// to the block index, marked as having an invalid ancestor. If all of its
+// parents were connected to the DAG before they became invalid, as is the
+// case when they are manually invalidated, the block is added along with
+// its parents and its body, and to the reachability tree, so that it's
+// reconnected to the DAG if they are reconsidered. Otherwise, only the
+// block's header is kept.
 func (dag *BlockDAG) addNodeToIndexWithInvalidAncestor(block *util.Block) error {
 	blockHeader := &block.MsgBlock().Header
-	newNode, _ := dag.newBlockNode(blockHeader, newBlockSet())
-	newNode.status = statusInvalidAncestor
+	parents, isReconnectable := dag.reconnectableParents(blockHeader)
+	if !isReconnectable {
+		newNode, _ := dag.newBlockNode(blockHeader, newBlockSet())
+		newNode.status = statusInvalidAncestor
+		dag.index.AddNode(newNode)
+
+		dbTx, err := dag.databaseContext.NewTx()
+		if err != nil {
+			return err
+		}
+		defer dbTx.RollbackUnlessClosed()
+		err = dag.index.flushToDB(dbTx)
+		if err != nil {
+			return err
+		}
+		return dbTx.Commit()
+	}
+
+	// Invalid blocks are not the children of their parents,
+	// so the parents' children are left as they are
+	newNode, selectedParentAnticone := dag.newBlockNode(blockHeader, parents)
+	newNode.status = statusDataStored | statusInvalidAncestor
 	dag.index.AddNode(newNode)
+	err := dag.reachabilityTree.addBlock(newNode, selectedParentAnticone)
+	if err != nil {
+		return errors.Wrap(err, "failed adding block to the reachability tree")
+	}
 
 	dbTx, err := dag.databaseContext.NewTx()
 	if err != nil {
 		return err
 	}
 	defer dbTx.RollbackUnlessClosed()
+	blockExists, err := dbaccess.HasBlock(dbTx, block.Hash())
+	if err != nil {
+		return err
+	}
+	if !blockExists {
+		err := storeBlock(dbTx, block)
+		if err != nil {
+			return err
+		}
+	}
 	err = dag.index.flushToDB(dbTx)
 	if err != nil {
 		return err
 	}
+	err = dag.reachabilityTree.storeState(dbTx)
+	if err != nil {
+		return err
+	}
 	return dbTx.Commit()
 }
 
+// reconnectableParents returns the parents of the block with the given
+// header, and whether all of them are in the reachability tree, which is
+// required for the block to be added to it. Blocks that failed validation
+// when they were first received were never added to the reachability tree.
+func (dag *BlockDAG) reconnectableParents(blockHeader *domainmessage.BlockHeader) (blockSet, bool) {
+	parents := newBlockSet()
+	for _, parentHash := range blockHeader.ParentHashes {
+		parent, ok := dag.index.LookupNode(parentHash)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := dag.reachabilityTree.store.reachabilityDataByHash(parentHash); !ok {
+			return nil, false
+		}
+		parents.add(parent)
+	}
+	return parents, true
+}
+
 // maybeAcceptBlock potentially accepts a block into the block DAG. It
 // performs several validation checks which depend on its position within
 // the block DAG before adding it. The block is expected to have already
@@ -112,6 +177,9 @@ func (dag *BlockDAG) maybeAcceptBlock(block *util.Block, flags BehaviorFlags) er
 	if err != nil {
 		return err
 	}
+	if newNode.hash.IsEqual(dag.assumeValid) {
+		dag.assumeValidPast = nil
+	}
 
 	// Notify the caller that the new block was accepted into the block
 	// DAG. The caller would typically want to react by relaying the
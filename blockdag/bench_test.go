@@ -0,0 +1,33 @@
+package blockdag
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// BenchmarkProcessBlock compares the on-disk database types by
+// the time it takes to process a block on top of a chain.
+func BenchmarkProcessBlock(b *testing.B) {
+	for _, dbType := range []string{dbaccess.FFLDBType, dbaccess.FFBoltDBType} {
+		b.Run(dbType, func(b *testing.B) {
+			dag, teardownFunc, err := dagSetupWithDBType(
+				fmt.Sprintf("BenchmarkProcessBlock-%s", dbType), dbType,
+				Config{DAGParams: &dagconfig.SimnetParams})
+			if err != nil {
+				b.Fatalf("Failed to setup DAG instance: %v", err)
+			}
+			defer teardownFunc()
+
+			tipHash := dag.Params.GenesisHash
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				block := PrepareAndProcessBlockForTest(b, dag, []*daghash.Hash{tipHash}, nil)
+				tipHash = block.BlockHash()
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package blockdag
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+// latestKnownCheckpoint returns the node of the latest checkpoint that is
+// already a valid block in the DAG, or nil if no such checkpoint exists.
+//
+// This function MUST be called with the DAG state lock held (for reads).
+func (dag *BlockDAG) latestKnownCheckpoint() *blockNode {
+	checkpoints := dag.Params.Checkpoints
+	for i := len(checkpoints) - 1; i >= 0; i-- {
+		node, ok := dag.index.LookupNode(checkpoints[i].Hash)
+		if ok && dag.index.NodeStatus(node).KnownValid() {
+			return node
+		}
+	}
+	return nil
+}
+
+// checkpointByHash returns the checkpoint with the given hash, or nil
+// if the hash is not of a checkpoint.
+func (dag *BlockDAG) checkpointByHash(hash *daghash.Hash) *dagconfig.Checkpoint {
+	for i := range dag.Params.Checkpoints {
+		if dag.Params.Checkpoints[i].Hash.IsEqual(hash) {
+			return &dag.Params.Checkpoints[i]
+		}
+	}
+	return nil
+}
+
+// checkCheckpoints ensures the new block doesn't conflict with the
+// checkpoints of the network: a checkpoint block must have the blue score
+// of its checkpoint, and once a checkpoint is in the DAG, every new block
+// must have it in its selected parent chain.
+//
+// This function MUST be called with the DAG state lock held (for reads).
+func (dag *BlockDAG) checkCheckpoints(newNode *blockNode) error {
+	if checkpoint := dag.checkpointByHash(newNode.hash); checkpoint != nil &&
+		newNode.blueScore != checkpoint.BlueScore {
+
+		str := fmt.Sprintf("block %s is a checkpoint with blue score %d, "+
+			"but has blue score %d", newNode.hash, checkpoint.BlueScore, newNode.blueScore)
+		return ruleError(ErrBadCheckpoint, str)
+	}
+
+	checkpointNode := dag.latestKnownCheckpoint()
+	if checkpointNode == nil || checkpointNode == newNode || checkpointNode == newNode.selectedParent {
+		return nil
+	}
+
+	// As in checkFinalityViolation, newNode doesn't have
+	// reachability data yet, so the selected parent chain
+	// of its selected parent is checked instead.
+	isInSelectedChain, err := dag.isInSelectedParentChainOf(checkpointNode, newNode.selectedParent)
+	if err != nil {
+		return err
+	}
+	if !isInSelectedChain {
+		str := fmt.Sprintf("the checkpoint %s is not in the selected parent chain of "+
+			"block %s", checkpointNode.hash, newNode.hash)
+		return ruleError(ErrCheckpointConflict, str)
+	}
+	return nil
+}
+
+// isAssumedValid returns whether the scripts of the given block are assumed
+// to be valid, because it is proven to be in the past of the assume-valid
+// block.
+//
+// While the assume-valid block isn't in the DAG yet, a block is proven to be
+// in its past if it was proven by ProveAssumeValidPast, or if the
+// assume-valid block is in the orphan pool and the block is one of its known
+// ancestors. Blocks with a lower blue score are not assumed to be in its
+// past, since they may be on a branch that isn't.
+//
+// This function MUST be called with the DAG state lock held (for reads).
+func (dag *BlockDAG) isAssumedValid(node *blockNode) (bool, error) {
+	if dag.assumeValid == nil {
+		return false, nil
+	}
+
+	assumeValidNode, ok := dag.index.LookupNode(dag.assumeValid)
+	if !ok {
+		if _, ok := dag.assumeValidPast[*node.hash]; ok {
+			return true, nil
+		}
+		return dag.isKnownOrphanAncestor(node.hash, dag.assumeValid), nil
+	}
+
+	// A block that is connected for the first time can't be in the past of
+	// a block that is already in the DAG. Blocks that are connected again,
+	// such as reconsidered blocks, already have reachability data.
+	if node == assumeValidNode {
+		return true, nil
+	}
+	if _, ok := dag.reachabilityTree.store.reachabilityDataByHash(node.hash); !ok {
+		return false, nil
+	}
+	return dag.isInPast(node, assumeValidNode)
+}
+
+// isKnownOrphanAncestor returns whether the block with the given hash is
+// reachable from the given orphan through the parents of orphan blocks.
+// Since every block commits to the hashes of its parents, this proves that
+// the block is in the past of the orphan.
+func (dag *BlockDAG) isKnownOrphanAncestor(hash *daghash.Hash, orphanHash *daghash.Hash) bool {
+	dag.orphanLock.RLock()
+	defer dag.orphanLock.RUnlock()
+
+	visited := make(map[daghash.Hash]struct{})
+	queue := []*daghash.Hash{orphanHash}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		orphan, ok := dag.orphans[*current]
+		if !ok {
+			continue
+		}
+		for _, parentHash := range orphan.block.MsgBlock().Header.ParentHashes {
+			if parentHash.IsEqual(hash) {
+				return true
+			}
+			if _, ok := visited[*parentHash]; ok {
+				continue
+			}
+			visited[*parentHash] = struct{}{}
+			queue = append(queue, parentHash)
+		}
+	}
+	return false
+}
+
+// AssumeValidToProve returns the hash of the assume-valid block if its past
+// should be proven with ProveAssumeValidPast, or nil if no assume-valid block
+// is set, it's already in the DAG, or its past was already proven.
+//
+// This function is safe for concurrent access.
+func (dag *BlockDAG) AssumeValidToProve() *daghash.Hash {
+	dag.dagLock.RLock()
+	defer dag.dagLock.RUnlock()
+
+	if dag.assumeValid == nil || dag.assumeValidPast != nil || dag.index.HaveBlock(dag.assumeValid) {
+		return nil
+	}
+	return dag.assumeValid
+}
+
+// ProveAssumeValidPast proves which blocks are in the past of the
+// assume-valid block from the given headers, which must include the header
+// of the assume-valid block and of every block in its past that isn't in the
+// DAG yet. Since every header commits to the hashes of its parents, the
+// blocks reached from the assume-valid block through these headers are
+// proven to be in its past, so their scripts aren't validated when they are
+// connected before the assume-valid block itself.
+//
+// This function is safe for concurrent access.
+func (dag *BlockDAG) ProveAssumeValidPast(headers []*domainmessage.BlockHeader) error {
+	dag.dagLock.Lock()
+	defer dag.dagLock.Unlock()
+
+	if dag.assumeValid == nil {
+		return errors.New("no assume-valid block is set")
+	}
+
+	headersByHash := make(map[daghash.Hash]*domainmessage.BlockHeader, len(headers))
+	for _, header := range headers {
+		headersByHash[*header.BlockHash()] = header
+	}
+
+	past := make(map[daghash.Hash]struct{})
+	queue := []*daghash.Hash{dag.assumeValid}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		header, ok := headersByHash[*current]
+		if !ok {
+			return errors.Errorf("the header of block %s, which is in the past of "+
+				"the assume-valid block %s, is missing", current, dag.assumeValid)
+		}
+		for _, parentHash := range header.ParentHashes {
+			if _, ok := past[*parentHash]; ok || dag.index.HaveBlock(parentHash) {
+				continue
+			}
+			past[*parentHash] = struct{}{}
+			queue = append(queue, parentHash)
+		}
+	}
+	dag.assumeValidPast = past
+	return nil
+}
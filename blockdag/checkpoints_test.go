@@ -0,0 +1,346 @@
+package blockdag
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/txscript"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+func TestCheckpoints(t *testing.T) {
+	// Load up blocks such that there is a fork in the DAG.
+	// (genesis block) -> 1 -> 2 -> 3 -> 4
+	//                          \-> 3b
+	var blocks []*util.Block
+	for _, file := range []string{"blk_0_to_4.dat", "blk_3B.dat"} {
+		blockTmp, err := LoadBlocks(filepath.Join("testdata/", file))
+		if err != nil {
+			t.Fatalf("Error loading file: %v\n", err)
+		}
+		blocks = append(blocks, blockTmp...)
+	}
+	block3, block4, block3B := blocks[3], blocks[4], blocks[5]
+
+	tests := []struct {
+		name              string
+		checkpoints       func(block3BlueScore uint64) []dagconfig.Checkpoint
+		expectedErrorCode map[*util.Block]ErrorCode
+	}{
+		{
+			name: "conflicting branch",
+			checkpoints: func(block3BlueScore uint64) []dagconfig.Checkpoint {
+				return []dagconfig.Checkpoint{{BlueScore: block3BlueScore, Hash: block3.Hash()}}
+			},
+			expectedErrorCode: map[*util.Block]ErrorCode{block3B: ErrCheckpointConflict},
+		},
+		{
+			name: "unexpected checkpoint blue score",
+			checkpoints: func(block3BlueScore uint64) []dagconfig.Checkpoint {
+				return []dagconfig.Checkpoint{
+					{BlueScore: block3BlueScore, Hash: block3.Hash()},
+					{BlueScore: block3BlueScore, Hash: block4.Hash()},
+				}
+			},
+			expectedErrorCode: map[*util.Block]ErrorCode{
+				block4:  ErrBadCheckpoint,
+				block3B: ErrCheckpointConflict,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		params := dagconfig.SimnetParams
+		dag, teardownFunc, err := DAGSetup("TestCheckpoints", true, Config{
+			DAGParams: &params,
+		})
+		if err != nil {
+			t.Fatalf("Failed to setup DAG instance: %v", err)
+		}
+		dag.TestSetCoinbaseMaturity(0)
+
+		for i := 1; i <= 3; i++ {
+			_, _, err := dag.ProcessBlock(blocks[i], BFNone)
+			if err != nil {
+				t.Fatalf("%s: ProcessBlock fail on block %d: %v", test.name, i, err)
+			}
+		}
+		block3Node, _ := dag.index.LookupNode(block3.Hash())
+		params.Checkpoints = test.checkpoints(block3Node.blueScore)
+
+		for _, block := range []*util.Block{block4, block3B} {
+			_, _, err := dag.ProcessBlock(block, BFNone)
+			expectedErrorCode, expectError := test.expectedErrorCode[block]
+			if !expectError {
+				if err != nil {
+					t.Errorf("%s: ProcessBlock unexpectedly failed on block %s: %s", test.name, block.Hash(), err)
+				}
+				continue
+			}
+			var ruleErr RuleError
+			if !errors.As(err, &ruleErr) || ruleErr.ErrorCode != expectedErrorCode {
+				t.Errorf("%s: expected block %s to fail with %s, but got: %v",
+					test.name, block.Hash(), expectedErrorCode, err)
+			}
+		}
+		teardownFunc()
+	}
+}
+
+func TestAssumeValid(t *testing.T) {
+	block1, block2, block3, block3BlueScore := buildAssumeValidChain(t)
+
+	params := dagconfig.SimnetParams
+	params.BlockCoinbaseMaturity = 0
+	params.Checkpoints = []dagconfig.Checkpoint{{BlueScore: block3BlueScore, Hash: block3.BlockHash()}}
+	dag, teardownFunc, err := DAGSetup("TestAssumeValid", true, Config{
+		DAGParams:   &params,
+		AssumeValid: block3.BlockHash(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	_, _, err = dag.ProcessBlock(util.NewBlock(block1), BFNoPoWCheck)
+	if err != nil {
+		t.Fatalf("ProcessBlock: %s", err)
+	}
+
+	// Before the assume-valid block is known, a block with an invalid
+	// script is rejected even though its blue score is lower.
+	sideBlock := prepareBlockWithInvalidScript(t, dag, block1)
+	_, _, err = dag.ProcessBlock(util.NewBlock(sideBlock), BFNoPoWCheck)
+	var ruleErr RuleError
+	if !errors.As(err, &ruleErr) || ruleErr.ErrorCode != ErrScriptValidation {
+		t.Fatalf("Expected a block with an invalid script before the assume-valid block "+
+			"is known to fail with %s, but got: %v", ErrScriptValidation, err)
+	}
+
+	isOrphan, _, err := dag.ProcessBlock(util.NewBlock(block3), BFNoPoWCheck)
+	if err != nil {
+		t.Fatalf("ProcessBlock: %s", err)
+	}
+	if !isOrphan {
+		t.Fatalf("Expected the assume-valid block to be an orphan")
+	}
+
+	// Once the assume-valid block is known, a block with an invalid script
+	// on a side branch, which isn't in its past, is still rejected.
+	sideBlock = prepareBlockWithInvalidScript(t, dag, block1)
+	_, _, err = dag.ProcessBlock(util.NewBlock(sideBlock), BFNoPoWCheck)
+	if !errors.As(err, &ruleErr) || ruleErr.ErrorCode != ErrScriptValidation {
+		t.Fatalf("Expected a block with an invalid script on a side branch to fail "+
+			"with %s, but got: %v", ErrScriptValidation, err)
+	}
+
+	// The scripts of block 2 aren't validated, since it's in the past of the
+	// assume-valid block, which is then unorphaned.
+	_, _, err = dag.ProcessBlock(util.NewBlock(block2), BFNoPoWCheck)
+	if err != nil {
+		t.Fatalf("Expected a block with an invalid script in the past of the "+
+			"assume-valid block to be accepted, but got: %s", err)
+	}
+	if !dag.IsInDAG(block3.BlockHash()) {
+		t.Fatalf("Expected the assume-valid block to be in the DAG")
+	}
+}
+
+func TestAssumeValidFromHeaders(t *testing.T) {
+	block1, block2, block3, block3BlueScore := buildAssumeValidChain(t)
+
+	params := dagconfig.SimnetParams
+	params.BlockCoinbaseMaturity = 0
+	params.Checkpoints = []dagconfig.Checkpoint{{BlueScore: block3BlueScore, Hash: block3.BlockHash()}}
+	dag, teardownFunc, err := DAGSetup("TestAssumeValidFromHeaders", true, Config{
+		DAGParams:   &params,
+		AssumeValid: block3.BlockHash(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	_, _, err = dag.ProcessBlock(util.NewBlock(block1), BFNoPoWCheck)
+	if err != nil {
+		t.Fatalf("ProcessBlock: %s", err)
+	}
+	err = dag.ProveAssumeValidPast([]*domainmessage.BlockHeader{&block2.Header, &block3.Header})
+	if err != nil {
+		t.Fatalf("ProveAssumeValidPast: %s", err)
+	}
+
+	// As in IBD, the blocks are received in topological order, so the
+	// assume-valid block isn't known when block 2 is connected. Its
+	// scripts still aren't validated, since its headers proved that it's
+	// in the past of the assume-valid block.
+	_, _, err = dag.ProcessBlock(util.NewBlock(block2), BFNoPoWCheck)
+	if err != nil {
+		t.Fatalf("Expected a block with an invalid script in the proven past of the "+
+			"assume-valid block to be accepted, but got: %s", err)
+	}
+
+	// A block with an invalid script that isn't in the proven past is
+	// still rejected.
+	sideBlock := prepareBlockWithInvalidScript(t, dag, block1)
+	_, _, err = dag.ProcessBlock(util.NewBlock(sideBlock), BFNoPoWCheck)
+	var ruleErr RuleError
+	if !errors.As(err, &ruleErr) || ruleErr.ErrorCode != ErrScriptValidation {
+		t.Fatalf("Expected a block with an invalid script on a side branch to fail "+
+			"with %s, but got: %v", ErrScriptValidation, err)
+	}
+
+	_, _, err = dag.ProcessBlock(util.NewBlock(block3), BFNoPoWCheck)
+	if err != nil {
+		t.Fatalf("ProcessBlock: %s", err)
+	}
+	if dag.assumeValidPast != nil {
+		t.Fatalf("Expected the proven past of the assume-valid block to be cleared " +
+			"once it's connected")
+	}
+}
+
+func TestProveAssumeValidPast(t *testing.T) {
+	// Load up blocks such that there is a fork in the DAG.
+	// (genesis block) -> 1 -> 2 -> 3 -> 4
+	//                          \-> 3b
+	var blocks []*util.Block
+	for _, file := range []string{"blk_0_to_4.dat", "blk_3B.dat"} {
+		blockTmp, err := LoadBlocks(filepath.Join("testdata/", file))
+		if err != nil {
+			t.Fatalf("Error loading file: %v\n", err)
+		}
+		blocks = append(blocks, blockTmp...)
+	}
+	block1, block2, block3, block4, block3B := blocks[1], blocks[2], blocks[3], blocks[4], blocks[5]
+
+	params := dagconfig.SimnetParams
+	dag, teardownFunc, err := DAGSetup("TestProveAssumeValidPast", true, Config{
+		DAGParams:   &params,
+		AssumeValid: block4.Hash(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+	dag.TestSetCoinbaseMaturity(0)
+
+	_, _, err = dag.ProcessBlock(block1, BFNone)
+	if err != nil {
+		t.Fatalf("ProcessBlock: %s", err)
+	}
+
+	headers := func(blocks ...*util.Block) []*domainmessage.BlockHeader {
+		headers := make([]*domainmessage.BlockHeader, len(blocks))
+		for i, block := range blocks {
+			headers[i] = &block.MsgBlock().Header
+		}
+		return headers
+	}
+
+	// The past of the assume-valid block isn't proven if a header between
+	// it and the DAG is missing, or if its own header is missing.
+	for _, missingBlock := range []*util.Block{block3, block4} {
+		var presentBlocks []*util.Block
+		for _, block := range []*util.Block{block2, block3, block4} {
+			if block != missingBlock {
+				presentBlocks = append(presentBlocks, block)
+			}
+		}
+		err = dag.ProveAssumeValidPast(headers(presentBlocks...))
+		if err == nil {
+			t.Fatalf("Expected ProveAssumeValidPast to fail without the header of block %s",
+				missingBlock.Hash())
+		}
+		if !dag.AssumeValidToProve().IsEqual(block4.Hash()) {
+			t.Fatalf("Expected the past of the assume-valid block to still need to be proven")
+		}
+	}
+
+	err = dag.ProveAssumeValidPast(headers(block2, block3B, block3, block4))
+	if err != nil {
+		t.Fatalf("ProveAssumeValidPast: %s", err)
+	}
+	if dag.AssumeValidToProve() != nil {
+		t.Fatalf("Expected the past of the assume-valid block to be proven")
+	}
+
+	for _, block := range []*util.Block{block2, block3, block3B} {
+		_, _, err = dag.ProcessBlock(block, BFNone)
+		if err != nil {
+			t.Fatalf("ProcessBlock: %s", err)
+		}
+	}
+	expectedAssumedValid := map[*util.Block]bool{block1: false, block2: true, block3: true, block3B: false}
+	for block, expected := range expectedAssumedValid {
+		node, ok := dag.index.LookupNode(block.Hash())
+		if !ok {
+			t.Fatalf("Block %s is unexpectedly not in the DAG", block.Hash())
+		}
+		isAssumedValid, err := dag.isAssumedValid(node)
+		if err != nil {
+			t.Fatalf("isAssumedValid: %s", err)
+		}
+		if isAssumedValid != expected {
+			t.Errorf("Expected isAssumedValid of block %s to be %t, but got %t",
+				block.Hash(), expected, isAssumedValid)
+		}
+	}
+}
+
+// buildAssumeValidChain builds the chain genesis -> 1 -> 2 -> 3, where
+// block 2 has an invalid script, to be used with block 3 as the
+// assume-valid block. It returns the blocks and the blue score of block 3.
+func buildAssumeValidChain(t *testing.T) (block1, block2, block3 *domainmessage.MsgBlock, block3BlueScore uint64) {
+	params := dagconfig.SimnetParams
+	params.BlockCoinbaseMaturity = 0
+	dag, teardownFunc, err := DAGSetup("buildAssumeValidChain", true, Config{
+		DAGParams: &params,
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	// The builder DAG assumes block 2 itself to be valid, so that it
+	// accepts its invalid script.
+	block1 = prepareAndProcessBlockByParentMsgBlocks(t, dag, params.GenesisBlock)
+	block2 = prepareBlockWithInvalidScript(t, dag, block1)
+	dag.assumeValid = block2.BlockHash()
+	_, _, err = dag.ProcessBlock(util.NewBlock(block2), BFNoPoWCheck)
+	if err != nil {
+		t.Fatalf("ProcessBlock: %s", err)
+	}
+	block3 = prepareAndProcessBlockByParentMsgBlocks(t, dag, block2)
+	return block1, block2, block3, nodeByMsgBlock(t, dag, block3).blueScore
+}
+
+// prepareBlockWithInvalidScript prepares a block on top of the given parent,
+// with a transaction that spends the parent's coinbase with a signature
+// script that doesn't match its P2SH script.
+func prepareBlockWithInvalidScript(t *testing.T, dag *BlockDAG, parent *domainmessage.MsgBlock) *domainmessage.MsgBlock {
+	signatureScript, err := txscript.PayToScriptHashSignatureScript([]byte{txscript.Op2}, nil)
+	if err != nil {
+		t.Fatalf("Failed to build signature script: %s", err)
+	}
+	txIn := &domainmessage.TxIn{
+		PreviousOutpoint: domainmessage.Outpoint{TxID: *parent.Transactions[0].TxID(), Index: 0},
+		SignatureScript:  signatureScript,
+		Sequence:         domainmessage.MaxTxInSequenceNum,
+	}
+	txOut := &domainmessage.TxOut{
+		ScriptPubKey: OpTrueScript,
+		Value:        uint64(1),
+	}
+	tx := domainmessage.NewNativeMsgTx(domainmessage.TxVersion, []*domainmessage.TxIn{txIn}, []*domainmessage.TxOut{txOut})
+
+	block, err := PrepareBlockForTest(dag, []*daghash.Hash{parent.BlockHash()}, []*domainmessage.MsgTx{tx})
+	if err != nil {
+		t.Fatalf("PrepareBlockForTest: %s", err)
+	}
+	return block
+}
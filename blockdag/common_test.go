@@ -104,7 +104,7 @@ func newTestDAG(params *dagconfig.Params) *BlockDAG {
 		powMaxBits:                     util.BigToCompact(params.PowMax),
 		index:                          index,
 		warningCaches:                  newThresholdCaches(vbNumBits),
-		deploymentCaches:               newThresholdCaches(dagconfig.DefinedDeployments),
+		deploymentCaches:               newThresholdCaches(uint32(len(params.Deployments))),
 	}
 
 	// Create a genesis block node and block index index populated with it
@@ -184,14 +184,8 @@ func nodeByMsgBlock(t *testing.T, dag *BlockDAG, block *domainmessage.MsgBlock)
 	return node
 }
 
-type fakeTimeSource struct {
-	time mstime.Time
-}
-
-func (fts *fakeTimeSource) Now() mstime.Time {
-	return fts.time
-}
-
 func newFakeTimeSource(fakeTime mstime.Time) TimeSource {
-	return &fakeTimeSource{time: fakeTime}
+	timeSource := NewTimeSource()
+	timeSource.SetMockTime(fakeTime)
+	return timeSource
 }
@@ -71,6 +71,7 @@ type BlockDAG struct {
 	sigCache        *txscript.SigCache
 	indexManager    IndexManager
 	genesis         *blockNode
+	assumeValid     *daghash.Hash
 
 	// The following fields are calculated based upon the provided DAG
 	// parameters. They are also set when the instance is created and
@@ -120,6 +121,11 @@ type BlockDAG struct {
 	delayedBlocks      map[daghash.Hash]*delayedBlock
 	delayedBlocksQueue delayedBlocksHeap
 
+	// assumeValidPast holds the hashes of the blocks that were proven by
+	// ProveAssumeValidPast to be in the past of the assume-valid block.
+	// It's cleared once the assume-valid block is connected.
+	assumeValidPast map[daghash.Hash]struct{}
+
 	// The following caches are used to efficiently keep track of the
 	// current deployment threshold state of each rule change deployment.
 	//
@@ -186,6 +192,7 @@ func New(config *Config) (*BlockDAG, error) {
 		timeSource:                     config.TimeSource,
 		sigCache:                       config.SigCache,
 		indexManager:                   config.IndexManager,
+		assumeValid:                    config.AssumeValid,
 		difficultyAdjustmentWindowSize: params.DifficultyAdjustmentWindowSize,
 		TimestampDeviationTolerance:    params.TimestampDeviationTolerance,
 		powMaxBits:                     util.BigToCompact(params.PowMax),
@@ -195,7 +202,7 @@ func New(config *Config) (*BlockDAG, error) {
 		delayedBlocks:                  make(map[daghash.Hash]*delayedBlock),
 		delayedBlocksQueue:             newDelayedBlocksHeap(),
 		warningCaches:                  newThresholdCaches(vbNumBits),
-		deploymentCaches:               newThresholdCaches(dagconfig.DefinedDeployments),
+		deploymentCaches:               newThresholdCaches(uint32(len(params.Deployments))),
 		blockCount:                     0,
 		subnetworkID:                   config.SubnetworkID,
 		startTime:                      mstime.Now(),
@@ -395,7 +402,7 @@ func (dag *BlockDAG) removeOrphanBlock(orphan *orphanBlock) {
 func (dag *BlockDAG) addOrphanBlock(block *util.Block) {
 	// Remove expired orphan blocks.
 	for _, oBlock := range dag.orphans {
-		if mstime.Now().After(oBlock.expiration) {
+		if dag.Now().After(oBlock.expiration) {
 			dag.removeOrphanBlock(oBlock)
 			continue
 		}
@@ -427,7 +434,7 @@ func (dag *BlockDAG) addOrphanBlock(block *util.Block) {
 
 	// Insert the block into the orphan map with an expiration time
 	// 1 hour from now.
-	expiration := mstime.Now().Add(time.Hour)
+	expiration := dag.Now().Add(time.Hour)
 	oBlock := &orphanBlock{
 		block:      block,
 		expiration: expiration,
@@ -668,21 +675,8 @@ func (dag *BlockDAG) connectBlock(node *blockNode,
 		}
 	}
 
-	if err := dag.checkFinalityViolation(node); err != nil {
-		return nil, err
-	}
-
-	if err := dag.validateGasLimit(block); err != nil {
-		return nil, err
-	}
-
 	newBlockPastUTXO, txsAcceptanceData, newBlockFeeData, newBlockMultiSet, err :=
-		node.verifyAndBuildUTXO(dag, block.Transactions(), fastAdd)
-	if err != nil {
-		return nil, errors.Wrapf(err, "error verifying UTXO for %s", node)
-	}
-
-	err = node.validateCoinbaseTransaction(dag, block, txsAcceptanceData)
+		dag.verifyBlockConnection(node, block, fastAdd)
 	if err != nil {
 		return nil, err
 	}
@@ -706,6 +700,42 @@ func (dag *BlockDAG) connectBlock(node *blockNode,
 	return chainUpdates, nil
 }
 
+// verifyBlockConnection validates the passed node/block against its
+// position in the DAG, and builds its past UTXO. To save extra traversals
+// it also returns the transactions acceptance data, the compactFeeData
+// for the block and its multiset.
+//
+// This function MUST be called with the DAG state lock held (for writes).
+func (dag *BlockDAG) verifyBlockConnection(node *blockNode, block *util.Block, fastAdd bool) (
+	newBlockPastUTXO UTXOSet, txsAcceptanceData MultiBlockTxsAcceptanceData,
+	newBlockFeeData compactFeeData, newBlockMultiSet *secp256k1.MultiSet, err error) {
+
+	if err := dag.checkFinalityViolation(node); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := dag.checkCheckpoints(node); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if err := dag.validateGasLimit(block); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	newBlockPastUTXO, txsAcceptanceData, newBlockFeeData, newBlockMultiSet, err =
+		node.verifyAndBuildUTXO(dag, block.Transactions(), fastAdd)
+	if err != nil {
+		return nil, nil, nil, nil, errors.Wrapf(err, "error verifying UTXO for %s", node)
+	}
+
+	err = node.validateCoinbaseTransaction(dag, block, txsAcceptanceData)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return newBlockPastUTXO, txsAcceptanceData, newBlockFeeData, newBlockMultiSet, nil
+}
+
 // calcMultiset returns the multiset of the past UTXO of the given block.
 func (node *blockNode) calcMultiset(dag *BlockDAG, acceptanceData MultiBlockTxsAcceptanceData,
 	selectedParentPastUTXO UTXOSet) (*secp256k1.MultiSet, error) {
@@ -1105,6 +1135,18 @@ func (dag *BlockDAG) applyDAGChanges(node *blockNode, newBlockPastUTXO UTXOSet,
 
 	dag.multisetStore.setMultiset(node, newBlockMultiset)
 
+	return dag.applyVirtualChanges(node, newBlockPastUTXO)
+}
+
+// applyVirtualChanges does steps 1-5 and 8 of applyDAGChanges for a node
+// that already has reachability data and a multiset.
+//
+// It returns the diff in the virtual block's UTXO set.
+//
+// This function MUST be called with the DAG state lock held (for writes).
+func (dag *BlockDAG) applyVirtualChanges(node *blockNode, newBlockPastUTXO UTXOSet) (
+	virtualUTXODiff *UTXODiff, chainUpdates *chainUpdates, err error) {
+
 	if err = node.updateParents(dag, newBlockPastUTXO); err != nil {
 		return nil, nil, errors.Wrapf(err, "failed updating parents of %s", node)
 	}
@@ -1462,6 +1504,13 @@ func (dag *BlockDAG) Now() mstime.Time {
 	return dag.timeSource.Now()
 }
 
+// TimeSource returns the time source of the DAG, for the parts of kaspad
+// that need to follow its mock time beyond calling Now, such as the ping
+// flows.
+func (dag *BlockDAG) TimeSource() TimeSource {
+	return dag.timeSource
+}
+
 // IsSynced returns whether or not the DAG believes it is synced. Several
 // factors are used to guess, but the key factors that allow the DAG to
 // believe it is synced are:
@@ -2043,6 +2092,16 @@ func (dag *BlockDAG) addDelayedBlock(block *util.Block, delay time.Duration) err
 	return dag.processDelayedBlocks()
 }
 
+// ProcessDelayedBlocks processes the delayed blocks which are due. Delayed
+// blocks are otherwise only processed after another block is processed, so
+// this should be called after the time source of the DAG is moved forward.
+func (dag *BlockDAG) ProcessDelayedBlocks() error {
+	dag.dagLock.Lock()
+	defer dag.dagLock.Unlock()
+
+	return dag.processDelayedBlocks()
+}
+
 // processDelayedBlocks loops over all delayed blocks and processes blocks which are due.
 // This method is invoked after processing a block (ProcessBlock method).
 func (dag *BlockDAG) processDelayedBlocks() error {
@@ -2135,6 +2194,13 @@ type Config struct {
 	// DatabaseContext is the context in which all database queries related to
 	// this DAG are going to run.
 	DatabaseContext *dbaccess.DatabaseContext
+
+	// AssumeValid is the hash of a block whose past is assumed to have
+	// valid scripts. Script validation is skipped for the blocks in its
+	// past.
+	//
+	// This field can be nil if all scripts should be validated.
+	AssumeValid *daghash.Hash
 }
 
 func (dag *BlockDAG) isKnownDelayedBlock(hash *daghash.Hash) bool {
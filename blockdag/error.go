@@ -210,6 +210,15 @@ const (
 	// ErrOrphanBlockIsNotAllowed indicates that an orphan block was submitted with
 	// BFDisallowOrphans flag raised.
 	ErrOrphanBlockIsNotAllowed
+
+	// ErrBadCheckpoint indicates a block that is expected to be at a
+	// checkpoint has a different blue score than the checkpoint.
+	ErrBadCheckpoint
+
+	// ErrCheckpointConflict indicates a block that doesn't have the latest
+	// known checkpoint in its selected parent chain, which means that it
+	// belongs to a branch that conflicts with the checkpoint.
+	ErrCheckpointConflict
 )
 
 // Map of ErrorCode values back to their constant names for pretty printing.
@@ -259,6 +268,8 @@ var errorCodeStrings = map[ErrorCode]string{
 	ErrInvalidParentsRelation:    "ErrInvalidParentsRelation",
 	ErrDelayedBlockIsNotAllowed:  "ErrDelayedBlockIsNotAllowed",
 	ErrOrphanBlockIsNotAllowed:   "ErrOrphanBlockIsNotAllowed",
+	ErrBadCheckpoint:             "ErrBadCheckpoint",
+	ErrCheckpointConflict:        "ErrCheckpointConflict",
 }
 
 // String returns the ErrorCode as a human-readable name.
@@ -58,6 +58,8 @@ func TestErrorCodeStringer(t *testing.T) {
 		{ErrInvalidParentsRelation, "ErrInvalidParentsRelation"},
 		{ErrDelayedBlockIsNotAllowed, "ErrDelayedBlockIsNotAllowed"},
 		{ErrOrphanBlockIsNotAllowed, "ErrOrphanBlockIsNotAllowed"},
+		{ErrBadCheckpoint, "ErrBadCheckpoint"},
+		{ErrCheckpointConflict, "ErrCheckpointConflict"},
 		{0xffff, "Unknown ErrorCode (65535)"},
 	}
 
@@ -48,6 +48,27 @@ func DropAcceptanceIndex(databaseContext *dbaccess.DatabaseContext) error {
 	return dbTx.Commit()
 }
 
+// MissingAcceptanceData returns the hashes of the blocks in the DAG whose
+// acceptance data is missing from the acceptance index of the given
+// database. Unlike Init, it doesn't attempt to insert the missing data.
+func MissingAcceptanceData(dag *blockdag.BlockDAG, databaseContext *dbaccess.DatabaseContext) ([]*daghash.Hash, error) {
+	var missingHashes []*daghash.Hash
+	err := dag.ForEachHash(func(hash daghash.Hash) error {
+		exists, err := dbaccess.HasAcceptanceData(databaseContext, &hash)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			missingHashes = append(missingHashes, &hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return missingHashes, nil
+}
+
 // Init initializes the hash-based acceptance index.
 //
 // This is part of the Indexer interface.
@@ -338,3 +338,41 @@ func copySymLink(source, dest string) error {
 	}
 	return os.Symlink(link, dest)
 }
+
+func TestMissingAcceptanceData(t *testing.T) {
+	params := dagconfig.SimnetParams
+	acceptanceIndex := NewAcceptanceIndex()
+	dag, teardownFunc, err := blockdag.DAGSetup("TestMissingAcceptanceData", true, blockdag.Config{
+		DAGParams:    &params,
+		IndexManager: NewManager([]Indexer{acceptanceIndex}),
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	block := blockdag.PrepareAndProcessBlockForTest(t, dag, []*daghash.Hash{params.GenesisHash}, nil)
+
+	missingHashes, err := MissingAcceptanceData(dag, acceptanceIndex.databaseContext)
+	if err != nil {
+		t.Fatalf("MissingAcceptanceData: %s", err)
+	}
+	if len(missingHashes) != 0 {
+		t.Fatalf("Expected no missing acceptance data, but got %s", missingHashes)
+	}
+
+	err = DropAcceptanceIndex(acceptanceIndex.databaseContext)
+	if err != nil {
+		t.Fatalf("DropAcceptanceIndex: %s", err)
+	}
+	missingHashes, err = MissingAcceptanceData(dag, acceptanceIndex.databaseContext)
+	if err != nil {
+		t.Fatalf("MissingAcceptanceData: %s", err)
+	}
+	expectedHashes := []*daghash.Hash{params.GenesisHash, block.BlockHash()}
+	daghash.Sort(missingHashes)
+	daghash.Sort(expectedHashes)
+	if !daghash.AreEqual(missingHashes, expectedHashes) {
+		t.Fatalf("Expected missing acceptance data for %s, but got %s", expectedHashes, missingHashes)
+	}
+}
@@ -0,0 +1,474 @@
+package blockdag
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+// InvalidateBlock manually marks the block with the given hash as invalid,
+// and all the blocks in its future as having an invalid ancestor. The
+// invalidated blocks are removed from the DAG's tips, selected parent chain
+// and virtual UTXO set, and are treated as any other invalid block until
+// they are reconsidered using ReconsiderBlock.
+//
+// This function is safe for concurrent access.
+func (dag *BlockDAG) InvalidateBlock(hash *daghash.Hash) error {
+	dag.dagLock.Lock()
+	updates, err := dag.invalidateBlock(hash)
+	dag.dagLock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	dag.sendChainChangedNotifications([]*chainUpdates{updates})
+	return nil
+}
+
+// ReconsiderBlock removes the invalidity marks from the block with the given
+// hash, from the blocks in its future and from its ancestors, and attempts to
+// connect them back to the DAG. Blocks that fail validation again remain
+// invalid, in which case an error is returned if the given block is one of
+// them.
+//
+// This function is safe for concurrent access.
+func (dag *BlockDAG) ReconsiderBlock(hash *daghash.Hash) error {
+	dag.dagLock.Lock()
+	allChainUpdates, err := dag.reconsiderBlock(hash)
+	dag.dagLock.Unlock()
+
+	dag.sendChainChangedNotifications(allChainUpdates)
+	return err
+}
+
+func (dag *BlockDAG) sendChainChangedNotifications(allChainUpdates []*chainUpdates) {
+	for _, chainUpdates := range allChainUpdates {
+		if len(chainUpdates.removedChainBlockHashes) == 0 && len(chainUpdates.addedChainBlockHashes) == 0 {
+			continue
+		}
+		dag.sendNotification(NTChainChanged, &ChainChangedNotificationData{
+			RemovedChainBlockHashes: chainUpdates.removedChainBlockHashes,
+			AddedChainBlockHashes:   chainUpdates.addedChainBlockHashes,
+		})
+	}
+}
+
+// invalidateBlock does the work of InvalidateBlock.
+//
+// This function MUST be called with the DAG state lock held (for writes).
+func (dag *BlockDAG) invalidateBlock(hash *daghash.Hash) (*chainUpdates, error) {
+	node, ok := dag.index.LookupNode(hash)
+	if !ok {
+		return nil, errors.Errorf("block %s is not known", hash)
+	}
+	if node.isGenesis() {
+		return nil, errors.New("the genesis block cannot be invalidated")
+	}
+
+	// A block that is already invalid is not a part of the DAG,
+	// so only its status has to change
+	if dag.index.NodeStatus(node).KnownInvalid() || !dag.index.NodeStatus(node).KnownValid() {
+		dag.index.UnsetStatusFlags(node, statusValid)
+		dag.index.SetStatusFlags(node, statusValidateFailed)
+		err := dag.saveInvalidationChanges(NewUTXODiff(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return &chainUpdates{}, nil
+	}
+
+	isFinalized := node == dag.lastFinalityPoint
+	if !isFinalized {
+		var err error
+		isFinalized, err = dag.isInPast(node, dag.lastFinalityPoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if isFinalized {
+		return nil, errors.Errorf("block %s is finalized and cannot be invalidated", hash)
+	}
+
+	invalidatedNodes := node.futureNodes()
+	tips := dag.virtual.tips().subtract(invalidatedNodes)
+	for invalidatedNode := range invalidatedNodes {
+		for parent := range invalidatedNode.parents {
+			if !invalidatedNodes.contains(parent) && len(parent.children.subtract(invalidatedNodes)) == 0 {
+				tips.add(parent)
+			}
+		}
+	}
+
+	// Make sure that the DAG doesn't revert past its last finality point
+	newVirtual, _ := dag.newBlockNode(nil, tips)
+	err := dag.checkFinalityViolation(newVirtual)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalidating block %s would revert the DAG past its "+
+			"last finality point", hash)
+	}
+
+	// Restore the past UTXO of every valid block whose UTXO diff has to
+	// change. This must be done before any diff is changed, since the
+	// diff chains of these blocks may pass through the invalidated blocks.
+	newDiffChildren := make(map[*blockNode]*blockNode)
+	for invalidatedNode := range invalidatedNodes {
+		for parent := range invalidatedNode.parents {
+			if invalidatedNodes.contains(parent) || tips.contains(parent) {
+				continue
+			}
+			diffChild, err := dag.utxoDiffStore.diffChildByNode(parent)
+			if err != nil {
+				return nil, err
+			}
+			if !invalidatedNodes.contains(diffChild) {
+				continue
+			}
+			for child := range parent.children {
+				if !invalidatedNodes.contains(child) {
+					newDiffChildren[parent] = child
+					break
+				}
+			}
+		}
+	}
+	pastUTXOs := make(map[*blockNode]UTXOSet)
+	nodesToRestore := tips.clone()
+	for parent, diffChild := range newDiffChildren {
+		nodesToRestore.add(parent)
+		nodesToRestore.add(diffChild)
+	}
+	for nodeToRestore := range nodesToRestore {
+		pastUTXOs[nodeToRestore], err = dag.restorePastUTXO(nodeToRestore)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dag.index.UnsetStatusFlags(node, statusValid)
+	dag.index.SetStatusFlags(node, statusValidateFailed)
+	for invalidatedNode := range invalidatedNodes {
+		if invalidatedNode != node {
+			dag.index.UnsetStatusFlags(invalidatedNode, statusValid)
+			dag.index.SetStatusFlags(invalidatedNode, statusInvalidAncestor)
+		}
+
+		// Invalid blocks are not the children of their parents, same
+		// as when they are loaded from the database
+		for parent := range invalidatedNode.parents {
+			parent.children.remove(invalidatedNode)
+		}
+	}
+	dag.blockCount -= uint64(len(invalidatedNodes))
+
+	chainUpdates := dag.virtual.SetTips(tips)
+
+	newVirtualUTXO, _, _, err := dag.pastUTXO(&dag.virtual.blockNode)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not restore past UTXO for virtual")
+	}
+	for parent, diffChild := range newDiffChildren {
+		diff, err := pastUTXOs[diffChild].diffFrom(pastUTXOs[parent])
+		if err != nil {
+			return nil, err
+		}
+		err = dag.utxoDiffStore.setBlockDiff(parent, diff)
+		if err != nil {
+			return nil, err
+		}
+		err = dag.utxoDiffStore.setBlockDiffChild(parent, diffChild)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for tip := range tips {
+		diff, err := newVirtualUTXO.diffFrom(pastUTXOs[tip])
+		if err != nil {
+			return nil, err
+		}
+		err = dag.utxoDiffStore.setBlockDiff(tip, diff)
+		if err != nil {
+			return nil, err
+		}
+		err = dag.utxoDiffStore.setBlockDiffChild(tip, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	diffSet := newVirtualUTXO.(*DiffUTXOSet)
+	virtualUTXODiff := diffSet.UTXODiff
+	err = dag.meldVirtualUTXO(diffSet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed melding the virtual UTXO")
+	}
+
+	err = dag.saveInvalidationChanges(virtualUTXODiff, invalidatedNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Invalidated block %s and %d blocks in its future", hash, len(invalidatedNodes)-1)
+	return chainUpdates, nil
+}
+
+// futureNodes returns the node and all the nodes
+// that are connected to the DAG in its future
+func (node *blockNode) futureNodes() blockSet {
+	futureNodes := blockSetFromSlice(node)
+	queue := []*blockNode{node}
+	for len(queue) > 0 {
+		var current *blockNode
+		current, queue = queue[0], queue[1:]
+		for child := range current.children {
+			if !futureNodes.contains(child) {
+				futureNodes.add(child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return futureNodes
+}
+
+// saveInvalidationChanges persists the changes made by invalidateBlock.
+// The UTXO diff data of the invalidated nodes no longer leads to the
+// virtual UTXO set, so it is removed.
+func (dag *BlockDAG) saveInvalidationChanges(virtualUTXODiff *UTXODiff, invalidatedNodes blockSet) error {
+	dbTx, err := dag.databaseContext.NewTx()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	err = dag.index.flushToDB(dbTx)
+	if err != nil {
+		return err
+	}
+
+	err = dag.utxoDiffStore.flushToDB(dbTx)
+	if err != nil {
+		return err
+	}
+
+	for invalidatedNode := range invalidatedNodes {
+		err = dag.utxoDiffStore.removeBlockDiffData(dbTx, invalidatedNode)
+		if err != nil && !dbaccess.IsNotFoundError(err) {
+			return err
+		}
+	}
+
+	state := &dagState{
+		TipHashes:         dag.TipHashes(),
+		LastFinalityPoint: dag.lastFinalityPoint.hash,
+		LocalSubnetworkID: dag.subnetworkID,
+	}
+	err = saveDAGState(dbTx, state)
+	if err != nil {
+		return err
+	}
+
+	err = updateUTXOSet(dbTx, virtualUTXODiff)
+	if err != nil {
+		return err
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return err
+	}
+
+	dag.index.clearDirtyEntries()
+	dag.utxoDiffStore.clearDirtyEntries()
+	return nil
+}
+
+// reconsiderBlock does the work of ReconsiderBlock. It returns the
+// updates to the selected parent chain made by every reconnected block.
+//
+// This function MUST be called with the DAG state lock held (for writes).
+func (dag *BlockDAG) reconsiderBlock(hash *daghash.Hash) ([]*chainUpdates, error) {
+	node, ok := dag.index.LookupNode(hash)
+	if !ok {
+		return nil, errors.Errorf("block %s is not known", hash)
+	}
+
+	nodesToReconsider := dag.invalidNodesRelatedTo(node)
+	for nodeToReconsider := range nodesToReconsider {
+		dag.index.UnsetStatusFlags(nodeToReconsider, statusValidateFailed|statusInvalidAncestor)
+	}
+
+	// Reconnect the nodes in topological order, so that
+	// every node is reconnected after its parents
+	sortedNodes := make([]*blockNode, 0, len(nodesToReconsider))
+	for nodeToReconsider := range nodesToReconsider {
+		sortedNodes = append(sortedNodes, nodeToReconsider)
+	}
+	sort.Slice(sortedNodes, func(i, j int) bool {
+		return sortedNodes[i].less(sortedNodes[j])
+	})
+
+	var allChainUpdates []*chainUpdates
+	var nodeErr error
+	for _, nodeToReconsider := range sortedNodes {
+		chainUpdates, err := dag.reconnectBlock(nodeToReconsider)
+		if err != nil {
+			var ruleErr RuleError
+			if !errors.As(err, &ruleErr) {
+				return allChainUpdates, err
+			}
+			log.Warnf("Reconsidered block %s is invalid: %s", nodeToReconsider.hash, err)
+			if nodeToReconsider == node {
+				nodeErr = errors.Wrapf(err, "block %s is invalid", hash)
+			}
+			continue
+		}
+		if chainUpdates != nil {
+			allChainUpdates = append(allChainUpdates, chainUpdates)
+		}
+	}
+
+	// Persist the status of nodes that were not reconnected
+	dbTx, err := dag.databaseContext.NewTx()
+	if err != nil {
+		return allChainUpdates, err
+	}
+	defer dbTx.RollbackUnlessClosed()
+	err = dag.index.flushToDB(dbTx)
+	if err != nil {
+		return allChainUpdates, err
+	}
+	err = dbTx.Commit()
+	if err != nil {
+		return allChainUpdates, err
+	}
+	dag.index.clearDirtyEntries()
+
+	log.Infof("Reconsidered %d invalid blocks related to block %s", len(nodesToReconsider), hash)
+	return allChainUpdates, nodeErr
+}
+
+// invalidNodesRelatedTo returns the invalid nodes out of the given node,
+// its ancestors, and the nodes in its future
+func (dag *BlockDAG) invalidNodesRelatedTo(node *blockNode) blockSet {
+	relatedNodes := newBlockSet()
+	if dag.index.NodeStatus(node).KnownInvalid() {
+		relatedNodes.add(node)
+	}
+
+	queue := []*blockNode{node}
+	visited := blockSetFromSlice(node)
+	for len(queue) > 0 {
+		var current *blockNode
+		current, queue = queue[0], queue[1:]
+		for parent := range current.parents {
+			if visited.contains(parent) {
+				continue
+			}
+			visited.add(parent)
+			if dag.index.NodeStatus(parent).KnownInvalid() {
+				relatedNodes.add(parent)
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	// Invalid nodes are not their parents' children, so the nodes in the
+	// future of the given node are found by going over all invalid nodes
+	// and checking whether the given node is one of their ancestors
+	dag.index.RLock()
+	invalidNodes := make([]*blockNode, 0)
+	for _, indexNode := range dag.index.index {
+		if indexNode.status.KnownInvalid() && indexNode != node {
+			invalidNodes = append(invalidNodes, indexNode)
+		}
+	}
+	dag.index.RUnlock()
+
+	isInFuture := make(map[*blockNode]bool)
+	var checkIsInFuture func(current *blockNode) bool
+	checkIsInFuture = func(current *blockNode) bool {
+		if result, ok := isInFuture[current]; ok {
+			return result
+		}
+		result := false
+		for parent := range current.parents {
+			if parent == node || (dag.index.NodeStatus(parent).KnownInvalid() && checkIsInFuture(parent)) {
+				result = true
+				break
+			}
+		}
+		isInFuture[current] = result
+		return result
+	}
+	for _, invalidNode := range invalidNodes {
+		if checkIsInFuture(invalidNode) {
+			relatedNodes.add(invalidNode)
+		}
+	}
+
+	return relatedNodes
+}
+
+// reconnectBlock validates a reconsidered node and connects it back to the
+// DAG. If any of its parents is still invalid, the node is marked as
+// having an invalid ancestor instead.
+//
+// This function MUST be called with the DAG state lock held (for writes).
+func (dag *BlockDAG) reconnectBlock(node *blockNode) (*chainUpdates, error) {
+	for parent := range node.parents {
+		if dag.index.NodeStatus(parent).KnownInvalid() {
+			dag.index.SetStatusFlags(node, statusInvalidAncestor)
+			return nil, nil
+		}
+	}
+	if dag.index.NodeStatus(node)&statusDataStored == 0 {
+		dag.index.SetStatusFlags(node, statusInvalidAncestor)
+		return nil, nil
+	}
+
+	block, err := dag.fetchBlockByHash(node.hash)
+	if err != nil {
+		return nil, err
+	}
+
+	newBlockPastUTXO, txsAcceptanceData, newBlockFeeData, newBlockMultiSet, err :=
+		dag.verifyBlockConnection(node, block, false)
+	if err != nil {
+		if errors.As(err, &RuleError{}) {
+			dag.index.SetStatusFlags(node, statusValidateFailed)
+		}
+		return nil, err
+	}
+
+	// A block that failed validation when it was first received
+	// was never added to the reachability tree
+	if _, ok := dag.reachabilityTree.store.reachabilityDataByHash(node.hash); !ok {
+		selectedParentAnticone, err := dag.ghostdag(node)
+		if err != nil {
+			return nil, err
+		}
+		err = dag.reachabilityTree.addBlock(node, selectedParentAnticone)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed adding block to the reachability tree")
+		}
+	}
+	// The multiset of a block that was valid before it got invalidated
+	// is already stored
+	if _, ok := dag.multisetStore.multisetByBlockHash(node.hash); !ok {
+		dag.multisetStore.setMultiset(node, newBlockMultiSet)
+	}
+
+	virtualUTXODiff, chainUpdates, err := dag.applyVirtualChanges(node, newBlockPastUTXO)
+	if err != nil {
+		return nil, err
+	}
+	dag.blockCount++
+
+	err = dag.saveChangesFromBlock(block, virtualUTXODiff, txsAcceptanceData, newBlockFeeData)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainUpdates, nil
+}
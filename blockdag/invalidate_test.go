@@ -0,0 +1,264 @@
+package blockdag
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/txscript"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+func TestInvalidateAndReconsiderBlock(t *testing.T) {
+	dag, teardownFunc, err := DAGSetup("TestInvalidateAndReconsiderBlock", true, Config{
+		DAGParams: &dagconfig.SimnetParams,
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+	dag.TestSetCoinbaseMaturity(0)
+
+	// Build the following DAG:
+	// genesis <- blockA1 <- blockB1
+	//                   <- blockA2 <- blockA3
+	genesis := dag.Params.GenesisBlock
+	blockA1 := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	blockB1 := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA1)
+	utxoSetWithoutA2 := dag.virtual.utxoSet.utxoCollection.clone()
+	tipHashesWithoutA2 := dag.TipHashes()
+
+	blockA2 := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA1)
+	blockA3 := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA2)
+	utxoSetWithA2 := dag.virtual.utxoSet.utxoCollection.clone()
+	tipHashesWithA2 := dag.TipHashes()
+
+	if !dag.SelectedTipHash().IsEqual(blockA3.BlockHash()) {
+		t.Fatalf("Expected the selected tip to be %s, but got %s",
+			blockA3.BlockHash(), dag.SelectedTipHash())
+	}
+
+	err = dag.InvalidateBlock(blockA2.BlockHash())
+	if err != nil {
+		t.Fatalf("InvalidateBlock: %s", err)
+	}
+
+	if status := dag.index.NodeStatus(nodeByMsgBlock(t, dag, blockA2)); status&statusValidateFailed == 0 {
+		t.Errorf("Expected blockA2 to be marked as failing validation, but got status %b", status)
+	}
+	if status := dag.index.NodeStatus(nodeByMsgBlock(t, dag, blockA3)); status&statusInvalidAncestor == 0 {
+		t.Errorf("Expected blockA3 to be marked as having an invalid ancestor, but got status %b", status)
+	}
+	assertTipHashes(t, dag, tipHashesWithoutA2)
+	if !dag.SelectedTipHash().IsEqual(blockB1.BlockHash()) {
+		t.Errorf("Expected the selected tip to be %s, but got %s",
+			blockB1.BlockHash(), dag.SelectedTipHash())
+	}
+	if !reflect.DeepEqual(dag.virtual.utxoSet.utxoCollection, utxoSetWithoutA2) {
+		t.Errorf("Unexpected UTXO set after invalidating blockA2")
+	}
+
+	// Blocks in the future of an invalidated block are rejected
+	_, err = PrepareBlockForTest(dag, []*daghash.Hash{blockA3.BlockHash()}, nil)
+	if err == nil {
+		t.Errorf("Expected preparing a block on top of blockA3 to fail")
+	}
+
+	err = dag.ReconsiderBlock(blockA3.BlockHash())
+	if err != nil {
+		t.Fatalf("ReconsiderBlock: %s", err)
+	}
+
+	for _, block := range []*daghash.Hash{blockA2.BlockHash(), blockA3.BlockHash()} {
+		node, _ := dag.index.LookupNode(block)
+		if status := dag.index.NodeStatus(node); !status.KnownValid() || status.KnownInvalid() {
+			t.Errorf("Expected block %s to be valid after reconsideration, but got status %b", block, status)
+		}
+	}
+	assertTipHashes(t, dag, tipHashesWithA2)
+	if !dag.SelectedTipHash().IsEqual(blockA3.BlockHash()) {
+		t.Errorf("Expected the selected tip to be %s, but got %s",
+			blockA3.BlockHash(), dag.SelectedTipHash())
+	}
+	if !reflect.DeepEqual(dag.virtual.utxoSet.utxoCollection, utxoSetWithA2) {
+		t.Errorf("Unexpected UTXO set after reconsidering blockA2")
+	}
+
+	// The DAG keeps growing normally on top of the reconsidered blocks
+	prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA3, blockB1)
+
+	err = dag.InvalidateBlock(genesis.BlockHash())
+	if err == nil {
+		t.Errorf("Expected invalidating the genesis block to fail")
+	}
+	err = dag.InvalidateBlock(&daghash.Hash{})
+	if err == nil {
+		t.Errorf("Expected invalidating an unknown block to fail")
+	}
+}
+
+// TestReconsiderBlockWithChildReceivedWhileInvalid makes sure that a block
+// received while its parent is invalidated is reconnected once its parent
+// is reconsidered.
+func TestReconsiderBlockWithChildReceivedWhileInvalid(t *testing.T) {
+	dag, teardownFunc, err := DAGSetup("TestReconsiderBlockWithChildReceivedWhileInvalid", true, Config{
+		DAGParams: &dagconfig.SimnetParams,
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+	dag.TestSetCoinbaseMaturity(0)
+
+	// Build the following DAG, where blockA3 is received
+	// only after blockA2 is invalidated:
+	// genesis <- blockA1 <- blockB1
+	//                   <- blockA2 <- blockA3
+	genesis := dag.Params.GenesisBlock
+	blockA1 := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA1)
+	blockA2 := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA1)
+	blockA3, err := PrepareBlockForTest(dag, []*daghash.Hash{blockA2.BlockHash()}, nil)
+	if err != nil {
+		t.Fatalf("PrepareBlockForTest: %s", err)
+	}
+
+	err = dag.InvalidateBlock(blockA2.BlockHash())
+	if err != nil {
+		t.Fatalf("InvalidateBlock: %s", err)
+	}
+	_, _, err = dag.ProcessBlock(util.NewBlock(blockA3), BFNoPoWCheck)
+	var ruleErr RuleError
+	if !errors.As(err, &ruleErr) || ruleErr.ErrorCode != ErrInvalidAncestorBlock {
+		t.Fatalf("Expected processing blockA3 to fail with ErrInvalidAncestorBlock, but got: %v", err)
+	}
+	if status := dag.index.NodeStatus(nodeByMsgBlock(t, dag, blockA3)); status&statusInvalidAncestor == 0 {
+		t.Errorf("Expected blockA3 to be marked as having an invalid ancestor, but got status %b", status)
+	}
+
+	err = dag.ReconsiderBlock(blockA2.BlockHash())
+	if err != nil {
+		t.Fatalf("ReconsiderBlock: %s", err)
+	}
+
+	if status := dag.index.NodeStatus(nodeByMsgBlock(t, dag, blockA3)); !status.KnownValid() || status.KnownInvalid() {
+		t.Errorf("Expected blockA3 to be valid after reconsideration, but got status %b", status)
+	}
+	if !dag.SelectedTipHash().IsEqual(blockA3.BlockHash()) {
+		t.Errorf("Expected the selected tip to be %s, but got %s",
+			blockA3.BlockHash(), dag.SelectedTipHash())
+	}
+
+	// The DAG keeps growing normally on top of blockA3
+	prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA3)
+}
+
+// TestInvalidateBlockPersistence makes sure that an invalidated block stays
+// invalid after the DAG is restarted on the same database.
+func TestInvalidateBlockPersistence(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "TestInvalidateBlockPersistence")
+	if err != nil {
+		t.Fatalf("error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(tempDir)
+	dbPath := filepath.Join(tempDir, "db")
+
+	databaseContext, err := dbaccess.New(dbPath)
+	if err != nil {
+		t.Fatalf("error creating db: %s", err)
+	}
+	isDatabaseOpen := true
+	defer func() {
+		if isDatabaseOpen {
+			databaseContext.Close()
+		}
+	}()
+	newDAG := func() *BlockDAG {
+		dag, err := New(&Config{
+			DatabaseContext: databaseContext,
+			DAGParams:       &dagconfig.SimnetParams,
+			TimeSource:      NewTimeSource(),
+			SigCache:        txscript.NewSigCache(1000),
+		})
+		if err != nil {
+			t.Fatalf("failed to create dag instance: %s", err)
+		}
+		dag.TestSetCoinbaseMaturity(0)
+		return dag
+	}
+	dag := newDAG()
+
+	// Build the following DAG:
+	// genesis <- blockA1 <- blockB1
+	//                   <- blockA2 <- blockA3
+	genesis := dag.Params.GenesisBlock
+	blockA1 := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	blockB1 := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA1)
+	tipHashesWithoutA2 := dag.TipHashes()
+	blockA2 := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA1)
+	blockA3 := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA2)
+
+	err = dag.InvalidateBlock(blockA2.BlockHash())
+	if err != nil {
+		t.Fatalf("InvalidateBlock: %s", err)
+	}
+	utxoSetWithoutA2 := dag.virtual.utxoSet.utxoCollection.clone()
+
+	// Restart the DAG on the same database
+	err = databaseContext.Close()
+	if err != nil {
+		t.Fatalf("error closing db: %s", err)
+	}
+	isDatabaseOpen = false
+	databaseContext, err = dbaccess.New(dbPath)
+	if err != nil {
+		t.Fatalf("error reopening db: %s", err)
+	}
+	isDatabaseOpen = true
+	dag = newDAG()
+
+	if status := dag.index.NodeStatus(nodeByMsgBlock(t, dag, blockA2)); status&statusValidateFailed == 0 {
+		t.Errorf("Expected blockA2 to be marked as failing validation after the restart, but got status %b", status)
+	}
+	if status := dag.index.NodeStatus(nodeByMsgBlock(t, dag, blockA3)); status&statusInvalidAncestor == 0 {
+		t.Errorf("Expected blockA3 to be marked as having an invalid ancestor after the restart, "+
+			"but got status %b", status)
+	}
+	for _, block := range []*daghash.Hash{blockA2.BlockHash(), blockA3.BlockHash()} {
+		node, _ := dag.index.LookupNode(block)
+		for tip := range dag.virtual.parents {
+			isInPast, err := dag.isInPast(node, tip)
+			if err != nil {
+				t.Fatalf("isInPast: %s", err)
+			}
+			if node == tip || isInPast {
+				t.Errorf("Expected block %s not to be in the past of the virtual after the restart", block)
+			}
+		}
+	}
+	assertTipHashes(t, dag, tipHashesWithoutA2)
+	if !dag.SelectedTipHash().IsEqual(blockB1.BlockHash()) {
+		t.Errorf("Expected the selected tip to be %s after the restart, but got %s",
+			blockB1.BlockHash(), dag.SelectedTipHash())
+	}
+	if !reflect.DeepEqual(dag.virtual.utxoSet.utxoCollection, utxoSetWithoutA2) {
+		t.Errorf("Unexpected UTXO set after the restart")
+	}
+}
+
+func assertTipHashes(t *testing.T, dag *BlockDAG, expectedTipHashes []*daghash.Hash) {
+	tipHashes := dag.TipHashes()
+	daghash.Sort(tipHashes)
+	expected := make([]*daghash.Hash, len(expectedTipHashes))
+	copy(expected, expectedTipHashes)
+	daghash.Sort(expected)
+	if !daghash.AreEqual(tipHashes, expected) {
+		t.Errorf("Expected tips %s, but got %s", expected, tipHashes)
+	}
+}
@@ -0,0 +1,108 @@
+package blockdag
+
+import (
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// SubgraphNode describes a single block of a DAG subgraph, along with its
+// GHOSTDAG coloring and its position in the DAG.
+type SubgraphNode struct {
+	Hash               *daghash.Hash
+	ParentHashes       []*daghash.Hash
+	SelectedParentHash *daghash.Hash
+	BlueScore          uint64
+
+	// IsBlue is whether the block is colored blue by the selected parent
+	// chain block that merges it (or by the virtual block, if it's
+	// not yet merged by any chain block).
+	IsBlue bool
+
+	// IsChainBlock is whether the block is in the selected parent chain
+	// of the virtual block.
+	IsChainBlock bool
+
+	// ReachabilityIntervalStart and ReachabilityIntervalEnd are the
+	// bounds of the block's interval in the reachability tree.
+	ReachabilityIntervalStart uint64
+	ReachabilityIntervalEnd   uint64
+
+	IsFinalityPoint         bool
+	IsTip                   bool
+	IsVirtualSelectedParent bool
+}
+
+// Subgraph returns the blocks in highHash's past that are not in lowHash's
+// past, including both highHash and lowHash themselves, ordered by blue
+// score, or up to the provided max number of blocks. See antiPastBetween
+// for further details.
+//
+// This function is safe for concurrent access.
+func (dag *BlockDAG) Subgraph(lowHash, highHash *daghash.Hash, maxNodes uint64) ([]*SubgraphNode, error) {
+	dag.dagLock.RLock()
+	defer dag.dagLock.RUnlock()
+
+	nodes, err := dag.antiPastBetween(lowHash, highHash, maxNodes)
+	if err != nil {
+		return nil, err
+	}
+	blues := dag.chainBluesAbove(nodes)
+
+	subgraph := make([]*SubgraphNode, len(nodes))
+	for i, node := range nodes {
+		treeNode, err := dag.reachabilityTree.store.treeNodeByBlockNode(node)
+		if err != nil {
+			return nil, err
+		}
+
+		var selectedParentHash *daghash.Hash
+		if node.selectedParent != nil {
+			selectedParentHash = node.selectedParent.hash
+		}
+
+		subgraph[i] = &SubgraphNode{
+			Hash:                      node.hash,
+			ParentHashes:              node.parents.hashes(),
+			SelectedParentHash:        selectedParentHash,
+			BlueScore:                 node.blueScore,
+			IsBlue:                    blues.contains(node),
+			IsChainBlock:              dag.virtual.selectedParentChainSet.contains(node),
+			ReachabilityIntervalStart: treeNode.interval.start,
+			ReachabilityIntervalEnd:   treeNode.interval.end,
+			IsFinalityPoint:           node == dag.lastFinalityPoint,
+			IsTip:                     dag.virtual.tips().contains(node),
+			IsVirtualSelectedParent:   node == dag.virtual.selectedParent,
+		}
+	}
+	return subgraph, nil
+}
+
+// chainBluesAbove returns the blues of the virtual block and of every
+// selected parent chain block that may merge any of the given nodes.
+// Since a block is merged only by blocks in its future, it's enough to
+// collect the blues of chain blocks whose blue score isn't lower than
+// the lowest blue score among the given nodes.
+//
+// This function MUST be called with the DAG state lock held (for reads).
+func (dag *BlockDAG) chainBluesAbove(nodes []*blockNode) blockSet {
+	blues := newBlockSet()
+	if len(nodes) == 0 {
+		return blues
+	}
+	minBlueScore := nodes[0].blueScore
+	for _, node := range nodes {
+		if node.blueScore < minBlueScore {
+			minBlueScore = node.blueScore
+		}
+	}
+
+	for _, blue := range dag.virtual.blues {
+		blues.add(blue)
+	}
+	chain := dag.virtual.selectedParentChainSlice
+	for i := len(chain) - 1; i >= 0 && chain[i].blueScore >= minBlueScore; i-- {
+		for _, blue := range chain[i].blues {
+			blues.add(blue)
+		}
+	}
+	return blues
+}
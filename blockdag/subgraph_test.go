@@ -0,0 +1,103 @@
+package blockdag
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+func TestSubgraph(t *testing.T) {
+	params := dagconfig.SimnetParams
+	params.K = 1
+	dag, teardownFunc, err := DAGSetupInMemory(Config{
+		DAGParams: &params,
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	// Build the following DAG, in which one of blockA, blockB and blockC
+	// must be red, since K=1:
+	// genesis <- blockA <- blockD
+	//         <- blockB <-
+	//         <- blockC <-
+	genesis := params.GenesisBlock
+	blockA := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	blockB := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	blockC := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	blockD := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA, blockB, blockC)
+
+	subgraph, err := dag.Subgraph(genesis.BlockHash(), blockD.BlockHash(), 100)
+	if err != nil {
+		t.Fatalf("Subgraph: %s", err)
+	}
+	nodesByHash := make(map[daghash.Hash]*SubgraphNode)
+	for _, node := range subgraph {
+		nodesByHash[*node.Hash] = node
+	}
+	if len(nodesByHash) != 5 {
+		t.Fatalf("Expected the subgraph to contain 5 blocks, but got %d", len(nodesByHash))
+	}
+	if genesisNode := nodesByHash[*genesis.BlockHash()]; genesisNode == nil || !genesisNode.IsChainBlock {
+		t.Errorf("Expected the subgraph to contain the genesis as a chain block")
+	}
+
+	blueHashes, err := dag.BluesByBlockHash(blockD.BlockHash())
+	if err != nil {
+		t.Fatalf("BluesByBlockHash: %s", err)
+	}
+	blues := make(map[daghash.Hash]bool)
+	for _, hash := range blueHashes {
+		blues[*hash] = true
+	}
+	redCount := 0
+	for _, block := range []*daghash.Hash{blockA.BlockHash(), blockB.BlockHash(), blockC.BlockHash()} {
+		node, ok := nodesByHash[*block]
+		if !ok {
+			t.Fatalf("Block %s is missing from the subgraph", block)
+		}
+		if node.IsBlue != blues[*block] {
+			t.Errorf("Expected IsBlue of block %s to be %t, but got %t", block, blues[*block], node.IsBlue)
+		}
+		if !node.IsBlue {
+			redCount++
+		}
+		if node.IsTip {
+			t.Errorf("Block %s is unexpectedly marked as a tip", block)
+		}
+		if node.ReachabilityIntervalStart > node.ReachabilityIntervalEnd {
+			t.Errorf("Block %s has an invalid reachability interval [%d,%d]", block,
+				node.ReachabilityIntervalStart, node.ReachabilityIntervalEnd)
+		}
+	}
+	if redCount != 1 {
+		t.Errorf("Expected exactly one red block, but got %d", redCount)
+	}
+
+	nodeD := nodesByHash[*blockD.BlockHash()]
+	if !nodeD.IsBlue || !nodeD.IsTip || !nodeD.IsVirtualSelectedParent || !nodeD.IsChainBlock {
+		t.Errorf("Expected blockD to be a blue chain block and the virtual's selected parent, but got %+v", nodeD)
+	}
+	if !nodesByHash[*nodeD.SelectedParentHash].IsChainBlock {
+		t.Errorf("Expected the selected parent of blockD to be a chain block")
+	}
+	if len(nodeD.ParentHashes) != 3 {
+		t.Errorf("Expected blockD to have 3 parents, but got %d", len(nodeD.ParentHashes))
+	}
+
+	// The subgraph is capped by the max number of blocks
+	subgraph, err = dag.Subgraph(genesis.BlockHash(), blockD.BlockHash(), 1)
+	if err != nil {
+		t.Fatalf("Subgraph: %s", err)
+	}
+	if len(subgraph) != 1 {
+		t.Errorf("Expected a capped subgraph to contain 1 block, but got %d", len(subgraph))
+	}
+
+	_, err = dag.Subgraph(blockD.BlockHash(), genesis.BlockHash(), 100)
+	if err == nil {
+		t.Errorf("Expected Subgraph to fail when lowHash is above highHash")
+	}
+}
@@ -44,10 +44,73 @@ func FileExists(name string) bool {
 // database. Setting it to false is useful in tests that handle database
 // opening/closing by themselves.
 func DAGSetup(dbName string, openDb bool, config Config) (*BlockDAG, func(), error) {
-	var teardown func()
+	if openDb {
+		return dagSetupWithDBType(dbName, dbaccess.FFLDBType, config)
+	}
+	return newDAGForTest(config, countSpawnedGoroutines())
+}
+
+// dagSetupWithDBType is the same as DAGSetup with openDb set to true,
+// except that the database is of the given on-disk type.
+func dagSetupWithDBType(dbName string, dbType string, config Config) (*BlockDAG, func(), error) {
+	restoreSpawn := countSpawnedGoroutines()
+
+	tmpDir, err := ioutil.TempDir("", "DAGSetup")
+	if err != nil {
+		return nil, nil, errors.Errorf("error creating temp dir: %s", err)
+	}
 
-	// To make sure that the teardown function is not called before any goroutines finished to run -
-	// overwrite `spawn` to count the number of running goroutines
+	// We set ldb.Options here to return nil because normally
+	// the database is initialized with very large caches that
+	// can make opening/closing the database for every test
+	// quite heavy.
+	originalLDBOptions := ldb.Options
+	ldb.Options = func() *opt.Options {
+		return nil
+	}
+
+	dbPath := filepath.Join(tmpDir, dbName)
+	_ = os.RemoveAll(dbPath)
+	databaseContext, err := dbaccess.Open(dbType, dbPath)
+	if err != nil {
+		return nil, nil, errors.Errorf("error creating db: %s", err)
+	}
+
+	config.DatabaseContext = databaseContext
+
+	// Setup a teardown function for cleaning up. This function is
+	// returned to the caller to be invoked when it is done testing.
+	teardown := func() {
+		restoreSpawn()
+		databaseContext.Close()
+		ldb.Options = originalLDBOptions
+		os.RemoveAll(dbPath)
+	}
+
+	return newDAGForTest(config, teardown)
+}
+
+// DAGSetupInMemory is the same as DAGSetup, except that the DAG
+// instance uses a new in-memory database instead of one on disk.
+func DAGSetupInMemory(config Config) (*BlockDAG, func(), error) {
+	restoreSpawn := countSpawnedGoroutines()
+
+	databaseContext := dbaccess.NewInMemory()
+	config.DatabaseContext = databaseContext
+	teardown := func() {
+		restoreSpawn()
+		databaseContext.Close()
+	}
+
+	return newDAGForTest(config, teardown)
+}
+
+// countSpawnedGoroutines overwrites `spawn` to count the number of
+// running goroutines. This is to make sure that the teardown function
+// is not called before any goroutines finished to run. It returns a
+// function that waits for all the spawned goroutines to finish and
+// restores the original `spawn`.
+func countSpawnedGoroutines() (restore func()) {
 	spawnWaitGroup := sync.WaitGroup{}
 	realSpawn := spawn
 	spawn = func(name string, f func()) {
@@ -57,48 +120,15 @@ func DAGSetup(dbName string, openDb bool, config Config) (*BlockDAG, func(), err
 			spawnWaitGroup.Done()
 		})
 	}
-
-	if openDb {
-		var err error
-		tmpDir, err := ioutil.TempDir("", "DAGSetup")
-		if err != nil {
-			return nil, nil, errors.Errorf("error creating temp dir: %s", err)
-		}
-
-		// We set ldb.Options here to return nil because normally
-		// the database is initialized with very large caches that
-		// can make opening/closing the database for every test
-		// quite heavy.
-		originalLDBOptions := ldb.Options
-		ldb.Options = func() *opt.Options {
-			return nil
-		}
-
-		dbPath := filepath.Join(tmpDir, dbName)
-		_ = os.RemoveAll(dbPath)
-		databaseContext, err := dbaccess.New(dbPath)
-		if err != nil {
-			return nil, nil, errors.Errorf("error creating db: %s", err)
-		}
-
-		config.DatabaseContext = databaseContext
-
-		// Setup a teardown function for cleaning up. This function is
-		// returned to the caller to be invoked when it is done testing.
-		teardown = func() {
-			spawnWaitGroup.Wait()
-			spawn = realSpawn
-			databaseContext.Close()
-			ldb.Options = originalLDBOptions
-			os.RemoveAll(dbPath)
-		}
-	} else {
-		teardown = func() {
-			spawnWaitGroup.Wait()
-			spawn = realSpawn
-		}
+	return func() {
+		spawnWaitGroup.Wait()
+		spawn = realSpawn
 	}
+}
 
+// newDAGForTest creates a new DAG instance with the given config,
+// and calls the given teardown function if it fails.
+func newDAGForTest(config Config, teardown func()) (*BlockDAG, func(), error) {
 	config.TimeSource = NewTimeSource()
 	config.SigCache = txscript.NewSigCache(1000)
 
@@ -297,7 +327,7 @@ func PrepareBlockForTest(dag *BlockDAG, parentHashes []*daghash.Hash, transactio
 
 // PrepareAndProcessBlockForTest prepares a block that points to the given parent
 // hashes and process it.
-func PrepareAndProcessBlockForTest(t *testing.T, dag *BlockDAG, parentHashes []*daghash.Hash, transactions []*domainmessage.MsgTx) *domainmessage.MsgBlock {
+func PrepareAndProcessBlockForTest(t testing.TB, dag *BlockDAG, parentHashes []*daghash.Hash, transactions []*domainmessage.MsgTx) *domainmessage.MsgBlock {
 	daghash.Sort(parentHashes)
 	block, err := PrepareBlockForTest(dag, parentHashes, transactions)
 	if err != nil {
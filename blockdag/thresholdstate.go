@@ -7,6 +7,7 @@ package blockdag
 import (
 	"fmt"
 
+	"github.com/kaspanet/kaspad/dagconfig"
 	"github.com/kaspanet/kaspad/util/daghash"
 	"github.com/pkg/errors"
 )
@@ -286,6 +287,77 @@ func (dag *BlockDAG) IsDeploymentActive(deploymentID uint32) (bool, error) {
 	return state == ThresholdActive, nil
 }
 
+// DeploymentInfo describes the state of a rule change deployment for the
+// block AFTER the current selected tip, and the signalling for it in the
+// current confirmation window.
+type DeploymentInfo struct {
+	ID         uint32
+	Deployment *dagconfig.ConsensusDeployment
+	State      ThresholdState
+
+	// WindowSize is the number of blocks in each confirmation window, and
+	// Threshold is the number of them that have to signal for the
+	// deployment in order to lock it in.
+	WindowSize uint64
+	Threshold  uint64
+
+	// WindowBlocks is the number of blocks in the current confirmation
+	// window so far, and SignallingBlocks is the number of them that
+	// signal for the deployment.
+	WindowBlocks     uint64
+	SignallingBlocks uint64
+}
+
+// DeploymentInfos returns the DeploymentInfo of every deployment of the
+// network, ordered by deployment ID.
+//
+// This function is safe for concurrent access.
+func (dag *BlockDAG) DeploymentInfos() ([]*DeploymentInfo, error) {
+	dag.dagLock.Lock()
+	defer dag.dagLock.Unlock()
+
+	selectedTip := dag.selectedTip()
+	windowSize := dag.Params.MinerConfirmationWindow
+	windowBlocks := (selectedTip.blueScore + 1) % windowSize
+	var windowNodes []*blockNode
+	if windowBlocks > 0 {
+		windowNodes = append(windowNodes, selectedTip)
+		windowNodes = append(windowNodes, blueBlockWindow(selectedTip, windowBlocks-1)...)
+	}
+
+	infos := make([]*DeploymentInfo, len(dag.Params.Deployments))
+	for id := range dag.Params.Deployments {
+		deployment := &dag.Params.Deployments[id]
+		state, err := dag.deploymentState(selectedTip, uint32(id))
+		if err != nil {
+			return nil, err
+		}
+
+		checker := deploymentChecker{deployment: deployment, dag: dag}
+		var signallingBlocks uint64
+		for _, node := range windowNodes {
+			condition, err := checker.Condition(node)
+			if err != nil {
+				return nil, err
+			}
+			if condition {
+				signallingBlocks++
+			}
+		}
+
+		infos[id] = &DeploymentInfo{
+			ID:               uint32(id),
+			Deployment:       deployment,
+			State:            state,
+			WindowSize:       windowSize,
+			Threshold:        dag.Params.RuleChangeActivationThreshold,
+			WindowBlocks:     windowBlocks,
+			SignallingBlocks: signallingBlocks,
+		}
+	}
+	return infos, nil
+}
+
 // deploymentState returns the current rule change threshold for a given
 // deploymentID. The threshold is evaluated from the point of view of the block
 // node passed in as the first argument to this method.
@@ -297,7 +369,7 @@ func (dag *BlockDAG) IsDeploymentActive(deploymentID uint32) (bool, error) {
 //
 // This function MUST be called with the DAG state lock held (for writes).
 func (dag *BlockDAG) deploymentState(prevNode *blockNode, deploymentID uint32) (ThresholdState, error) {
-	if deploymentID > uint32(len(dag.Params.Deployments)) {
+	if deploymentID >= uint32(len(dag.Params.Deployments)) {
 		return ThresholdFailed, errors.Errorf("deployment ID %d does not exist", deploymentID)
 	}
 
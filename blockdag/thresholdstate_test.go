@@ -5,8 +5,10 @@
 package blockdag
 
 import (
+	"math"
 	"testing"
 
+	"github.com/kaspanet/kaspad/dagconfig"
 	"github.com/kaspanet/kaspad/util/daghash"
 )
 
@@ -132,3 +134,61 @@ nextTest:
 		}
 	}
 }
+
+// TestDeploymentInfos ensures that DeploymentInfos reports the state of a
+// deployment and the signalling for it in the current confirmation window
+// as the deployment progresses.
+func TestDeploymentInfos(t *testing.T) {
+	params := dagconfig.SimnetParams
+	params.MinerConfirmationWindow = 4
+	params.RuleChangeActivationThreshold = 3
+	params.Deployments = []dagconfig.ConsensusDeployment{
+		{Name: "test", BitNumber: 1, StartTime: 0, ExpireTime: math.MaxUint64},
+	}
+	dag, teardownFunc, err := DAGSetup("TestDeploymentInfos", true, Config{
+		DAGParams: &params,
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	tests := []struct {
+		blueScore        uint64
+		state            ThresholdState
+		windowBlocks     uint64
+		signallingBlocks uint64
+	}{
+		// The first window is always in the defined state, so its
+		// blocks don't signal
+		{blueScore: 2, state: ThresholdDefined, windowBlocks: 3, signallingBlocks: 0},
+		{blueScore: 3, state: ThresholdStarted, windowBlocks: 0, signallingBlocks: 0},
+		{blueScore: 5, state: ThresholdStarted, windowBlocks: 2, signallingBlocks: 2},
+		{blueScore: 7, state: ThresholdLockedIn, windowBlocks: 0, signallingBlocks: 0},
+		{blueScore: 11, state: ThresholdActive, windowBlocks: 0, signallingBlocks: 0},
+	}
+
+	tip := params.GenesisBlock
+	for _, test := range tests {
+		for dag.SelectedTipBlueScore() < test.blueScore {
+			tip = prepareAndProcessBlockByParentMsgBlocks(t, dag, tip)
+		}
+
+		infos, err := dag.DeploymentInfos()
+		if err != nil {
+			t.Fatalf("DeploymentInfos: %s", err)
+		}
+		if len(infos) != 1 {
+			t.Fatalf("Expected a single deployment info, but got %d", len(infos))
+		}
+		info := infos[0]
+		if info.State != test.state || info.WindowBlocks != test.windowBlocks ||
+			info.SignallingBlocks != test.signallingBlocks {
+
+			t.Errorf("Unexpected deployment info at blue score %d: got state %s, "+
+				"%d window blocks and %d signalling blocks, want state %s, %d window "+
+				"blocks and %d signalling blocks", test.blueScore, info.State, info.WindowBlocks,
+				info.SignallingBlocks, test.state, test.windowBlocks, test.signallingBlocks)
+		}
+	}
+}
@@ -1,25 +1,192 @@
 package blockdag
 
 import (
+	"sync"
+	"time"
+
 	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
 )
 
 // TimeSource is the interface to access time.
 type TimeSource interface {
 	// Now returns the current time.
 	Now() mstime.Time
+
+	// After returns a channel that receives the current time once the
+	// time source reaches the time that is d from now. d must have a
+	// millisecond precision.
+	After(d time.Duration) <-chan mstime.Time
+
+	// CancelAfter stops the given channel, which was returned by After,
+	// from receiving the time, and releases it. It does nothing if the
+	// channel has already received the time.
+	CancelAfter(c <-chan mstime.Time)
+
+	// SetMockTime makes the time source return the given time instead of
+	// the local time until it's changed again. Setting it to the zero
+	// time makes the time source go back to the local time.
+	SetMockTime(mockTime mstime.Time)
+
+	// AdvanceMockTime moves the mock time forward by d. It returns an
+	// error if the mock time isn't set.
+	AdvanceMockTime(d time.Duration) error
 }
 
 // timeSource provides an implementation of the TimeSource interface
-// that simply returns the current local time.
-type timeSource struct{}
+// that returns the current local time, unless a mock time is set.
+type timeSource struct {
+	lock sync.Mutex
+
+	// mockTime is the time returned by Now, or the zero time if the local
+	// time should be returned.
+	mockTime mstime.Time
+
+	// waiters are the channels returned by After that haven't received
+	// the time yet.
+	waiters map[*timeWaiter]struct{}
+}
 
-// Now returns the current local time, with one millisecond precision.
-func (m *timeSource) Now() mstime.Time {
+// timeWaiter is a channel returned by After, along with the time it should
+// receive the time at.
+type timeWaiter struct {
+	deadline mstime.Time
+	c        chan mstime.Time
+
+	// timer fires the waiter while the local time is used, and is nil
+	// while a mock time is set.
+	timer *time.Timer
+}
+
+// Now returns the current local time, with one millisecond precision, or
+// the mock time if it's set.
+func (ts *timeSource) Now() mstime.Time {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+	return ts.nowNoLock()
+}
+
+func (ts *timeSource) nowNoLock() mstime.Time {
+	if !ts.mockTime.IsZero() {
+		return ts.mockTime
+	}
 	return mstime.Now()
 }
 
+// After returns a channel that receives the current time once the time
+// source reaches the time that is d from now. While a mock time is set,
+// this only happens when the mock time is set or advanced past that time.
+func (ts *timeSource) After(d time.Duration) <-chan mstime.Time {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	waiter := &timeWaiter{
+		deadline: ts.nowNoLock().Add(d),
+		c:        make(chan mstime.Time, 1),
+	}
+	ts.waiters[waiter] = struct{}{}
+	ts.scheduleNoLock(waiter)
+	return waiter.c
+}
+
+// CancelAfter stops the given channel, which was returned by After, from
+// receiving the time, and releases it. It does nothing if the channel has
+// already received the time.
+func (ts *timeSource) CancelAfter(c <-chan mstime.Time) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	for waiter := range ts.waiters {
+		if waiter.c != c {
+			continue
+		}
+		if waiter.timer != nil {
+			waiter.timer.Stop()
+		}
+		delete(ts.waiters, waiter)
+		return
+	}
+}
+
+// SetMockTime makes Now return the given time until it's changed again.
+// Setting it to the zero time makes Now return the local time.
+func (ts *timeSource) SetMockTime(mockTime mstime.Time) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	ts.mockTime = mockTime
+	ts.rescheduleAllNoLock()
+}
+
+// AdvanceMockTime moves the mock time forward by d. It returns an error if
+// the mock time isn't set.
+func (ts *timeSource) AdvanceMockTime(d time.Duration) error {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	if ts.mockTime.IsZero() {
+		return errors.New("mock time is not set")
+	}
+	if d < 0 {
+		return errors.Errorf("mock time can't be moved backwards (by %s)", -d)
+	}
+	ts.mockTime = ts.mockTime.Add(d)
+	ts.rescheduleAllNoLock()
+	return nil
+}
+
+// rescheduleAllNoLock makes all the waiters wait according to the current
+// clock of the time source, which might have changed.
+//
+// This function MUST be called with the time source lock held.
+func (ts *timeSource) rescheduleAllNoLock() {
+	for waiter := range ts.waiters {
+		if waiter.timer != nil {
+			waiter.timer.Stop()
+			waiter.timer = nil
+		}
+		ts.scheduleNoLock(waiter)
+	}
+}
+
+// scheduleNoLock fires the waiter if its deadline has passed, and otherwise
+// sets a timer for it if the local time is used.
+//
+// This function MUST be called with the time source lock held.
+func (ts *timeSource) scheduleNoLock(waiter *timeWaiter) {
+	now := ts.nowNoLock()
+	if !waiter.deadline.After(now) {
+		ts.fireNoLock(waiter)
+		return
+	}
+	if !ts.mockTime.IsZero() {
+		return
+	}
+	waiter.timer = time.AfterFunc(waiter.deadline.Sub(now), func() {
+		ts.lock.Lock()
+		defer ts.lock.Unlock()
+
+		// The waiter might have already been fired, or the clock of
+		// the time source might have changed after the timer fired
+		// but before the lock was acquired.
+		if _, ok := ts.waiters[waiter]; !ok || ts.nowNoLock().Before(waiter.deadline) {
+			return
+		}
+		ts.fireNoLock(waiter)
+	})
+}
+
+// fireNoLock sends the current time to the waiter and removes it.
+//
+// This function MUST be called with the time source lock held.
+func (ts *timeSource) fireNoLock(waiter *timeWaiter) {
+	delete(ts.waiters, waiter)
+	waiter.c <- ts.nowNoLock()
+}
+
 // NewTimeSource returns a new instance of a TimeSource
 func NewTimeSource() TimeSource {
-	return &timeSource{}
+	return &timeSource{
+		waiters: make(map[*timeWaiter]struct{}),
+	}
 }
@@ -0,0 +1,139 @@
+package blockdag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func TestMockTime(t *testing.T) {
+	timeSource := NewTimeSource()
+
+	err := timeSource.AdvanceMockTime(time.Second)
+	if err == nil {
+		t.Fatalf("AdvanceMockTime: expected an error when the mock time isn't set")
+	}
+
+	mockTime := mstime.UnixMilliseconds(1000000)
+	timeSource.SetMockTime(mockTime)
+	if timeSource.Now().UnixMilliseconds() != mockTime.UnixMilliseconds() {
+		t.Fatalf("expected the time to be %s but got %s", mockTime, timeSource.Now())
+	}
+
+	err = timeSource.AdvanceMockTime(time.Minute)
+	if err != nil {
+		t.Fatalf("AdvanceMockTime: %s", err)
+	}
+	if timeSource.Now().UnixMilliseconds() != mockTime.Add(time.Minute).UnixMilliseconds() {
+		t.Fatalf("expected the time to be %s but got %s", mockTime.Add(time.Minute), timeSource.Now())
+	}
+
+	err = timeSource.AdvanceMockTime(-time.Second)
+	if err == nil {
+		t.Fatalf("AdvanceMockTime: expected an error when moving the mock time backwards")
+	}
+
+	// Setting the zero time goes back to the local time.
+	timeSource.SetMockTime(mstime.Time{})
+	if mstime.Since(timeSource.Now()) > time.Minute {
+		t.Fatalf("expected the local time but got %s", timeSource.Now())
+	}
+}
+
+func TestMockTimeAfter(t *testing.T) {
+	timeSource := NewTimeSource()
+	mockTime := mstime.UnixMilliseconds(1000000)
+	timeSource.SetMockTime(mockTime)
+
+	c := timeSource.After(time.Minute)
+	select {
+	case <-c:
+		t.Fatalf("After fired before the mock time was advanced")
+	default:
+	}
+
+	err := timeSource.AdvanceMockTime(time.Minute - time.Millisecond)
+	if err != nil {
+		t.Fatalf("AdvanceMockTime: %s", err)
+	}
+	select {
+	case <-c:
+		t.Fatalf("After fired before its time")
+	default:
+	}
+
+	err = timeSource.AdvanceMockTime(time.Millisecond)
+	if err != nil {
+		t.Fatalf("AdvanceMockTime: %s", err)
+	}
+	select {
+	case now := <-c:
+		if now.UnixMilliseconds() != mockTime.Add(time.Minute).UnixMilliseconds() {
+			t.Errorf("expected After to receive %s but got %s", mockTime.Add(time.Minute), now)
+		}
+	default:
+		t.Fatalf("After didn't fire after the mock time was advanced")
+	}
+
+	// Setting the mock time past the deadline fires as well.
+	c = timeSource.After(time.Hour)
+	timeSource.SetMockTime(mockTime.Add(2 * time.Hour))
+	select {
+	case <-c:
+	default:
+		t.Fatalf("After didn't fire after the mock time was set past it")
+	}
+
+	// Without a mock time, After uses the local time.
+	timeSource.SetMockTime(mstime.Time{})
+	select {
+	case <-timeSource.After(time.Millisecond):
+	case <-time.After(10 * time.Second):
+		t.Fatalf("After didn't fire with the local time")
+	}
+}
+
+func TestCancelAfter(t *testing.T) {
+	ts := NewTimeSource().(*timeSource)
+	mockTime := mstime.UnixMilliseconds(1000000)
+	ts.SetMockTime(mockTime)
+
+	c := ts.After(time.Minute)
+	ts.CancelAfter(c)
+	if len(ts.waiters) != 0 {
+		t.Fatalf("CancelAfter didn't remove the waiter")
+	}
+	err := ts.AdvanceMockTime(time.Hour)
+	if err != nil {
+		t.Fatalf("AdvanceMockTime: %s", err)
+	}
+	select {
+	case <-c:
+		t.Fatalf("After fired after it was canceled")
+	default:
+	}
+
+	// Canceling a channel that already received the time does nothing.
+	c = ts.After(time.Minute)
+	err = ts.AdvanceMockTime(time.Minute)
+	if err != nil {
+		t.Fatalf("AdvanceMockTime: %s", err)
+	}
+	ts.CancelAfter(c)
+	select {
+	case <-c:
+	default:
+		t.Fatalf("After didn't fire after the mock time was advanced")
+	}
+
+	// Canceling works with the local time as well.
+	ts.SetMockTime(mstime.Time{})
+	c = ts.After(100 * time.Millisecond)
+	ts.CancelAfter(c)
+	select {
+	case <-c:
+		t.Fatalf("After fired after it was canceled")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
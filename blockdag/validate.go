@@ -994,10 +994,18 @@ func (dag *BlockDAG) checkConnectToPastUTXO(block *blockNode, pastUTXO UTXOSet,
 		// transactions are actually allowed to spend the coins by running the
 		// expensive SCHNORR signature check scripts. Doing this last helps
 		// prevent CPU exhaustion attacks.
-		err := checkBlockScripts(block, pastUTXO, transactions, scriptFlags, dag.sigCache)
+		// Scripts of blocks in the past of the assume-valid block are not
+		// checked, since that block is known to be valid.
+		isAssumedValid, err := dag.isAssumedValid(block)
 		if err != nil {
 			return nil, err
 		}
+		if !isAssumedValid {
+			err := checkBlockScripts(block, pastUTXO, transactions, scriptFlags, dag.sigCache)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	return feeData, nil
 }
@@ -0,0 +1,290 @@
+package blockdag
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+// VerifyConsistency checks that the data the DAG loaded from the database
+// is consistent with itself. Namely, it checks that:
+//  - The body of every block in the block index is stored in the database
+//  - The reachability intervals agree with the parent/child relations
+//    between blocks
+//  - The stored multiset of every block matches its UTXO commitment, and
+//    the multisets of the tips and of a random sample of multisetSampleSize
+//    other non-finalized blocks match the ones recomputed from the UTXO set
+//    and the UTXO diffs
+//
+// It returns a description of every inconsistency found. The returned
+// error is non-nil only if the verification itself could not be completed.
+//
+// This function is safe for concurrent access.
+func (dag *BlockDAG) VerifyConsistency(multisetSampleSize int) ([]string, error) {
+	dag.dagLock.RLock()
+	defer dag.dagLock.RUnlock()
+
+	var inconsistencies []string
+	for _, verify := range []func() ([]string, error){
+		dag.verifyBlockBodies,
+		dag.verifyReachability,
+		func() ([]string, error) { return dag.verifyMultisets(multisetSampleSize) },
+	} {
+		found, err := verify()
+		if err != nil {
+			return nil, err
+		}
+		inconsistencies = append(inconsistencies, found...)
+	}
+	return inconsistencies, nil
+}
+
+// verifyBlockBodies checks that the body of every block in the block index
+// is stored in the database, and that it matches its hash.
+//
+// This function MUST be called with the DAG state lock held (for reads).
+func (dag *BlockDAG) verifyBlockBodies() ([]string, error) {
+	var inconsistencies []string
+	for hash := range dag.index.index {
+		hash := hash
+		exists, err := dbaccess.HasBlock(dag.databaseContext, &hash)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("block %s is in the block index but its body is missing", hash))
+			continue
+		}
+
+		blockBytes, err := dbaccess.FetchBlock(dag.databaseContext, &hash)
+		if err != nil {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("failed to read the body of block %s: %s", hash, err))
+			continue
+		}
+		block, err := util.NewBlockFromBytes(blockBytes)
+		if err != nil {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("failed to deserialize the body of block %s: %s", hash, err))
+			continue
+		}
+		if !block.Hash().IsEqual(&hash) {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("the body stored for block %s has hash %s", hash, block.Hash()))
+		}
+	}
+	return inconsistencies, nil
+}
+
+// verifyReachability checks that every block has reachability data, that
+// its reachability tree parent is its selected parent, that its interval
+// is contained in the interval of its tree parent, and that every parent
+// of it is in its past according to the reachability data.
+//
+// This function MUST be called with the DAG state lock held (for reads).
+func (dag *BlockDAG) verifyReachability() ([]string, error) {
+	var inconsistencies []string
+	for _, node := range dag.index.index {
+		treeNode, err := dag.reachabilityTree.store.treeNodeByBlockNode(node)
+		if err != nil {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("block %s has no reachability data", node.hash))
+			continue
+		}
+
+		if node.selectedParent != nil {
+			if treeNode.parent == nil || treeNode.parent.blockNode != node.selectedParent {
+				inconsistencies = append(inconsistencies,
+					fmt.Sprintf("the reachability tree parent of block %s is not its selected parent", node.hash))
+			} else if treeNode.interval.start < treeNode.parent.interval.start ||
+				treeNode.interval.end > treeNode.parent.interval.end {
+
+				inconsistencies = append(inconsistencies,
+					fmt.Sprintf("the reachability interval %s of block %s is not contained in the "+
+						"interval %s of its selected parent", treeNode.interval, node.hash,
+						treeNode.parent.interval))
+			}
+		}
+
+		for parent := range node.parents {
+			if _, err := dag.reachabilityTree.store.treeNodeByBlockNode(parent); err != nil {
+				// The missing reachability data of the parent is
+				// reported separately.
+				continue
+			}
+			isInPast, err := dag.isInPast(parent, node)
+			if err != nil {
+				return nil, err
+			}
+			if !isInPast {
+				inconsistencies = append(inconsistencies,
+					fmt.Sprintf("according to the reachability data, parent %s of block %s "+
+						"is not in its past", parent.hash, node.hash))
+			}
+		}
+	}
+	return inconsistencies, nil
+}
+
+// verifyMultisets checks that the stored multiset of every valid block
+// matches its UTXO commitment. Furthermore, it restores the past UTXO of
+// every tip and of a random sample of sampleSize other non-finalized
+// blocks from the UTXO set and the UTXO diffs, and checks that its
+// multiset matches the stored one.
+//
+// This function MUST be called with the DAG state lock held (for reads).
+func (dag *BlockDAG) verifyMultisets(sampleSize int) ([]string, error) {
+	var inconsistencies []string
+	var candidates []*blockNode
+	for _, node := range dag.index.index {
+		if !dag.index.NodeStatus(node).KnownValid() {
+			continue
+		}
+		ms, err := dag.multisetStore.multisetByBlockNode(node)
+		if err != nil {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("block %s has no stored multiset", node.hash))
+			continue
+		}
+		multisetHash := daghash.Hash(*ms.Finalize())
+		if !multisetHash.IsEqual(node.utxoCommitment) {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("the stored multiset of block %s is %s, but its UTXO commitment is %s",
+					node.hash, multisetHash, node.utxoCommitment))
+			continue
+		}
+		if !node.isFinalized && !dag.virtual.tips().contains(node) {
+			candidates = append(candidates, node)
+		}
+	}
+
+	rand.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	if len(candidates) > sampleSize {
+		candidates = candidates[:sampleSize]
+	}
+	sample := candidates
+	for tip := range dag.virtual.tips() {
+		sample = append(sample, tip)
+	}
+
+	virtualMultiset, err := utxoCollectionMultiset(secp256k1.NewMultiset(), dag.virtual.utxoSet.utxoCollection, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range sample {
+		storedMultiset, err := dag.multisetStore.multisetByBlockNode(node)
+		if err != nil {
+			// The missing multiset is reported above
+			continue
+		}
+		pastUTXO, err := dag.restorePastUTXO(node)
+		if err != nil {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("failed to restore the past UTXO of block %s: %s", node.hash, err))
+			continue
+		}
+		diff := pastUTXO.(*DiffUTXOSet).UTXODiff
+		ms := secp256k1.NewMultiset()
+		ms.Combine(virtualMultiset)
+		ms, err = utxoCollectionMultiset(ms, diff.toRemove, true)
+		if err != nil {
+			return nil, err
+		}
+		ms, err = utxoCollectionMultiset(ms, diff.toAdd, false)
+		if err != nil {
+			return nil, err
+		}
+		multisetHash := daghash.Hash(*ms.Finalize())
+		storedMultisetHash := daghash.Hash(*storedMultiset.Finalize())
+		if !multisetHash.IsEqual(&storedMultisetHash) {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("the multiset of the past UTXO of block %s is %s, but the stored one is %s",
+					node.hash, multisetHash, storedMultisetHash))
+		}
+	}
+	return inconsistencies, nil
+}
+
+// utxoCollectionMultiset adds every entry of the given collection to the
+// given multiset, or removes them from it if remove is true.
+func utxoCollectionMultiset(ms *secp256k1.MultiSet, collection utxoCollection, remove bool) (*secp256k1.MultiSet, error) {
+	for outpoint, entry := range collection {
+		outpoint := outpoint
+		var err error
+		if remove {
+			ms, err = removeUTXOFromMultiset(ms, entry, &outpoint)
+		} else {
+			ms, err = addUTXOToMultiset(ms, entry, &outpoint)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ms, nil
+}
+
+// StoredBlockHashes returns the hashes of all the blocks in the block index
+// of the given database, ordered by blue score, split into the ones whose
+// stored status is known to be invalid and all the rest. Since it doesn't
+// require the DAG state to be loaded, it is usable even if the DAG state is
+// corrupted.
+func StoredBlockHashes(dbContext dbaccess.Context) (hashes []*daghash.Hash,
+	invalidHashes []*daghash.Hash, err error) {
+
+	cursor, err := dbaccess.BlockIndexCursor(dbContext)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, nil, err
+		}
+		hash, err := blockHashFromBlockIndexKey(key.Suffix())
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "malformed block index key %x", key.Suffix())
+		}
+		blockRow, err := cursor.Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		status, err := storedBlockStatus(blockRow)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "malformed block index entry of block %s", hash)
+		}
+		if status.KnownInvalid() {
+			invalidHashes = append(invalidHashes, hash)
+			continue
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, invalidHashes, nil
+}
+
+// storedBlockStatus returns the status stored in the given value of the
+// block index bucket. See deserializeBlockNode for its format.
+func storedBlockStatus(blockRow []byte) (blockStatus, error) {
+	buffer := bytes.NewReader(blockRow)
+	var header domainmessage.BlockHeader
+	err := header.Deserialize(buffer)
+	if err != nil {
+		return 0, err
+	}
+	statusByte, err := buffer.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	return blockStatus(statusByte), nil
+}
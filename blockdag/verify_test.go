@@ -0,0 +1,113 @@
+package blockdag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+func TestVerifyConsistency(t *testing.T) {
+	dag, teardownFunc, err := DAGSetupInMemory(Config{
+		DAGParams: &dagconfig.SimnetParams,
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	// Build the following DAG:
+	// genesis <- blockA <- blockB <- blockC
+	//                   <- blockD
+	genesis := dag.Params.GenesisBlock
+	blockA := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	blockB := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA)
+	prepareAndProcessBlockByParentMsgBlocks(t, dag, blockB)
+	prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA)
+
+	inconsistencies, err := dag.VerifyConsistency(100)
+	if err != nil {
+		t.Fatalf("VerifyConsistency: %s", err)
+	}
+	if len(inconsistencies) != 0 {
+		t.Fatalf("Unexpected inconsistencies: %s", inconsistencies)
+	}
+
+	// Corrupt the UTXO diff of blockB, which isn't a tip, so its
+	// recomputed multiset no longer matches the stored one
+	diff, err := dag.utxoDiffStore.diffByNode(nodeByMsgBlock(t, dag, blockB))
+	if err != nil {
+		t.Fatalf("diffByNode: %s", err)
+	}
+	outpoint := domainmessage.Outpoint{TxID: daghash.TxID{1}}
+	diff.toAdd.add(outpoint, NewUTXOEntry(&domainmessage.TxOut{Value: 1}, false, 0))
+
+	// Corrupt the reachability interval of blockA
+	treeNode, err := dag.reachabilityTree.store.treeNodeByBlockNode(nodeByMsgBlock(t, dag, blockA))
+	if err != nil {
+		t.Fatalf("treeNodeByBlockNode: %s", err)
+	}
+	treeNode.interval = newReachabilityInterval(treeNode.parent.interval.end+1, treeNode.parent.interval.end+2)
+
+	inconsistencies, err = dag.VerifyConsistency(100)
+	if err != nil {
+		t.Fatalf("VerifyConsistency: %s", err)
+	}
+	for _, expected := range []string{
+		"the multiset of the past UTXO of block " + blockB.BlockHash().String(),
+		"the reachability interval [",
+	} {
+		found := false
+		for _, inconsistency := range inconsistencies {
+			if strings.HasPrefix(inconsistency, expected) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("Expected an inconsistency starting with %q, but got: %s", expected, inconsistencies)
+		}
+	}
+}
+
+func TestStoredBlockHashes(t *testing.T) {
+	dag, teardownFunc, err := DAGSetupInMemory(Config{
+		DAGParams: &dagconfig.SimnetParams,
+	})
+	if err != nil {
+		t.Fatalf("Failed to setup DAG instance: %v", err)
+	}
+	defer teardownFunc()
+
+	// Build the following DAG, and invalidate blockC:
+	// genesis <- blockA <- blockB
+	//                   <- blockC <- blockD
+	genesis := dag.Params.GenesisBlock
+	blockA := prepareAndProcessBlockByParentMsgBlocks(t, dag, genesis)
+	blockB := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA)
+	blockC := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockA)
+	blockD := prepareAndProcessBlockByParentMsgBlocks(t, dag, blockC)
+	err = dag.InvalidateBlock(blockC.BlockHash())
+	if err != nil {
+		t.Fatalf("InvalidateBlock: %s", err)
+	}
+
+	hashes, invalidHashes, err := StoredBlockHashes(dag.databaseContext)
+	if err != nil {
+		t.Fatalf("StoredBlockHashes: %s", err)
+	}
+	expectedHashes := []*daghash.Hash{genesis.BlockHash(), blockA.BlockHash(), blockB.BlockHash()}
+	daghash.Sort(hashes)
+	daghash.Sort(expectedHashes)
+	if !daghash.AreEqual(hashes, expectedHashes) {
+		t.Errorf("Unexpected hashes. Want: %s, got: %s", expectedHashes, hashes)
+	}
+	expectedInvalidHashes := []*daghash.Hash{blockC.BlockHash(), blockD.BlockHash()}
+	daghash.Sort(invalidHashes)
+	daghash.Sort(expectedInvalidHashes)
+	if !daghash.AreEqual(invalidHashes, expectedInvalidHashes) {
+		t.Errorf("Unexpected invalid hashes. Want: %s, got: %s", expectedInvalidHashes, invalidHashes)
+	}
+}
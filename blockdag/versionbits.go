@@ -17,11 +17,16 @@ const (
 
 	// vbTopMask is the bitmask to use to determine whether or not the
 	// version bits scheme is in use.
-	vbTopMask = 0xe0000000
+	vbTopMask = 0xf0000000
 
 	// vbNumBits is the total number of bits available for use with the
 	// version bits scheme.
-	vbNumBits = 29
+	vbNumBits = 28
+
+	// MaxDeploymentBitNumber is the highest bit number of the version
+	// bits that may be used by deployments. The bits above it are the top
+	// bits that indicate the version bits scheme is in use.
+	MaxDeploymentBitNumber = vbNumBits - 1
 
 	// unknownVerNumToCheck is the number of previous blocks to consider
 	// when checking for a threshold of unknown block versions for the
@@ -0,0 +1,63 @@
+package blockdag
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+)
+
+// TestVersionBitsMask makes sure that the top bits of the version bits
+// scheme are recognized by vbTopMask, and that they don't overlap the bits
+// available for deployments.
+func TestVersionBitsMask(t *testing.T) {
+	if vbTopBits&vbTopMask != vbTopBits {
+		t.Fatalf("TestVersionBitsMask: vbTopMask 0x%08x doesn't cover vbTopBits 0x%08x",
+			uint32(vbTopMask), uint32(vbTopBits))
+	}
+	deploymentBitsMask := uint32(1)<<vbNumBits - 1
+	if deploymentBitsMask&vbTopMask != 0 {
+		t.Fatalf("TestVersionBitsMask: the %d deployment bits overlap vbTopMask 0x%08x",
+			vbNumBits, uint32(vbTopMask))
+	}
+
+	for bit := uint8(0); bit < vbNumBits; bit++ {
+		checker := deploymentChecker{deployment: &dagconfig.ConsensusDeployment{BitNumber: bit}}
+
+		// A version with the top bits that sets the bit signals for
+		// the deployment
+		signallingNode := &blockNode{version: int32(vbTopBits | uint32(1)<<bit)}
+		isSignalling, err := checker.Condition(signallingNode)
+		if err != nil {
+			t.Fatalf("TestVersionBitsMask: Condition unexpectedly failed: %s", err)
+		}
+		if !isSignalling {
+			t.Errorf("TestVersionBitsMask: version 0x%08x unexpectedly doesn't signal bit %d",
+				uint32(signallingNode.version), bit)
+		}
+
+		// A version without the top bits doesn't use the version bits
+		// scheme, so it doesn't signal for the deployment
+		nonSignallingNode := &blockNode{version: int32(uint32(1) << bit)}
+		isSignalling, err = checker.Condition(nonSignallingNode)
+		if err != nil {
+			t.Fatalf("TestVersionBitsMask: Condition unexpectedly failed: %s", err)
+		}
+		if isSignalling {
+			t.Errorf("TestVersionBitsMask: version 0x%08x unexpectedly signals bit %d",
+				uint32(nonSignallingNode.version), bit)
+		}
+	}
+
+	// The deployments of the default networks must use bits that are
+	// available for deployments
+	for _, params := range []*dagconfig.Params{&dagconfig.MainnetParams, &dagconfig.TestnetParams,
+		&dagconfig.RegressionNetParams, &dagconfig.SimnetParams, &dagconfig.DevnetParams} {
+
+		for _, deployment := range params.Deployments {
+			if deployment.BitNumber >= vbNumBits {
+				t.Errorf("TestVersionBitsMask: deployment %s of %s uses bit %d, which is one of the top bits",
+					deployment.Name, params.Name, deployment.BitNumber)
+			}
+		}
+	}
+}
@@ -111,10 +111,10 @@ func (v *virtualBlock) updateSelectedParentSet(oldSelectedParent *blockNode) *ch
 // given blockSet.
 //
 // This function is safe for concurrent access.
-func (v *virtualBlock) SetTips(tips blockSet) {
+func (v *virtualBlock) SetTips(tips blockSet) *chainUpdates {
 	v.mtx.Lock()
 	defer v.mtx.Unlock()
-	v.setTips(tips)
+	return v.setTips(tips)
 }
 
 // addTip adds the given tip to the set of tips in the virtual block.
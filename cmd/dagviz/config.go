@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	formatDOT  = "dot"
+	formatJSON = "json"
+)
+
+var defaultRPCServer = "localhost"
+
+type configFlags struct {
+	ShowVersion bool   `short:"V" long:"version" description:"Display version information and exit"`
+	RPCUser     string `short:"u" long:"rpcuser" description:"RPC username"`
+	RPCPassword string `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
+	RPCServer   string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	RPCCert     string `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
+	DisableTLS  bool   `long:"notls" description:"Disable TLS"`
+	LowHash     string `long:"lowhash" description:"Hash of the lowest block of the exported subgraph. Defaults to the genesis block"`
+	HighHash    string `long:"highhash" description:"Hash of the highest block of the exported subgraph. Defaults to the selected tip"`
+	Format      string `short:"f" long:"format" description:"Output format: dot or json"`
+	OutputFile  string `short:"o" long:"output" description:"File to write the subgraph to. Defaults to stdout"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		RPCServer: defaultRPCServer,
+		Format:    formatDOT,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+
+	// Show the version and exit if the version flag was specified.
+	if cfg.ShowVersion {
+		appName := filepath.Base(os.Args[0])
+		appName = strings.TrimSuffix(appName, filepath.Ext(appName))
+		fmt.Println(appName, "version", version.Version())
+		os.Exit(0)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.RPCUser == "" {
+		return nil, errors.New("--rpcuser is required")
+	}
+	if cfg.RPCPassword == "" {
+		return nil, errors.New("--rpcpass is required")
+	}
+
+	if cfg.RPCCert == "" && !cfg.DisableTLS {
+		return nil, errors.New("either --notls or --rpccert must be specified")
+	}
+	if cfg.RPCCert != "" && cfg.DisableTLS {
+		return nil, errors.New("--rpccert should be omitted if --notls is used")
+	}
+
+	if cfg.Format != formatDOT && cfg.Format != formatJSON {
+		return nil, errors.Errorf("unknown format %s. Supported formats are %s and %s",
+			cfg.Format, formatDOT, formatJSON)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/kaspanet/kaspad/rpc/model"
+)
+
+const (
+	blueColor = "lightblue"
+	redColor  = "lightcoral"
+
+	// shortHashLength is the number of characters of a block hash that
+	// are shown in node labels.
+	shortHashLength = 8
+)
+
+// writeJSON writes the subgraph to w as indented JSON.
+func writeJSON(w io.Writer, subgraph *model.GetDAGSubgraphResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(subgraph)
+}
+
+// writeDOT writes the subgraph to w in the Graphviz DOT language. Blue
+// blocks are colored blue and red blocks are colored red. Selected parent
+// chain blocks are drawn with a bold border, and the last finality point
+// with a double border. Edges point from a block to its parents, where
+// the edge to the selected parent is solid and the rest are dashed. An
+// additional virtual node points to the tips of the DAG.
+func writeDOT(w io.Writer, subgraph *model.GetDAGSubgraphResult) error {
+	nodes := make(map[string]bool, len(subgraph.Nodes))
+	for _, node := range subgraph.Nodes {
+		nodes[node.Hash] = true
+	}
+
+	lines := []string{
+		"digraph DAG {",
+		"\trankdir=RL;",
+		"\tnode [shape=box, style=filled];",
+	}
+	for _, node := range subgraph.Nodes {
+		color := redColor
+		if node.IsBlue {
+			color = blueColor
+		}
+		attributes := fmt.Sprintf("label=\"%s\\nblue score %d\\n[%d,%d]\", fillcolor=%s",
+			shortHash(node.Hash), node.BlueScore,
+			node.ReachabilityIntervalStart, node.ReachabilityIntervalEnd, color)
+		if node.IsChainBlock || node.IsVirtualSelectedParent {
+			attributes += ", penwidth=3"
+		}
+		if node.IsFinalityPoint {
+			attributes += ", peripheries=2"
+		}
+		lines = append(lines, fmt.Sprintf("\t\"%s\" [%s];", node.Hash, attributes))
+	}
+
+	hasVirtual := false
+	for _, node := range subgraph.Nodes {
+		for _, parentHash := range node.ParentHashes {
+			if !nodes[parentHash] {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("\t\"%s\" -> \"%s\"%s;",
+				node.Hash, parentHash, edgeStyle(parentHash == node.SelectedParentHash)))
+		}
+		if node.IsTip {
+			hasVirtual = true
+			lines = append(lines, fmt.Sprintf("\t\"virtual\" -> \"%s\"%s;",
+				node.Hash, edgeStyle(node.IsVirtualSelectedParent)))
+		}
+	}
+	if hasVirtual {
+		lines = append(lines, "\t\"virtual\" [shape=ellipse, style=dashed];")
+	}
+	lines = append(lines, "}")
+
+	for _, line := range lines {
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func edgeStyle(isSelectedParent bool) string {
+	if isSelectedParent {
+		return ""
+	}
+	return " [style=dashed]"
+}
+
+func shortHash(hash string) string {
+	if len(hash) <= shortHashLength {
+		return hash
+	}
+	return hash[:shortHashLength]
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/kaspanet/kaspad/rpc/client"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing command-line arguments: %s\n", err)
+		os.Exit(1)
+	}
+
+	err = exportSubgraph(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting the DAG subgraph: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func exportSubgraph(cfg *configFlags) error {
+	rpcClient, err := connectToServer(cfg)
+	if err != nil {
+		return err
+	}
+	defer rpcClient.Shutdown()
+
+	lowHash := cfg.NetParams().GenesisHash
+	if cfg.LowHash != "" {
+		lowHash, err = daghash.NewHashFromStr(cfg.LowHash)
+		if err != nil {
+			return errors.Wrapf(err, "error decoding low hash")
+		}
+	}
+	var highHash *daghash.Hash
+	if cfg.HighHash != "" {
+		highHash, err = daghash.NewHashFromStr(cfg.HighHash)
+		if err != nil {
+			return errors.Wrapf(err, "error decoding high hash")
+		}
+	} else {
+		highHash, err = rpcClient.GetSelectedTipHash()
+		if err != nil {
+			return errors.Wrapf(err, "error getting the selected tip hash")
+		}
+	}
+
+	subgraph, err := rpcClient.GetDAGSubgraph(lowHash, highHash)
+	if err != nil {
+		return errors.Wrapf(err, "error getting the DAG subgraph")
+	}
+
+	var output io.Writer = os.Stdout
+	if cfg.OutputFile != "" {
+		file, err := os.Create(cfg.OutputFile)
+		if err != nil {
+			return errors.Wrapf(err, "error creating output file")
+		}
+		defer file.Close()
+		output = file
+	}
+
+	switch cfg.Format {
+	case formatJSON:
+		return writeJSON(output, subgraph)
+	default:
+		return writeDOT(output, subgraph)
+	}
+}
+
+func connectToServer(cfg *configFlags) (*client.Client, error) {
+	var cert []byte
+	if !cfg.DisableTLS {
+		var err error
+		cert, err = ioutil.ReadFile(cfg.RPCCert)
+		if err != nil {
+			return nil, errors.Errorf("Error reading certificates file: %s", err)
+		}
+	}
+
+	rpcAddr, err := cfg.NetParams().NormalizeRPCServerAddress(cfg.RPCServer)
+	if err != nil {
+		return nil, err
+	}
+
+	connCfg := &client.ConnConfig{
+		Host:         rpcAddr,
+		User:         cfg.RPCUser,
+		Pass:         cfg.RPCPassword,
+		DisableTLS:   cfg.DisableTLS,
+		HTTPPostMode: true,
+		Certificates: cert,
+	}
+	return client.New(connCfg, nil)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+var (
+	kaspadHomeDir  = util.AppDataDir("kaspad", false)
+	defaultDataDir = filepath.Join(kaspadHomeDir, "data")
+)
+
+type configFlags struct {
+	DataDir string `short:"b" long:"datadir" description:"Location of the kaspad data directory"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		DataDir: defaultDataDir,
+	}
+	parser := flags.NewParser(cfg, flags.Default)
+	_, err := parser.Parse()
+	if err != nil {
+		var flagsErr *flags.Error
+		if ok := errors.As(err, &flagsErr); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		}
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// databasePath returns the path of the database of the given type
+// of the selected network.
+func (cfg *configFlags) databasePath(dbType string) string {
+	return filepath.Join(cfg.DataDir, cfg.NetParams().Name, dbaccess.DirectoryName(dbType))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaspanet/kaspad/database/ffbolt"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing command-line arguments: %s\n", err)
+		os.Exit(1)
+	}
+
+	err = migrateDatabase(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error migrating the database: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// migrateDatabase copies the ffldb database of the selected network
+// into a new ffbolt database next to it. The ffldb database is left
+// as it is, so that the node can keep using it until the migration
+// is complete. If the migration fails, the partial ffbolt database
+// is removed.
+func migrateDatabase(cfg *configFlags) error {
+	sourcePath := cfg.databasePath(dbaccess.FFLDBType)
+	destinationPath := cfg.databasePath(dbaccess.FFBoltDBType)
+
+	if _, err := os.Stat(destinationPath); err == nil {
+		return errors.Errorf("an ffbolt database already exists at %s", destinationPath)
+	} else if !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+
+	fmt.Printf("Migrating the database at %s into %s\n", sourcePath, destinationPath)
+	err := ffbolt.MigrateFromFFLDB(sourcePath, destinationPath, func(keyCount int) {
+		fmt.Printf("Copied %d keys\n", keyCount)
+	})
+	if err != nil {
+		removeErr := os.RemoveAll(destinationPath)
+		if removeErr != nil {
+			return errors.Wrapf(err, "failed to remove the partial database at %s: %s",
+				destinationPath, removeErr)
+		}
+		return err
+	}
+
+	fmt.Printf("Done. Start kaspad with --dbtype=%s to use the migrated database\n",
+		dbaccess.FFBoltDBType)
+	return nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"os"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+)
+
+type configFlags struct {
+	NetParamsFile string `long:"netparams" description:"The JSON or TOML network parameters file that defines the genesis block" required:"true"`
+	Now           bool   `long:"now" description:"Use the current time as the genesis timestamp instead of the timestamp in the network parameters file"`
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{}
+	parser := flags.NewParser(cfg, flags.Default)
+	_, err := parser.Parse()
+	if err != nil {
+		var flagsErr *flags.Error
+		if ok := errors.As(err, &flagsErr); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		}
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing command-line arguments: %s\n", err)
+		os.Exit(1)
+	}
+
+	err = genGenesis(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		os.Exit(1)
+	}
+}
+
+// genGenesis mines the genesis block defined in the network parameters file
+// and prints the genesis fields that have to be set in the file. The file
+// itself isn't changed, so that its formatting and comments are kept.
+func genGenesis(cfg *configFlags) error {
+	paramsFile, err := dagconfig.ReadParamsFile(cfg.NetParamsFile)
+	if err != nil {
+		return err
+	}
+	if cfg.Now {
+		paramsFile.Genesis.Timestamp = mstime.Now().UnixMilliseconds()
+	}
+	// The hash in the file belongs to the previous genesis block, if any.
+	paramsFile.Genesis.Hash = ""
+
+	genesisBlock, err := paramsFile.GenesisBlock()
+	if err != nil {
+		return err
+	}
+	err = solveGenesis(genesisBlock)
+	if err != nil {
+		return err
+	}
+
+	paramsFile.Genesis.Bits = genesisBlock.Header.Bits
+	paramsFile.Genesis.Nonce = genesisBlock.Header.Nonce
+	paramsFile.Genesis.Hash = genesisBlock.BlockHash().String()
+
+	// Make sure that kaspad accepts the rest of the parameters as well.
+	_, err = paramsFile.Params()
+	if err != nil {
+		return errors.Wrapf(err, "invalid network parameters in %s", cfg.NetParamsFile)
+	}
+
+	fmt.Printf("Mined the genesis block %s of %s. Set the following genesis fields in %s:\n\n",
+		paramsFile.Genesis.Hash, paramsFile.Name, cfg.NetParamsFile)
+	if strings.EqualFold(filepath.Ext(cfg.NetParamsFile), ".toml") {
+		printTOML(&paramsFile.Genesis)
+	} else {
+		printJSON(&paramsFile.Genesis)
+	}
+	return nil
+}
+
+// solveGenesis finds a nonce for which the genesis block satisfies its
+// target difficulty.
+func solveGenesis(genesisBlock *domainmessage.MsgBlock) error {
+	target := util.CompactToBig(genesisBlock.Header.Bits)
+	for nonce := uint64(0); ; nonce++ {
+		genesisBlock.Header.Nonce = nonce
+		if daghash.HashToBig(genesisBlock.BlockHash()).Cmp(target) <= 0 {
+			return nil
+		}
+		if nonce == ^uint64(0) {
+			return errors.New("no nonce satisfies the target difficulty of the genesis block; " +
+				"change its timestamp or coinbase data")
+		}
+	}
+}
+
+func printTOML(genesis *dagconfig.GenesisFile) {
+	fmt.Printf("[genesis]\n")
+	fmt.Printf("timestamp = %d\n", genesis.Timestamp)
+	fmt.Printf("bits = 0x%08x\n", genesis.Bits)
+	fmt.Printf("nonce = %d\n", genesis.Nonce)
+	fmt.Printf("hash = %q\n", genesis.Hash)
+}
+
+func printJSON(genesis *dagconfig.GenesisFile) {
+	fmt.Printf("\"genesis\": {\n")
+	fmt.Printf("\t\"timestamp\": %d,\n", genesis.Timestamp)
+	fmt.Printf("\t\"bits\": %d,\n", genesis.Bits)
+	fmt.Printf("\t\"nonce\": %d,\n", genesis.Nonce)
+	fmt.Printf("\t\"hash\": %q\n", genesis.Hash)
+	fmt.Printf("}\n")
+}
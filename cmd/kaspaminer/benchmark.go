@@ -0,0 +1,43 @@
+package main
+
+import (
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+const (
+	// benchmarkParentCount is the number of parents of the synthetic
+	// block header hashed by the benchmark. The time it takes to hash
+	// a header grows with its number of parents.
+	benchmarkParentCount = 10
+
+	// benchmarkBits is the difficulty of the synthetic block header.
+	// Its target is 1, so the header is practically never solved and
+	// the threads keep hashing.
+	benchmarkBits = 0x03000001
+)
+
+// benchmark starts hashing a synthetic block header on numThreads threads
+// and logging the hash rate, without connecting to an RPC server. The
+// threads keep hashing until the process exits.
+func benchmark(numThreads int) {
+	parentHashes := make([]*daghash.Hash, benchmarkParentCount)
+	for i := range parentHashes {
+		parentHashes[i] = randomHash()
+	}
+	header := domainmessage.NewBlockHeader(1, parentHashes, randomHash(), randomHash(), randomHash(),
+		benchmarkBits, 0)
+	block := util.NewBlock(domainmessage.NewMsgBlock(header))
+
+	log.Infof("Benchmarking the hash rate with %d threads", numThreads)
+	hashCounters = make([]hashCounter, numThreads)
+	logHashRate(numThreads)
+	solveBlocks(block, numThreads, make(chan struct{}), make(chan *util.Block))
+}
+
+func randomHash() *daghash.Hash {
+	var hash daghash.Hash
+	random.Read(hash[:])
+	return &hash
+}
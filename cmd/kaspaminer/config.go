@@ -27,27 +27,31 @@ var (
 	defaultLogFile    = filepath.Join(defaultHomeDir, defaultLogFilename)
 	defaultErrLogFile = filepath.Join(defaultHomeDir, defaultErrLogFilename)
 	defaultRPCServer  = "localhost"
+	defaultNumThreads = 1
 )
 
 type configFlags struct {
-	ShowVersion       bool   `short:"V" long:"version" description:"Display version information and exit"`
-	RPCUser           string `short:"u" long:"rpcuser" description:"RPC username"`
-	RPCPassword       string `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
-	RPCServer         string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
-	RPCCert           string `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
-	DisableTLS        bool   `long:"notls" description:"Disable TLS"`
-	MiningAddr        string `long:"miningaddr" description:"Address to mine to"`
-	Verbose           bool   `long:"verbose" short:"v" description:"Enable logging of RPC requests"`
-	NumberOfBlocks    uint64 `short:"n" long:"numblocks" description:"Number of blocks to mine. If omitted, will mine until the process is interrupted."`
-	BlockDelay        uint64 `long:"block-delay" description:"Delay for block submission (in milliseconds). This is used only for testing purposes."`
-	MineWhenNotSynced bool   `long:"mine-when-not-synced" description:"Mine even if the node is not synced with the rest of the network."`
-	Profile           string `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	ShowVersion       bool     `short:"V" long:"version" description:"Display version information and exit"`
+	RPCUser           string   `short:"u" long:"rpcuser" description:"RPC username"`
+	RPCPassword       string   `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password"`
+	RPCServer         string   `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	RPCCert           string   `short:"c" long:"rpccert" description:"RPC server certificate chain for validation"`
+	DisableTLS        bool     `long:"notls" description:"Disable TLS"`
+	MiningAddrs       []string `long:"miningaddr" description:"Address to mine to. If given more than once, blocks are mined to the addresses in round-robin"`
+	Verbose           bool     `long:"verbose" short:"v" description:"Enable logging of RPC requests"`
+	NumberOfBlocks    uint64   `short:"n" long:"numblocks" description:"Number of blocks to mine. If omitted, will mine until the process is interrupted."`
+	BlockDelay        uint64   `long:"block-delay" description:"Delay for block submission (in milliseconds). This is used only for testing purposes."`
+	MineWhenNotSynced bool     `long:"mine-when-not-synced" description:"Mine even if the node is not synced with the rest of the network."`
+	NumThreads        int      `short:"t" long:"threads" description:"Number of threads to mine with. The nonce space is split between them"`
+	Benchmark         bool     `long:"benchmark" description:"Measure the hash rate by hashing a synthetic block header without connecting to an RPC server, until the process is interrupted"`
+	Profile           string   `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	config.NetworkFlags
 }
 
 func parseConfig() (*configFlags, error) {
 	cfg := &configFlags{
-		RPCServer: defaultRPCServer,
+		RPCServer:  defaultRPCServer,
+		NumThreads: defaultNumThreads,
 	}
 	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
 	_, err := parser.Parse()
@@ -69,18 +73,30 @@ func parseConfig() (*configFlags, error) {
 		return nil, err
 	}
 
-	if cfg.RPCUser == "" {
-		return nil, errors.New("--rpcuser is required")
-	}
-	if cfg.RPCPassword == "" {
-		return nil, errors.New("--rpcpass is required")
+	if cfg.NumThreads < 1 {
+		return nil, errors.New("--threads must be at least 1")
 	}
 
-	if cfg.RPCCert == "" && !cfg.DisableTLS {
-		return nil, errors.New("either --notls or --rpccert must be specified")
-	}
-	if cfg.RPCCert != "" && cfg.DisableTLS {
-		return nil, errors.New("--rpccert should be omitted if --notls is used")
+	// The benchmark doesn't connect to an RPC server or mine to any
+	// address.
+	if !cfg.Benchmark {
+		if cfg.RPCUser == "" {
+			return nil, errors.New("--rpcuser is required")
+		}
+		if cfg.RPCPassword == "" {
+			return nil, errors.New("--rpcpass is required")
+		}
+
+		if cfg.RPCCert == "" && !cfg.DisableTLS {
+			return nil, errors.New("either --notls or --rpccert must be specified")
+		}
+		if cfg.RPCCert != "" && cfg.DisableTLS {
+			return nil, errors.New("--rpccert should be omitted if --notls is used")
+		}
+
+		if len(cfg.MiningAddrs) == 0 {
+			return nil, errors.New("--miningaddr is required")
+		}
 	}
 
 	if cfg.Profile != "" {
@@ -38,20 +38,29 @@ func main() {
 		profiling.Start(cfg.Profile, log)
 	}
 
+	if cfg.Benchmark {
+		benchmark(cfg.NumThreads)
+		<-interrupt
+		return
+	}
+
 	client, err := connectToServer(cfg)
 	if err != nil {
 		panic(errors.Wrap(err, "error connecting to the RPC server"))
 	}
 	defer client.Disconnect()
 
-	miningAddr, err := util.DecodeAddress(cfg.MiningAddr, cfg.ActiveNetParams.Prefix)
-	if err != nil {
-		panic(errors.Wrap(err, "error decoding mining address"))
+	miningAddrs := make([]util.Address, len(cfg.MiningAddrs))
+	for i, miningAddr := range cfg.MiningAddrs {
+		miningAddrs[i], err = util.DecodeAddress(miningAddr, cfg.ActiveNetParams.Prefix)
+		if err != nil {
+			panic(errors.Wrapf(err, "error decoding mining address %s", miningAddr))
+		}
 	}
 
 	doneChan := make(chan struct{})
 	spawn("mineLoop", func() {
-		err = mineLoop(client, cfg.NumberOfBlocks, cfg.BlockDelay, cfg.MineWhenNotSynced, miningAddr)
+		err = mineLoop(client, cfg.NumberOfBlocks, cfg.BlockDelay, cfg.MineWhenNotSynced, miningAddrs, cfg.NumThreads)
 		if err != nil {
 			panic(errors.Wrap(err, "error in mine loop"))
 		}
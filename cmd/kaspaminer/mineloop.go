@@ -2,11 +2,15 @@ package main
 
 import (
 	nativeerrors "errors"
+	"fmt"
+	"math"
 	"math/rand"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/kaspanet/kaspad/domainmessage"
 	clientpkg "github.com/kaspanet/kaspad/rpc/client"
 	"github.com/kaspanet/kaspad/rpc/model"
 	"github.com/kaspanet/kaspad/util"
@@ -15,13 +19,39 @@ import (
 )
 
 var random = rand.New(rand.NewSource(time.Now().UnixNano()))
-var hashesTried uint64
 
-const logHashRateInterval = 10 * time.Second
+// hashCounter counts the hashes a mining thread tried since the hash rate
+// was last logged. It's padded to a cache line so that the counters of
+// different threads don't share one.
+type hashCounter struct {
+	hashesTried uint64
+	_           [56]byte
+}
+
+var hashCounters []hashCounter
+
+// currentTemplate is the template that is currently being solved, kept
+// to log how fresh it is.
+var currentTemplate struct {
+	sync.Mutex
+	longPollID string
+	receivedAt time.Time
+}
+
+const (
+	logHashRateInterval = 10 * time.Second
+
+	// staleTemplateAge is the age after which the template that is being
+	// solved is considered stale. Templates are normally replaced much
+	// more often, so this usually means that the node is stuck or that
+	// the connection to it is broken.
+	staleTemplateAge = time.Minute
+)
 
 func mineLoop(client *minerClient, numberOfBlocks uint64, blockDelay uint64, mineWhenNotSynced bool,
-	miningAddr util.Address) error {
+	miningAddrs []util.Address, numThreads int) error {
 
+	hashCounters = make([]hashCounter, numThreads)
 	errChan := make(chan error)
 
 	templateStopChan := make(chan struct{})
@@ -30,8 +60,11 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, blockDelay uint64, min
 	spawn("mineLoop-internalLoop", func() {
 		wg := sync.WaitGroup{}
 		for i := uint64(0); numberOfBlocks == 0 || i < numberOfBlocks; i++ {
+			// When mining to several addresses, each block is mined
+			// to the next address in turn.
+			miningAddr := miningAddrs[i%uint64(len(miningAddrs))]
 			foundBlock := make(chan *util.Block)
-			mineNextBlock(client, miningAddr, foundBlock, mineWhenNotSynced, templateStopChan, errChan)
+			mineNextBlock(client, miningAddr, foundBlock, mineWhenNotSynced, numThreads, templateStopChan, errChan)
 			block := <-foundBlock
 			templateStopChan <- struct{}{}
 			wg.Add(1)
@@ -39,7 +72,7 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, blockDelay uint64, min
 				if blockDelay != 0 {
 					time.Sleep(time.Duration(blockDelay) * time.Millisecond)
 				}
-				err := handleFoundBlock(client, block)
+				err := handleFoundBlock(client, block, miningAddr)
 				if err != nil {
 					errChan <- err
 				}
@@ -50,7 +83,7 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, blockDelay uint64, min
 		doneChan <- struct{}{}
 	})
 
-	logHashRate()
+	logHashRate(numThreads)
 
 	select {
 	case err := <-errChan:
@@ -60,36 +93,78 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, blockDelay uint64, min
 	}
 }
 
-func logHashRate() {
+func logHashRate(numThreads int) {
 	spawn("logHashRate", func() {
 		lastCheck := time.Now()
 		for range time.Tick(logHashRateInterval) {
-			currentHashesTried := hashesTried
 			currentTime := time.Now()
-			kiloHashesTried := float64(currentHashesTried) / 1000.0
-			hashRate := kiloHashesTried / currentTime.Sub(lastCheck).Seconds()
-			log.Infof("Current hash rate is %.2f Khash/s", hashRate)
+			elapsedSeconds := currentTime.Sub(lastCheck).Seconds()
 			lastCheck = currentTime
-			// subtract from hashesTried the hashes we already sampled
-			atomic.AddUint64(&hashesTried, -currentHashesTried)
+
+			totalHashRate := 0.0
+			threadHashRates := make([]string, numThreads)
+			for i := range hashCounters {
+				// Subtract the sampled hashes from the counter instead of
+				// resetting it, so that no hashes are lost.
+				hashesTried := atomic.LoadUint64(&hashCounters[i].hashesTried)
+				atomic.AddUint64(&hashCounters[i].hashesTried, -hashesTried)
+
+				hashRate := float64(hashesTried) / 1000.0 / elapsedSeconds
+				totalHashRate += hashRate
+				threadHashRates[i] = fmt.Sprintf("thread %d: %.2f", i, hashRate)
+			}
+			if numThreads == 1 {
+				log.Infof("Current hash rate is %.2f Khash/s", totalHashRate)
+			} else {
+				log.Infof("Current hash rate is %.2f Khash/s (%s)", totalHashRate,
+					strings.Join(threadHashRates, ", "))
+			}
+			logTemplateFreshness()
 		}
 	})
 }
 
+// logTemplateFreshness logs how long ago the template that is being solved
+// was received, and warns if it's stale.
+func logTemplateFreshness() {
+	currentTemplate.Lock()
+	defer currentTemplate.Unlock()
+
+	if currentTemplate.receivedAt.IsZero() {
+		return
+	}
+	age := time.Since(currentTemplate.receivedAt).Round(time.Millisecond)
+	if age > staleTemplateAge {
+		log.Warnf("Still solving template %s that was received %s ago -- the node "+
+			"may be stuck or unreachable", currentTemplate.longPollID, age)
+		return
+	}
+	log.Infof("Solving template %s that was received %s ago", currentTemplate.longPollID, age)
+}
+
+func setCurrentTemplate(longPollID string) {
+	currentTemplate.Lock()
+	defer currentTemplate.Unlock()
+
+	currentTemplate.longPollID = longPollID
+	currentTemplate.receivedAt = time.Now()
+}
+
 func mineNextBlock(client *minerClient, miningAddr util.Address, foundBlock chan *util.Block, mineWhenNotSynced bool,
-	templateStopChan chan struct{}, errChan chan error) {
+	numThreads int, templateStopChan chan struct{}, errChan chan error) {
 
 	newTemplateChan := make(chan *model.GetBlockTemplateResult)
 	spawn("templatesLoop", func() {
 		templatesLoop(client, miningAddr, newTemplateChan, errChan, templateStopChan)
 	})
 	spawn("solveLoop", func() {
-		solveLoop(newTemplateChan, foundBlock, mineWhenNotSynced, errChan)
+		solveLoop(newTemplateChan, foundBlock, mineWhenNotSynced, numThreads, errChan)
 	})
 }
 
-func handleFoundBlock(client *minerClient, block *util.Block) error {
-	log.Infof("Found block %s with parents %s. Submitting to %s", block.Hash(), block.MsgBlock().Header.ParentHashes, client.Host())
+func handleFoundBlock(client *minerClient, block *util.Block, miningAddr util.Address) error {
+	log.Infof("Found block %s with parents %s paying to %s. Submitting to %s", block.Hash(),
+		block.MsgBlock().Header.ParentHashes, miningAddr, client.Host())
 
 	err := client.SubmitBlock(block, &model.SubmitBlockOptions{})
 	if err != nil {
@@ -98,25 +173,69 @@ func handleFoundBlock(client *minerClient, block *util.Block) error {
 	return nil
 }
 
-func solveBlock(block *util.Block, stopChan chan struct{}, foundBlock chan *util.Block) {
+// nonceRange returns the first nonce and the number of nonces of the part
+// of the nonce space that the mining thread with the given index searches.
+// The nonce space is split evenly between the threads so that they never
+// try the same nonce.
+func nonceRange(threadIndex int, numThreads int) (start uint64, size uint64) {
+	size = math.MaxUint64 / uint64(numThreads)
+	start = uint64(threadIndex) * size
+	if threadIndex == numThreads-1 {
+		size += math.MaxUint64 % uint64(numThreads)
+	}
+	return start, size
+}
+
+// solveBlocks starts numThreads mining threads that search for a nonce that
+// solves the passed block, each in its own part of the nonce space starting
+// from a random nonce in it.
+func solveBlocks(block *util.Block, numThreads int, stopChan chan struct{}, foundBlock chan *util.Block) {
+	for i := 0; i < numThreads; i++ {
+		threadIndex := i
+		startOffset := random.Uint64()
+		spawn("solveBlock", func() {
+			solveBlock(block, threadIndex, numThreads, startOffset, stopChan, foundBlock)
+		})
+	}
+}
+
+func solveBlock(block *util.Block, threadIndex int, numThreads int, startOffset uint64,
+	stopChan chan struct{}, foundBlock chan *util.Block) {
+
 	msgBlock := block.MsgBlock()
-	targetDifficulty := util.CompactToBig(msgBlock.Header.Bits)
-	initialNonce := random.Uint64()
-	for i := initialNonce; i != initialNonce-1; i++ {
+	// Every thread works on its own copy of the header, so that
+	// the threads don't overwrite each other's nonces.
+	header := msgBlock.Header
+	targetDifficulty := util.CompactToBig(header.Bits)
+	start, size := nonceRange(threadIndex, numThreads)
+	nonce := start + startOffset%size
+	hashesTried := &hashCounters[threadIndex].hashesTried
+	for i := uint64(0); i < size; i++ {
 		select {
 		case <-stopChan:
 			return
 		default:
-			msgBlock.Header.Nonce = i
-			hash := msgBlock.BlockHash()
-			atomic.AddUint64(&hashesTried, 1)
+			header.Nonce = nonce
+			hash := header.BlockHash()
+			atomic.AddUint64(hashesTried, 1)
 			if daghash.HashToBig(hash).Cmp(targetDifficulty) <= 0 {
-				foundBlock <- block
+				solvedBlock := util.NewBlock(&domainmessage.MsgBlock{
+					Header:       header,
+					Transactions: msgBlock.Transactions,
+				})
+				// Another thread may have solved the block first.
+				select {
+				case foundBlock <- solvedBlock:
+				case <-stopChan:
+				}
 				return
 			}
+			nonce++
+			if nonce-start == size {
+				nonce = start
+			}
 		}
 	}
-
 }
 
 func templatesLoop(client *minerClient, miningAddr util.Address,
@@ -162,7 +281,7 @@ func getBlockTemplate(client *minerClient, miningAddr util.Address, longPollID s
 }
 
 func solveLoop(newTemplateChan chan *model.GetBlockTemplateResult, foundBlock chan *util.Block,
-	mineWhenNotSynced bool, errChan chan error) {
+	mineWhenNotSynced bool, numThreads int, errChan chan error) {
 
 	var stopOldTemplateSolving chan struct{}
 	for template := range newTemplateChan {
@@ -185,9 +304,8 @@ func solveLoop(newTemplateChan chan *model.GetBlockTemplateResult, foundBlock ch
 			return
 		}
 
-		spawn("solveBlock", func() {
-			solveBlock(block, stopOldTemplateSolving, foundBlock)
-		})
+		setCurrentTemplate(template.LongPollID)
+		solveBlocks(block, numThreads, stopOldTemplateSolving, foundBlock)
 	}
 	if stopOldTemplateSolving != nil {
 		close(stopOldTemplateSolving)
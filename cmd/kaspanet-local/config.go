@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/integration/localnet"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultLogFilename    = "kaspanet-local.log"
+	defaultErrLogFilename = "kaspanet-local_err.log"
+)
+
+var (
+	// Default configuration options
+	defaultHomeDir      = util.AppDataDir("kaspanet-local", false)
+	defaultLogFile      = filepath.Join(defaultHomeDir, defaultLogFilename)
+	defaultErrLogFile   = filepath.Join(defaultHomeDir, defaultErrLogFilename)
+	defaultNumNodes     = 3
+	defaultTopology     = string(localnet.TopologyLine)
+	defaultBasePort     = 16700
+	defaultMineInterval = time.Second
+	defaultDebugLevel   = "warn"
+)
+
+type configFlags struct {
+	ShowVersion       bool          `short:"V" long:"version" description:"Display version information and exit"`
+	NumNodes          int           `short:"n" long:"nodes" description:"Number of nodes to run"`
+	Topology          string        `long:"topology" description:"How the nodes are connected {line, ring, star, random}"`
+	Seed              int64         `long:"seed" description:"Seed of the random topology. If omitted, a seed is chosen and logged, so that the run can be reproduced"`
+	BasePort          int           `long:"baseport" description:"Node i listens on port baseport+2i for P2P and on port baseport+2i+1 for RPC"`
+	KaspadPath        string        `long:"kaspad" description:"Path of a kaspad executable. If set, every node runs as a child process of it instead of in-process"`
+	DataDir           string        `short:"b" long:"datadir" description:"Directory to keep the data of the nodes in. If omitted, temporary directories are used and removed on exit"`
+	Miners            []int         `long:"miner" description:"Index of a node that mines a block every mineinterval. May be given more than once"`
+	MineInterval      time.Duration `long:"mineinterval" description:"How often each miner mines a block"`
+	Partition         string        `long:"partition" description:"Groups of node indexes to split the network into, such as 0,1|2,3. The nodes that aren't in any group form one more group"`
+	PartitionAfter    time.Duration `long:"partitionafter" description:"How long after the nodes are connected the network is partitioned"`
+	PartitionDuration time.Duration `long:"partitionduration" description:"How long the network stays partitioned before it's healed. If omitted, it isn't healed"`
+	NetLatency        time.Duration `long:"netlatency" description:"Simulated network latency by which every P2P message that a node receives is delayed"`
+	DebugLevel        string        `short:"d" long:"debuglevel" description:"Logging level of the in-process nodes {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems"`
+	config.NetworkFlags
+
+	partitionGroups [][]int
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		NumNodes:     defaultNumNodes,
+		Topology:     defaultTopology,
+		BasePort:     defaultBasePort,
+		MineInterval: defaultMineInterval,
+		DebugLevel:   defaultDebugLevel,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+
+	// Show the version and exit if the version flag was specified.
+	if cfg.ShowVersion {
+		appName := filepath.Base(os.Args[0])
+		appName = strings.TrimSuffix(appName, filepath.Ext(appName))
+		fmt.Println(appName, "version", version.Version())
+		os.Exit(0)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The nodes run on simnet unless another network is selected
+	if !cfg.Testnet && !cfg.RegressionTest && !cfg.Simnet && !cfg.Devnet && cfg.NetParamsFile == "" {
+		cfg.Simnet = true
+	}
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.NumNodes < 1 {
+		return nil, errors.New("--nodes must be at least 1")
+	}
+	if cfg.BasePort < 1024 || cfg.BasePort+2*cfg.NumNodes-1 > 65535 {
+		return nil, errors.Errorf("the ports of %d nodes starting from --baseport must be between 1024 and 65535",
+			cfg.NumNodes)
+	}
+
+	for _, miner := range cfg.Miners {
+		if miner < 0 || miner >= cfg.NumNodes {
+			return nil, errors.Errorf("--miner %d isn't the index of any of the %d nodes", miner, cfg.NumNodes)
+		}
+	}
+	if len(cfg.Miners) > 0 {
+		if !cfg.ActiveNetParams.EnableGenerate {
+			return nil, errors.Errorf("--miner can't be used on %s, since it doesn't allow generating blocks",
+				cfg.ActiveNetParams.Name)
+		}
+		if cfg.MineInterval <= 0 {
+			return nil, errors.New("--mineinterval must be positive")
+		}
+	}
+
+	if cfg.Partition != "" {
+		cfg.partitionGroups, err = parsePartition(cfg.Partition, cfg.NumNodes)
+		if err != nil {
+			return nil, err
+		}
+	} else if cfg.PartitionAfter != 0 || cfg.PartitionDuration != 0 {
+		return nil, errors.New("--partitionafter and --partitionduration require --partition")
+	}
+	if cfg.PartitionAfter < 0 || cfg.PartitionDuration < 0 {
+		return nil, errors.New("--partitionafter and --partitionduration must not be negative")
+	}
+
+	if cfg.NetLatency < 0 {
+		return nil, errors.New("--netlatency must not be negative")
+	}
+	if cfg.NetLatency > 0 && cfg.Testnet {
+		return nil, errors.New("--netlatency can't be used on testnet")
+	}
+
+	initLog(defaultLogFile, defaultErrLogFile)
+
+	return cfg, nil
+}
+
+// parsePartition parses groups of node indexes in the format of
+// 0,1|2,3
+func parsePartition(partition string, numNodes int) ([][]int, error) {
+	var groups [][]int
+	for _, groupString := range strings.Split(partition, "|") {
+		var group []int
+		for _, nodeString := range strings.Split(groupString, ",") {
+			node, err := strconv.Atoi(strings.TrimSpace(nodeString))
+			if err != nil {
+				return nil, errors.Errorf("invalid --partition %s: %s isn't a node index", partition, nodeString)
+			}
+			if node < 0 || node >= numNodes {
+				return nil, errors.Errorf("invalid --partition %s: %d isn't the index of any of the %d nodes",
+					partition, node, numNodes)
+			}
+			group = append(group, node)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/integration/localnet"
+	"github.com/kaspanet/kaspad/logger"
+	"github.com/kaspanet/kaspad/signal"
+	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	defer panics.HandlePanic(log, "MAIN", nil)
+	interrupt := signal.InterruptListener()
+
+	cfg, err := parseConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing command-line arguments: %s\n", err)
+		os.Exit(1)
+	}
+
+	// Show version at startup.
+	log.Infof("Version %s", version.Version())
+
+	if cfg.KaspadPath == "" {
+		err := logger.ParseAndSetDebugLevels(cfg.DebugLevel)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing --debuglevel: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	edges, err := localnet.Topology(cfg.Topology).Edges(cfg.NumNodes, rand.New(rand.NewSource(cfg.Seed)))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing --topology: %s\n", err)
+		os.Exit(1)
+	}
+	log.Infof("Running %d nodes in a %s topology with seed %d", cfg.NumNodes, cfg.Topology, cfg.Seed)
+
+	nodes, err := startNodes(cfg)
+	if err != nil {
+		panic(errors.Wrap(err, "error starting the nodes"))
+	}
+	network := localnet.NewNetwork(nodes)
+	defer func() {
+		err := network.Teardown()
+		if err != nil {
+			log.Errorf("Error tearing down the network: %s", err)
+		}
+	}()
+
+	for i, node := range nodes {
+		log.Infof("Node %d: P2P %s, RPC %s, ID %s", i, node.P2PAddress(), node.RPCAddress(), node.NodeID())
+	}
+	err = network.Connect(edges)
+	if err != nil {
+		log.Errorf("Error connecting the nodes: %s", err)
+		return
+	}
+	for _, edge := range edges {
+		log.Infof("Connected node %d to node %d", edge.Outgoing, edge.Incoming)
+	}
+	log.Infof("The network is running. RPC username: %s, password: %s", localnet.RPCUser, localnet.RPCPass)
+
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, miner := range cfg.Miners {
+		miner := miner
+		wg.Add(1)
+		spawn(fmt.Sprintf("mineLoop-%d", miner), func() {
+			defer wg.Done()
+			mineLoop(nodes[miner], miner, cfg.MineInterval, quit)
+		})
+	}
+	if len(cfg.partitionGroups) > 0 {
+		wg.Add(1)
+		spawn("partitionLoop", func() {
+			defer wg.Done()
+			partitionLoop(network, cfg.partitionGroups, cfg.PartitionAfter, cfg.PartitionDuration, quit)
+		})
+	}
+
+	<-interrupt
+	close(quit)
+	wg.Wait()
+}
+
+// startNodes starts the nodes of the network, and tears down the ones that
+// already started if any of them fails to start.
+func startNodes(cfg *configFlags) ([]localnet.Node, error) {
+	nodes := make([]localnet.Node, 0, cfg.NumNodes)
+	for i := 0; i < cfg.NumNodes; i++ {
+		params := &localnet.Params{
+			P2PAddress:   fmt.Sprintf("127.0.0.1:%d", cfg.BasePort+2*i),
+			RPCAddress:   fmt.Sprintf("127.0.0.1:%d", cfg.BasePort+2*i+1),
+			NetworkFlags: cfg.NetworkFlags,
+			NetLatency:   cfg.NetLatency,
+		}
+		if cfg.DataDir != "" {
+			params.DataDir = filepath.Join(cfg.DataDir, fmt.Sprintf("node%d", i))
+		}
+
+		var node localnet.Node
+		var err error
+		if cfg.KaspadPath != "" {
+			node, err = localnet.NewProcessHarness(cfg.KaspadPath, params)
+		} else {
+			node, err = localnet.NewAppHarness(params)
+		}
+		if err != nil {
+			for _, startedNode := range nodes {
+				startedNode.Teardown()
+			}
+			return nil, errors.Wrapf(err, "error starting node %d", i)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// mineLoop makes node mine a block every interval until quit is closed
+func mineLoop(node localnet.Node, nodeIndex int, interval time.Duration, quit <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-quit:
+			return
+		}
+
+		block, err := localnet.MineBlock(node, nil)
+		if err != nil {
+			log.Warnf("Node %d failed to mine a block: %s", nodeIndex, err)
+			continue
+		}
+		log.Infof("Node %d mined block %s", nodeIndex, block.Hash())
+	}
+}
+
+// partitionLoop partitions the network into groups after partitionAfter,
+// and heals it after partitionDuration, unless partitionDuration is 0.
+func partitionLoop(network *localnet.Network, groups [][]int, partitionAfter, partitionDuration time.Duration,
+	quit <-chan struct{}) {
+
+	select {
+	case <-time.After(partitionAfter):
+	case <-quit:
+		return
+	}
+	err := network.Partition(groups...)
+	if err != nil {
+		log.Errorf("Error partitioning the network: %s", err)
+		return
+	}
+	log.Infof("Partitioned the network into %v", groups)
+
+	if partitionDuration == 0 {
+		return
+	}
+	select {
+	case <-time.After(partitionDuration):
+	case <-quit:
+		return
+	}
+	err = network.Heal()
+	if err != nil {
+		log.Errorf("Error healing the network: %s", err)
+		return
+	}
+	log.Infof("Healed the network")
+}
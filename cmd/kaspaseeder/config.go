@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/logger"
+	"github.com/kaspanet/kaspad/logs"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultLogFilename    = "kaspaseeder.log"
+	defaultErrLogFilename = "kaspaseeder_err.log"
+	defaultListen         = ":5354"
+	defaultThreads        = 8
+	defaultDebugLevel     = "info"
+)
+
+var (
+	// Default configuration options
+	defaultHomeDir = util.AppDataDir("kaspaseeder", false)
+)
+
+type configFlags struct {
+	ShowVersion bool     `short:"V" long:"version" description:"Display version information and exit"`
+	AppDir      string   `short:"b" long:"appdir" description:"Directory to store data and logs"`
+	Host        string   `short:"H" long:"host" description:"Domain name this seeder serves (e.g. seed.example.com)"`
+	Nameserver  string   `short:"n" long:"nameserver" description:"Domain name of the nameserver this seeder runs on (e.g. ns.example.com)"`
+	Listen      string   `short:"l" long:"listen" description:"Interface/port to listen for DNS queries on"`
+	Seeders     []string `short:"s" long:"seeder" description:"Address (ip:port) of a peer to start crawling from -- may be specified multiple times"`
+	Threads     uint8    `long:"threads" description:"Number of peers to crawl concurrently"`
+	DebugLevel  string   `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	Profile     string   `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		AppDir:     defaultHomeDir,
+		Listen:     defaultListen,
+		Threads:    defaultThreads,
+		DebugLevel: defaultDebugLevel,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err := parser.Parse()
+
+	// Show the version and exit if the version flag was specified.
+	if cfg.ShowVersion {
+		appName := filepath.Base(os.Args[0])
+		appName = strings.TrimSuffix(appName, filepath.Ext(appName))
+		fmt.Println(appName, "version", version.Version())
+		os.Exit(0)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Host == "" {
+		return nil, errors.New("--host is required")
+	}
+	if cfg.Nameserver == "" {
+		return nil, errors.New("--nameserver is required")
+	}
+	if cfg.Threads == 0 {
+		return nil, errors.New("--threads must be greater than 0")
+	}
+
+	_, _, err = net.SplitHostPort(cfg.Listen)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid --listen address %s", cfg.Listen)
+	}
+	for _, seeder := range cfg.Seeders {
+		_, _, err = net.SplitHostPort(seeder)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid --seeder address %s", seeder)
+		}
+	}
+
+	if cfg.Profile != "" {
+		profilePort, err := strconv.Atoi(cfg.Profile)
+		if err != nil || profilePort < 1024 || profilePort > 65535 {
+			return nil, errors.New("The profile port must be between 1024 and 65535")
+		}
+	}
+
+	// Special show command to list supported subsystems and exit.
+	if cfg.DebugLevel == "show" {
+		fmt.Println("Supported subsystems", logger.SupportedSubsystems())
+		os.Exit(0)
+	}
+
+	cfg.AppDir = filepath.Join(cfg.AppDir, cfg.NetParams().Name)
+	logDir := filepath.Join(cfg.AppDir, "logs")
+	initLog(filepath.Join(logDir, defaultLogFilename), filepath.Join(logDir, defaultErrLogFilename))
+
+	err = logger.ParseAndSetDebugLevels(cfg.DebugLevel)
+	if err != nil {
+		return nil, err
+	}
+	level, ok := logs.LevelFromString(cfg.DebugLevel)
+	if ok {
+		log.SetLevel(level)
+	}
+
+	return cfg, nil
+}
+
+// kaspadConfig builds the kaspad config required by the
+// net adapter and the address manager
+func (cfg *configFlags) kaspadConfig() *config.Config {
+	return &config.Config{
+		Flags: &config.Flags{
+			DataDir:      cfg.AppDir,
+			NetworkFlags: cfg.NetworkFlags,
+		},
+		Lookup: net.LookupIP,
+	}
+}
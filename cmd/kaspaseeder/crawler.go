@@ -0,0 +1,287 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/addressmanager"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/netadapter"
+	routerpkg "github.com/kaspanet/kaspad/netadapter/router"
+	"github.com/kaspanet/kaspad/protocol/common"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+const (
+	// crawlInterval is how long the crawler rests between crawl rounds
+	crawlInterval = 10 * time.Second
+
+	// newAddressesPerRound is how many addresses the crawler takes
+	// from the address manager in every crawl round, on top of the
+	// nodes it already tracks
+	newAddressesPerRound = 100
+
+	userAgentName = "kaspaseeder"
+)
+
+// crawler polls peers in the network to learn about their
+// liveness, version, services and subnetwork, and about the
+// addresses of more peers
+type crawler struct {
+	cfg            *config.Config
+	netAdapter     *netadapter.NetAdapter
+	addressManager *addressmanager.AddressManager
+	nodes          *nodeSet
+	threads        int
+
+	pendingPolls     map[addressmanager.AddressKey]chan struct{}
+	pendingPollsLock sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newCrawler(cfg *config.Config, netAdapter *netadapter.NetAdapter,
+	addressManager *addressmanager.AddressManager, nodes *nodeSet, threads int) *crawler {
+
+	c := &crawler{
+		cfg:            cfg,
+		netAdapter:     netAdapter,
+		addressManager: addressManager,
+		nodes:          nodes,
+		threads:        threads,
+		pendingPolls:   make(map[addressmanager.AddressKey]chan struct{}),
+		quit:           make(chan struct{}),
+	}
+	netAdapter.SetRouterInitializer(c.routerInitializer)
+	return c
+}
+
+func (c *crawler) start() {
+	c.wg.Add(1)
+	spawn("crawler.crawlLoop", c.crawlLoop)
+}
+
+func (c *crawler) stop() {
+	close(c.quit)
+	c.wg.Wait()
+}
+
+func (c *crawler) crawlLoop() {
+	defer c.wg.Done()
+
+	for {
+		c.crawlRound()
+
+		known, good := c.nodes.counts()
+		log.Infof("Crawl round done. Known nodes: %d, good nodes: %d", known, good)
+
+		select {
+		case <-c.quit:
+			return
+		case <-time.After(crawlInterval):
+		}
+	}
+}
+
+// crawlRound polls every node that is due for a poll, using up to
+// c.threads concurrent connections
+func (c *crawler) crawlRound() {
+	c.addAddressesFromAddressManager()
+
+	addresses := c.nodes.addressesToPoll()
+	log.Debugf("Polling %d nodes", len(addresses))
+
+	addressChan := make(chan *domainmessage.NetAddress)
+	wg := sync.WaitGroup{}
+	wg.Add(c.threads)
+	for i := 0; i < c.threads; i++ {
+		spawn("crawler.crawlRound-poll", func() {
+			defer wg.Done()
+			for address := range addressChan {
+				c.poll(address)
+			}
+		})
+	}
+
+	defer func() {
+		close(addressChan)
+		wg.Wait()
+	}()
+	for _, address := range addresses {
+		select {
+		case <-c.quit:
+			return
+		case addressChan <- address:
+		}
+	}
+}
+
+// addAddressesFromAddressManager starts tracking addresses picked by
+// the address manager, so that addresses it had saved during previous
+// runs are polled as well
+func (c *crawler) addAddressesFromAddressManager() {
+	for i := 0; i < newAddressesPerRound; i++ {
+		knownAddress := c.addressManager.GetAddress()
+		if knownAddress == nil {
+			return
+		}
+		c.nodes.add(knownAddress.NetAddress())
+	}
+}
+
+// poll connects to the given address and waits until the connection's
+// poll flow is done with it
+func (c *crawler) poll(address *domainmessage.NetAddress) {
+	c.nodes.attempted(address)
+	c.addressManager.Attempt(address)
+
+	addressKey := addressmanager.NetAddressKey(address)
+	pollDone := make(chan struct{})
+	c.pendingPollsLock.Lock()
+	c.pendingPolls[addressKey] = pollDone
+	c.pendingPollsLock.Unlock()
+	defer func() {
+		c.pendingPollsLock.Lock()
+		defer c.pendingPollsLock.Unlock()
+		delete(c.pendingPolls, addressKey)
+	}()
+
+	err := c.netAdapter.Connect(string(addressKey))
+	if err != nil {
+		log.Debugf("Couldn't connect to %s: %s", addressKey, err)
+		return
+	}
+
+	// The poll flow is bounded by its own message timeouts, so this
+	// is only a safeguard against connections that never start
+	const pollTimeout = 3 * common.DefaultTimeout
+	select {
+	case <-pollDone:
+	case <-time.After(pollTimeout):
+		log.Debugf("Timed out polling %s", addressKey)
+	}
+}
+
+func (c *crawler) finishPoll(address *domainmessage.NetAddress) {
+	c.pendingPollsLock.Lock()
+	defer c.pendingPollsLock.Unlock()
+
+	addressKey := addressmanager.NetAddressKey(address)
+	if pollDone, ok := c.pendingPolls[addressKey]; ok {
+		close(pollDone)
+		delete(c.pendingPolls, addressKey)
+	}
+}
+
+func (c *crawler) routerInitializer(router *routerpkg.Router, netConnection *netadapter.NetConnection) {
+	versionRoute, err := router.AddIncomingRoute([]domainmessage.MessageCommand{domainmessage.CmdVersion})
+	if err != nil {
+		panic(err)
+	}
+	verAckRoute, err := router.AddIncomingRoute([]domainmessage.MessageCommand{domainmessage.CmdVerAck})
+	if err != nil {
+		panic(err)
+	}
+	addressesRoute, err := router.AddIncomingRoute([]domainmessage.MessageCommand{domainmessage.CmdAddresses})
+	if err != nil {
+		panic(err)
+	}
+
+	// The peer may send us any other message once the handshake
+	// is done. These are not interesting to the crawler, so they
+	// are routed to a route that is never read.
+	var ignoredCommands []domainmessage.MessageCommand
+	for command := range domainmessage.MessageCommandToString {
+		if command != domainmessage.CmdVersion && command != domainmessage.CmdVerAck &&
+			command != domainmessage.CmdAddresses {
+			ignoredCommands = append(ignoredCommands, command)
+		}
+	}
+	_, err = router.AddIncomingRoute(ignoredCommands)
+	if err != nil {
+		panic(err)
+	}
+
+	netConnection.SetOnInvalidMessageHandler(func(err error) {
+		log.Debugf("Received an invalid message from %s: %s", netConnection, err)
+	})
+
+	spawn("crawler.routerInitializer-pollFlow", func() {
+		defer netConnection.Disconnect()
+
+		address := netConnection.NetAddress()
+		defer c.finishPoll(address)
+
+		err := c.pollFlow(address, versionRoute, verAckRoute, addressesRoute, router.OutgoingRoute())
+		if err != nil {
+			log.Debugf("Failed polling %s: %s", netConnection, err)
+		}
+	})
+}
+
+// pollFlow performs a handshake with the peer and then asks
+// it for the addresses it knows about
+func (c *crawler) pollFlow(address *domainmessage.NetAddress,
+	versionRoute, verAckRoute, addressesRoute, outgoingRoute *routerpkg.Route) error {
+
+	netParams := c.cfg.NetParams()
+	msgVersion := domainmessage.NewMsgVersion(nil, c.netAdapter.ID(), netParams.Name, netParams.GenesisHash, nil)
+	msgVersion.AddUserAgent(userAgentName, version.Version())
+	msgVersion.DisableRelayTx = true
+	err := outgoingRoute.Enqueue(msgVersion)
+	if err != nil {
+		return err
+	}
+
+	message, err := versionRoute.DequeueWithTimeout(common.DefaultTimeout)
+	if err != nil {
+		return err
+	}
+	peerVersion := message.(*domainmessage.MsgVersion)
+	if peerVersion.Network != netParams.Name {
+		return errors.Errorf("peer is on network %s", peerVersion.Network)
+	}
+	err = outgoingRoute.Enqueue(domainmessage.NewMsgVerAck())
+	if err != nil {
+		return err
+	}
+	_, err = verAckRoute.DequeueWithTimeout(common.DefaultTimeout)
+	if err != nil {
+		return err
+	}
+
+	c.nodes.succeeded(address, peerVersion)
+	c.addressManager.Good(address, peerVersion.SubnetworkID)
+	log.Debugf("Peer %s is up (protocol version %d, user agent %s, services %s, subnetwork %s)",
+		address.TCPAddress(), peerVersion.ProtocolVersion, peerVersion.UserAgent, peerVersion.Services,
+		peerVersion.SubnetworkID)
+
+	err = outgoingRoute.Enqueue(domainmessage.NewMsgRequestAddresses(true, nil))
+	if err != nil {
+		return err
+	}
+	message, err = addressesRoute.DequeueWithTimeout(common.DefaultTimeout)
+	if err != nil {
+		return err
+	}
+	msgAddresses := message.(*domainmessage.MsgAddresses)
+	if len(msgAddresses.AddrList) > addressmanager.GetAddressesMax {
+		return errors.Errorf("address count %d exceeded %d",
+			len(msgAddresses.AddrList), addressmanager.GetAddressesMax)
+	}
+
+	routableAddresses := make([]*domainmessage.NetAddress, 0, len(msgAddresses.AddrList))
+	for _, peerAddress := range msgAddresses.AddrList {
+		if c.addressManager.IsRoutable(peerAddress) {
+			routableAddresses = append(routableAddresses, peerAddress)
+		}
+	}
+	c.addressManager.AddAddresses(routableAddresses, address, nil)
+	c.nodes.add(routableAddresses...)
+	log.Debugf("Received %d addresses from %s", len(msgAddresses.AddrList), address.TCPAddress())
+
+	return nil
+}
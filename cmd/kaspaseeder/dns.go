@@ -0,0 +1,253 @@
+package main
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/kaspanet/kaspad/dnsseed"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util/subnetworkid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	// maxIPsPerResponse is the maximum number of A or AAAA
+	// records returned in response to a single query
+	maxIPsPerResponse = 8
+
+	// recordTTL is the TTL, in seconds, of the returned records
+	recordTTL = 30
+
+	// maxDNSMessageSize is the maximum size of a DNS message over UDP
+	maxDNSMessageSize = 512
+)
+
+// dnsServer is an authoritative DNS server that answers A and AAAA
+// queries with the IPs of good nodes. It understands the subdomains
+// queried by dnsseed.SeedFromDNS:
+//
+//	x<hex service flags>.<host> - nodes with the given services
+//	n.<host>                    - full nodes only
+//	n<subnetwork ID>.<host>     - nodes of the given subnetwork
+//
+// which may be combined, e.g. n.x5.<host>.
+type dnsServer struct {
+	host       string
+	nameserver string
+	listen     string
+	nodes      *nodeSet
+
+	connection net.PacketConn
+}
+
+func newDNSServer(host, nameserver, listen string, nodes *nodeSet) *dnsServer {
+	return &dnsServer{
+		host:       canonicalName(host),
+		nameserver: canonicalName(nameserver),
+		listen:     listen,
+		nodes:      nodes,
+	}
+}
+
+// canonicalName returns the given domain name in lowercase and
+// fully qualified form
+func canonicalName(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+func (s *dnsServer) start() error {
+	connection, err := net.ListenPacket("udp", s.listen)
+	if err != nil {
+		return errors.Wrapf(err, "error listening on %s", s.listen)
+	}
+	s.connection = connection
+
+	spawn("dnsServer.serve", s.serve)
+
+	log.Infof("DNS server listening on %s", s.listen)
+	return nil
+}
+
+func (s *dnsServer) stop() error {
+	return s.connection.Close()
+}
+
+func (s *dnsServer) serve() {
+	buffer := make([]byte, maxDNSMessageSize)
+	for {
+		length, address, err := s.connection.ReadFrom(buffer)
+		if err != nil {
+			if opError := (&net.OpError{}); errors.As(err, &opError) && !opError.Temporary() {
+				return
+			}
+			log.Warnf("Error reading DNS query: %s", err)
+			continue
+		}
+
+		response, err := s.handleQuery(buffer[:length])
+		if err != nil {
+			log.Debugf("Bad DNS query from %s: %s", address, err)
+			continue
+		}
+		_, err = s.connection.WriteTo(response, address)
+		if err != nil {
+			log.Debugf("Error sending DNS response to %s: %s", address, err)
+		}
+	}
+}
+
+// handleQuery builds the response to the given serialized DNS query
+func (s *dnsServer) handleQuery(query []byte) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if header.Response {
+		return nil, errors.New("received a response instead of a query")
+	}
+	question, err := parser.Question()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	responseHeader := dnsmessage.Header{
+		ID:               header.ID,
+		Response:         true,
+		OpCode:           header.OpCode,
+		Authoritative:    true,
+		RecursionDesired: header.RecursionDesired,
+	}
+
+	name := strings.ToLower(question.Name.String())
+	filter, err := s.parseName(name)
+	if err != nil {
+		log.Debugf("Can't answer DNS query for %s: %s", name, err)
+		if errors.Is(err, errNotAuthoritative) {
+			responseHeader.Authoritative = false
+			responseHeader.RCode = dnsmessage.RCodeRefused
+		} else {
+			responseHeader.RCode = dnsmessage.RCodeNameError
+		}
+		return buildDNSResponse(responseHeader, question, nil)
+	}
+	log.Debugf("DNS query for %s %s", name, question.Type)
+
+	resourceHeader := dnsmessage.ResourceHeader{
+		Name:  question.Name,
+		Type:  question.Type,
+		Class: dnsmessage.ClassINET,
+		TTL:   recordTTL,
+	}
+
+	var answers []func(builder *dnsmessage.Builder) error
+	switch question.Type {
+	case dnsmessage.TypeA:
+		for _, ip := range s.nodes.goodIPs(filter, true, maxIPsPerResponse) {
+			resource := dnsmessage.AResource{}
+			copy(resource.A[:], ip.To4())
+			answers = append(answers, func(builder *dnsmessage.Builder) error {
+				return builder.AResource(resourceHeader, resource)
+			})
+		}
+	case dnsmessage.TypeAAAA:
+		for _, ip := range s.nodes.goodIPs(filter, false, maxIPsPerResponse) {
+			resource := dnsmessage.AAAAResource{}
+			copy(resource.AAAA[:], ip.To16())
+			answers = append(answers, func(builder *dnsmessage.Builder) error {
+				return builder.AAAAResource(resourceHeader, resource)
+			})
+		}
+	case dnsmessage.TypeNS:
+		nameserver, err := dnsmessage.NewName(s.nameserver)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		answers = append(answers, func(builder *dnsmessage.Builder) error {
+			return builder.NSResource(resourceHeader, dnsmessage.NSResource{NS: nameserver})
+		})
+	}
+
+	return buildDNSResponse(responseHeader, question, answers)
+}
+
+func buildDNSResponse(header dnsmessage.Header, question dnsmessage.Question,
+	answers []func(builder *dnsmessage.Builder) error) ([]byte, error) {
+
+	builder := dnsmessage.NewBuilder(make([]byte, 0, maxDNSMessageSize), header)
+	builder.EnableCompression()
+	err := builder.StartQuestions()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = builder.Question(question)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = builder.StartAnswers()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, answer := range answers {
+		err = answer(&builder)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	response, err := builder.Finish()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return response, nil
+}
+
+var errNotAuthoritative = errors.New("name is outside of the served domain")
+
+// parseName parses the filter out of the subdomain labels
+// of the given lowercase, fully qualified name
+func (s *dnsServer) parseName(name string) (*nodeFilter, error) {
+	filter := &nodeFilter{
+		requiredServices:      domainmessage.SFNodeNetwork,
+		includeAllSubnetworks: true,
+	}
+	if name == s.host {
+		return filter, nil
+	}
+	if !strings.HasSuffix(name, "."+s.host) {
+		return nil, errNotAuthoritative
+	}
+
+	labels := strings.Split(strings.TrimSuffix(name, "."+s.host), ".")
+	for _, label := range labels {
+		if label == "" {
+			return nil, errors.Errorf("empty label in %s", name)
+		}
+		switch label[0] {
+		case dnsseed.ServiceFlagPrefixChar:
+			services, err := strconv.ParseUint(label[1:], 16, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid service flags in %s", label)
+			}
+			filter.requiredServices = domainmessage.ServiceFlag(services)
+		case dnsseed.SubnetworkIDPrefixChar:
+			filter.includeAllSubnetworks = false
+			if len(label) == 1 {
+				continue
+			}
+			subnetworkID, err := subnetworkid.NewFromStr(label[1:])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid subnetwork ID in %s", label)
+			}
+			filter.subnetworkID = subnetworkID
+		default:
+			return nil, errors.Errorf("unknown label %s", label)
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util/subnetworkid"
+	"github.com/pkg/errors"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	testHost       = "seed.example.com"
+	testNameserver = "ns.example.com"
+)
+
+func TestParseName(t *testing.T) {
+	server := newDNSServer(testHost, testNameserver, "", newNodeSet(testDefaultPort))
+	subnetworkID := &subnetworkid.SubnetworkID{5}
+
+	tests := []struct {
+		name           string
+		expectedFilter *nodeFilter
+		expectedErr    error
+	}{
+		{
+			name: "seed.example.com.",
+			expectedFilter: &nodeFilter{requiredServices: domainmessage.SFNodeNetwork,
+				includeAllSubnetworks: true},
+		},
+		{
+			name: "x5.seed.example.com.",
+			expectedFilter: &nodeFilter{requiredServices: domainmessage.SFNodeNetwork | domainmessage.SFNodeBloom,
+				includeAllSubnetworks: true},
+		},
+		{
+			name:           "n.seed.example.com.",
+			expectedFilter: &nodeFilter{requiredServices: domainmessage.SFNodeNetwork},
+		},
+		{
+			name:           "n" + subnetworkID.String() + ".seed.example.com.",
+			expectedFilter: &nodeFilter{requiredServices: domainmessage.SFNodeNetwork, subnetworkID: subnetworkID},
+		},
+		{
+			name:           "n.x4.seed.example.com.",
+			expectedFilter: &nodeFilter{requiredServices: domainmessage.SFNodeBloom},
+		},
+		{
+			name:        "example.com.",
+			expectedErr: errNotAuthoritative,
+		},
+		{
+			name:        "notseed.example.com.",
+			expectedErr: errNotAuthoritative,
+		},
+		{
+			name: "y.seed.example.com.",
+		},
+		{
+			name: "xz.seed.example.com.",
+		},
+		{
+			name: "nz.seed.example.com.",
+		},
+		{
+			name: "x5..seed.example.com.",
+		},
+	}
+
+	for _, test := range tests {
+		filter, err := server.parseName(test.name)
+		if test.expectedFilter == nil {
+			if err == nil {
+				t.Errorf("%s: expected parseName to fail", test.name)
+				continue
+			}
+			if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+				t.Errorf("%s: expected error %s, but got: %s", test.name, test.expectedErr, err)
+			}
+			if test.expectedErr == nil && errors.Is(err, errNotAuthoritative) {
+				t.Errorf("%s: unexpectedly got %s", test.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: parseName unexpectedly failed: %s", test.name, err)
+			continue
+		}
+		if filter.requiredServices != test.expectedFilter.requiredServices ||
+			filter.includeAllSubnetworks != test.expectedFilter.includeAllSubnetworks ||
+			!filter.subnetworkID.IsEqual(test.expectedFilter.subnetworkID) {
+
+			t.Errorf("%s: expected filter %+v, but got %+v", test.name, test.expectedFilter, filter)
+		}
+	}
+}
+
+func TestHandleQuery(t *testing.T) {
+	nodes := newNodeSet(testDefaultPort)
+	addGoodNode(nodes, "1.2.3.4", testDefaultPort, domainmessage.SFNodeNetwork, nil)
+	addGoodNode(nodes, "2001:db8::1", testDefaultPort, domainmessage.SFNodeNetwork, nil)
+	addGoodNode(nodes, "5.6.7.8", testDefaultPort, domainmessage.SFNodeNetwork, &subnetworkid.SubnetworkID{5})
+	server := newDNSServer(testHost, testNameserver, "", nodes)
+
+	tests := []struct {
+		name            string
+		queryType       dnsmessage.Type
+		expectedRCode   dnsmessage.RCode
+		expectedAnswers []string
+	}{
+		{
+			name:            "seed.example.com.",
+			queryType:       dnsmessage.TypeA,
+			expectedRCode:   dnsmessage.RCodeSuccess,
+			expectedAnswers: []string{"1.2.3.4", "5.6.7.8"},
+		},
+		{
+			name:            "SEED.Example.com.",
+			queryType:       dnsmessage.TypeA,
+			expectedRCode:   dnsmessage.RCodeSuccess,
+			expectedAnswers: []string{"1.2.3.4", "5.6.7.8"},
+		},
+		{
+			name:            "n.seed.example.com.",
+			queryType:       dnsmessage.TypeA,
+			expectedRCode:   dnsmessage.RCodeSuccess,
+			expectedAnswers: []string{"1.2.3.4"},
+		},
+		{
+			name:            "seed.example.com.",
+			queryType:       dnsmessage.TypeAAAA,
+			expectedRCode:   dnsmessage.RCodeSuccess,
+			expectedAnswers: []string{"2001:db8::1"},
+		},
+		{
+			name:            "x4.seed.example.com.",
+			queryType:       dnsmessage.TypeA,
+			expectedRCode:   dnsmessage.RCodeSuccess,
+			expectedAnswers: nil,
+		},
+		{
+			name:            "seed.example.com.",
+			queryType:       dnsmessage.TypeNS,
+			expectedRCode:   dnsmessage.RCodeSuccess,
+			expectedAnswers: []string{"ns.example.com."},
+		},
+		{
+			name:            "unknown.seed.example.com.",
+			queryType:       dnsmessage.TypeA,
+			expectedRCode:   dnsmessage.RCodeNameError,
+			expectedAnswers: nil,
+		},
+		{
+			name:            "other.example.com.",
+			queryType:       dnsmessage.TypeA,
+			expectedRCode:   dnsmessage.RCodeRefused,
+			expectedAnswers: nil,
+		},
+	}
+
+	for i, test := range tests {
+		query := buildTestDNSQuery(t, uint16(i), test.name, test.queryType)
+		response, err := server.handleQuery(query)
+		if err != nil {
+			t.Fatalf("%s %s: handleQuery unexpectedly failed: %s", test.name, test.queryType, err)
+		}
+
+		var parser dnsmessage.Parser
+		header, err := parser.Start(response)
+		if err != nil {
+			t.Fatalf("%s %s: Start unexpectedly failed: %s", test.name, test.queryType, err)
+		}
+		if header.ID != uint16(i) || !header.Response {
+			t.Errorf("%s %s: unexpected response header %+v", test.name, test.queryType, header)
+		}
+		if header.RCode != test.expectedRCode {
+			t.Errorf("%s %s: expected RCode %s, but got %s", test.name, test.queryType,
+				test.expectedRCode, header.RCode)
+		}
+		err = parser.SkipAllQuestions()
+		if err != nil {
+			t.Fatalf("%s %s: SkipAllQuestions unexpectedly failed: %s", test.name, test.queryType, err)
+		}
+		answers, err := parser.AllAnswers()
+		if err != nil {
+			t.Fatalf("%s %s: AllAnswers unexpectedly failed: %s", test.name, test.queryType, err)
+		}
+
+		actualAnswers := make(map[string]struct{})
+		for _, answer := range answers {
+			if answer.Header.Type != test.queryType || answer.Header.TTL != recordTTL {
+				t.Errorf("%s %s: unexpected answer header %+v", test.name, test.queryType, answer.Header)
+			}
+			switch body := answer.Body.(type) {
+			case *dnsmessage.AResource:
+				actualAnswers[net.IP(body.A[:]).String()] = struct{}{}
+			case *dnsmessage.AAAAResource:
+				actualAnswers[net.IP(body.AAAA[:]).String()] = struct{}{}
+			case *dnsmessage.NSResource:
+				actualAnswers[body.NS.String()] = struct{}{}
+			}
+		}
+		if len(actualAnswers) != len(test.expectedAnswers) {
+			t.Errorf("%s %s: expected answers %v, but got %v", test.name, test.queryType,
+				test.expectedAnswers, actualAnswers)
+			continue
+		}
+		for _, expectedAnswer := range test.expectedAnswers {
+			if _, ok := actualAnswers[expectedAnswer]; !ok {
+				t.Errorf("%s %s: expected answers %v, but got %v", test.name, test.queryType,
+					test.expectedAnswers, actualAnswers)
+			}
+		}
+	}
+
+	// A response can't be handled as a query
+	response, err := server.handleQuery(buildTestDNSQuery(t, 0, testHost+".", dnsmessage.TypeA))
+	if err != nil {
+		t.Fatalf("handleQuery unexpectedly failed: %s", err)
+	}
+	_, err = server.handleQuery(response)
+	if err == nil {
+		t.Errorf("Expected handleQuery to fail on a response")
+	}
+}
+
+func buildTestDNSQuery(t *testing.T, id uint16, name string, queryType dnsmessage.Type) []byte {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: id, RecursionDesired: true})
+	err := builder.StartQuestions()
+	if err != nil {
+		t.Fatalf("StartQuestions unexpectedly failed: %s", err)
+	}
+	err = builder.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(name),
+		Type:  queryType,
+		Class: dnsmessage.ClassINET,
+	})
+	if err != nil {
+		t.Fatalf("Question unexpectedly failed: %s", err)
+	}
+	query, err := builder.Finish()
+	if err != nil {
+		t.Fatalf("Finish unexpectedly failed: %s", err)
+	}
+	return query
+}
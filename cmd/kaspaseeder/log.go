@@ -0,0 +1,15 @@
+package main
+
+import (
+	"github.com/kaspanet/kaspad/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var (
+	log   = logger.BackendLog.Logger("SEED")
+	spawn = panics.GoroutineWrapperFunc(log)
+)
+
+func initLog(logFile, errLogFile string) {
+	logger.InitLog(logFile, errLogFile)
+}
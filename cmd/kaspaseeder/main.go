@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	_ "net/http/pprof"
+
+	"github.com/kaspanet/kaspad/addressmanager"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/netadapter"
+	"github.com/kaspanet/kaspad/signal"
+	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/kaspanet/kaspad/util/profiling"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+const databaseDirectoryName = "database"
+
+func main() {
+	defer panics.HandlePanic(log, "MAIN", nil)
+	interrupt := signal.InterruptListener()
+
+	cfg, err := parseConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing command-line arguments: %s\n", err)
+		os.Exit(1)
+	}
+
+	// Show version at startup.
+	log.Infof("Version %s", version.Version())
+
+	// Enable http profiling server if requested.
+	if cfg.Profile != "" {
+		profiling.Start(cfg.Profile, log)
+	}
+
+	kaspadConfig := cfg.kaspadConfig()
+
+	databaseContext, err := dbaccess.New(filepath.Join(cfg.AppDir, databaseDirectoryName))
+	if err != nil {
+		panic(errors.Wrap(err, "error opening the database"))
+	}
+	defer func() {
+		err := databaseContext.Close()
+		if err != nil {
+			log.Errorf("Error closing the database: %+v", err)
+		}
+	}()
+
+	addressManager, err := addressmanager.New(kaspadConfig, databaseContext)
+	if err != nil {
+		panic(errors.Wrap(err, "error creating the address manager"))
+	}
+	err = addressManager.Start()
+	if err != nil {
+		panic(errors.Wrap(err, "error starting the address manager"))
+	}
+	defer func() {
+		err := addressManager.Stop()
+		if err != nil {
+			log.Errorf("Error stopping the address manager: %+v", err)
+		}
+	}()
+
+	netAdapter, err := netadapter.NewNetAdapter(kaspadConfig)
+	if err != nil {
+		panic(errors.Wrap(err, "error creating the net adapter"))
+	}
+
+	defaultPort, err := strconv.ParseUint(cfg.NetParams().DefaultPort, 10, 16)
+	if err != nil {
+		panic(errors.Wrap(err, "error parsing the network's default port"))
+	}
+	nodes := newNodeSet(uint16(defaultPort))
+	for _, seeder := range cfg.Seeders {
+		err := addressManager.AddAddressByIP(seeder, nil)
+		if err != nil {
+			panic(errors.Wrapf(err, "error adding seeder %s", seeder))
+		}
+	}
+
+	crawler := newCrawler(kaspadConfig, netAdapter, addressManager, nodes, int(cfg.Threads))
+	err = netAdapter.Start()
+	if err != nil {
+		panic(errors.Wrap(err, "error starting the net adapter"))
+	}
+	defer func() {
+		err := netAdapter.Stop()
+		if err != nil {
+			log.Errorf("Error stopping the net adapter: %+v", err)
+		}
+	}()
+	crawler.start()
+	defer crawler.stop()
+
+	dnsServer := newDNSServer(cfg.Host, cfg.Nameserver, cfg.Listen, nodes)
+	err = dnsServer.start()
+	if err != nil {
+		panic(errors.Wrap(err, "error starting the DNS server"))
+	}
+	defer func() {
+		err := dnsServer.stop()
+		if err != nil {
+			log.Errorf("Error stopping the DNS server: %+v", err)
+		}
+	}()
+
+	<-interrupt
+	log.Infof("Seeder shutting down")
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/addressmanager"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util/subnetworkid"
+)
+
+const (
+	// goodNodePollInterval is how often nodes that responded
+	// to their last poll are polled again
+	goodNodePollInterval = 30 * time.Minute
+
+	// badNodePollInterval is how often nodes that failed
+	// their last poll are polled again
+	badNodePollInterval = 2 * time.Hour
+
+	// staleNodeTimeout is how long a node may go without a successful
+	// poll before it is forgotten
+	staleNodeTimeout = 24 * time.Hour
+)
+
+// node is a peer the seeder knows about, along with everything
+// learned about it the last time it was successfully polled
+type node struct {
+	address     *domainmessage.NetAddress
+	firstSeen   time.Time
+	lastAttempt time.Time
+	lastSuccess time.Time
+
+	protocolVersion uint32
+	userAgent       string
+	services        domainmessage.ServiceFlag
+	subnetworkID    *subnetworkid.SubnetworkID
+}
+
+// isGood returns whether the node responded to its last poll
+func (n *node) isGood() bool {
+	return !n.lastSuccess.IsZero() && !n.lastSuccess.Before(n.lastAttempt)
+}
+
+func (n *node) needsPoll(now time.Time) bool {
+	if n.lastAttempt.IsZero() {
+		return true
+	}
+	if n.isGood() {
+		return now.Sub(n.lastAttempt) > goodNodePollInterval
+	}
+	return now.Sub(n.lastAttempt) > badNodePollInterval
+}
+
+func (n *node) isStale(now time.Time) bool {
+	lastSeen := n.lastSuccess
+	if lastSeen.IsZero() {
+		lastSeen = n.firstSeen
+	}
+	return now.Sub(lastSeen) > staleNodeTimeout
+}
+
+// nodeFilter selects the nodes to answer a DNS query with
+type nodeFilter struct {
+	requiredServices      domainmessage.ServiceFlag
+	includeAllSubnetworks bool
+	subnetworkID          *subnetworkid.SubnetworkID
+}
+
+func (f *nodeFilter) matches(n *node) bool {
+	if n.services&f.requiredServices != f.requiredServices {
+		return false
+	}
+	return f.includeAllSubnetworks || n.subnetworkID.IsEqual(f.subnetworkID)
+}
+
+// nodeSet keeps track of the liveness of every known peer
+type nodeSet struct {
+	nodes       map[addressmanager.AddressKey]*node
+	defaultPort uint16
+	mutex       sync.RWMutex
+}
+
+func newNodeSet(defaultPort uint16) *nodeSet {
+	return &nodeSet{
+		nodes:       make(map[addressmanager.AddressKey]*node),
+		defaultPort: defaultPort,
+	}
+}
+
+// add starts tracking the given addresses, ignoring
+// the ones that are already known
+func (s *nodeSet) add(addresses ...*domainmessage.NetAddress) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for _, address := range addresses {
+		addressKey := addressmanager.NetAddressKey(address)
+		if _, ok := s.nodes[addressKey]; ok {
+			continue
+		}
+		s.nodes[addressKey] = &node{address: address, firstSeen: now}
+	}
+}
+
+// attempted marks that a poll of the given node has started
+func (s *nodeSet) attempted(address *domainmessage.NetAddress) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if n, ok := s.nodes[addressmanager.NetAddressKey(address)]; ok {
+		n.lastAttempt = time.Now()
+	}
+}
+
+// succeeded records the version the given node
+// has sent during a successful poll
+func (s *nodeSet) succeeded(address *domainmessage.NetAddress, msgVersion *domainmessage.MsgVersion) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	n, ok := s.nodes[addressmanager.NetAddressKey(address)]
+	if !ok {
+		return
+	}
+	n.lastSuccess = time.Now()
+	n.protocolVersion = msgVersion.ProtocolVersion
+	n.userAgent = msgVersion.UserAgent
+	n.services = msgVersion.Services
+	n.subnetworkID = msgVersion.SubnetworkID
+}
+
+// addressesToPoll returns the addresses of all the nodes that are due
+// for a poll, and forgets the ones that have been gone for too long
+func (s *nodeSet) addressesToPoll() []*domainmessage.NetAddress {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	var addresses []*domainmessage.NetAddress
+	for addressKey, n := range s.nodes {
+		if n.isStale(now) {
+			delete(s.nodes, addressKey)
+			continue
+		}
+		if n.needsPoll(now) {
+			addresses = append(addresses, n.address)
+		}
+	}
+	return addresses
+}
+
+// goodIPs returns up to maxIPs random IPs of good nodes listening on
+// the default port that match the given filter. If isIPv4 is true only
+// IPv4 addresses are returned, otherwise only IPv6 addresses are.
+func (s *nodeSet) goodIPs(filter *nodeFilter, isIPv4 bool, maxIPs int) []net.IP {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var ips []net.IP
+	for _, n := range s.nodes {
+		if !n.isGood() || n.address.Port != s.defaultPort || !filter.matches(n) {
+			continue
+		}
+		if (n.address.IP.To4() != nil) != isIPv4 {
+			continue
+		}
+		ips = append(ips, n.address.IP)
+	}
+
+	rand.Shuffle(len(ips), func(i, j int) {
+		ips[i], ips[j] = ips[j], ips[i]
+	})
+	if len(ips) > maxIPs {
+		ips = ips[:maxIPs]
+	}
+	return ips
+}
+
+// counts returns the number of known nodes and how many of them are good
+func (s *nodeSet) counts() (known int, good int) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for _, n := range s.nodes {
+		if n.isGood() {
+			good++
+		}
+	}
+	return len(s.nodes), good
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/addressmanager"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util/subnetworkid"
+)
+
+const testDefaultPort = 16111
+
+// addGoodNode adds a node with the given address to the set and
+// marks it as successfully polled with the given version details
+func addGoodNode(set *nodeSet, ip string, port uint16, services domainmessage.ServiceFlag,
+	subnetworkID *subnetworkid.SubnetworkID) {
+
+	address := domainmessage.NewNetAddressIPPort(net.ParseIP(ip), port, 0)
+	set.add(address)
+	set.attempted(address)
+	set.succeeded(address, &domainmessage.MsgVersion{
+		ProtocolVersion: domainmessage.ProtocolVersion,
+		Services:        services,
+		SubnetworkID:    subnetworkID,
+	})
+}
+
+func TestNodeStatus(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name              string
+		node              *node
+		expectedGood      bool
+		expectedNeedsPoll bool
+		expectedStale     bool
+	}{
+		{
+			name:              "never polled",
+			node:              &node{firstSeen: now},
+			expectedGood:      false,
+			expectedNeedsPoll: true,
+			expectedStale:     false,
+		},
+		{
+			name: "recently polled successfully",
+			node: &node{firstSeen: now.Add(-time.Hour), lastAttempt: now.Add(-time.Minute),
+				lastSuccess: now.Add(-time.Minute)},
+			expectedGood:      true,
+			expectedNeedsPoll: false,
+			expectedStale:     false,
+		},
+		{
+			name: "good node due for a poll",
+			node: &node{firstSeen: now.Add(-time.Hour), lastAttempt: now.Add(-goodNodePollInterval - time.Minute),
+				lastSuccess: now.Add(-goodNodePollInterval - time.Minute)},
+			expectedGood:      true,
+			expectedNeedsPoll: true,
+			expectedStale:     false,
+		},
+		{
+			name: "recently failed a poll",
+			node: &node{firstSeen: now.Add(-time.Hour), lastAttempt: now.Add(-time.Minute),
+				lastSuccess: now.Add(-time.Hour)},
+			expectedGood:      false,
+			expectedNeedsPoll: false,
+			expectedStale:     false,
+		},
+		{
+			name:              "bad node due for a poll",
+			node:              &node{firstSeen: now.Add(-time.Hour), lastAttempt: now.Add(-badNodePollInterval - time.Minute)},
+			expectedGood:      false,
+			expectedNeedsPoll: true,
+			expectedStale:     false,
+		},
+		{
+			name: "never responded for too long",
+			node: &node{firstSeen: now.Add(-staleNodeTimeout - time.Minute),
+				lastAttempt: now.Add(-time.Minute)},
+			expectedGood:      false,
+			expectedNeedsPoll: false,
+			expectedStale:     true,
+		},
+		{
+			name: "last responded too long ago",
+			node: &node{firstSeen: now.Add(-2 * staleNodeTimeout), lastAttempt: now.Add(-time.Minute),
+				lastSuccess: now.Add(-staleNodeTimeout - time.Minute)},
+			expectedGood:      false,
+			expectedNeedsPoll: false,
+			expectedStale:     true,
+		},
+	}
+
+	for _, test := range tests {
+		if isGood := test.node.isGood(); isGood != test.expectedGood {
+			t.Errorf("%s: expected isGood to be %t, but got %t", test.name, test.expectedGood, isGood)
+		}
+		if needsPoll := test.node.needsPoll(now); needsPoll != test.expectedNeedsPoll {
+			t.Errorf("%s: expected needsPoll to be %t, but got %t", test.name, test.expectedNeedsPoll, needsPoll)
+		}
+		if isStale := test.node.isStale(now); isStale != test.expectedStale {
+			t.Errorf("%s: expected isStale to be %t, but got %t", test.name, test.expectedStale, isStale)
+		}
+	}
+}
+
+func TestNodeFilterMatches(t *testing.T) {
+	subnetworkID := &subnetworkid.SubnetworkID{5}
+	otherSubnetworkID := &subnetworkid.SubnetworkID{6}
+	fullNode := &node{services: domainmessage.SFNodeNetwork}
+	bloomNode := &node{services: domainmessage.SFNodeNetwork | domainmessage.SFNodeBloom}
+	partialNode := &node{services: domainmessage.SFNodeNetwork, subnetworkID: subnetworkID}
+
+	tests := []struct {
+		name     string
+		filter   *nodeFilter
+		node     *node
+		expected bool
+	}{
+		{
+			name:     "default filter",
+			filter:   &nodeFilter{requiredServices: domainmessage.SFNodeNetwork, includeAllSubnetworks: true},
+			node:     partialNode,
+			expected: true,
+		},
+		{
+			name:     "missing service",
+			filter:   &nodeFilter{requiredServices: domainmessage.SFNodeBloom, includeAllSubnetworks: true},
+			node:     fullNode,
+			expected: false,
+		},
+		{
+			name:     "required services are a subset",
+			filter:   &nodeFilter{requiredServices: domainmessage.SFNodeBloom, includeAllSubnetworks: true},
+			node:     bloomNode,
+			expected: true,
+		},
+		{
+			name:     "full nodes only with full node",
+			filter:   &nodeFilter{requiredServices: domainmessage.SFNodeNetwork},
+			node:     fullNode,
+			expected: true,
+		},
+		{
+			name:     "full nodes only with partial node",
+			filter:   &nodeFilter{requiredServices: domainmessage.SFNodeNetwork},
+			node:     partialNode,
+			expected: false,
+		},
+		{
+			name:     "same subnetwork",
+			filter:   &nodeFilter{requiredServices: domainmessage.SFNodeNetwork, subnetworkID: subnetworkID},
+			node:     partialNode,
+			expected: true,
+		},
+		{
+			name:     "other subnetwork",
+			filter:   &nodeFilter{requiredServices: domainmessage.SFNodeNetwork, subnetworkID: otherSubnetworkID},
+			node:     partialNode,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		if matches := test.filter.matches(test.node); matches != test.expected {
+			t.Errorf("%s: expected matches to be %t, but got %t", test.name, test.expected, matches)
+		}
+	}
+}
+
+func TestNodeSet(t *testing.T) {
+	set := newNodeSet(testDefaultPort)
+
+	addGoodNode(set, "1.2.3.4", testDefaultPort, domainmessage.SFNodeNetwork, nil)
+	addGoodNode(set, "5.6.7.8", testDefaultPort, domainmessage.SFNodeNetwork, nil)
+	addGoodNode(set, "2001:db8::1", testDefaultPort, domainmessage.SFNodeNetwork, nil)
+	addGoodNode(set, "9.9.9.9", testDefaultPort+1, domainmessage.SFNodeNetwork, nil)
+	addGoodNode(set, "8.8.8.8", testDefaultPort, domainmessage.SFNodeNetwork, &subnetworkid.SubnetworkID{5})
+
+	// A node that failed its poll
+	badAddress := domainmessage.NewNetAddressIPPort(net.ParseIP("4.4.4.4"), testDefaultPort, 0)
+	set.add(badAddress)
+	set.attempted(badAddress)
+
+	// A node that was never polled, added twice
+	newAddress := domainmessage.NewNetAddressIPPort(net.ParseIP("3.3.3.3"), testDefaultPort, 0)
+	set.add(newAddress, newAddress)
+
+	known, good := set.counts()
+	if known != 7 || good != 5 {
+		t.Fatalf("Expected 7 known nodes and 5 good nodes, but got %d and %d", known, good)
+	}
+
+	addressesToPoll := set.addressesToPoll()
+	if len(addressesToPoll) != 1 || !addressesToPoll[0].IP.Equal(newAddress.IP) {
+		t.Fatalf("Expected only %s to need a poll, but got %v", newAddress.IP, addressesToPoll)
+	}
+
+	filter := &nodeFilter{requiredServices: domainmessage.SFNodeNetwork, includeAllSubnetworks: true}
+	checkGoodIPs(t, set.goodIPs(filter, true, maxIPsPerResponse), "1.2.3.4", "5.6.7.8", "8.8.8.8")
+	checkGoodIPs(t, set.goodIPs(filter, false, maxIPsPerResponse), "2001:db8::1")
+
+	fullNodeFilter := &nodeFilter{requiredServices: domainmessage.SFNodeNetwork}
+	checkGoodIPs(t, set.goodIPs(fullNodeFilter, true, maxIPsPerResponse), "1.2.3.4", "5.6.7.8")
+
+	if ips := set.goodIPs(filter, true, 2); len(ips) != 2 {
+		t.Fatalf("Expected goodIPs to return 2 IPs, but got %d", len(ips))
+	}
+
+	// Nodes that have been gone for too long are forgotten
+	set.nodes[addressmanager.NetAddressKey(newAddress)].firstSeen = time.Now().Add(-staleNodeTimeout - time.Minute)
+	set.addressesToPoll()
+	known, _ = set.counts()
+	if known != 6 {
+		t.Fatalf("Expected a stale node to be forgotten, but %d nodes are known", known)
+	}
+}
+
+// checkGoodIPs checks that the given IPs are exactly the expected ones,
+// in any order
+func checkGoodIPs(t *testing.T, ips []net.IP, expected ...string) {
+	actual := make([]string, len(ips))
+	for i, ip := range ips {
+		actual[i] = ip.String()
+	}
+	sort.Strings(actual)
+	sort.Strings(expected)
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected good IPs %v, but got %v", expected, actual)
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			t.Fatalf("Expected good IPs %v, but got %v", expected, actual)
+		}
+	}
+}
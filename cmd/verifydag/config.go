@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+const defaultMultisetSampleSize = 100
+
+var (
+	kaspadHomeDir  = util.AppDataDir("kaspad", false)
+	defaultDataDir = filepath.Join(kaspadHomeDir, "data")
+)
+
+type configFlags struct {
+	DataDir            string `short:"b" long:"datadir" description:"Location of the kaspad data directory"`
+	DbType             string `long:"dbtype" description:"Database backend of the database to verify {ffldb, ffbolt}"`
+	AcceptanceIndex    bool   `long:"acceptanceindex" description:"Verify that the acceptance index is complete, and maintain it when repairing"`
+	MultisetSampleSize int    `long:"multisetsample" description:"Number of non-finalized blocks, in addition to the DAG tips, whose multisets are recomputed from the UTXO set"`
+	Repair             bool   `long:"repair" description:"If any inconsistency is found, rebuild the database by reprocessing all of its blocks. The original database is kept as a backup"`
+	config.NetworkFlags
+}
+
+func parseConfig() (*configFlags, error) {
+	cfg := &configFlags{
+		DataDir:            defaultDataDir,
+		DbType:             dbaccess.FFLDBType,
+		MultisetSampleSize: defaultMultisetSampleSize,
+	}
+	parser := flags.NewParser(cfg, flags.Default)
+	_, err := parser.Parse()
+	if err != nil {
+		var flagsErr *flags.Error
+		if ok := errors.As(err, &flagsErr); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		}
+		return nil, err
+	}
+
+	err = cfg.ResolveNetwork(parser)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.DbType != dbaccess.FFLDBType && cfg.DbType != dbaccess.FFBoltDBType {
+		return nil, errors.Errorf("--dbtype must be either %s or %s",
+			dbaccess.FFLDBType, dbaccess.FFBoltDBType)
+	}
+
+	if cfg.MultisetSampleSize < 0 {
+		return nil, errors.New("--multisetsample must not be negative")
+	}
+
+	return cfg, nil
+}
+
+// databasePath returns the path of the database of the selected network.
+func (cfg *configFlags) databasePath() string {
+	return filepath.Join(cfg.DataDir, cfg.NetParams().Name, dbaccess.DirectoryName(cfg.DbType))
+}
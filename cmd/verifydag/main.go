@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/blockdag/indexers"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing command-line arguments: %s\n", err)
+		os.Exit(1)
+	}
+
+	inconsistencies, err := verifyDatabase(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error verifying the database: %s\n", err)
+		os.Exit(1)
+	}
+	sort.Strings(inconsistencies)
+	for _, inconsistency := range inconsistencies {
+		fmt.Println(inconsistency)
+	}
+	if len(inconsistencies) == 0 {
+		fmt.Println("No inconsistencies found")
+		return
+	}
+	fmt.Printf("Found %d inconsistencies\n", len(inconsistencies))
+
+	if !cfg.Repair {
+		os.Exit(1)
+	}
+	err = rebuildDatabase(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error rebuilding the database: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// verifyDatabase opens the database in read-only mode, and returns a
+// description of every inconsistency found in it.
+func verifyDatabase(cfg *configFlags) ([]string, error) {
+	databaseContext, err := dbaccess.OpenReadOnly(cfg.DbType, cfg.databasePath())
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening the database")
+	}
+	defer databaseContext.Close()
+
+	dag, err := blockdag.New(&blockdag.Config{
+		DAGParams:       cfg.NetParams(),
+		TimeSource:      blockdag.NewTimeSource(),
+		DatabaseContext: databaseContext,
+	})
+	if err != nil {
+		// Failing to load the DAG state is by itself an
+		// inconsistency, which is repaired the same way
+		return []string{fmt.Sprintf("failed to load the DAG state: %s", err)}, nil
+	}
+
+	inconsistencies, err := dag.VerifyConsistency(cfg.MultisetSampleSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AcceptanceIndex {
+		missingHashes, err := indexers.MissingAcceptanceData(dag, databaseContext)
+		if err != nil {
+			return nil, err
+		}
+		for _, hash := range missingHashes {
+			inconsistencies = append(inconsistencies,
+				fmt.Sprintf("block %s is missing from the acceptance index", hash))
+		}
+	}
+	return inconsistencies, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/blockdag/indexers"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/txscript"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+const sigCacheMaxSize = 100000
+
+// rebuildDatabase rebuilds all the data derived from the blocks, such as
+// the reachability data, the multisets, the UTXO set and the acceptance
+// index, by reprocessing every block whose body is stored in the database
+// into a new database of the same type. The data of the node itself, such
+// as the known peers, is copied over as it is. Once done, the new database
+// replaces the original one, which is kept as a backup.
+func rebuildDatabase(cfg *configFlags) error {
+	databasePath := cfg.databasePath()
+	rebuiltDatabasePath := databasePath + "-rebuilt"
+	err := os.RemoveAll(rebuiltDatabasePath)
+	if err != nil {
+		return err
+	}
+
+	err = reprocessBlocks(cfg, databasePath, rebuiltDatabasePath)
+	if err != nil {
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s-backup-%s", databasePath, time.Now().Format("20060102150405"))
+	err = os.Rename(databasePath, backupPath)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(rebuiltDatabasePath, databasePath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("The database was rebuilt. The original database was moved to %s\n", backupPath)
+	return nil
+}
+
+// reprocessBlocks fully validates and processes every block whose body is
+// stored in the database in sourcePath into a new database in targetPath,
+// in blue score order. Blocks that are stored as invalid are skipped.
+func reprocessBlocks(cfg *configFlags, sourcePath, targetPath string) error {
+	sourceContext, err := dbaccess.OpenReadOnly(cfg.DbType, sourcePath)
+	if err != nil {
+		return errors.Wrapf(err, "error opening the database")
+	}
+	defer sourceContext.Close()
+
+	hashes, invalidHashes, err := blockdag.StoredBlockHashes(sourceContext)
+	if err != nil {
+		return err
+	}
+
+	// The rebuilt database gets the current schema version once
+	// it's created, since its data is all written by this version
+	targetContext, err := dbaccess.Open(cfg.DbType, targetPath)
+	if err != nil {
+		return errors.Wrapf(err, "error creating the rebuilt database")
+	}
+	defer targetContext.Close()
+
+	err = dbaccess.CopyNodeData(sourceContext, targetContext)
+	if err != nil {
+		return errors.Wrapf(err, "error copying the data of the node")
+	}
+
+	var indexManager blockdag.IndexManager
+	if cfg.AcceptanceIndex {
+		indexManager = indexers.NewManager([]indexers.Indexer{indexers.NewAcceptanceIndex()})
+	}
+	dag, err := blockdag.New(&blockdag.Config{
+		DAGParams:       cfg.NetParams(),
+		TimeSource:      blockdag.NewTimeSource(),
+		SigCache:        txscript.NewSigCache(sigCacheMaxSize),
+		DatabaseContext: targetContext,
+		IndexManager:    indexManager,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Reprocessing %d blocks, skipping %d invalid blocks\n", len(hashes), len(invalidHashes))
+	var lostBlocks int
+	for _, hash := range hashes {
+		if dag.IsKnownBlock(hash) {
+			continue
+		}
+
+		blockBytes, err := dbaccess.FetchBlock(sourceContext, hash)
+		if err != nil {
+			fmt.Printf("Dropping block %s: its body could not be read: %s\n", hash, err)
+			lostBlocks++
+			continue
+		}
+		block, err := util.NewBlockFromBytes(blockBytes)
+		if err != nil {
+			fmt.Printf("Dropping block %s: its body could not be deserialized: %s\n", hash, err)
+			lostBlocks++
+			continue
+		}
+		if !dag.AreKnownBlocks(block.MsgBlock().Header.ParentHashes) {
+			fmt.Printf("Dropping block %s: some of its parents were dropped\n", hash)
+			lostBlocks++
+			continue
+		}
+
+		// The stored data can't be trusted, so every block
+		// is fully validated again
+		isOrphan, isDelayed, err := dag.ProcessBlock(block, blockdag.BFNone)
+		if err != nil {
+			fmt.Printf("Dropping block %s: it was rejected: %s\n", hash, err)
+			lostBlocks++
+			continue
+		}
+		if isOrphan || isDelayed {
+			fmt.Printf("Dropping block %s: it could not be processed immediately\n", hash)
+			lostBlocks++
+		}
+	}
+	fmt.Printf("Reprocessed %d blocks, dropped %d blocks\n", len(hashes)-lostBlocks, lostBlocks)
+	return nil
+}
@@ -7,7 +7,9 @@ package config
 import (
 	"bufio"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net"
@@ -18,14 +20,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kaspanet/kaspad/blockdag"
 	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/dbaccess"
 
 	"github.com/pkg/errors"
 
 	"github.com/btcsuite/go-socks/socks"
 	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/domainmessage"
 	"github.com/kaspanet/kaspad/logger"
 	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
 	"github.com/kaspanet/kaspad/util/network"
 	"github.com/kaspanet/kaspad/util/subnetworkid"
 	"github.com/kaspanet/kaspad/version"
@@ -52,11 +58,29 @@ const (
 	blockMaxMassMax              = 10000000
 	defaultMinRelayTxFee         = 1e-5 // 1 sompi per byte
 	defaultMaxOrphanTransactions = 100
+	defaultMaxChainCount         = 25
+	defaultMaxChainMass          = 1000000
+	defaultStratumDifficulty     = 1
+	defaultStratumShareTime      = time.Second * 10
 	//DefaultMaxOrphanTxSize is the default maximum size for an orphan transaction
 	DefaultMaxOrphanTxSize = 100000
 	defaultSigCacheMaxSize = 100000
 	sampleConfigFilename   = "sample-kaspad.conf"
 	defaultAcceptanceIndex = false
+	defaultP2PEncryption   = P2PEncryptionNone
+)
+
+// The supported values of the --p2pencryption option
+const (
+	// P2PEncryptionNone disables encryption of P2P connections
+	P2PEncryptionNone = "none"
+
+	// P2PEncryptionOpportunistic encrypts P2P connections
+	// whenever the remote peer supports it
+	P2PEncryptionOpportunistic = "opportunistic"
+
+	// P2PEncryptionRequired refuses plaintext P2P connections
+	P2PEncryptionRequired = "required"
 )
 
 var (
@@ -88,10 +112,17 @@ type Flags struct {
 	Listeners            []string      `long:"listen" description:"Add an interface/port to listen for connections (default all interfaces port: 16111, testnet: 16211)"`
 	TargetOutboundPeers  int           `long:"outpeers" description:"Target number of outbound peers"`
 	MaxInboundPeers      int           `long:"maxinpeers" description:"Max number of inbound peers"`
+	MaxIBDUpload         uint64        `long:"maxibdupload" description:"Max number of megabytes of blocks served to syncing peers every 24 hours -- Syncing peers are disconnected once it is reached (0 for unlimited)"`
 	DisableBanning       bool          `long:"nobanning" description:"Disable banning of misbehaving peers"`
 	BanDuration          time.Duration `long:"banduration" description:"How long to ban misbehaving peers. Valid time units are {s, m, h}. Minimum 1 second"`
 	BanThreshold         uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
 	Whitelists           []string      `long:"whitelist" description:"Add an IP network or IP that will not be banned. (eg. 192.168.1.0/24 or ::1)"`
+	ASMap                string        `long:"asmap" description:"File mapping IP prefixes to autonomous system numbers, one '<prefix> <ASN>' per line. Used to diversify peers across autonomous systems"`
+	P2PEncryption        string        `long:"p2pencryption" description:"Encryption of P2P connections {none, opportunistic, required}"`
+	P2PCert              string        `long:"p2pcert" description:"File containing the P2P certificate (default: p2p.cert in the data directory)"`
+	P2PKey               string        `long:"p2pkey" description:"File containing the P2P certificate key (default: p2p.key in the data directory)"`
+	NetLatency           time.Duration `long:"netlatency" description:"Delay every incoming P2P message by the given duration to simulate network latency. Valid time units are {ms, s, m} -- Not allowed on mainnet and testnet"`
+	ConnectPins          []string      `long:"connectpin" description:"Pin the identity of a --connect peer as <address>=<fingerprint>, where the fingerprint is the one the peer logs on startup -- NOTE: Connections to pinned peers are always encrypted"`
 	RPCUser              string        `short:"u" long:"rpcuser" description:"Username for RPC connections"`
 	RPCPass              string        `short:"P" long:"rpcpass" default-mask:"-" description:"Password for RPC connections"`
 	RPCLimitUser         string        `long:"rpclimituser" description:"Username for limited RPC connections"`
@@ -110,23 +141,37 @@ type Flags struct {
 	Proxy                string        `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser            string        `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass            string        `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
-	DbType               string        `long:"dbtype" description:"Database backend to use for the Block DAG"`
+	DbType               string        `long:"dbtype" description:"Database backend to use for the Block DAG {ffldb, ffbolt, memory} -- The memory backend keeps nothing once kaspad stops"`
 	Profile              string        `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	CPUProfile           string        `long:"cpuprofile" description:"Write CPU profile to the specified file"`
 	DebugLevel           string        `short:"d" long:"debuglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
 	Upnp                 bool          `long:"upnp" description:"Use UPnP to map our listening port outside of NAT"`
 	MinRelayTxFee        float64       `long:"minrelaytxfee" description:"The minimum transaction fee in KAS/kB to be considered a non-zero fee."`
 	MaxOrphanTxs         int           `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
+	MaxAncestors         uint64        `long:"maxancestors" description:"Max number of unconfirmed ancestors of a transaction in the mempool, including itself (0 for no limit)"`
+	MaxAncestorMass      uint64        `long:"maxancestormass" description:"Max total mass of the unconfirmed ancestors of a transaction in the mempool, including itself (0 for no limit)"`
+	MaxDescendants       uint64        `long:"maxdescendants" description:"Max number of unconfirmed descendants of a transaction in the mempool, including itself (0 for no limit)"`
+	MaxDescendantMass    uint64        `long:"maxdescendantmass" description:"Max total mass of the unconfirmed descendants of a transaction in the mempool, including itself (0 for no limit)"`
 	BlockMaxMass         uint64        `long:"blockmaxmass" description:"Maximum transaction mass to be used when creating a block"`
+	BlockVersion         int32         `long:"blockversion-override" description:"Use the given version for block templates instead of the one calculated from the state of the rule change deployments"`
+	Signal               []string      `long:"signal" description:"Always signal for the given rule change deployment in block templates, or never signal for it if prefixed with '-' (eg. --signal=dummy or --signal=-dummy)"`
+	Deployments          []string      `long:"deployment" description:"Define an ad-hoc rule change deployment as <name>:<bit>:<starttime>:<expiretime>, with times in unix milliseconds -- Only allowed on simnet and regtest"`
 	UserAgentComments    []string      `long:"uacomment" description:"Comment to add to the user agent -- See BIP 14 for more information."`
 	NoPeerBloomFilters   bool          `long:"nopeerbloomfilters" description:"Disable bloom filtering support"`
 	SigCacheMaxSize      uint          `long:"sigcachemaxsize" description:"The maximum number of entries in the signature verification cache"`
+	AssumeValid          string        `long:"assumevalid" description:"Skip script validation for blocks in the past of the given checkpoint block hash (default: the latest checkpoint of the network, if it has any; 0 to validate all scripts)"`
 	BlocksOnly           bool          `long:"blocksonly" description:"Do not accept transactions from remote peers."`
 	AcceptanceIndex      bool          `long:"acceptanceindex" description:"Maintain a full hash-based acceptance index which makes the getChainFromBlock RPC available"`
 	DropAcceptanceIndex  bool          `long:"dropacceptanceindex" description:"Deletes the hash-based acceptance index from the database on start up and then exits."`
 	RelayNonStd          bool          `long:"relaynonstd" description:"Relay non-standard transactions regardless of the default settings for the active network."`
 	RejectNonStd         bool          `long:"rejectnonstd" description:"Reject non-standard transactions regardless of the default settings for the active network."`
 	ResetDatabase        bool          `long:"reset-db" description:"Reset database before starting node. It's needed when switching between subnetworks."`
+	DryRunMigration      bool          `long:"dry-run-migration" description:"Run the pending database schema migrations, roll them back and exit without starting the node"`
+	RestoreDatabase      string        `long:"restore-db" description:"Validate the database backup in the given directory, as written by the backupDatabase RPC, and replace the database with it before starting node. The replaced database is kept alongside it."`
+	StratumListeners     []string      `long:"stratumlisten" description:"Add an interface/port to listen for Stratum mining connections (default port: 16112, testnet: 16212) -- NOTE: The Stratum server is disabled unless at least one is specified"`
+	StratumPayAddress    string        `long:"stratumpayaddr" description:"Address to pay blocks mined through the Stratum server to -- Required if --stratumlisten is used"`
+	StratumDifficulty    float64       `long:"stratumdifficulty" description:"Share difficulty that new Stratum workers start at before it's adjusted to their hash rate"`
+	StratumShareTime     time.Duration `long:"stratumsharetime" description:"Time between the shares of each Stratum worker that its share difficulty is adjusted for. Valid time units are {s, m, h}"`
 	NetworkFlags
 }
 
@@ -141,6 +186,19 @@ type Config struct {
 	MinRelayTxFee util.Amount
 	Whitelists    []*net.IPNet
 	SubnetworkID  *subnetworkid.SubnetworkID // nil in full nodes
+	AssumeValid   *daghash.Hash              // nil if all scripts are validated
+
+	// StratumPayAddress is the address that blocks mined through the
+	// Stratum server pay to. nil if the Stratum server is disabled
+	StratumPayAddress util.Address
+
+	// SignalDeployments maps the bit numbers of the deployments passed
+	// to --signal to whether block templates should signal for them
+	SignalDeployments map[uint8]bool
+
+	// P2PPinnedPeers maps --connect peer addresses to the
+	// fingerprints of their P2P certificates
+	P2PPinnedPeers map[string]string
 }
 
 // serviceOptions defines the configuration options for the daemon as a service on
@@ -149,6 +207,17 @@ type serviceOptions struct {
 	ServiceCommand string `short:"s" long:"service" description:"Service command {install, remove, start, stop}"`
 }
 
+// isSupportedDBType returns whether the given database type
+// is one of the supported database types.
+func isSupportedDBType(dbType string) bool {
+	for _, supportedDBType := range dbaccess.SupportedDBTypes {
+		if dbType == supportedDBType {
+			return true
+		}
+	}
+	return false
+}
+
 // cleanAndExpandPath expands environment variables and leading ~ in the
 // passed path, cleans the result, and returns it.
 func cleanAndExpandPath(path string) string {
@@ -189,9 +258,17 @@ func defaultFlags() *Flags {
 		RPCCert:              defaultRPCCertFile,
 		BlockMaxMass:         defaultBlockMaxMass,
 		MaxOrphanTxs:         defaultMaxOrphanTransactions,
+		MaxAncestors:         defaultMaxChainCount,
+		MaxAncestorMass:      defaultMaxChainMass,
+		MaxDescendants:       defaultMaxChainCount,
+		MaxDescendantMass:    defaultMaxChainMass,
 		SigCacheMaxSize:      defaultSigCacheMaxSize,
 		MinRelayTxFee:        defaultMinRelayTxFee,
 		AcceptanceIndex:      defaultAcceptanceIndex,
+		P2PEncryption:        defaultP2PEncryption,
+		StratumDifficulty:    defaultStratumDifficulty,
+		StratumShareTime:     defaultStratumShareTime,
+		DbType:               dbaccess.FFLDBType,
 	}
 }
 
@@ -396,6 +473,16 @@ func LoadConfig() (cfg *Config, remainingArgs []string, err error) {
 		return nil, nil, err
 	}
 
+	// Validate database type.
+	if !isSupportedDBType(cfg.DbType) {
+		str := "%s: The specified database type [%s] is invalid -- " +
+			"supported types: %s"
+		err := errors.Errorf(str, funcName, cfg.DbType, strings.Join(dbaccess.SupportedDBTypes, ", "))
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// Validate profile port number
 	if cfg.Profile != "" {
 		profilePort, err := strconv.Atoi(cfg.Profile)
@@ -596,6 +683,26 @@ func LoadConfig() (cfg *Config, remainingArgs []string, err error) {
 		return nil, nil, err
 	}
 
+	// --reset-db and --restore-db do not mix.
+	if cfg.ResetDatabase && cfg.RestoreDatabase != "" {
+		err := errors.Errorf("%s: the --reset-db and --restore-db "+
+			"options may not be activated at the same time",
+			funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	if cfg.RestoreDatabase != "" {
+		if cfg.DbType == dbaccess.MemoryDBType {
+			err := errors.Errorf("%s: the --restore-db option may not be "+
+				"used with the %s database type", funcName, dbaccess.MemoryDBType)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.RestoreDatabase = cleanAndExpandPath(cfg.RestoreDatabase)
+	}
+
 	// Add default port to all listener addresses if needed and remove
 	// duplicate addresses.
 	cfg.Listeners, err = network.NormalizeAddresses(cfg.Listeners,
@@ -612,6 +719,48 @@ func LoadConfig() (cfg *Config, remainingArgs []string, err error) {
 		return nil, nil, err
 	}
 
+	// Add default port to all Stratum listener addresses if needed and
+	// remove duplicate addresses.
+	cfg.StratumListeners, err = network.NormalizeAddresses(cfg.StratumListeners,
+		cfg.NetParams().StratumPort)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The Stratum server needs an address to pay the blocks mined through
+	// it to, and sane share difficulty settings.
+	if len(cfg.StratumListeners) > 0 {
+		if cfg.Flags.StratumPayAddress == "" {
+			str := "%s: the --stratumpayaddr option is required when using --stratumlisten"
+			err := errors.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		cfg.StratumPayAddress, err = util.DecodeAddress(cfg.Flags.StratumPayAddress, cfg.NetParams().Prefix)
+		if err != nil {
+			str := "%s: stratumpayaddr '%s' failed to decode: %s"
+			err := errors.Errorf(str, funcName, cfg.Flags.StratumPayAddress, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		if cfg.StratumDifficulty <= 0 {
+			str := "%s: the stratumdifficulty option must be greater than 0 -- parsed [%g]"
+			err := errors.Errorf(str, funcName, cfg.StratumDifficulty)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+		if cfg.StratumShareTime <= 0 {
+			str := "%s: the stratumsharetime option must be greater than 0 -- parsed [%s]"
+			err := errors.Errorf(str, funcName, cfg.StratumShareTime)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	}
+
 	// Only allow TLS to be disabled if the RPC is bound to localhost
 	// addresses.
 	if !cfg.DisableRPC && cfg.DisableTLS {
@@ -665,6 +814,87 @@ func LoadConfig() (cfg *Config, remainingArgs []string, err error) {
 		return nil, nil, err
 	}
 
+	// Validate the P2P encryption mode.
+	switch cfg.P2PEncryption {
+	case P2PEncryptionNone, P2PEncryptionOpportunistic, P2PEncryptionRequired:
+	default:
+		str := "%s: The p2pencryption option must be one of {%s, %s, %s} -- parsed [%s]"
+		err := errors.Errorf(str, funcName, P2PEncryptionNone,
+			P2PEncryptionOpportunistic, P2PEncryptionRequired, cfg.P2PEncryption)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	if cfg.ASMap != "" {
+		cfg.ASMap = cleanAndExpandPath(cfg.ASMap)
+	}
+
+	if cfg.P2PCert != "" {
+		cfg.P2PCert = cleanAndExpandPath(cfg.P2PCert)
+	}
+	if cfg.P2PKey != "" {
+		cfg.P2PKey = cleanAndExpandPath(cfg.P2PKey)
+	}
+
+	// Parse the pinned identities of --connect peers.
+	cfg.P2PPinnedPeers, err = parseConnectPins(cfg.ConnectPins, cfg.ConnectPeers, cfg.NetParams().DefaultPort)
+	if err != nil {
+		err := errors.Errorf("%s: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Simulated network latency is only for test networks, since it slows
+	// down the propagation of blocks.
+	if cfg.NetLatency < 0 {
+		str := "%s: the netlatency option must not be negative -- parsed [%s]"
+		err := errors.Errorf(str, funcName, cfg.NetLatency)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+	if cfg.NetLatency > 0 && (cfg.NetParams().Net == domainmessage.Mainnet ||
+		cfg.NetParams().Net == domainmessage.Testnet) {
+
+		str := "%s: the netlatency option is not allowed on %s"
+		err := errors.Errorf(str, funcName, cfg.NetParams().Name)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Add the ad-hoc deployments to a copy of the network parameters,
+	// so that the parameters of the network itself are left intact.
+	if len(cfg.Deployments) > 0 {
+		cfg.ActiveNetParams, err = addDeployments(cfg.NetParams(), cfg.Deployments)
+		if err != nil {
+			err := errors.Errorf("%s: %s", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, nil, err
+		}
+	}
+
+	cfg.SignalDeployments, err = parseSignal(cfg.Signal, cfg.NetParams())
+	if err != nil {
+		err := errors.Errorf("%s: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
+	// Parse the assume-valid block hash, defaulting to the latest
+	// checkpoint of the network.
+	cfg.AssumeValid, err = parseAssumeValid(cfg.Flags.AssumeValid, cfg.NetParams())
+	if err != nil {
+		err := errors.Errorf("%s: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, nil, err
+	}
+
 	// Setup dial and DNS resolution (lookup) functions depending on the
 	// specified options. The default is to use the standard
 	// net.DialTimeout function as well as the system DNS resolver. When a
@@ -700,6 +930,147 @@ func LoadConfig() (cfg *Config, remainingArgs []string, err error) {
 	return cfg, remainingArgs, nil
 }
 
+// addDeployments returns a copy of the given network parameters with the
+// ad-hoc deployments defined by --deployment values of the form
+// <name>:<bit>:<starttime>:<expiretime> added to its deployments.
+func addDeployments(netParams *dagconfig.Params, deployments []string) (*dagconfig.Params, error) {
+	if netParams.Net != domainmessage.Simnet && netParams.Net != domainmessage.Regtest {
+		return nil, errors.Errorf("Ad-hoc deployments are only allowed on simnet and regtest")
+	}
+
+	params := *netParams
+	params.Deployments = append([]dagconfig.ConsensusDeployment{}, netParams.Deployments...)
+	for _, deployment := range deployments {
+		fields := strings.Split(deployment, ":")
+		if len(fields) != 4 || fields[0] == "" {
+			return nil, errors.Errorf("The deployment value '%s' is not of the form "+
+				"<name>:<bit>:<starttime>:<expiretime>", deployment)
+		}
+		bitNumber, err := strconv.ParseUint(fields[1], 10, 8)
+		if err != nil || bitNumber > blockdag.MaxDeploymentBitNumber {
+			return nil, errors.Errorf("The bit of the deployment '%s' must be between 0 and %d",
+				fields[0], blockdag.MaxDeploymentBitNumber)
+		}
+		startTime, err := strconv.ParseUint(fields[2], 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("The start time of the deployment '%s' is invalid: %s", fields[0], err)
+		}
+		expireTime, err := strconv.ParseUint(fields[3], 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("The expire time of the deployment '%s' is invalid: %s", fields[0], err)
+		}
+
+		for _, existing := range params.Deployments {
+			if existing.Name == fields[0] {
+				return nil, errors.Errorf("The deployment '%s' is already defined", fields[0])
+			}
+			if uint64(existing.BitNumber) == bitNumber {
+				return nil, errors.Errorf("The bit of the deployment '%s' is already used by "+
+					"the deployment '%s'", fields[0], existing.Name)
+			}
+		}
+
+		params.Deployments = append(params.Deployments, dagconfig.ConsensusDeployment{
+			Name:       fields[0],
+			BitNumber:  uint8(bitNumber),
+			StartTime:  startTime,
+			ExpireTime: expireTime,
+		})
+	}
+	return &params, nil
+}
+
+// parseSignal parses --signal values into a map from deployment bit
+// number to whether block templates should signal for the deployment.
+func parseSignal(signal []string, netParams *dagconfig.Params) (map[uint8]bool, error) {
+	signalDeployments := make(map[uint8]bool, len(signal))
+	for _, name := range signal {
+		shouldSignal := !strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+
+		found := false
+		for _, deployment := range netParams.Deployments {
+			if deployment.Name == name {
+				signalDeployments[deployment.BitNumber] = shouldSignal
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("The signal value '%s' is not a deployment of this network", name)
+		}
+	}
+	return signalDeployments, nil
+}
+
+// parseAssumeValid parses the --assumevalid value into a block hash,
+// which must be a checkpoint of the network. An empty value means the
+// latest checkpoint of the network, if it has any, and "0" disables
+// assume-valid.
+func parseAssumeValid(assumeValid string, netParams *dagconfig.Params) (*daghash.Hash, error) {
+	switch assumeValid {
+	case "":
+		if len(netParams.Checkpoints) == 0 {
+			return nil, nil
+		}
+		return netParams.Checkpoints[len(netParams.Checkpoints)-1].Hash, nil
+	case "0":
+		return nil, nil
+	}
+	hash, err := daghash.NewHashFromStr(assumeValid)
+	if err != nil {
+		return nil, errors.Errorf("The assumevalid value '%s' is not a valid block hash", assumeValid)
+	}
+	// Only checkpoints are accepted, since they're known to be in the
+	// selected parent chain of the network
+	for _, checkpoint := range netParams.Checkpoints {
+		if checkpoint.Hash.IsEqual(hash) {
+			return hash, nil
+		}
+	}
+	return nil, errors.Errorf("The assumevalid block %s is not a checkpoint of %s", hash, netParams.Name)
+}
+
+// parseConnectPins parses --connectpin values of the form
+// <address>=<fingerprint> into a map from normalized address to
+// fingerprint. Every pinned address must also be a --connect peer.
+func parseConnectPins(connectPins []string, connectPeers []string, defaultPort string) (map[string]string, error) {
+	pinnedPeers := make(map[string]string, len(connectPins))
+	for _, connectPin := range connectPins {
+		separatorIndex := strings.LastIndex(connectPin, "=")
+		if separatorIndex == -1 {
+			return nil, errors.Errorf("The connectpin value '%s' is not "+
+				"of the form <address>=<fingerprint>", connectPin)
+		}
+		address, err := network.NormalizeAddress(connectPin[:separatorIndex], defaultPort)
+		if err != nil {
+			return nil, err
+		}
+		fingerprint := strings.ToLower(connectPin[separatorIndex+1:])
+
+		decodedFingerprint, err := hex.DecodeString(fingerprint)
+		if err != nil || len(decodedFingerprint) != sha256.Size {
+			return nil, errors.Errorf("The connectpin fingerprint '%s' is not "+
+				"a hex-encoded SHA256 hash", fingerprint)
+		}
+
+		isConnectPeer := false
+		for _, connectPeer := range connectPeers {
+			if connectPeer == address {
+				isConnectPeer = true
+				break
+			}
+		}
+		if !isConnectPeer {
+			return nil, errors.Errorf("The connectpin address '%s' is not "+
+				"one of the --connect peers", address)
+		}
+
+		pinnedPeers[address] = fingerprint
+	}
+	return pinnedPeers, nil
+}
+
 // createDefaultConfig copies the file sample-kaspad.conf to the given destination path,
 // and populates it with some randomly generated RPC username and password.
 func createDefaultConfigFile(destinationPath string) error {
@@ -8,6 +8,8 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/util/daghash"
 	"github.com/kaspanet/kaspad/util/subnetworkid"
 )
 
@@ -88,3 +90,86 @@ func TestConstants(t *testing.T) {
 		t.Errorf("subnetworkid.SubnetworkIDRegistry value was changed from 2, therefore you probably need to update the help text for SubnetworkID")
 	}
 }
+
+func TestAddDeployments(t *testing.T) {
+	params, err := addDeployments(&dagconfig.SimnetParams, []string{"softfork:1:0:1000"})
+	if err != nil {
+		t.Fatalf("addDeployments: %s", err)
+	}
+	if len(dagconfig.SimnetParams.Deployments) != dagconfig.DefinedDeployments {
+		t.Fatalf("addDeployments unexpectedly changed the deployments of simnet")
+	}
+	expectedDeployment := dagconfig.ConsensusDeployment{Name: "softfork", BitNumber: 1, StartTime: 0, ExpireTime: 1000}
+	if len(params.Deployments) != dagconfig.DefinedDeployments+1 ||
+		params.Deployments[dagconfig.DefinedDeployments] != expectedDeployment {
+
+		t.Fatalf("Unexpected deployments %+v", params.Deployments)
+	}
+
+	signalDeployments, err := parseSignal([]string{"softfork", "-dummy"}, params)
+	if err != nil {
+		t.Fatalf("parseSignal: %s", err)
+	}
+	dummyBit := params.Deployments[dagconfig.DeploymentTestDummy].BitNumber
+	if len(signalDeployments) != 2 || !signalDeployments[1] || signalDeployments[dummyBit] {
+		t.Errorf("Unexpected signalled deployments %v", signalDeployments)
+	}
+
+	invalidTests := []struct {
+		params      *dagconfig.Params
+		deployments []string
+	}{
+		{params: &dagconfig.TestnetParams, deployments: []string{"softfork:1:0:1000"}},
+		{params: &dagconfig.SimnetParams, deployments: []string{"softfork:1:0"}},
+		{params: &dagconfig.SimnetParams, deployments: []string{"softfork:28:0:1000"}},
+		{params: &dagconfig.SimnetParams, deployments: []string{"dummy:1:0:1000"}},
+		{params: &dagconfig.SimnetParams, deployments: []string{"softfork:1:0:1000", "other:1:0:1000"}},
+	}
+	for _, test := range invalidTests {
+		_, err := addDeployments(test.params, test.deployments)
+		if err == nil {
+			t.Errorf("Expected adding the deployments %v to %s to fail", test.deployments, test.params.Name)
+		}
+	}
+
+	_, err = parseSignal([]string{"unknown"}, params)
+	if err == nil {
+		t.Errorf("Expected signalling for an unknown deployment to fail")
+	}
+}
+
+func TestParseAssumeValid(t *testing.T) {
+	params := dagconfig.SimnetParams
+	checkpointHash := &daghash.Hash{1}
+	params.Checkpoints = []dagconfig.Checkpoint{{BlueScore: 10, Hash: checkpointHash}}
+
+	hash, err := parseAssumeValid("", &params)
+	if err != nil {
+		t.Fatalf("parseAssumeValid: %s", err)
+	}
+	if !hash.IsEqual(checkpointHash) {
+		t.Errorf("Expected the default assume-valid block to be the latest checkpoint %s, but got %s",
+			checkpointHash, hash)
+	}
+
+	hash, err = parseAssumeValid(checkpointHash.String(), &params)
+	if err != nil {
+		t.Fatalf("parseAssumeValid: %s", err)
+	}
+	if !hash.IsEqual(checkpointHash) {
+		t.Errorf("Expected the assume-valid block %s, but got %s", checkpointHash, hash)
+	}
+
+	hash, err = parseAssumeValid("0", &params)
+	if err != nil {
+		t.Fatalf("parseAssumeValid: %s", err)
+	}
+	if hash != nil {
+		t.Errorf("Expected 0 to disable assume-valid, but got %s", hash)
+	}
+
+	_, err = parseAssumeValid((&daghash.Hash{2}).String(), &params)
+	if err == nil {
+		t.Errorf("Expected a block that isn't a checkpoint to be rejected")
+	}
+}
@@ -10,10 +10,11 @@ import (
 
 // NetworkFlags holds the network configuration, that is which network is selected.
 type NetworkFlags struct {
-	Testnet         bool `long:"testnet" description:"Use the test network"`
-	RegressionTest  bool `long:"regtest" description:"Use the regression test network"`
-	Simnet          bool `long:"simnet" description:"Use the simulation test network"`
-	Devnet          bool `long:"devnet" description:"Use the development test network"`
+	Testnet         bool   `long:"testnet" description:"Use the test network"`
+	RegressionTest  bool   `long:"regtest" description:"Use the regression test network"`
+	Simnet          bool   `long:"simnet" description:"Use the simulation test network"`
+	Devnet          bool   `long:"devnet" description:"Use the development test network"`
+	NetParamsFile   string `long:"netparams" description:"Use the custom network defined in the given JSON or TOML network parameters file"`
 	ActiveNetParams *dagconfig.Params
 }
 
@@ -43,8 +44,17 @@ func (networkFlags *NetworkFlags) ResolveNetwork(parser *flags.Parser) error {
 		numNets++
 		networkFlags.ActiveNetParams = &dagconfig.DevnetParams
 	}
+	if networkFlags.NetParamsFile != "" {
+		numNets++
+		params, err := dagconfig.LoadParams(networkFlags.NetParamsFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return err
+		}
+		networkFlags.ActiveNetParams = params
+	}
 	if numNets > 1 {
-		message := "Multiple networks parameters (testnet, simnet, devnet, etc.) cannot be used" +
+		message := "Multiple networks parameters (testnet, simnet, devnet, netparams, etc.) cannot be used" +
 			"together. Please choose only one network"
 		err := errors.Errorf(message)
 		fmt.Fprintln(os.Stderr, err)
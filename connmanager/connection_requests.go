@@ -108,6 +108,15 @@ func (c *ConnectionManager) AddConnectionRequest(address string, isPermanent boo
 // RemoveConnection disconnects the connection for the given address
 // and removes it entirely from the connection manager.
 func (c *ConnectionManager) RemoveConnection(address string) {
-	// TODO(libp2p): unimplemented
-	panic("unimplemented")
+	c.connectionRequestsLock.Lock()
+	defer c.connectionRequestsLock.Unlock()
+
+	delete(c.activeRequested, address)
+	delete(c.pendingRequested, address)
+
+	for _, connection := range c.netAdapter.Connections() {
+		if connection.Address() == address {
+			connection.Disconnect()
+		}
+	}
 }
@@ -1,6 +1,9 @@
 package connmanager
 
 import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -10,6 +13,7 @@ import (
 	"github.com/kaspanet/kaspad/netadapter"
 
 	"github.com/kaspanet/kaspad/config"
+	"github.com/pkg/errors"
 )
 
 // connectionRequest represents a user request (either through CLI or RPC) to connect to a certain node
@@ -29,11 +33,15 @@ type ConnectionManager struct {
 
 	activeRequested  map[string]*connectionRequest
 	pendingRequested map[string]*connectionRequest
-	activeOutgoing   map[string]struct{}
+	activeOutgoing   map[string]string // address to network group
 	targetOutgoing   int
 	activeIncoming   map[string]struct{}
 	maxIncoming      int
 
+	peerQualityFunc PeerQualityFunc
+	netGroupKey     uint64
+	evictionLock    sync.Mutex
+
 	stop                   uint32
 	connectionRequestsLock sync.Mutex
 
@@ -43,16 +51,23 @@ type ConnectionManager struct {
 
 // New instantiates a new instance of a ConnectionManager
 func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *addressmanager.AddressManager) (*ConnectionManager, error) {
+	var netGroupKey [8]byte
+	_, err := io.ReadFull(crand.Reader, netGroupKey[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	c := &ConnectionManager{
 		cfg:              cfg,
 		netAdapter:       netAdapter,
 		addressManager:   addressManager,
 		activeRequested:  map[string]*connectionRequest{},
 		pendingRequested: map[string]*connectionRequest{},
-		activeOutgoing:   map[string]struct{}{},
+		activeOutgoing:   map[string]string{},
 		activeIncoming:   map[string]struct{}{},
 		resetLoopChan:    make(chan struct{}),
 		loopTicker:       time.NewTicker(connectionsLoopInterval),
+		netGroupKey:      binary.LittleEndian.Uint64(netGroupKey[:]),
 	}
 
 	connectPeers := cfg.AddPeers
@@ -0,0 +1,287 @@
+package connmanager
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	"github.com/kaspanet/kaspad/netadapter"
+)
+
+// These constants define how many inbound peers are protected from
+// eviction by each of the eviction protection criteria
+const (
+	protectedByNetGroupCount          = 4
+	protectedByPingCount              = 8
+	protectedByNovelTransactionsCount = 4
+	protectedByNovelBlocksCount       = 4
+)
+
+// PeerQuality holds the metrics by which inbound peers are
+// judged when one of them has to be evicted
+type PeerQuality struct {
+	LastPingDuration         time.Duration
+	LastNovelBlockTime       time.Time
+	LastNovelTransactionTime time.Time
+	TimeConnected            time.Duration
+}
+
+// PeerQualityFunc returns the quality of the peer associated with the
+// given connection, and false if the connection does not have a ready
+// peer yet
+type PeerQualityFunc func(connection *netadapter.NetConnection) (*PeerQuality, bool)
+
+// EvictionProtection is the reason an inbound peer is protected from eviction
+type EvictionProtection string
+
+// These are the possible eviction protections of an inbound peer
+const (
+	EvictionProtectionNone              EvictionProtection = "none"
+	EvictionProtectionNetGroup          EvictionProtection = "netgroup"
+	EvictionProtectionPing              EvictionProtection = "ping"
+	EvictionProtectionNovelTransactions EvictionProtection = "transactions"
+	EvictionProtectionNovelBlocks       EvictionProtection = "blocks"
+	EvictionProtectionUptime            EvictionProtection = "uptime"
+)
+
+type evictionCandidate struct {
+	connection    *netadapter.NetConnection
+	netGroup      string
+	keyedNetGroup uint64
+	quality       *PeerQuality
+	protection    EvictionProtection
+}
+
+// SetPeerQualityFunc sets the function used to evaluate
+// inbound peers when one of them has to be evicted
+func (c *ConnectionManager) SetPeerQualityFunc(peerQualityFunc PeerQualityFunc) {
+	c.peerQualityFunc = peerQualityFunc
+}
+
+// AdmitIncomingConnection decides whether the given new incoming connection
+// may be kept. If the maximum number of incoming connections was reached,
+// the worst unprotected inbound peer is evicted to make room for it.
+// Returns false if all inbound peers are protected, in which case the new
+// connection should be dropped.
+func (c *ConnectionManager) AdmitIncomingConnection(connection *netadapter.NetConnection) bool {
+	c.evictionLock.Lock()
+	defer c.evictionLock.Unlock()
+
+	incomingConnections := c.incomingConnections(connection)
+	if len(incomingConnections) < c.maxIncoming {
+		return true
+	}
+
+	candidate := selectCandidateToEvict(c.evictionCandidates(incomingConnections))
+	if candidate == nil {
+		log.Debugf("Refusing incoming connection %s: all inbound peers are protected from eviction",
+			connection)
+		return false
+	}
+
+	log.Infof("Evicting inbound peer %s (network group %s) to make room for %s",
+		candidate.connection, candidate.netGroup, connection)
+	candidate.connection.Disconnect()
+	return true
+}
+
+// EvictionProtections returns, for every ready inbound peer, the reason
+// it is protected from eviction, or EvictionProtectionNone if it would
+// be considered for eviction
+func (c *ConnectionManager) EvictionProtections() map[*netadapter.NetConnection]EvictionProtection {
+	c.evictionLock.Lock()
+	defer c.evictionLock.Unlock()
+
+	candidates := c.evictionCandidates(c.incomingConnections(nil))
+	protectCandidates(candidates)
+
+	protections := make(map[*netadapter.NetConnection]EvictionProtection, len(candidates))
+	for _, candidate := range candidates {
+		protections[candidate.connection] = candidate.protection
+	}
+	return protections
+}
+
+// incomingConnections returns all the incoming connections
+// except for the given one
+func (c *ConnectionManager) incomingConnections(
+	exclude *netadapter.NetConnection) []*netadapter.NetConnection {
+
+	var incomingConnections []*netadapter.NetConnection
+	for _, connection := range c.netAdapter.Connections() {
+		if !connection.IsOutbound() && connection != exclude {
+			incomingConnections = append(incomingConnections, connection)
+		}
+	}
+	return incomingConnections
+}
+
+// evictionCandidates returns the candidates for eviction out of the given
+// connections. Connections that do not have a ready peer yet cannot be
+// judged, and are therefore not candidates.
+func (c *ConnectionManager) evictionCandidates(
+	connections []*netadapter.NetConnection) []*evictionCandidate {
+
+	if c.peerQualityFunc == nil {
+		return nil
+	}
+
+	candidates := make([]*evictionCandidate, 0, len(connections))
+	for _, connection := range connections {
+		quality, ok := c.peerQualityFunc(connection)
+		if !ok {
+			continue
+		}
+		netGroup := c.addressManager.GroupKey(connection.NetAddress())
+		candidates = append(candidates, &evictionCandidate{
+			connection:    connection,
+			netGroup:      netGroup,
+			keyedNetGroup: c.keyedNetGroup(netGroup),
+			quality:       quality,
+			protection:    EvictionProtectionNone,
+		})
+	}
+	return candidates
+}
+
+// keyedNetGroup maps the given network group to a number using this node's
+// secret key, so that an attacker cannot predict which network groups
+// are protected from eviction
+func (c *ConnectionManager) keyedNetGroup(netGroup string) uint64 {
+	hasher := fnv.New64a()
+	var key [8]byte
+	binary.LittleEndian.PutUint64(key[:], c.netGroupKey)
+	hasher.Write(key[:])
+	hasher.Write([]byte(netGroup))
+	return hasher.Sum64()
+}
+
+// protectCandidates marks the candidates that are protected from eviction.
+// Each criterion protects peers that are hard for an attacker to imitate:
+// one peer from each of a few unpredictable network groups, the peers with
+// the lowest ping, the peers that most recently relayed new transactions
+// and blocks, and out of the rest, the half that has been connected the
+// longest.
+func protectCandidates(candidates []*evictionCandidate) {
+	protectByNetGroup(candidates, protectedByNetGroupCount)
+
+	protect(candidates, EvictionProtectionPing, protectedByPingCount,
+		func(candidate *evictionCandidate) bool { return candidate.quality.LastPingDuration > 0 },
+		func(a, b *evictionCandidate) bool {
+			return a.quality.LastPingDuration < b.quality.LastPingDuration
+		})
+
+	protect(candidates, EvictionProtectionNovelTransactions, protectedByNovelTransactionsCount,
+		func(candidate *evictionCandidate) bool { return !candidate.quality.LastNovelTransactionTime.IsZero() },
+		func(a, b *evictionCandidate) bool {
+			return a.quality.LastNovelTransactionTime.After(b.quality.LastNovelTransactionTime)
+		})
+
+	protect(candidates, EvictionProtectionNovelBlocks, protectedByNovelBlocksCount,
+		func(candidate *evictionCandidate) bool { return !candidate.quality.LastNovelBlockTime.IsZero() },
+		func(a, b *evictionCandidate) bool {
+			return a.quality.LastNovelBlockTime.After(b.quality.LastNovelBlockTime)
+		})
+
+	unprotectedCount := 0
+	for _, candidate := range candidates {
+		if candidate.protection == EvictionProtectionNone {
+			unprotectedCount++
+		}
+	}
+	protect(candidates, EvictionProtectionUptime, unprotectedCount/2,
+		func(candidate *evictionCandidate) bool { return true },
+		func(a, b *evictionCandidate) bool { return a.quality.TimeConnected > b.quality.TimeConnected })
+}
+
+// protect sorts the unprotected candidates that are eligible for the given
+// protection by the given less function, and protects the first count of them
+func protect(candidates []*evictionCandidate, protection EvictionProtection, count int,
+	isEligible func(candidate *evictionCandidate) bool, less func(a, b *evictionCandidate) bool) {
+
+	eligibleCandidates := make([]*evictionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.protection == EvictionProtectionNone && isEligible(candidate) {
+			eligibleCandidates = append(eligibleCandidates, candidate)
+		}
+	}
+	sort.SliceStable(eligibleCandidates, func(i, j int) bool {
+		return less(eligibleCandidates[i], eligibleCandidates[j])
+	})
+
+	for i := 0; i < count && i < len(eligibleCandidates); i++ {
+		eligibleCandidates[i].protection = protection
+	}
+}
+
+// protectByNetGroup protects the longest connected unprotected candidate of
+// each of the count network groups with the highest keyed network groups.
+// Only one candidate is protected per network group, so that an attacker
+// whose network group happens to be keyed highly doesn't get all of its
+// peers protected.
+func protectByNetGroup(candidates []*evictionCandidate, count int) {
+	eligibleCandidates := make([]*evictionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.protection == EvictionProtectionNone {
+			eligibleCandidates = append(eligibleCandidates, candidate)
+		}
+	}
+	sort.SliceStable(eligibleCandidates, func(i, j int) bool {
+		a, b := eligibleCandidates[i], eligibleCandidates[j]
+		if a.keyedNetGroup != b.keyedNetGroup {
+			return a.keyedNetGroup > b.keyedNetGroup
+		}
+		return a.quality.TimeConnected > b.quality.TimeConnected
+	})
+
+	protectedNetGroups := make(map[uint64]struct{}, count)
+	for _, candidate := range eligibleCandidates {
+		if len(protectedNetGroups) == count {
+			break
+		}
+		if _, ok := protectedNetGroups[candidate.keyedNetGroup]; ok {
+			continue
+		}
+		candidate.protection = EvictionProtectionNetGroup
+		protectedNetGroups[candidate.keyedNetGroup] = struct{}{}
+	}
+}
+
+// selectCandidateToEvict returns the candidate that should be evicted, or
+// nil if all the candidates are protected. Out of the unprotected candidates,
+// the youngest connection of the most represented network group is chosen,
+// so that an attacker with many connections from the same network group
+// evicts its own peers first.
+func selectCandidateToEvict(candidates []*evictionCandidate) *evictionCandidate {
+	protectCandidates(candidates)
+
+	netGroups := make(map[string][]*evictionCandidate)
+	for _, candidate := range candidates {
+		if candidate.protection == EvictionProtectionNone {
+			netGroups[candidate.netGroup] = append(netGroups[candidate.netGroup], candidate)
+		}
+	}
+
+	var toEvict *evictionCandidate
+	largestNetGroupSize := 0
+	for _, netGroupCandidates := range netGroups {
+		youngest := netGroupCandidates[0]
+		for _, candidate := range netGroupCandidates[1:] {
+			if candidate.quality.TimeConnected < youngest.quality.TimeConnected {
+				youngest = candidate
+			}
+		}
+
+		// On a tie between network groups, prefer evicting from the
+		// one with the youngest connection
+		if len(netGroupCandidates) > largestNetGroupSize ||
+			(len(netGroupCandidates) == largestNetGroupSize &&
+				youngest.quality.TimeConnected < toEvict.quality.TimeConnected) {
+
+			largestNetGroupSize = len(netGroupCandidates)
+			toEvict = youngest
+		}
+	}
+	return toEvict
+}
@@ -0,0 +1,95 @@
+package connmanager
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSelectCandidateToEvict(t *testing.T) {
+	now := time.Now()
+
+	// Honest peers are each in their own network group, and have
+	// been useful to us in different ways
+	var candidates []*evictionCandidate
+	for i := 0; i < 20; i++ {
+		candidates = append(candidates, &evictionCandidate{
+			netGroup:      fmt.Sprintf("honest%d", i),
+			keyedNetGroup: uint64(i),
+			quality: &PeerQuality{
+				LastPingDuration:         time.Duration(i+1) * time.Millisecond,
+				LastNovelBlockTime:       now.Add(-time.Duration(i) * time.Minute),
+				LastNovelTransactionTime: now.Add(-time.Duration(i) * time.Second),
+				TimeConnected:            time.Duration(i+1) * time.Hour,
+			},
+			protection: EvictionProtectionNone,
+		})
+	}
+
+	// An attacker fills the rest of the slots from a single network group
+	var attackerCandidates []*evictionCandidate
+	for i := 0; i < 20; i++ {
+		candidate := &evictionCandidate{
+			netGroup:      "attacker",
+			keyedNetGroup: 1000,
+			quality: &PeerQuality{
+				TimeConnected: time.Duration(i+1) * time.Minute,
+			},
+			protection: EvictionProtectionNone,
+		}
+		attackerCandidates = append(attackerCandidates, candidate)
+		candidates = append(candidates, candidate)
+	}
+
+	toEvict := selectCandidateToEvict(candidates)
+	if toEvict == nil {
+		t.Fatalf("Expected a candidate to be evicted")
+	}
+	if toEvict.netGroup != "attacker" {
+		t.Fatalf("Expected an attacker peer to be evicted, but got a peer from %s", toEvict.netGroup)
+	}
+	if toEvict != attackerCandidates[0] {
+		t.Fatalf("Expected the youngest unprotected attacker peer to be evicted, but got one "+
+			"connected for %s", toEvict.quality.TimeConnected)
+	}
+
+	// The attacker's peers share a single keyed network group, so only
+	// its longest connected peer is protected by it, and the rest of the
+	// network group protections go to the honest peers with the highest
+	// keyed network groups
+	for i, candidate := range attackerCandidates {
+		isLongestConnected := i == len(attackerCandidates)-1
+		if isLongestConnected != (candidate.protection == EvictionProtectionNetGroup) {
+			t.Errorf("Expected only the longest connected attacker peer to be protected by network group, "+
+				"but attacker peer %d is protected by %s", i, candidate.protection)
+		}
+	}
+	for i := 20 - protectedByNetGroupCount + 1; i < 20; i++ {
+		if candidates[i].protection != EvictionProtectionNetGroup {
+			t.Errorf("Expected honest peer %d to be protected by network group, but got %s",
+				i, candidates[i].protection)
+		}
+	}
+	for i, candidate := range candidates[:protectedByPingCount] {
+		if candidate.protection != EvictionProtectionPing {
+			t.Errorf("Expected honest peer %d to be protected by ping, but got %s", i, candidate.protection)
+		}
+	}
+}
+
+func TestSelectCandidateToEvictAllProtected(t *testing.T) {
+	var candidates []*evictionCandidate
+	for i := 0; i < protectedByNetGroupCount; i++ {
+		candidates = append(candidates, &evictionCandidate{
+			netGroup:      fmt.Sprintf("group%d", i),
+			keyedNetGroup: uint64(i),
+			quality:       &PeerQuality{TimeConnected: time.Hour},
+			protection:    EvictionProtectionNone,
+		})
+	}
+
+	toEvict := selectCandidateToEvict(candidates)
+	if toEvict != nil {
+		t.Fatalf("Expected no candidate to be evicted, but got one from %s", toEvict.netGroup)
+	}
+}
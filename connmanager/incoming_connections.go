@@ -1,20 +1,36 @@
 package connmanager
 
+import "github.com/kaspanet/kaspad/netadapter"
+
 // checkIncomingConnections makes sure there's no more than maxIncoming incoming connections
-// if there are - it randomly disconnects enough to go below that number
+// if there are - it evicts enough of them, by the inbound eviction policy, to go below that number
 func (c *ConnectionManager) checkIncomingConnections(incomingConnectionSet connectionSet) {
 	if len(incomingConnectionSet) <= c.maxIncoming {
 		return
 	}
 
+	c.evictionLock.Lock()
+	defer c.evictionLock.Unlock()
+
 	numConnectionsOverMax := len(incomingConnectionSet) - c.maxIncoming
-	// randomly disconnect nodes until the number of incoming connections is smaller than maxIncoming
-	for _, connection := range incomingConnectionSet {
-		connection.Disconnect()
+	for numConnectionsOverMax > 0 {
+		incomingConnections := make([]*netadapter.NetConnection, 0, len(incomingConnectionSet))
+		for _, connection := range incomingConnectionSet {
+			incomingConnections = append(incomingConnections, connection)
+		}
 
-		numConnectionsOverMax--
-		if numConnectionsOverMax == 0 {
-			break
+		// If all the ready peers are protected, fall back to disconnecting
+		// an arbitrary connection, such as one that didn't finish its
+		// handshake yet
+		connectionToEvict := incomingConnections[0]
+		candidate := selectCandidateToEvict(c.evictionCandidates(incomingConnections))
+		if candidate != nil {
+			connectionToEvict = candidate.connection
 		}
+
+		log.Debugf("Evicting inbound peer %s: too many incoming connections", connectionToEvict)
+		connectionToEvict.Disconnect()
+		incomingConnectionSet.remove(connectionToEvict)
+		numConnectionsOverMax--
 	}
 }
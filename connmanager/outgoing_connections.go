@@ -1,7 +1,8 @@
 package connmanager
 
 // checkOutgoingConnections goes over all activeOutgoing and makes sure they are still active.
-// Then it opens connections so that we have targetOutgoing active connections
+// Then it opens connections so that we have targetOutgoing active connections, each to a
+// different network group
 func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 	for address := range c.activeOutgoing {
 		connection, ok := connSet.get(address)
@@ -22,6 +23,11 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 	log.Debugf("Have got %d outgoing connections out of target %d, adding %d more",
 		liveConnections, c.targetOutgoing, c.targetOutgoing-liveConnections)
 
+	activeGroups := make(map[string]struct{}, len(c.activeOutgoing))
+	for _, group := range c.activeOutgoing {
+		activeGroups[group] = struct{}{}
+	}
+
 	connectionsNeededCount := c.targetOutgoing - len(c.activeOutgoing)
 	connectionAttempts := connectionsNeededCount * 2
 	for i := 0; i < connectionAttempts; i++ {
@@ -48,6 +54,14 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 			continue
 		}
 
+		// Networks that accept unroutable addresses are typically local test
+		// networks, where all nodes are likely to share a network group
+		group := c.addressManager.GroupKey(netAddress)
+		if _, ok := activeGroups[group]; ok && !c.cfg.NetParams().AcceptUnroutable {
+			log.Debugf("Skipping %s: already connected to a peer in network group %s", addressString, group)
+			continue
+		}
+
 		c.addressManager.Attempt(netAddress)
 		err = c.initiateConnection(addressString)
 		if err != nil {
@@ -56,6 +70,7 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 		}
 
 		c.addressManager.Connected(netAddress)
-		c.activeOutgoing[addressString] = struct{}{}
+		c.activeOutgoing[addressString] = group
+		activeGroups[group] = struct{}{}
 	}
 }
@@ -59,5 +59,11 @@ If an application does not use one of the standard Kaspa networks, a new
 Params struct may be created which defines the parameters for the non-
 standard network. As a general rule of thumb, all network parameters
 should be unique to the network, but parameter collisions can still occur.
+
+Such a network, for example a private development network, may also be
+defined in a JSON or TOML file and loaded with LoadParams, which is what
+kaspad does with the --netparams option. The fields of the file are described
+by ParamsFile, and the genesis block it defines can be mined with the
+gengenesis tool.
 */
 package dagconfig
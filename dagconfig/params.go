@@ -58,6 +58,9 @@ const (
 // ConsensusDeployment defines details related to a specific consensus rule
 // change that is voted in. This is part of BIP0009.
 type ConsensusDeployment struct {
+	// Name is a human-readable identifier of the deployment.
+	Name string
+
 	// BitNumber defines the specific bit number within the block version
 	// this particular soft-fork deployment refers to.
 	BitNumber uint8
@@ -83,9 +86,20 @@ const (
 	// determine how many defined deployments there currently are.
 
 	// DefinedDeployments is the number of currently defined deployments.
+	// Networks used for testing may define additional, ad-hoc deployments
+	// after these.
 	DefinedDeployments
 )
 
+// Checkpoint identifies a known good block in the block DAG. Blocks that
+// conflict with a checkpoint are rejected, which prevents forks from old
+// blocks, and checkpoints may be used as assume-valid blocks during initial
+// block download. A checkpoint must be a block in the selected parent chain.
+type Checkpoint struct {
+	BlueScore uint64
+	Hash      *daghash.Hash
+}
+
 // KType defines the size of GHOSTDAG consensus algorithm K parameter.
 type KType uint8
 
@@ -109,6 +123,9 @@ type Params struct {
 	// DefaultPort defines the default peer-to-peer port for the network.
 	DefaultPort string
 
+	// StratumPort defines the default Stratum mining server port
+	StratumPort string
+
 	// DNSSeeds defines a list of DNS seeds for the network that are used
 	// as one method to discover peers.
 	DNSSeeds []string
@@ -146,6 +163,9 @@ type Params struct {
 	// to calculate the required difficulty of each block.
 	DifficultyAdjustmentWindowSize uint64
 
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints []Checkpoint
+
 	// These fields are related to voting on consensus rule changes as
 	// defined by BIP0009.
 	//
@@ -158,10 +178,10 @@ type Params struct {
 	// state retarget window.
 	//
 	// Deployments define the specific consensus rule changes to be voted
-	// on.
+	// on, indexed by deployment ID.
 	RuleChangeActivationThreshold uint64
 	MinerConfirmationWindow       uint64
-	Deployments                   [DefinedDeployments]ConsensusDeployment
+	Deployments                   []ConsensusDeployment
 
 	// Mempool parameters
 	RelayNonStdTxs bool
@@ -181,6 +201,16 @@ type Params struct {
 
 	// DisableDifficultyAdjustment determine whether to use difficulty
 	DisableDifficultyAdjustment bool
+
+	// EnableGenerate enables the generate and generateToAddress RPCs,
+	// which mine blocks in-process. It should only be set on networks
+	// with a difficulty low enough for blocks to be solved on the CPU.
+	EnableGenerate bool
+
+	// EnableMockTime enables the setMockTime and advanceMockTime RPCs,
+	// which replace the clock of the node. It should only be set on
+	// networks used for testing.
+	EnableMockTime bool
 }
 
 // NormalizeRPCServerAddress returns addr with the current network default
@@ -196,6 +226,7 @@ var MainnetParams = Params{
 	Net:         domainmessage.Mainnet,
 	RPCPort:     "16110",
 	DefaultPort: "16111",
+	StratumPort: "16112",
 	DNSSeeds:    []string{"dnsseed.kas.pa"},
 
 	// DAG parameters
@@ -209,15 +240,19 @@ var MainnetParams = Params{
 	DifficultyAdjustmentWindowSize: difficultyAdjustmentWindowSize,
 	TimestampDeviationTolerance:    timestampDeviationTolerance,
 
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints: nil,
+
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
 	//   target proof of work timespan / target proof of work spacing
 	RuleChangeActivationThreshold: 1916, // 95% of MinerConfirmationWindow
 	MinerConfirmationWindow:       2016, //
-	Deployments: [DefinedDeployments]ConsensusDeployment{
+	Deployments: []ConsensusDeployment{
 		DeploymentTestDummy: {
-			BitNumber:  28,
+			Name:       "dummy",
+			BitNumber:  27,
 			StartTime:  1199145601000, // January 1, 2008 UTC
 			ExpireTime: 1230767999000, // December 31, 2008 UTC
 		},
@@ -240,6 +275,10 @@ var MainnetParams = Params{
 	EnableNonNativeSubnetworks: false,
 
 	DisableDifficultyAdjustment: false,
+
+	EnableGenerate: false,
+
+	EnableMockTime: false,
 }
 
 // RegressionNetParams defines the network parameters for the regression test
@@ -251,6 +290,7 @@ var RegressionNetParams = Params{
 	Net:         domainmessage.Regtest,
 	RPCPort:     "16210",
 	DefaultPort: "16211",
+	StratumPort: "16212",
 	DNSSeeds:    []string{},
 
 	// DAG parameters
@@ -264,15 +304,19 @@ var RegressionNetParams = Params{
 	DifficultyAdjustmentWindowSize: difficultyAdjustmentWindowSize,
 	TimestampDeviationTolerance:    timestampDeviationTolerance,
 
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints: nil,
+
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
 	//   target proof of work timespan / target proof of work spacing
 	RuleChangeActivationThreshold: 108, // 75%  of MinerConfirmationWindow
 	MinerConfirmationWindow:       144,
-	Deployments: [DefinedDeployments]ConsensusDeployment{
+	Deployments: []ConsensusDeployment{
 		DeploymentTestDummy: {
-			BitNumber:  28,
+			Name:       "dummy",
+			BitNumber:  27,
 			StartTime:  0,             // Always available for vote
 			ExpireTime: math.MaxInt64, // Never expires
 		},
@@ -295,6 +339,10 @@ var RegressionNetParams = Params{
 	EnableNonNativeSubnetworks: false,
 
 	DisableDifficultyAdjustment: false,
+
+	EnableGenerate: true,
+
+	EnableMockTime: true,
 }
 
 // TestnetParams defines the network parameters for the test Kaspa network.
@@ -304,6 +352,7 @@ var TestnetParams = Params{
 	Net:         domainmessage.Testnet,
 	RPCPort:     "16210",
 	DefaultPort: "16211",
+	StratumPort: "16212",
 	DNSSeeds:    []string{"testnet-dnsseed.kas.pa"},
 
 	// DAG parameters
@@ -317,15 +366,19 @@ var TestnetParams = Params{
 	DifficultyAdjustmentWindowSize: difficultyAdjustmentWindowSize,
 	TimestampDeviationTolerance:    timestampDeviationTolerance,
 
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints: nil,
+
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
 	//   target proof of work timespan / target proof of work spacing
 	RuleChangeActivationThreshold: 1512, // 75% of MinerConfirmationWindow
 	MinerConfirmationWindow:       2016,
-	Deployments: [DefinedDeployments]ConsensusDeployment{
+	Deployments: []ConsensusDeployment{
 		DeploymentTestDummy: {
-			BitNumber:  28,
+			Name:       "dummy",
+			BitNumber:  27,
 			StartTime:  1199145601000, // January 1, 2008 UTC
 			ExpireTime: 1230767999000, // December 31, 2008 UTC
 		},
@@ -348,6 +401,10 @@ var TestnetParams = Params{
 	EnableNonNativeSubnetworks: false,
 
 	DisableDifficultyAdjustment: false,
+
+	EnableGenerate: false,
+
+	EnableMockTime: false,
 }
 
 // SimnetParams defines the network parameters for the simulation test Kaspa
@@ -363,6 +420,7 @@ var SimnetParams = Params{
 	Net:         domainmessage.Simnet,
 	RPCPort:     "16510",
 	DefaultPort: "16511",
+	StratumPort: "16512",
 	DNSSeeds:    []string{}, // NOTE: There must NOT be any seeds.
 
 	// DAG parameters
@@ -376,15 +434,19 @@ var SimnetParams = Params{
 	DifficultyAdjustmentWindowSize: difficultyAdjustmentWindowSize,
 	TimestampDeviationTolerance:    timestampDeviationTolerance,
 
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints: nil,
+
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
 	//   target proof of work timespan / target proof of work spacing
 	RuleChangeActivationThreshold: 75, // 75% of MinerConfirmationWindow
 	MinerConfirmationWindow:       100,
-	Deployments: [DefinedDeployments]ConsensusDeployment{
+	Deployments: []ConsensusDeployment{
 		DeploymentTestDummy: {
-			BitNumber:  28,
+			Name:       "dummy",
+			BitNumber:  27,
 			StartTime:  0,             // Always available for vote
 			ExpireTime: math.MaxInt64, // Never expires
 		},
@@ -405,6 +467,10 @@ var SimnetParams = Params{
 	EnableNonNativeSubnetworks: false,
 
 	DisableDifficultyAdjustment: true,
+
+	EnableGenerate: true,
+
+	EnableMockTime: true,
 }
 
 // DevnetParams defines the network parameters for the development Kaspa network.
@@ -414,6 +480,7 @@ var DevnetParams = Params{
 	Net:         domainmessage.Devnet,
 	RPCPort:     "16610",
 	DefaultPort: "16611",
+	StratumPort: "16612",
 	DNSSeeds:    []string{}, // NOTE: There must NOT be any seeds.
 
 	// DAG parameters
@@ -427,15 +494,19 @@ var DevnetParams = Params{
 	DifficultyAdjustmentWindowSize: difficultyAdjustmentWindowSize,
 	TimestampDeviationTolerance:    timestampDeviationTolerance,
 
+	// Checkpoints ordered from oldest to newest.
+	Checkpoints: nil,
+
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
 	//   target proof of work timespan / target proof of work spacing
 	RuleChangeActivationThreshold: 1512, // 75% of MinerConfirmationWindow
 	MinerConfirmationWindow:       2016,
-	Deployments: [DefinedDeployments]ConsensusDeployment{
+	Deployments: []ConsensusDeployment{
 		DeploymentTestDummy: {
-			BitNumber:  28,
+			Name:       "dummy",
+			BitNumber:  27,
 			StartTime:  1199145601000, // January 1, 2008 UTC
 			ExpireTime: 1230767999000, // December 31, 2008 UTC
 		},
@@ -458,6 +529,10 @@ var DevnetParams = Params{
 	EnableNonNativeSubnetworks: false,
 
 	DisableDifficultyAdjustment: false,
+
+	EnableGenerate: true,
+
+	EnableMockTime: true,
 }
 
 var (
@@ -0,0 +1,367 @@
+package dagconfig
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/coinbasepayload"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/kaspanet/kaspad/util/subnetworkid"
+)
+
+// ParamsFile defines a custom network, such as a private development
+// network, as it's written in a JSON or TOML file. The keys in both formats
+// are the JSON names of the fields, and fields that are omitted take the
+// values of DevnetParams, except for Name, Net, Prefix and the genesis
+// Timestamp, which are required.
+type ParamsFile struct {
+	Name        string   `json:"name"`
+	Net         uint32   `json:"net"`
+	RPCPort     string   `json:"rpcPort"`
+	DefaultPort string   `json:"defaultPort"`
+	StratumPort string   `json:"stratumPort"`
+	DNSSeeds    []string `json:"dnsSeeds"`
+
+	K                              uint8  `json:"k"`
+	PowMaxBits                     uint   `json:"powMaxBits"`
+	BlockCoinbaseMaturity          uint64 `json:"blockCoinbaseMaturity"`
+	SubsidyReductionInterval       uint64 `json:"subsidyReductionInterval"`
+	TargetTimePerBlock             string `json:"targetTimePerBlock"`
+	FinalityDuration               string `json:"finalityDuration"`
+	TimestampDeviationTolerance    uint64 `json:"timestampDeviationTolerance"`
+	DifficultyAdjustmentWindowSize uint64 `json:"difficultyAdjustmentWindowSize"`
+	DisableDifficultyAdjustment    bool   `json:"disableDifficultyAdjustment"`
+
+	RuleChangeActivationThreshold uint64 `json:"ruleChangeActivationThreshold"`
+	MinerConfirmationWindow       uint64 `json:"minerConfirmationWindow"`
+
+	RelayNonStdTxs             bool   `json:"relayNonStdTxs"`
+	AcceptUnroutable           bool   `json:"acceptUnroutable"`
+	Prefix                     string `json:"prefix"`
+	PrivateKeyID               byte   `json:"privateKeyId"`
+	EnableNonNativeSubnetworks bool   `json:"enableNonNativeSubnetworks"`
+	EnableGenerate             bool   `json:"enableGenerate"`
+	EnableMockTime             bool   `json:"enableMockTime"`
+
+	Genesis GenesisFile `json:"genesis"`
+}
+
+// GenesisFile defines the genesis block of a custom network in a ParamsFile.
+type GenesisFile struct {
+	// Timestamp is the time of the genesis block as a Unix timestamp in
+	// milliseconds.
+	Timestamp int64 `json:"timestamp"`
+
+	// Bits is the target difficulty of the genesis block in compact form.
+	// It defaults to the difficulty of PowMaxBits.
+	Bits uint32 `json:"bits"`
+
+	// Nonce is a nonce for which the genesis block satisfies its target
+	// difficulty, as found by gengenesis.
+	Nonce uint64 `json:"nonce"`
+
+	// CoinbaseData is added to the payload of the coinbase transaction of
+	// the genesis block, so that the genesis blocks of networks that share
+	// all of their other parameters differ. It defaults to the network name.
+	CoinbaseData string `json:"coinbaseData"`
+
+	// Hash is the expected hash of the genesis block. It's optional, and
+	// if it's set, loading the file fails if the hash of the genesis block
+	// is different.
+	Hash string `json:"hash"`
+}
+
+// defaultParamsFile returns a ParamsFile with the values of DevnetParams.
+func defaultParamsFile() *ParamsFile {
+	return &ParamsFile{
+		RPCPort:     DevnetParams.RPCPort,
+		DefaultPort: DevnetParams.DefaultPort,
+		StratumPort: DevnetParams.StratumPort,
+		DNSSeeds:    []string{},
+
+		K:                              uint8(DevnetParams.K),
+		PowMaxBits:                     uint(DevnetParams.PowMax.BitLen()),
+		BlockCoinbaseMaturity:          DevnetParams.BlockCoinbaseMaturity,
+		SubsidyReductionInterval:       DevnetParams.SubsidyReductionInterval,
+		TargetTimePerBlock:             DevnetParams.TargetTimePerBlock.String(),
+		FinalityDuration:               DevnetParams.FinalityDuration.String(),
+		TimestampDeviationTolerance:    DevnetParams.TimestampDeviationTolerance,
+		DifficultyAdjustmentWindowSize: DevnetParams.DifficultyAdjustmentWindowSize,
+		DisableDifficultyAdjustment:    DevnetParams.DisableDifficultyAdjustment,
+
+		RuleChangeActivationThreshold: DevnetParams.RuleChangeActivationThreshold,
+		MinerConfirmationWindow:       DevnetParams.MinerConfirmationWindow,
+
+		RelayNonStdTxs:             DevnetParams.RelayNonStdTxs,
+		AcceptUnroutable:           DevnetParams.AcceptUnroutable,
+		PrivateKeyID:               DevnetParams.PrivateKeyID,
+		EnableNonNativeSubnetworks: DevnetParams.EnableNonNativeSubnetworks,
+		EnableGenerate:             DevnetParams.EnableGenerate,
+		EnableMockTime:             DevnetParams.EnableMockTime,
+	}
+}
+
+// ReadParamsFile reads a ParamsFile from the given path. Files with a .toml
+// extension are parsed as TOML, and all other files are parsed as JSON.
+func ReadParamsFile(path string) (*ParamsFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the network parameters file %s", path)
+	}
+
+	// TOML files are converted to JSON, so that the fields that are
+	// omitted from both formats keep their default values the same way.
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		tree, err := toml.LoadBytes(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing the network parameters file %s", path)
+		}
+		data, err = json.Marshal(tree.ToMap())
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing the network parameters file %s", path)
+		}
+	}
+
+	paramsFile := defaultParamsFile()
+	err = json.Unmarshal(data, paramsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error parsing the network parameters file %s", path)
+	}
+	return paramsFile, nil
+}
+
+// LoadParams reads the custom network defined in the file at the given path
+// and returns its parameters. See ParamsFile for the format of the file.
+func LoadParams(path string) (*Params, error) {
+	paramsFile, err := ReadParamsFile(path)
+	if err != nil {
+		return nil, err
+	}
+	params, err := paramsFile.Params()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid network parameters in %s", path)
+	}
+	return params, nil
+}
+
+// Params returns the parameters of the network defined by the file. It
+// registers the address prefix and the magic bytes of the network, and
+// returns an error if they're used by a different network, or if the genesis
+// block doesn't satisfy its target difficulty.
+func (f *ParamsFile) Params() (*Params, error) {
+	if f.Name == "" {
+		return nil, errors.New("the network name is required")
+	}
+	for _, defaultParams := range []*Params{&MainnetParams, &TestnetParams, &RegressionNetParams,
+		&SimnetParams, &DevnetParams} {
+
+		if f.Name == defaultParams.Name {
+			return nil, errors.Errorf("the network name %s is used by a default network", f.Name)
+		}
+	}
+	// The network name is used as a directory name for the data and the
+	// logs of the network, so it's limited to a safe set of characters.
+	for _, r := range f.Name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return nil, errors.Errorf("the network name %s may only contain letters, digits, '-' and '_'", f.Name)
+		}
+	}
+	if f.Net == 0 {
+		return nil, errors.New("the network magic (net) is required")
+	}
+	if f.Prefix == "" {
+		return nil, errors.New("the address prefix is required")
+	}
+	if f.K == 0 {
+		return nil, errors.New("k must be greater than 0")
+	}
+	if f.TimestampDeviationTolerance == 0 {
+		return nil, errors.New("timestampDeviationTolerance must be greater than 0")
+	}
+	if f.DifficultyAdjustmentWindowSize == 0 {
+		return nil, errors.New("difficultyAdjustmentWindowSize must be greater than 0")
+	}
+	if f.MinerConfirmationWindow == 0 {
+		return nil, errors.New("minerConfirmationWindow must be greater than 0")
+	}
+	if f.RuleChangeActivationThreshold == 0 {
+		return nil, errors.New("ruleChangeActivationThreshold must be greater than 0")
+	}
+	targetTimePerBlock, err := parseParamsDuration("targetTimePerBlock", f.TargetTimePerBlock)
+	if err != nil {
+		return nil, err
+	}
+	finalityDuration, err := parseParamsDuration("finalityDuration", f.FinalityDuration)
+	if err != nil {
+		return nil, err
+	}
+	if finalityDuration < targetTimePerBlock {
+		return nil, errors.New("finalityDuration must not be shorter than targetTimePerBlock")
+	}
+	if f.RuleChangeActivationThreshold > f.MinerConfirmationWindow {
+		return nil, errors.New("ruleChangeActivationThreshold must not be greater than minerConfirmationWindow")
+	}
+
+	powMax, err := f.powMax()
+	if err != nil {
+		return nil, err
+	}
+	genesisBlock, err := f.GenesisBlock()
+	if err != nil {
+		return nil, err
+	}
+	genesisHash := genesisBlock.BlockHash()
+	if f.Genesis.Hash != "" {
+		expectedHash, err := daghash.NewHashFromStr(f.Genesis.Hash)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid genesis hash")
+		}
+		if !genesisHash.IsEqual(expectedHash) {
+			return nil, errors.Errorf("the hash of the genesis block is %s instead of %s", genesisHash, expectedHash)
+		}
+	}
+	err = checkGenesisProofOfWork(genesisBlock, powMax)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := util.RegisterBech32Prefix(f.Prefix, f.Name)
+	if err != nil {
+		return nil, err
+	}
+	net := domainmessage.KaspaNet(f.Net)
+	err = domainmessage.RegisterKaspaNet(net, f.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Params{
+		K:           KType(f.K),
+		Name:        f.Name,
+		Net:         net,
+		RPCPort:     f.RPCPort,
+		DefaultPort: f.DefaultPort,
+		StratumPort: f.StratumPort,
+		DNSSeeds:    f.DNSSeeds,
+
+		// DAG parameters
+		GenesisBlock:                   genesisBlock,
+		GenesisHash:                    genesisHash,
+		PowMax:                         powMax,
+		BlockCoinbaseMaturity:          f.BlockCoinbaseMaturity,
+		SubsidyReductionInterval:       f.SubsidyReductionInterval,
+		TargetTimePerBlock:             targetTimePerBlock,
+		FinalityDuration:               finalityDuration,
+		DifficultyAdjustmentWindowSize: f.DifficultyAdjustmentWindowSize,
+		TimestampDeviationTolerance:    f.TimestampDeviationTolerance,
+
+		// Consensus rule change deployments.
+		RuleChangeActivationThreshold: f.RuleChangeActivationThreshold,
+		MinerConfirmationWindow:       f.MinerConfirmationWindow,
+		Deployments:                   append([]ConsensusDeployment(nil), DevnetParams.Deployments...),
+
+		// Mempool parameters
+		RelayNonStdTxs: f.RelayNonStdTxs,
+
+		AcceptUnroutable: f.AcceptUnroutable,
+
+		Prefix:       prefix,
+		PrivateKeyID: f.PrivateKeyID,
+
+		EnableNonNativeSubnetworks:  f.EnableNonNativeSubnetworks,
+		DisableDifficultyAdjustment: f.DisableDifficultyAdjustment,
+		EnableGenerate:              f.EnableGenerate,
+		EnableMockTime:              f.EnableMockTime,
+	}, nil
+}
+
+// GenesisBlock builds the genesis block defined by the file. The returned
+// block doesn't necessarily satisfy its target difficulty.
+func (f *ParamsFile) GenesisBlock() (*domainmessage.MsgBlock, error) {
+	if f.Genesis.Timestamp <= 0 {
+		return nil, errors.New("the genesis timestamp is required")
+	}
+	bits := f.Genesis.Bits
+	if bits == 0 {
+		powMax, err := f.powMax()
+		if err != nil {
+			return nil, err
+		}
+		bits = util.BigToCompact(powMax)
+	}
+	coinbaseData := f.Genesis.CoinbaseData
+	if coinbaseData == "" {
+		coinbaseData = f.Name
+	}
+
+	// The genesis coinbase pays to an OP_FALSE script, like the genesis
+	// of testnet, since its outputs can never be spent.
+	payload, err := coinbasepayload.SerializeCoinbasePayload(0, []byte{0x00}, []byte(coinbaseData))
+	if err != nil {
+		return nil, err
+	}
+	coinbaseTx := domainmessage.NewSubnetworkMsgTx(1, []*domainmessage.TxIn{}, []*domainmessage.TxOut{},
+		subnetworkid.SubnetworkIDCoinbase, 0, payload)
+	hashMerkleRoot := coinbaseTx.TxHash()
+
+	return &domainmessage.MsgBlock{
+		Header: domainmessage.BlockHeader{
+			Version:              0x10000000,
+			ParentHashes:         []*daghash.Hash{},
+			HashMerkleRoot:       hashMerkleRoot,
+			AcceptedIDMerkleRoot: &daghash.ZeroHash,
+			UTXOCommitment:       &daghash.ZeroHash,
+			Timestamp:            mstime.UnixMilliseconds(f.Genesis.Timestamp),
+			Bits:                 bits,
+			Nonce:                f.Genesis.Nonce,
+		},
+		Transactions: []*domainmessage.MsgTx{coinbaseTx},
+	}, nil
+}
+
+// powMax returns the highest proof of work value of the network, which is
+// 2^PowMaxBits - 1.
+func (f *ParamsFile) powMax() (*big.Int, error) {
+	if f.PowMaxBits == 0 || f.PowMaxBits > 256 {
+		return nil, errors.Errorf("powMaxBits must be between 1 and 256, but got %d", f.PowMaxBits)
+	}
+	return new(big.Int).Sub(new(big.Int).Lsh(bigOne, f.PowMaxBits), bigOne), nil
+}
+
+// checkGenesisProofOfWork returns an error if the genesis block doesn't
+// satisfy its target difficulty, or if its target difficulty is higher than
+// powMax.
+func checkGenesisProofOfWork(genesisBlock *domainmessage.MsgBlock, powMax *big.Int) error {
+	target := util.CompactToBig(genesisBlock.Header.Bits)
+	if target.Sign() <= 0 || target.Cmp(powMax) > 0 {
+		return errors.Errorf("the genesis bits %08x are out of the range of powMaxBits", genesisBlock.Header.Bits)
+	}
+	if daghash.HashToBig(genesisBlock.BlockHash()).Cmp(target) > 0 {
+		return errors.Errorf("the genesis block with nonce %d doesn't satisfy its target difficulty; "+
+			"use gengenesis to find a nonce for it", genesisBlock.Header.Nonce)
+	}
+	return nil
+}
+
+// parseParamsDuration parses a duration of a ParamsFile, which must be
+// positive and have a millisecond precision.
+func parseParamsDuration(name string, value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s", name)
+	}
+	if duration <= 0 || duration%time.Millisecond != 0 {
+		return 0, errors.Errorf("%s must be a positive number of milliseconds, but got %s", name, value)
+	}
+	return duration, nil
+}
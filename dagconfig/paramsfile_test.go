@@ -0,0 +1,283 @@
+package dagconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+const testParamsFileTOML = `
+name = "paramsfiletest"
+net = 0x7e570001
+prefix = "kaspatest-file"
+k = 5
+powMaxBits = 255
+targetTimePerBlock = "2s"
+finalityDuration = "10m"
+enableGenerate = true
+
+[genesis]
+timestamp = 1600000000000
+nonce = %d
+`
+
+const testParamsFileJSON = `{
+	"name": "paramsfiletest",
+	"net": 2119630849,
+	"prefix": "kaspatest-file",
+	"k": 5,
+	"powMaxBits": 255,
+	"targetTimePerBlock": "2s",
+	"finalityDuration": "10m",
+	"enableGenerate": true,
+	"genesis": {
+		"timestamp": 1600000000000,
+		"nonce": %d
+	}
+}`
+
+func writeTestParamsFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	return path
+}
+
+// solveTestGenesis returns a nonce for which the genesis block defined in
+// paramsFile satisfies its target difficulty.
+func solveTestGenesis(t *testing.T, paramsFile *ParamsFile) uint64 {
+	genesisBlock, err := paramsFile.GenesisBlock()
+	if err != nil {
+		t.Fatalf("GenesisBlock: %s", err)
+	}
+	target := util.CompactToBig(genesisBlock.Header.Bits)
+	for nonce := uint64(0); ; nonce++ {
+		genesisBlock.Header.Nonce = nonce
+		if daghash.HashToBig(genesisBlock.BlockHash()).Cmp(target) <= 0 {
+			return nonce
+		}
+	}
+}
+
+func TestLoadParams(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestLoadParams")
+	if err != nil {
+		t.Fatalf("TempDir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	unsolvedPath := writeTestParamsFile(t, dir, "params.toml", fmt.Sprintf(testParamsFileTOML, 0))
+	paramsFile, err := ReadParamsFile(unsolvedPath)
+	if err != nil {
+		t.Fatalf("ReadParamsFile: %s", err)
+	}
+	nonce := solveTestGenesis(t, paramsFile)
+
+	var loadedParams []*Params
+	for _, file := range []struct {
+		name    string
+		content string
+	}{
+		{"params.toml", testParamsFileTOML},
+		{"params.json", testParamsFileJSON},
+	} {
+		content := fmt.Sprintf(file.content, nonce)
+		path := writeTestParamsFile(t, dir, file.name, content)
+		params, err := LoadParams(path)
+		if err != nil {
+			t.Fatalf("LoadParams(%s): %s", file.name, err)
+		}
+		loadedParams = append(loadedParams, params)
+	}
+
+	for _, params := range loadedParams {
+		if params.Name != "paramsfiletest" {
+			t.Errorf("expected the name paramsfiletest but got %s", params.Name)
+		}
+		if params.Net.String() != "paramsfiletest" {
+			t.Errorf("expected the net to be registered as paramsfiletest but got %s", params.Net)
+		}
+		if params.Prefix.String() != "kaspatest-file" {
+			t.Errorf("expected the prefix kaspatest-file but got %s", params.Prefix)
+		}
+		if params.K != 5 {
+			t.Errorf("expected K to be 5 but got %d", params.K)
+		}
+		if params.TargetTimePerBlock != 2*time.Second {
+			t.Errorf("expected a TargetTimePerBlock of 2s but got %s", params.TargetTimePerBlock)
+		}
+		if params.PowMax.BitLen() != 255 {
+			t.Errorf("expected a PowMax of 255 bits but got %d bits", params.PowMax.BitLen())
+		}
+		if !params.EnableGenerate {
+			t.Errorf("expected EnableGenerate to be set")
+		}
+		if params.EnableMockTime != DevnetParams.EnableMockTime {
+			t.Errorf("expected EnableMockTime to take the value of devnet")
+		}
+
+		// Omitted fields take the values of devnet.
+		if params.DefaultPort != DevnetParams.DefaultPort {
+			t.Errorf("expected the default port %s but got %s", DevnetParams.DefaultPort, params.DefaultPort)
+		}
+		if params.BlockCoinbaseMaturity != DevnetParams.BlockCoinbaseMaturity {
+			t.Errorf("expected a BlockCoinbaseMaturity of %d but got %d",
+				DevnetParams.BlockCoinbaseMaturity, params.BlockCoinbaseMaturity)
+		}
+
+		if !params.GenesisHash.IsEqual(params.GenesisBlock.BlockHash()) {
+			t.Errorf("GenesisHash %s doesn't match the genesis block hash %s",
+				params.GenesisHash, params.GenesisBlock.BlockHash())
+		}
+		address, err := util.NewAddressPubKeyHash(make([]byte, 20), params.Prefix)
+		if err != nil {
+			t.Fatalf("NewAddressPubKeyHash: %s", err)
+		}
+		if _, err := util.DecodeAddress(address.String(), params.Prefix); err != nil {
+			t.Errorf("DecodeAddress: %s", err)
+		}
+	}
+	if !loadedParams[0].GenesisHash.IsEqual(loadedParams[1].GenesisHash) {
+		t.Errorf("the TOML and JSON files define different genesis blocks: %s and %s",
+			loadedParams[0].GenesisHash, loadedParams[1].GenesisHash)
+	}
+}
+
+func TestLoadParamsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestLoadParamsErrors")
+	if err != nil {
+		t.Fatalf("TempDir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "missing name",
+			content:       `{"net": 1, "prefix": "kaspaerr", "genesis": {"timestamp": 1}}`,
+			expectedError: "the network name is required",
+		},
+		{
+			name:          "default network name",
+			content:       `{"name": "kaspa-devnet", "net": 1, "prefix": "kaspaerr", "genesis": {"timestamp": 1}}`,
+			expectedError: "is used by a default network",
+		},
+		{
+			name:          "invalid network name",
+			content:       `{"name": "../net", "net": 1, "prefix": "kaspaerr", "genesis": {"timestamp": 1}}`,
+			expectedError: "may only contain",
+		},
+		{
+			name:          "missing prefix",
+			content:       `{"name": "errnet", "net": 1, "genesis": {"timestamp": 1}}`,
+			expectedError: "the address prefix is required",
+		},
+		{
+			name:          "missing genesis timestamp",
+			content:       `{"name": "errnet", "net": 1, "prefix": "kaspaerr"}`,
+			expectedError: "the genesis timestamp is required",
+		},
+		{
+			name: "zero timestamp deviation tolerance",
+			content: `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "timestampDeviationTolerance": 0,
+				"genesis": {"timestamp": 1}}`,
+			expectedError: "timestampDeviationTolerance must be greater than 0",
+		},
+		{
+			name: "zero difficulty adjustment window size",
+			content: `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "difficultyAdjustmentWindowSize": 0,
+				"genesis": {"timestamp": 1}}`,
+			expectedError: "difficultyAdjustmentWindowSize must be greater than 0",
+		},
+		{
+			name: "zero miner confirmation window",
+			content: `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "minerConfirmationWindow": 0,
+				"ruleChangeActivationThreshold": 0, "genesis": {"timestamp": 1}}`,
+			expectedError: "minerConfirmationWindow must be greater than 0",
+		},
+		{
+			name: "zero rule change activation threshold",
+			content: `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "ruleChangeActivationThreshold": 0,
+				"genesis": {"timestamp": 1}}`,
+			expectedError: "ruleChangeActivationThreshold must be greater than 0",
+		},
+		{
+			name: "invalid duration",
+			content: `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "targetTimePerBlock": "1.5ms",
+				"genesis": {"timestamp": 1}}`,
+			expectedError: "targetTimePerBlock must be a positive number of milliseconds",
+		},
+		{
+			name:          "unsolved genesis",
+			content:       `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "powMaxBits": 64, "genesis": {"timestamp": 1}}`,
+			expectedError: "doesn't satisfy its target difficulty",
+		},
+		{
+			name: "wrong genesis hash",
+			content: `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "genesis": {"timestamp": 1,
+				"hash": "0000000000000000000000000000000000000000000000000000000000000001"}}`,
+			expectedError: "the hash of the genesis block is",
+		},
+		{
+			name:          "valid network",
+			content:       `{"name": "errnet", "net": 1, "prefix": "kaspaerr", "powMaxBits": 256, "genesis": {"timestamp": 1}}`,
+			expectedError: "",
+		},
+		{
+			name: "net magic of a different network",
+			content: `{"name": "errnet2", "net": 1, "prefix": "kaspaerr2", "powMaxBits": 256,
+				"genesis": {"timestamp": 1}}`,
+			expectedError: "is already used by",
+		},
+		{
+			name: "net magic of a default network",
+			content: `{"name": "errnet3", "net": ` + strconv.FormatUint(uint64(domainmessage.Mainnet), 10) + `,
+				"prefix": "kaspaerr3", "powMaxBits": 256, "genesis": {"timestamp": 1}}`,
+			expectedError: "is already used by",
+		},
+		{
+			name: "address prefix of a different network",
+			content: `{"name": "errnet4", "net": 4, "prefix": "kaspaerr", "powMaxBits": 256,
+				"genesis": {"timestamp": 1}}`,
+			expectedError: "address prefix kaspaerr is already used by errnet",
+		},
+		{
+			name: "address prefix of a default network",
+			content: `{"name": "errnet5", "net": 5, "prefix": "kaspatest", "powMaxBits": 256,
+				"genesis": {"timestamp": 1}}`,
+			expectedError: "address prefix kaspatest is used by a default network",
+		},
+	}
+
+	for _, test := range tests {
+		path := writeTestParamsFile(t, dir, "params.json", test.content)
+		_, err := LoadParams(path)
+		if test.expectedError == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", test.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error but got none", test.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("%s: expected an error containing %q but got %q", test.name, test.expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package database_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+)
+
+const (
+	// benchmarkUTXOSetSize is the size of the UTXO set that
+	// BenchmarkUTXOWorkload starts with.
+	benchmarkUTXOSetSize = 100000
+
+	// benchmarkUTXOsPerBlock is the amount of UTXO entries
+	// that every block in BenchmarkUTXOWorkload spends and
+	// creates.
+	benchmarkUTXOsPerBlock = 200
+
+	// benchmarkUTXOEntrySize is roughly the size of a serialized
+	// UTXO entry with a P2SH script public key.
+	benchmarkUTXOEntrySize = 50
+
+	// benchmarkBlockSize is the size of the blocks that
+	// BenchmarkAppendToStore appends.
+	benchmarkBlockSize = 100000
+)
+
+var benchmarkUTXOBucket = database.MakeBucket([]byte("utxo"))
+
+// benchmarkOutpointKey returns a key the size of a serialized
+// outpoint for the given index.
+func benchmarkOutpointKey(index int) *database.Key {
+	outpoint := make([]byte, 36)
+	binary.LittleEndian.PutUint64(outpoint, uint64(index))
+	return benchmarkUTXOBucket.Key(outpoint)
+}
+
+// BenchmarkUTXOWorkload compares the database types by the time
+// it takes to apply the UTXO changes of a block: every block
+// spends the oldest UTXO entries, checks the existence of some
+// of them first, and adds new ones, all in a single transaction.
+func BenchmarkUTXOWorkload(b *testing.B) {
+	for _, prepareDatabase := range databasePrepareFuncs {
+		func() {
+			db, dbType, teardownFunc := prepareDatabase(b, "BenchmarkUTXOWorkload")
+			defer teardownFunc()
+
+			b.Run(dbType, func(b *testing.B) {
+				entry := make([]byte, benchmarkUTXOEntrySize)
+				for i := 0; i < benchmarkUTXOSetSize; i += benchmarkUTXOsPerBlock {
+					dbTx, err := db.Begin()
+					if err != nil {
+						b.Fatalf("Begin: %s", err)
+					}
+					for j := i; j < i+benchmarkUTXOsPerBlock; j++ {
+						err := dbTx.Put(benchmarkOutpointKey(j), entry)
+						if err != nil {
+							b.Fatalf("Put: %s", err)
+						}
+					}
+					err = dbTx.Commit()
+					if err != nil {
+						b.Fatalf("Commit: %s", err)
+					}
+				}
+
+				oldestIndex := 0
+				nextIndex := benchmarkUTXOSetSize
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					dbTx, err := db.Begin()
+					if err != nil {
+						b.Fatalf("Begin: %s", err)
+					}
+					for j := 0; j < benchmarkUTXOsPerBlock; j++ {
+						key := benchmarkOutpointKey(oldestIndex)
+						_, err := dbTx.Get(key)
+						if err != nil {
+							b.Fatalf("Get: %s", err)
+						}
+						err = dbTx.Delete(key)
+						if err != nil {
+							b.Fatalf("Delete: %s", err)
+						}
+						err = dbTx.Put(benchmarkOutpointKey(nextIndex), entry)
+						if err != nil {
+							b.Fatalf("Put: %s", err)
+						}
+						oldestIndex++
+						nextIndex++
+					}
+					err = dbTx.Commit()
+					if err != nil {
+						b.Fatalf("Commit: %s", err)
+					}
+				}
+			})
+		}()
+	}
+}
+
+// BenchmarkAppendToStore compares the database types by the time
+// it takes to store a block and read it back.
+func BenchmarkAppendToStore(b *testing.B) {
+	for _, prepareDatabase := range databasePrepareFuncs {
+		func() {
+			db, dbType, teardownFunc := prepareDatabase(b, "BenchmarkAppendToStore")
+			defer teardownFunc()
+
+			b.Run(dbType, func(b *testing.B) {
+				block := make([]byte, benchmarkBlockSize)
+				b.SetBytes(benchmarkBlockSize)
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					dbTx, err := db.Begin()
+					if err != nil {
+						b.Fatalf("Begin: %s", err)
+					}
+					location, err := dbTx.AppendToStore("blocks", block)
+					if err != nil {
+						b.Fatalf("AppendToStore: %s", err)
+					}
+					err = dbTx.Put(database.MakeBucket([]byte("block-index")).
+						Key([]byte(fmt.Sprintf("%d", i))), location)
+					if err != nil {
+						b.Fatalf("Put: %s", err)
+					}
+					err = dbTx.Commit()
+					if err != nil {
+						b.Fatalf("Commit: %s", err)
+					}
+					_, err = db.RetrieveFromStore("blocks", location)
+					if err != nil {
+						b.Fatalf("RetrieveFromStore: %s", err)
+					}
+				}
+			})
+		}()
+	}
+}
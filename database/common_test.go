@@ -3,21 +3,25 @@ package database_test
 import (
 	"fmt"
 	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffbolt"
 	"github.com/kaspanet/kaspad/database/ffldb"
+	"github.com/kaspanet/kaspad/database/memorydb"
 	"io/ioutil"
 	"testing"
 )
 
-type databasePrepareFunc func(t *testing.T, testName string) (db database.Database, name string, teardownFunc func())
+type databasePrepareFunc func(t testing.TB, testName string) (db database.Database, name string, teardownFunc func())
 
 // databasePrepareFuncs is a set of functions, in which each function
 // prepares a separate database type for testing.
 // See testForAllDatabaseTypes for further details.
 var databasePrepareFuncs = []databasePrepareFunc{
 	prepareFFLDBForTest,
+	prepareFFBoltForTest,
+	prepareMemoryDBForTest,
 }
 
-func prepareFFLDBForTest(t *testing.T, testName string) (db database.Database, name string, teardownFunc func()) {
+func prepareFFLDBForTest(t testing.TB, testName string) (db database.Database, name string, teardownFunc func()) {
 	// Create a temp db to run tests against
 	path, err := ioutil.TempDir("", testName)
 	if err != nil {
@@ -39,6 +43,40 @@ func prepareFFLDBForTest(t *testing.T, testName string) (db database.Database, n
 	return db, "ffldb", teardownFunc
 }
 
+func prepareFFBoltForTest(t testing.TB, testName string) (db database.Database, name string, teardownFunc func()) {
+	// Create a temp db to run tests against
+	path, err := ioutil.TempDir("", testName)
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	db, err = ffbolt.Open(path)
+	if err != nil {
+		t.Fatalf("%s: Open unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	teardownFunc = func() {
+		err = db.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly "+
+				"failed: %s", testName, err)
+		}
+	}
+	return db, "ffbolt", teardownFunc
+}
+
+func prepareMemoryDBForTest(t testing.TB, testName string) (db database.Database, name string, teardownFunc func()) {
+	db = memorydb.Open()
+	teardownFunc = func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly "+
+				"failed: %s", testName, err)
+		}
+	}
+	return db, "memorydb", teardownFunc
+}
+
 // testForAllDatabaseTypes runs the given testFunc for every database
 // type defined in databasePrepareFuncs. This is to make sure that
 // all supported database types adhere to the assumptions defined in
@@ -14,6 +14,11 @@ type Database interface {
 	// Begin begins a new database transaction.
 	Begin() (Transaction, error)
 
+	// Backup writes a consistent copy of the database into the
+	// directory at destinationPath while the database keeps
+	// being used.
+	Backup(destinationPath string) error
+
 	// Close closes the database.
 	Close() error
 }
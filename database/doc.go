@@ -6,8 +6,12 @@ Overview
 This package provides a database layer to store and retrieve data in a simple
 and efficient manner.
 
-The current backend is ffldb, which makes use of leveldb, flat files, and strict
-checksums in key areas to ensure data integrity.
+The main backend is ffldb, which makes use of leveldb, flat files, and strict
+checksums in key areas to ensure data integrity. ffbolt stores its flat files
+the same way, but keeps its key/value data in bolt, a B+tree that doesn't need
+background compaction. Existing ffldb databases can be migrated to it with
+cmd/dbmigrate. There is also memorydb, which keeps all of its data in memory,
+and is meant for tests and ephemeral nodes.
 
 Implementors of additional backends are required to implement the following interfaces:
 
@@ -6,7 +6,18 @@ import "errors"
 // found in the database.
 var ErrNotFound = errors.New("not found")
 
+// ErrCorruption denotes that data in the database was found
+// to be corrupted, e.g. because it doesn't match its checksum
+// or because it was only partially written. Unlike most other
+// errors, retrying the same operation will fail the same way.
+var ErrCorruption = errors.New("database corruption")
+
 // IsNotFoundError checks whether an error is an ErrNotFound.
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
+
+// IsCorruptionError checks whether an error is an ErrCorruption.
+func IsCorruptionError(err error) bool {
+	return errors.Is(err, ErrCorruption)
+}
@@ -0,0 +1,58 @@
+package bolt
+
+import (
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// batch is a set of writes that are applied to the
+// database together, in the order they were made in.
+type batch struct {
+	operations []batchOperation
+}
+
+// batchOperation is a single write in a batch. If isDelete
+// is true, it deletes the key, and value is ignored.
+type batchOperation struct {
+	key      []byte
+	value    []byte
+	isDelete bool
+}
+
+func newBatch() *batch {
+	return &batch{}
+}
+
+func (b *batch) put(key []byte, value []byte) {
+	b.operations = append(b.operations, batchOperation{
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+}
+
+func (b *batch) delete(key []byte) {
+	b.operations = append(b.operations, batchOperation{
+		key:      append([]byte{}, key...),
+		isDelete: true,
+	})
+}
+
+func (b *batch) reset() {
+	b.operations = nil
+}
+
+// apply applies the batch to the given bolt bucket.
+func (b *batch) apply(bucket *bbolt.Bucket) error {
+	for _, operation := range b.operations {
+		var err error
+		if operation.isDelete {
+			err = bucket.Delete(operation.key)
+		} else {
+			err = bucket.Put(operation.key, operation.value)
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
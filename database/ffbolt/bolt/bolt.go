@@ -0,0 +1,230 @@
+package bolt
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// dataFileName is the name of the file in which a bolt
+// database keeps its data.
+const dataFileName = "kaspad.bolt"
+
+// rootBucketName is the name of the bolt bucket that holds
+// all the key/value pairs. Keys are stored in it with their
+// full path, so that the order of keys is the same as it
+// is in other database types.
+var rootBucketName = []byte("kaspad")
+
+// BoltDB defines a thin wrapper around bolt.
+type BoltDB struct {
+	bolt *bbolt.DB
+
+	// readTxs are the read-only transactions that back open
+	// cursors, transactions and snapshots. Closing a bolt
+	// database waits until all of them are rolled back, so
+	// Close rolls back any that were left open.
+	readTxs     map[*bbolt.Tx]struct{}
+	readTxsLock sync.Mutex
+}
+
+// NewBoltDB opens a bolt instance in the directory defined by
+// the given path. If it doesn't exist, it's created.
+func NewBoltDB(path string) (*BoltDB, error) {
+	return openBoltDB(path, false)
+}
+
+// NewReadOnlyBoltDB opens an existing bolt instance in the
+// directory defined by the given path in read-only mode.
+func NewReadOnlyBoltDB(path string) (*BoltDB, error) {
+	return openBoltDB(path, true)
+}
+
+func openBoltDB(path string, readOnly bool) (*BoltDB, error) {
+	options := bbolt.Options{}
+	if defaultOptions := Options(); defaultOptions != nil {
+		options = *defaultOptions
+	}
+	options.ReadOnly = readOnly
+
+	dataFilePath := DataFilePath(path)
+	if readOnly {
+		exists, err := fileExists(dataFilePath)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, errors.Errorf("bolt database %s does not exist", dataFilePath)
+		}
+	} else {
+		err := makeDirectory(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bolt, err := bbolt.Open(dataFilePath, 0600, &options)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !readOnly {
+		err = bolt.Update(func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(rootBucketName)
+			return err
+		})
+		if err != nil {
+			closeErr := bolt.Close()
+			if closeErr != nil {
+				return nil, errors.Wrapf(err, "error occurred during bolt close: %s", closeErr)
+			}
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	db := &BoltDB{
+		bolt:    bolt,
+		readTxs: make(map[*bbolt.Tx]struct{}),
+	}
+	return db, nil
+}
+
+// Close closes the bolt instance. Any cursors, transactions
+// and snapshots that are still open are released.
+func (db *BoltDB) Close() error {
+	db.readTxsLock.Lock()
+	for readTx := range db.readTxs {
+		err := readTx.Rollback()
+		if err != nil {
+			log.Warnf("failed to release a read transaction: %s", err)
+		}
+	}
+	db.readTxs = make(map[*bbolt.Tx]struct{})
+	db.readTxsLock.Unlock()
+
+	err := db.bolt.Close()
+	return errors.WithStack(err)
+}
+
+// beginReadTx begins a read-only transaction that has to be
+// released with releaseReadTx.
+func (db *BoltDB) beginReadTx() (*bbolt.Tx, error) {
+	db.readTxsLock.Lock()
+	defer db.readTxsLock.Unlock()
+
+	readTx, err := db.bolt.Begin(false)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	db.readTxs[readTx] = struct{}{}
+	return readTx, nil
+}
+
+// releaseReadTx rolls back the given read-only transaction,
+// unless it had already been rolled back by Close.
+func (db *BoltDB) releaseReadTx(readTx *bbolt.Tx) error {
+	db.readTxsLock.Lock()
+	defer db.readTxsLock.Unlock()
+
+	if _, ok := db.readTxs[readTx]; !ok {
+		return nil
+	}
+	delete(db.readTxs, readTx)
+	return errors.WithStack(readTx.Rollback())
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *BoltDB) Put(key *database.Key, value []byte) error {
+	batch := newBatch()
+	batch.put(key.Bytes(), value)
+	return db.write(batch)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *BoltDB) Get(key *database.Key) ([]byte, error) {
+	var data []byte
+	err := db.bolt.View(func(tx *bbolt.Tx) error {
+		var err error
+		data, err = get(tx, key)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *BoltDB) Has(key *database.Key) (bool, error) {
+	var exists bool
+	err := db.bolt.View(func(tx *bbolt.Tx) error {
+		var err error
+		exists, err = has(tx, key)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *BoltDB) Delete(key *database.Key) error {
+	batch := newBatch()
+	batch.delete(key.Bytes())
+	return db.write(batch)
+}
+
+// Cursor begins a new cursor over the given prefix. The cursor
+// holds a read-only transaction open until it is closed.
+func (db *BoltDB) Cursor(bucket *database.Bucket) (*BoltCursor, error) {
+	tx, err := db.beginReadTx()
+	if err != nil {
+		return nil, err
+	}
+	return newCursor(db, tx, bucket, true)
+}
+
+// PutRaw writes the given key/value pairs, which are expected to be
+// raw keys that already include their bucket path, to the database
+// in a single transaction. It is mainly meant for importing data
+// from other databases.
+func (db *BoltDB) PutRaw(keys [][]byte, values [][]byte) error {
+	if len(keys) != len(values) {
+		return errors.Errorf("got %d keys but %d values", len(keys), len(values))
+	}
+	batch := newBatch()
+	for i, key := range keys {
+		batch.put(key, values[i])
+	}
+	return db.write(batch)
+}
+
+// write applies the given batch to the database in a single
+// read-write transaction.
+func (db *BoltDB) write(batch *batch) error {
+	err := db.bolt.Update(func(tx *bbolt.Tx) error {
+		return batch.apply(tx.Bucket(rootBucketName))
+	})
+	return errors.WithStack(err)
+}
+
+func get(tx *bbolt.Tx, key *database.Key) ([]byte, error) {
+	data := tx.Bucket(rootBucketName).Get(key.Bytes())
+	if data == nil {
+		return nil, errors.Wrapf(database.ErrNotFound,
+			"key %s not found", key)
+	}
+	// Values returned by bolt are only valid for the
+	// lifetime of the transaction, so they're copied.
+	return append([]byte{}, data...), nil
+}
+
+func has(tx *bbolt.Tx, key *database.Key) (bool, error) {
+	return tx.Bucket(rootBucketName).Get(key.Bytes()) != nil, nil
+}
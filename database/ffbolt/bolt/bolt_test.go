@@ -0,0 +1,105 @@
+package bolt
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+)
+
+func prepareDatabaseForTest(t *testing.T, testName string) (db *BoltDB, teardownFunc func()) {
+	// Create a temp db to run tests against
+	path, err := ioutil.TempDir("", testName)
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	db, err = NewBoltDB(path)
+	if err != nil {
+		t.Fatalf("%s: NewBoltDB unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	teardownFunc = func() {
+		err = db.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly "+
+				"failed: %s", testName, err)
+		}
+	}
+	return db, teardownFunc
+}
+
+func TestBoltDBSanity(t *testing.T) {
+	db, teardownFunc := prepareDatabaseForTest(t, "TestBoltDBSanity")
+	defer teardownFunc()
+
+	// Put something into the db
+	key := database.MakeBucket().Key([]byte("key"))
+	putData := []byte("Hello world!")
+	err := db.Put(key, putData)
+	if err != nil {
+		t.Fatalf("TestBoltDBSanity: Put returned "+
+			"unexpected error: %s", err)
+	}
+
+	// Get from the key previously put to
+	getData, err := db.Get(key)
+	if err != nil {
+		t.Fatalf("TestBoltDBSanity: Get returned "+
+			"unexpected error: %s", err)
+	}
+
+	// Make sure that the put data and the get data are equal
+	if !reflect.DeepEqual(getData, putData) {
+		t.Fatalf("TestBoltDBSanity: get data and "+
+			"put data are not equal. Put: %s, got: %s",
+			string(putData), string(getData))
+	}
+}
+
+func TestCloseWithOpenReadTxs(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestCloseWithOpenReadTxs")
+	if err != nil {
+		t.Fatalf("TestCloseWithOpenReadTxs: TempDir unexpectedly "+
+			"failed: %s", err)
+	}
+	db, err := NewBoltDB(path)
+	if err != nil {
+		t.Fatalf("TestCloseWithOpenReadTxs: NewBoltDB unexpectedly "+
+			"failed: %s", err)
+	}
+
+	// Leave a cursor, a transaction and a snapshot open
+	cursor, err := db.Cursor(database.MakeBucket())
+	if err != nil {
+		t.Fatalf("TestCloseWithOpenReadTxs: Cursor unexpectedly "+
+			"failed: %s", err)
+	}
+	_, err = db.Begin()
+	if err != nil {
+		t.Fatalf("TestCloseWithOpenReadTxs: Begin unexpectedly "+
+			"failed: %s", err)
+	}
+	_, err = db.Snapshot()
+	if err != nil {
+		t.Fatalf("TestCloseWithOpenReadTxs: Snapshot unexpectedly "+
+			"failed: %s", err)
+	}
+
+	// Close would have blocked forever if it didn't
+	// release the read transactions of the above
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("TestCloseWithOpenReadTxs: Close unexpectedly "+
+			"failed: %s", err)
+	}
+
+	// Closing the cursor after the database is closed
+	// must not fail
+	err = cursor.Close()
+	if err != nil {
+		t.Fatalf("TestCloseWithOpenReadTxs: cursor Close unexpectedly "+
+			"failed: %s", err)
+	}
+}
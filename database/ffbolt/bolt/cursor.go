@@ -0,0 +1,150 @@
+package bolt
+
+import (
+	"bytes"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// BoltCursor is a thin wrapper around native bolt cursors.
+// It is limited to the keys with the prefix of its bucket.
+type BoltCursor struct {
+	db         *BoltDB
+	tx         *bbolt.Tx
+	boltCursor *bbolt.Cursor
+	bucket     *database.Bucket
+	prefix     []byte
+
+	// ownsTx is true if the cursor has its own read-only
+	// transaction, which it rolls back once it's closed.
+	ownsTx bool
+
+	// currentKey and currentValue are the current key/value
+	// pair, or nil if the cursor is before its first pair
+	// or exhausted.
+	currentKey   []byte
+	currentValue []byte
+	isStarted    bool
+
+	isClosed bool
+}
+
+func newCursor(db *BoltDB, tx *bbolt.Tx, bucket *database.Bucket, ownsTx bool) (*BoltCursor, error) {
+	rootBucket := tx.Bucket(rootBucketName)
+	if rootBucket == nil {
+		if ownsTx {
+			_ = db.releaseReadTx(tx)
+		}
+		return nil, errors.Errorf("bucket %s does not exist", rootBucketName)
+	}
+	return &BoltCursor{
+		db:         db,
+		tx:         tx,
+		boltCursor: rootBucket.Cursor(),
+		bucket:     bucket,
+		prefix:     bucket.Path(),
+		ownsTx:     ownsTx,
+		isClosed:   false,
+	}, nil
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *BoltCursor) Next() bool {
+	if c.isClosed {
+		panic("cannot call next on a closed cursor")
+	}
+	if !c.isStarted {
+		return c.First()
+	}
+	if c.currentKey == nil {
+		return false
+	}
+	return c.setCurrent(c.boltCursor.Next())
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *BoltCursor) First() bool {
+	if c.isClosed {
+		panic("cannot call first on a closed cursor")
+	}
+	c.isStarted = true
+	return c.setCurrent(c.boltCursor.Seek(c.prefix))
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *BoltCursor) Seek(key *database.Key) error {
+	if c.isClosed {
+		return errors.New("cannot seek a closed cursor")
+	}
+
+	c.isStarted = true
+	keyBytes := key.Bytes()
+	found := c.setCurrent(c.boltCursor.Seek(keyBytes))
+	if !found || !bytes.Equal(c.currentKey, keyBytes) {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+	return nil
+}
+
+// setCurrent sets the current key/value pair to the given one, as
+// long as its key has the prefix of the cursor's bucket. It returns
+// whether it's set.
+func (c *BoltCursor) setCurrent(key []byte, value []byte) bool {
+	if key == nil || !bytes.HasPrefix(key, c.prefix) {
+		c.currentKey = nil
+		c.currentValue = nil
+		return false
+	}
+	c.currentKey = key
+	c.currentValue = value
+	return true
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+// Note that the key is trimmed to not include the prefix the cursor was opened
+// with.
+func (c *BoltCursor) Key() (*database.Key, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the key of a closed cursor")
+	}
+	if c.currentKey == nil {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"key of an exhausted cursor")
+	}
+	suffix := bytes.TrimPrefix(c.currentKey, c.prefix)
+	return c.bucket.Key(append([]byte(nil), suffix...)), nil
+}
+
+// Value returns the value of the current key/value pair, or ErrNotFound if done.
+func (c *BoltCursor) Value() ([]byte, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the value of a closed cursor")
+	}
+	if c.currentKey == nil {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"value of an exhausted cursor")
+	}
+	// Values returned by bolt point into its read-only memory
+	// map, so they're copied to keep the caller from modifying
+	// them.
+	return append([]byte{}, c.currentValue...), nil
+}
+
+// Close releases associated resources.
+func (c *BoltCursor) Close() error {
+	if c.isClosed {
+		return errors.New("cannot close an already closed cursor")
+	}
+	c.isClosed = true
+
+	if c.ownsTx {
+		return c.db.releaseReadTx(c.tx)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package bolt
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// DataFilePath returns the path of the file in which the bolt
+// database in the directory defined by the given path keeps its
+// data.
+func DataFilePath(path string) string {
+	return filepath.Join(path, dataFileName)
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, errors.WithStack(err)
+}
+
+func makeDirectory(path string) error {
+	return errors.WithStack(os.MkdirAll(path, 0700))
+}
@@ -0,0 +1,26 @@
+package bolt
+
+import bbolt "go.etcd.io/bbolt"
+
+var (
+	defaultOptions = bbolt.Options{
+		Timeout:      0,
+		FreelistType: bbolt.FreelistMapType,
+
+		// Every open read-only transaction of a bolt database holds
+		// its memory map, and growing the map waits until they are
+		// all closed. Since transactions of this package keep a
+		// read-only transaction open as their snapshot, a large
+		// initial map makes sure that the map practically never
+		// needs to grow while they are open. Note that this only
+		// reserves address space, not memory.
+		InitialMmapSize: 64 * 1024 * 1024 * 1024, // 64 GiB
+	}
+
+	// Options is a function that returns a bolt
+	// bbolt.Options struct for opening a database.
+	// It's defined as a variable for the sake of testing.
+	Options = func() *bbolt.Options {
+		return &defaultOptions
+	}
+)
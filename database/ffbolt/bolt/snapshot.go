@@ -0,0 +1,83 @@
+package bolt
+
+import (
+	"os"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// BoltSnapshot is a thin wrapper around a native bolt read-only
+// transaction. It provides a consistent, read-only view of the
+// database at the time it was taken, regardless of any writes
+// that happen afterwards.
+type BoltSnapshot struct {
+	db         *BoltDB
+	tx         *bbolt.Tx
+	isReleased bool
+}
+
+// Snapshot takes a snapshot of the current state of the database.
+// The returned snapshot must be released once it's no longer used.
+func (db *BoltDB) Snapshot() (*BoltSnapshot, error) {
+	tx, err := db.beginReadTx()
+	if err != nil {
+		return nil, err
+	}
+	return &BoltSnapshot{
+		db:         db,
+		tx:         tx,
+		isReleased: false,
+	}, nil
+}
+
+// Cursor begins a new cursor over the given bucket, as it was
+// when the snapshot was taken.
+func (s *BoltSnapshot) Cursor(bucket *database.Bucket) (*BoltCursor, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot open a cursor from a released snapshot")
+	}
+	return newCursor(s.db, s.tx, bucket, false)
+}
+
+// CopyTo copies the snapshot into a new bolt database in the
+// directory defined by the given path. The directory must not
+// contain an existing database.
+func (s *BoltSnapshot) CopyTo(path string) error {
+	if s.isReleased {
+		return errors.New("cannot copy a released snapshot")
+	}
+	err := makeDirectory(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(DataFilePath(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = s.tx.WriteTo(file)
+	if err != nil {
+		_ = file.Close()
+		return errors.WithStack(err)
+	}
+	err = file.Sync()
+	if err != nil {
+		_ = file.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(file.Close())
+}
+
+// Release releases the snapshot. Any operations on a released
+// snapshot will fail.
+func (s *BoltSnapshot) Release() {
+	if s.isReleased {
+		return
+	}
+	s.isReleased = true
+	err := s.db.releaseReadTx(s.tx)
+	if err != nil {
+		log.Warnf("failed to release snapshot: %s", err)
+	}
+}
@@ -0,0 +1,136 @@
+package bolt
+
+import (
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+	bbolt "go.etcd.io/bbolt"
+)
+
+// BoltTransaction is a thin wrapper around a native bolt read-only
+// transaction, which serves as a snapshot, and a batch of writes.
+//
+// Bolt allows only a single read-write transaction at a time, which
+// would block any other writer for as long as the transaction is
+// open. Instead, the transaction reads from a snapshot of the
+// database at the moment it begins, and its writes are applied
+// together in a single read-write transaction once it's committed,
+// the same way as LevelDB transactions are.
+//
+// Note: Transactions provide data consistency over the state of
+// the database as it was when the transaction started. As it's
+// currently implemented, if one puts data into the transaction
+// then it will not be available to get within the same transaction.
+type BoltTransaction struct {
+	db       *BoltDB
+	snapshot *bbolt.Tx
+	batch    *batch
+	isClosed bool
+}
+
+// Begin begins a new transaction.
+func (db *BoltDB) Begin() (*BoltTransaction, error) {
+	snapshot, err := db.beginReadTx()
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &BoltTransaction{
+		db:       db,
+		snapshot: snapshot,
+		batch:    newBatch(),
+		isClosed: false,
+	}
+	return transaction, nil
+}
+
+// Commit commits whatever changes were made to the database
+// within this transaction.
+func (tx *BoltTransaction) Commit() error {
+	if tx.isClosed {
+		return errors.New("cannot commit a closed transaction")
+	}
+
+	tx.isClosed = true
+
+	// The snapshot is released before the batch is written, since
+	// writing may require growing the memory map, which waits
+	// for every open read-only transaction to close.
+	err := tx.db.releaseReadTx(tx.snapshot)
+	if err != nil {
+		return err
+	}
+	return tx.db.write(tx.batch)
+}
+
+// Rollback rolls back whatever changes were made to the
+// database within this transaction.
+func (tx *BoltTransaction) Rollback() error {
+	if tx.isClosed {
+		return errors.New("cannot rollback a closed transaction")
+	}
+
+	tx.isClosed = true
+	tx.batch.reset()
+	return tx.db.releaseReadTx(tx.snapshot)
+}
+
+// RollbackUnlessClosed rolls back changes that were made to
+// the database within the transaction, unless the transaction
+// had already been closed using either Rollback or Commit.
+func (tx *BoltTransaction) RollbackUnlessClosed() error {
+	if tx.isClosed {
+		return nil
+	}
+	return tx.Rollback()
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (tx *BoltTransaction) Put(key *database.Key, value []byte) error {
+	if tx.isClosed {
+		return errors.New("cannot put into a closed transaction")
+	}
+
+	tx.batch.put(key.Bytes(), value)
+	return nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (tx *BoltTransaction) Get(key *database.Key) ([]byte, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot get from a closed transaction")
+	}
+
+	return get(tx.snapshot, key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (tx *BoltTransaction) Has(key *database.Key) (bool, error) {
+	if tx.isClosed {
+		return false, errors.New("cannot has from a closed transaction")
+	}
+
+	return has(tx.snapshot, key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (tx *BoltTransaction) Delete(key *database.Key) error {
+	if tx.isClosed {
+		return errors.New("cannot delete from a closed transaction")
+	}
+
+	tx.batch.delete(key.Bytes())
+	return nil
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (tx *BoltTransaction) Cursor(bucket *database.Bucket) (*BoltCursor, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot open a cursor from a closed transaction")
+	}
+
+	return newCursor(tx.db, tx.snapshot, bucket, false)
+}
@@ -0,0 +1,94 @@
+package ffbolt
+
+import (
+	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffbolt/bolt"
+	"github.com/kaspanet/kaspad/database/ffkv"
+)
+
+// Engine is the ffkv engine that keeps the key/value data of ffbolt
+// databases in bolt.
+//
+// Unlike LevelDB, bolt is a B+tree that is updated in place, so
+// it never stalls writes to compact its data in the background.
+var Engine ffkv.Engine = boltEngine{}
+
+// Open opens a new ffbolt with the given path.
+func Open(path string) (database.Database, error) {
+	return ffkv.Open(path, Engine)
+}
+
+// OpenReadOnly opens an existing ffbolt with the given path in
+// read-only mode. Every write to it fails. Note that since repairing
+// the flat-file stores requires writing to them, they are not repaired
+// as they are in Open.
+func OpenReadOnly(path string) (database.Database, error) {
+	return ffkv.OpenReadOnly(path, Engine)
+}
+
+// ValidateBackup checks that the directory at path contains a
+// complete backup of an ffbolt, as written by Backup.
+func ValidateBackup(path string) error {
+	return ffkv.ValidateBackup(path, Engine)
+}
+
+type boltEngine struct{}
+
+func (boltEngine) Open(path string) (ffkv.KeyValueDB, error) {
+	boltInstance, err := bolt.NewBoltDB(path)
+	if err != nil {
+		return nil, err
+	}
+	return boltDB{boltInstance}, nil
+}
+
+func (boltEngine) OpenReadOnly(path string) (ffkv.KeyValueDB, error) {
+	boltInstance, err := bolt.NewReadOnlyBoltDB(path)
+	if err != nil {
+		return nil, err
+	}
+	return boltDB{boltInstance}, nil
+}
+
+// boltDB adapts bolt.BoltDB to the ffkv.KeyValueDB interface
+type boltDB struct {
+	*bolt.BoltDB
+}
+
+func (db boltDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	cursor, err := db.BoltDB.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
+
+func (db boltDB) Begin() (ffkv.KeyValueTransaction, error) {
+	boltTx, err := db.BoltDB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return boltTransaction{boltTx}, nil
+}
+
+func (db boltDB) Snapshot() (ffkv.KeyValueSnapshot, error) {
+	snapshot, err := db.BoltDB.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// boltTransaction adapts bolt.BoltTransaction to the
+// ffkv.KeyValueTransaction interface
+type boltTransaction struct {
+	*bolt.BoltTransaction
+}
+
+func (tx boltTransaction) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	cursor, err := tx.BoltTransaction.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return cursor, nil
+}
@@ -0,0 +1,119 @@
+package ffbolt
+
+import (
+	"github.com/kaspanet/kaspad/database/ffbolt/bolt"
+	"github.com/kaspanet/kaspad/database/ffkv"
+	"github.com/kaspanet/kaspad/database/ffldb/ff"
+	"github.com/kaspanet/kaspad/database/ffldb/ldb"
+	"github.com/pkg/errors"
+)
+
+// migrationBatchSize is the amount of key/value pairs that
+// MigrateFromFFLDB writes in every bolt transaction.
+const migrationBatchSize = 10000
+
+// MigrateFromFFLDB copies the ffldb database at sourcePath into a
+// new ffbolt database at destinationPath. The destination directory
+// must either not exist or be empty. The source database is opened
+// in read-only mode and is not modified. Since both databases keep
+// their key/value data under the same keys and share the flat-file
+// format, the copy is exact.
+//
+// progress, if not nil, is called after every batch of key/value
+// pairs is written with the amount of pairs written so far.
+func MigrateFromFFLDB(sourcePath string, destinationPath string, progress func(keyCount int)) error {
+	err := ffkv.PrepareBackupDirectory(destinationPath)
+	if err != nil {
+		return err
+	}
+
+	levelDB, err := ldb.NewReadOnlyLevelDB(sourcePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the ffldb database at %s", sourcePath)
+	}
+	defer func() {
+		err := levelDB.Close()
+		if err != nil {
+			log.Warnf("failed to close the ffldb database at %s: %s", sourcePath, err)
+		}
+	}()
+
+	destination, err := bolt.NewBoltDB(destinationPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err := destination.Close()
+		if err != nil {
+			log.Warnf("failed to close the ffbolt database at %s: %s", destinationPath, err)
+		}
+	}()
+
+	err = copyKeyValueData(levelDB, destination, progress)
+	if err != nil {
+		return err
+	}
+
+	// The flat-file store locations are read from the copy, so
+	// that the copied flat files match the copied key/value data
+	// exactly.
+	flatFiles, err := ffkv.FlatFileLocations(boltDB{destination})
+	if err != nil {
+		return err
+	}
+	source := ff.NewFlatFileDB(sourcePath)
+	defer func() {
+		err := source.Close()
+		if err != nil {
+			log.Warnf("failed to close the flat files at %s: %s", sourcePath, err)
+		}
+	}()
+	for storeName, currentLocation := range flatFiles {
+		err := source.Backup(storeName, currentLocation, destinationPath)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyKeyValueData(levelDB *ldb.LevelDB, boltDB *bolt.BoltDB, progress func(keyCount int)) error {
+	snapshot, err := levelDB.Snapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	keyCount := 0
+	keys := make([][]byte, 0, migrationBatchSize)
+	values := make([][]byte, 0, migrationBatchSize)
+	flush := func() error {
+		err := boltDB.PutRaw(keys, values)
+		if err != nil {
+			return err
+		}
+		keyCount += len(keys)
+		keys = keys[:0]
+		values = values[:0]
+		if progress != nil {
+			progress(keyCount)
+		}
+		return nil
+	}
+
+	err = snapshot.ForEach(func(key []byte, value []byte) error {
+		keys = append(keys, append([]byte{}, key...))
+		values = append(values, append([]byte{}, value...))
+		if len(keys) >= migrationBatchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return flush()
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package ffbolt
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffldb"
+)
+
+func TestMigrateFromFFLDB(t *testing.T) {
+	sourcePath, err := ioutil.TempDir("", "TestMigrateFromFFLDB-source")
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: TempDir unexpectedly failed: %s", err)
+	}
+	defer os.RemoveAll(sourcePath)
+	source, err := ffldb.Open(sourcePath)
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: Open unexpectedly failed: %s", err)
+	}
+
+	// Put data into both the leveldb and a flat-file store
+	storeName := "test"
+	bucket := database.MakeBucket([]byte("bucket"))
+	keyCount := migrationBatchSize + 1
+	for i := 0; i < keyCount; i++ {
+		err := source.Put(bucket.Key([]byte{byte(i >> 8), byte(i)}), []byte{byte(i)})
+		if err != nil {
+			t.Fatalf("TestMigrateFromFFLDB: Put unexpectedly failed: %s", err)
+		}
+	}
+	location, err := source.AppendToStore(storeName, []byte("data"))
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: AppendToStore unexpectedly failed: %s", err)
+	}
+	err = source.Close()
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: Close unexpectedly failed: %s", err)
+	}
+
+	destinationPath, err := ioutil.TempDir("", "TestMigrateFromFFLDB-destination")
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: TempDir unexpectedly failed: %s", err)
+	}
+	defer os.RemoveAll(destinationPath)
+	lastProgress := 0
+	err = MigrateFromFFLDB(sourcePath, destinationPath, func(keyCount int) {
+		lastProgress = keyCount
+	})
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: MigrateFromFFLDB unexpectedly failed: %s", err)
+	}
+
+	// The flat-files bucket holds one more key for the store
+	if lastProgress != keyCount+1 {
+		t.Fatalf("TestMigrateFromFFLDB: unexpected progress. "+
+			"Want: %d, got: %d", keyCount+1, lastProgress)
+	}
+
+	// Migrating into a non-empty directory is not allowed
+	err = MigrateFromFFLDB(sourcePath, destinationPath, nil)
+	if err == nil {
+		t.Fatalf("TestMigrateFromFFLDB: MigrateFromFFLDB into a non-empty " +
+			"directory unexpectedly succeeded")
+	}
+
+	destination, err := Open(destinationPath)
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: Open unexpectedly failed: %s", err)
+	}
+	defer func() {
+		err := destination.Close()
+		if err != nil {
+			t.Fatalf("TestMigrateFromFFLDB: Close unexpectedly failed: %s", err)
+		}
+	}()
+
+	cursor, err := destination.Cursor(bucket)
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: Cursor unexpectedly failed: %s", err)
+	}
+	defer cursor.Close()
+	migratedKeyCount := 0
+	for cursor.Next() {
+		value, err := cursor.Value()
+		if err != nil {
+			t.Fatalf("TestMigrateFromFFLDB: Value unexpectedly failed: %s", err)
+		}
+		if !reflect.DeepEqual(value, []byte{byte(migratedKeyCount)}) {
+			t.Fatalf("TestMigrateFromFFLDB: unexpected value %x for key %d",
+				value, migratedKeyCount)
+		}
+		migratedKeyCount++
+	}
+	if migratedKeyCount != keyCount {
+		t.Fatalf("TestMigrateFromFFLDB: unexpected key count. "+
+			"Want: %d, got: %d", keyCount, migratedKeyCount)
+	}
+
+	data, err := destination.RetrieveFromStore(storeName, location)
+	if err != nil {
+		t.Fatalf("TestMigrateFromFFLDB: RetrieveFromStore unexpectedly failed: %s", err)
+	}
+	if !reflect.DeepEqual(data, []byte("data")) {
+		t.Fatalf("TestMigrateFromFFLDB: unexpected data %s", data)
+	}
+}
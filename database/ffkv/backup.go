@@ -0,0 +1,120 @@
+package ffkv
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Backup writes a consistent copy of the database into the
+// directory at destinationPath while the database keeps being
+// used. The directory must either not exist or be empty.
+//
+// The copy is made of a key/value snapshot and of the flat-file
+// stores truncated to the current locations recorded in that
+// snapshot. Since a store's current location is updated in the
+// same transaction as the data referencing it, the flat files
+// of the copy contain exactly the data the snapshot refers to.
+// This method is part of the Database interface.
+func (db *ffkv) Backup(destinationPath string) error {
+	err := PrepareBackupDirectory(destinationPath)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := db.keyValueDB.Snapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	err = snapshot.CopyTo(destinationPath)
+	if err != nil {
+		return err
+	}
+
+	// Read the flat-file store locations from the copy, since
+	// they have to match the snapshot rather than the database,
+	// which may have been written to since the snapshot was taken.
+	backup, err := openReadOnly(destinationPath, db.engine)
+	if err != nil {
+		return err
+	}
+	flatFiles, err := FlatFileLocations(backup.keyValueDB)
+	if err != nil {
+		closeErr := backup.Close()
+		if closeErr != nil {
+			return errors.Wrapf(err, "error occurred during backup close: %s", closeErr)
+		}
+		return err
+	}
+	err = backup.Close()
+	if err != nil {
+		return err
+	}
+
+	for storeName, currentLocation := range flatFiles {
+		err := db.flatFileDB.Backup(storeName, currentLocation, destinationPath)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateBackup checks that the directory at path contains a
+// complete backup of a database of the given engine, as written by
+// Backup. Namely, it checks that its key/value database can be
+// opened and that every flat-file store in it ends exactly at the
+// current location recorded for it.
+func ValidateBackup(path string, engine Engine) error {
+	backup, err := openReadOnly(path, engine)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the backup at %s", path)
+	}
+	defer func() {
+		err := backup.Close()
+		if err != nil {
+			log.Warnf("failed to close the backup at %s: %s", path, err)
+		}
+	}()
+
+	flatFiles, err := FlatFileLocations(backup.keyValueDB)
+	if err != nil {
+		return err
+	}
+	for storeName, currentLocation := range flatFiles {
+		isAtLocation, err := backup.flatFileDB.IsAtLocation(storeName, currentLocation)
+		if err != nil {
+			return err
+		}
+		if !isAtLocation {
+			return errors.Errorf("the flat files of store '%s' in the backup at %s "+
+				"don't match its recorded current location", storeName, path)
+		}
+	}
+	return nil
+}
+
+// PrepareBackupDirectory creates the directory at path if it
+// doesn't exist, and makes sure that it's empty otherwise.
+func PrepareBackupDirectory(path string) error {
+	err := os.MkdirAll(path, 0700)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	directory, err := os.Open(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer directory.Close()
+	names, err := directory.Readdirnames(1)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return errors.WithStack(err)
+	}
+	if len(names) > 0 {
+		return errors.Errorf("backup directory %s is not empty", path)
+	}
+	return nil
+}
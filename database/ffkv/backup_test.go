@@ -0,0 +1,144 @@
+package ffkv_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffkv"
+)
+
+func TestBackup(t *testing.T) {
+	testForAllEngines(t, "TestBackup", testBackup)
+}
+
+func testBackup(t *testing.T, engine ffkv.Engine, testName string) {
+	db, teardownFunc := prepareDatabaseForTest(t, engine)
+	defer teardownFunc()
+
+	// Put data into both the key/value database and a flat-file store
+	storeName := "test"
+	key := database.MakeBucket().Key([]byte("key"))
+	err := db.Put(key, []byte("value"))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+	location1, err := db.AppendToStore(storeName, []byte("data1"))
+	if err != nil {
+		t.Fatalf("%s: AppendToStore unexpectedly failed: %s", testName, err)
+	}
+
+	// Append more data in a transaction that's committed only
+	// after the backup is taken. The backup must not contain it,
+	// even though it's already written to the flat files.
+	dbTx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("%s: Begin unexpectedly failed: %s", testName, err)
+	}
+	location2, err := dbTx.AppendToStore(storeName, []byte("data2"))
+	if err != nil {
+		t.Fatalf("%s: AppendToStore unexpectedly failed: %s", testName, err)
+	}
+
+	backupPath, err := ioutil.TempDir("", "TestBackup-backup")
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly failed: %s", testName, err)
+	}
+	defer os.RemoveAll(backupPath)
+	err = db.Backup(backupPath)
+	if err != nil {
+		t.Fatalf("%s: Backup unexpectedly failed: %s", testName, err)
+	}
+	err = dbTx.Commit()
+	if err != nil {
+		t.Fatalf("%s: Commit unexpectedly failed: %s", testName, err)
+	}
+
+	// Backing up into a non-empty directory is not allowed
+	err = db.Backup(backupPath)
+	if err == nil {
+		t.Fatalf("%s: Backup into a non-empty directory unexpectedly succeeded", testName)
+	}
+
+	err = ffkv.ValidateBackup(backupPath, engine)
+	if err != nil {
+		t.Fatalf("%s: ValidateBackup unexpectedly failed: %s", testName, err)
+	}
+
+	backup, err := ffkv.Open(backupPath, engine)
+	if err != nil {
+		t.Fatalf("%s: Open unexpectedly failed: %s", testName, err)
+	}
+	value, err := backup.Get(key)
+	if err != nil {
+		t.Fatalf("%s: Get unexpectedly failed: %s", testName, err)
+	}
+	if !reflect.DeepEqual(value, []byte("value")) {
+		t.Fatalf("%s: unexpected value %s", testName, value)
+	}
+	data, err := backup.RetrieveFromStore(storeName, location1)
+	if err != nil {
+		t.Fatalf("%s: RetrieveFromStore unexpectedly failed: %s", testName, err)
+	}
+	if !reflect.DeepEqual(data, []byte("data1")) {
+		t.Fatalf("%s: unexpected data %s", testName, data)
+	}
+	_, err = backup.RetrieveFromStore(storeName, location2)
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("%s: expected RetrieveFromStore to return "+
+			"ErrNotFound for data committed after the backup, but got: %v", testName, err)
+	}
+	err = backup.Close()
+	if err != nil {
+		t.Fatalf("%s: Close unexpectedly failed: %s", testName, err)
+	}
+}
+
+func TestValidateBackup(t *testing.T) {
+	testForAllEngines(t, "TestValidateBackup", testValidateBackup)
+}
+
+func testValidateBackup(t *testing.T, engine ffkv.Engine, testName string) {
+	db, teardownFunc := prepareDatabaseForTest(t, engine)
+	defer teardownFunc()
+
+	storeName := "test"
+	_, err := db.AppendToStore(storeName, []byte("data"))
+	if err != nil {
+		t.Fatalf("%s: AppendToStore unexpectedly failed: %s", testName, err)
+	}
+
+	backupPath, err := ioutil.TempDir("", "TestValidateBackup-backup")
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly failed: %s", testName, err)
+	}
+	defer os.RemoveAll(backupPath)
+	err = db.Backup(backupPath)
+	if err != nil {
+		t.Fatalf("%s: Backup unexpectedly failed: %s", testName, err)
+	}
+
+	// Truncate the backed up flat file, as if the copy was interrupted
+	err = os.Truncate(filepath.Join(backupPath, storeName+"-000000000.fdb"), 1)
+	if err != nil {
+		t.Fatalf("%s: Truncate unexpectedly failed: %s", testName, err)
+	}
+	err = ffkv.ValidateBackup(backupPath, engine)
+	if err == nil {
+		t.Fatalf("%s: ValidateBackup of a truncated backup unexpectedly succeeded", testName)
+	}
+
+	// A directory that doesn't contain a database is not a valid backup
+	emptyPath, err := ioutil.TempDir("", "TestValidateBackup-empty")
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly failed: %s", testName, err)
+	}
+	defer os.RemoveAll(emptyPath)
+	err = ffkv.ValidateBackup(emptyPath, engine)
+	if err == nil {
+		t.Fatalf("%s: ValidateBackup of an empty directory unexpectedly succeeded", testName)
+	}
+}
@@ -0,0 +1,53 @@
+package ffkv_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffbolt"
+	"github.com/kaspanet/kaspad/database/ffkv"
+	"github.com/kaspanet/kaspad/database/ffldb"
+)
+
+// engines are the key/value engines that the tests of this package
+// run against. See testForAllEngines for further details.
+var engines = []struct {
+	name   string
+	engine ffkv.Engine
+}{
+	{name: "ffldb", engine: ffldb.Engine},
+	{name: "ffbolt", engine: ffbolt.Engine},
+}
+
+// testForAllEngines runs the given testFunc for every engine defined
+// in engines, so that the flat-file logic is tested on top of all of
+// them.
+func testForAllEngines(t *testing.T, testName string,
+	testFunc func(t *testing.T, engine ffkv.Engine, testName string)) {
+
+	for _, engine := range engines {
+		testName := fmt.Sprintf("%s: %s", engine.name, testName)
+		testFunc(t, engine.engine, testName)
+	}
+}
+
+func prepareDatabaseForTest(t *testing.T, engine ffkv.Engine) (db database.Database, teardownFunc func()) {
+	// Create a temp db to run tests against
+	path, err := ioutil.TempDir("", "ffkv")
+	if err != nil {
+		t.Fatalf("TempDir unexpectedly failed: %s", err)
+	}
+	db, err = ffkv.Open(path, engine)
+	if err != nil {
+		t.Fatalf("Open unexpectedly failed: %s", err)
+	}
+	teardownFunc = func() {
+		err = db.Close()
+		if err != nil {
+			t.Fatalf("Close unexpectedly failed: %s", err)
+		}
+	}
+	return db, teardownFunc
+}
@@ -0,0 +1,263 @@
+/*
+Package ffkv implements a database utilizing a key/value engine for
+key-value data and flat-files for raw data storage.
+
+The key/value engine is given as a parameter, so that the flat-file
+logic is shared by every database that is built on top of it, such as
+ffldb and ffbolt.
+*/
+package ffkv
+
+import (
+	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffldb/ff"
+	"github.com/pkg/errors"
+)
+
+var (
+	// flatFilesBucket keeps an index flat-file stores and their
+	// current locations. Among other things, it is used to repair
+	// the database in case a corruption occurs. It is the same for
+	// every key/value engine, so that the key/value data of the
+	// databases can be copied as-is between them.
+	flatFilesBucket = database.MakeBucket([]byte("flat-files"))
+)
+
+// Engine opens the key/value databases that ffkv databases keep their
+// key/value data in.
+type Engine interface {
+	// Open opens a key/value database in the directory defined by
+	// path, and creates it if it doesn't exist.
+	Open(path string) (KeyValueDB, error)
+
+	// OpenReadOnly opens an existing key/value database in the
+	// directory defined by path in read-only mode.
+	OpenReadOnly(path string) (KeyValueDB, error)
+}
+
+// KeyValueDB is a key/value database opened by an Engine.
+type KeyValueDB interface {
+	Put(key *database.Key, value []byte) error
+	Get(key *database.Key) ([]byte, error)
+	Has(key *database.Key) (bool, error)
+	Delete(key *database.Key) error
+	Cursor(bucket *database.Bucket) (database.Cursor, error)
+	Begin() (KeyValueTransaction, error)
+
+	// Snapshot takes a snapshot of the current state of the
+	// database. The returned snapshot must be released once it's
+	// no longer used.
+	Snapshot() (KeyValueSnapshot, error)
+
+	Close() error
+}
+
+// KeyValueTransaction is a transaction of a KeyValueDB.
+type KeyValueTransaction interface {
+	Put(key *database.Key, value []byte) error
+	Get(key *database.Key) ([]byte, error)
+	Has(key *database.Key) (bool, error)
+	Delete(key *database.Key) error
+	Cursor(bucket *database.Bucket) (database.Cursor, error)
+	Rollback() error
+	Commit() error
+	RollbackUnlessClosed() error
+}
+
+// KeyValueSnapshot is a consistent, read-only view of a KeyValueDB
+// at the time it was taken.
+type KeyValueSnapshot interface {
+	// CopyTo copies the snapshot into a new key/value database of
+	// the same engine in the directory defined by path.
+	CopyTo(path string) error
+
+	// Release releases the snapshot.
+	Release()
+}
+
+// ffkv is a database utilizing a key/value engine for key-value data
+// and flat-files for raw data storage.
+type ffkv struct {
+	flatFileDB *ff.FlatFileDB
+	keyValueDB KeyValueDB
+	engine     Engine
+}
+
+// Open opens a new database with the given path, keeping its key/value
+// data in a database of the given engine.
+func Open(path string, engine Engine) (database.Database, error) {
+	flatFileDB := ff.NewFlatFileDB(path)
+	keyValueDB, err := engine.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &ffkv{
+		flatFileDB: flatFileDB,
+		keyValueDB: keyValueDB,
+		engine:     engine,
+	}
+
+	err = db.initialize()
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// OpenReadOnly opens an existing database with the given path in
+// read-only mode. Every write to it fails. Note that since repairing
+// the flat-file stores requires writing to them, they are not repaired
+// as they are in Open.
+func OpenReadOnly(path string, engine Engine) (database.Database, error) {
+	return openReadOnly(path, engine)
+}
+
+func openReadOnly(path string, engine Engine) (*ffkv, error) {
+	flatFileDB := ff.NewFlatFileDB(path)
+	keyValueDB, err := engine.OpenReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &ffkv{
+		flatFileDB: flatFileDB,
+		keyValueDB: keyValueDB,
+		engine:     engine,
+	}
+	return db, nil
+}
+
+// Close closes the database.
+// This method is part of the Database interface.
+func (db *ffkv) Close() error {
+	err := db.flatFileDB.Close()
+	if err != nil {
+		keyValueDBCloseErr := db.keyValueDB.Close()
+		if keyValueDBCloseErr != nil {
+			return errors.Wrapf(err, "err occurred during key/value database close: %s", keyValueDBCloseErr)
+		}
+		return err
+	}
+	return db.keyValueDB.Close()
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+// This method is part of the DataAccessor interface.
+func (db *ffkv) Put(key *database.Key, value []byte) error {
+	return db.keyValueDB.Put(key, value)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+// This method is part of the DataAccessor interface.
+func (db *ffkv) Get(key *database.Key) ([]byte, error) {
+	return db.keyValueDB.Get(key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+// This method is part of the DataAccessor interface.
+func (db *ffkv) Has(key *database.Key) (bool, error) {
+	return db.keyValueDB.Has(key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+// This method is part of the DataAccessor interface.
+func (db *ffkv) Delete(key *database.Key) error {
+	return db.keyValueDB.Delete(key)
+}
+
+// AppendToStore appends the given data to the flat
+// file store defined by storeName. This function
+// returns a serialized location handle that's meant
+// to be stored and later used when querying the data
+// that has just now been inserted.
+// This method is part of the DataAccessor interface.
+func (db *ffkv) AppendToStore(storeName string, data []byte) ([]byte, error) {
+	return appendToStore(db, db.flatFileDB, storeName, data)
+}
+
+func appendToStore(accessor database.DataAccessor, ffdb *ff.FlatFileDB, storeName string, data []byte) ([]byte, error) {
+	// Save a reference to the current location in case
+	// we fail and need to rollback.
+	previousLocation, err := ffdb.CurrentLocation(storeName)
+	if err != nil {
+		return nil, err
+	}
+	rollback := func() error {
+		return ffdb.Rollback(storeName, previousLocation)
+	}
+
+	// Append the data to the store and rollback in case of an error.
+	location, err := ffdb.Write(storeName, data)
+	if err != nil {
+		rollbackErr := rollback()
+		if rollbackErr != nil {
+			return nil, errors.Wrapf(err, "error occurred during rollback: %s", rollbackErr)
+		}
+		return nil, err
+	}
+
+	// Get the new location. If this fails we won't be able to update
+	// the current store location, in which case we roll back.
+	currentLocation, err := ffdb.CurrentLocation(storeName)
+	if err != nil {
+		rollbackErr := rollback()
+		if rollbackErr != nil {
+			return nil, errors.Wrapf(err, "error occurred during rollback: %s", rollbackErr)
+		}
+		return nil, err
+	}
+
+	// Set the current store location and roll back in case an error.
+	err = setCurrentStoreLocation(accessor, storeName, currentLocation)
+	if err != nil {
+		rollbackErr := rollback()
+		if rollbackErr != nil {
+			return nil, errors.Wrapf(err, "error occurred during rollback: %s", rollbackErr)
+		}
+		return nil, err
+	}
+
+	return location, err
+}
+
+func setCurrentStoreLocation(accessor database.DataAccessor, storeName string, location []byte) error {
+	locationKey := flatFilesBucket.Key([]byte(storeName))
+	return accessor.Put(locationKey, location)
+}
+
+// RetrieveFromStore retrieves data from the store defined by
+// storeName using the given serialized location handle. It
+// returns ErrNotFound if the location does not exist. See
+// AppendToStore for further details.
+// This method is part of the DataAccessor interface.
+func (db *ffkv) RetrieveFromStore(storeName string, location []byte) ([]byte, error) {
+	return db.flatFileDB.Read(storeName, location)
+}
+
+// Cursor begins a new cursor over the given bucket.
+// This method is part of the DataAccessor interface.
+func (db *ffkv) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return db.keyValueDB.Cursor(bucket)
+}
+
+// Begin begins a new ffkv transaction.
+// This method is part of the Database interface.
+func (db *ffkv) Begin() (database.Transaction, error) {
+	keyValueTx, err := db.keyValueDB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &transaction{
+		keyValueTx: keyValueTx,
+		ffdb:       db.flatFileDB,
+		isClosed:   false,
+	}
+	return transaction, nil
+}
@@ -0,0 +1,109 @@
+package ffkv_test
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffkv"
+)
+
+// flatFilesBucket is the bucket in which ffkv keeps the current
+// locations of the flat-file stores
+var flatFilesBucket = database.MakeBucket([]byte("flat-files"))
+
+func TestRepairFlatFiles(t *testing.T) {
+	testForAllEngines(t, "TestRepairFlatFiles", testRepairFlatFiles)
+}
+
+func testRepairFlatFiles(t *testing.T, engine ffkv.Engine, testName string) {
+	// Create a temp db to run tests against
+	path, err := ioutil.TempDir("", "TestRepairFlatFiles")
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	db, err := ffkv.Open(path, engine)
+	if err != nil {
+		t.Fatalf("%s: Open unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	isOpen := true
+	defer func() {
+		if isOpen {
+			err := db.Close()
+			if err != nil {
+				t.Fatalf("%s: Close unexpectedly "+
+					"failed: %s", testName, err)
+			}
+		}
+	}()
+
+	// Append data to the same store
+	storeName := "test"
+	_, err = db.AppendToStore(storeName, []byte("data1"))
+	if err != nil {
+		t.Fatalf("%s: AppendToStore unexpectedly "+
+			"failed: %s", testName, err)
+	}
+
+	// Grab the current location to test against later
+	locationKey := flatFilesBucket.Key([]byte(storeName))
+	oldCurrentLocation, err := db.Get(locationKey)
+	if err != nil {
+		t.Fatalf("%s: Get unexpectedly "+
+			"failed: %s", testName, err)
+	}
+
+	// Append more data to the same store. We expect this to disappear later.
+	location2, err := db.AppendToStore(storeName, []byte("data2"))
+	if err != nil {
+		t.Fatalf("%s: AppendToStore unexpectedly "+
+			"failed: %s", testName, err)
+	}
+
+	// Manually update the current location to point to the first piece of data
+	err = db.Put(locationKey, oldCurrentLocation)
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly "+
+			"failed: %s", testName, err)
+	}
+
+	// Reopen the database
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("%s: Close unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	isOpen = false
+	db, err = ffkv.Open(path, engine)
+	if err != nil {
+		t.Fatalf("%s: Open unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	isOpen = true
+
+	// Make sure that we can't get data that no longer exists
+	_, err = db.RetrieveFromStore(storeName, location2)
+	if err == nil {
+		t.Fatalf("%s: RetrieveFromStore "+
+			"unexpectedly succeeded", testName)
+	}
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("%s: RetrieveFromStore "+
+			"returned wrong error: %s", testName, err)
+	}
+
+	// Make sure that the store rolled back to the old current location,
+	// so that new data is written where the removed data used to be
+	location3, err := db.AppendToStore(storeName, []byte("data3"))
+	if err != nil {
+		t.Fatalf("%s: AppendToStore unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	if !reflect.DeepEqual(location2, location3) {
+		t.Fatalf("%s: the current location did "+
+			"not roll back", testName)
+	}
+}
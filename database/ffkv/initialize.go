@@ -0,0 +1,61 @@
+package ffkv
+
+// initialize initializes the database. If this function fails then the
+// database is irrecoverably corrupted.
+func (db *ffkv) initialize() error {
+	flatFiles, err := FlatFileLocations(db.keyValueDB)
+	if err != nil {
+		return err
+	}
+	for storeName, currentLocation := range flatFiles {
+		err := db.tryRepair(storeName, currentLocation)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FlatFileLocations returns the current locations of all the flat-file
+// stores, as recorded in the given key/value database.
+func FlatFileLocations(keyValueDB KeyValueDB) (map[string][]byte, error) {
+	flatFilesCursor, err := keyValueDB.Cursor(flatFilesBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := flatFilesCursor.Close()
+		if err != nil {
+			log.Warnf("cursor failed to close")
+		}
+	}()
+
+	flatFiles := make(map[string][]byte)
+	for flatFilesCursor.Next() {
+		storeNameKey, err := flatFilesCursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		storeName := string(storeNameKey.Suffix())
+
+		currentLocation, err := flatFilesCursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		flatFiles[storeName] = currentLocation
+	}
+	return flatFiles, nil
+}
+
+// tryRepair attempts to sync the store with the current location value.
+// Possible scenarios:
+// a. currentLocation and the store are synced. RecoverTail does nothing.
+// b. currentLocation is smaller than the store's location. RecoverTail
+//    truncates the uncommitted, possibly partially written, data.
+// c. currentLocation is greater than the store's location. RecoverTail
+//    returns ErrCorruption. This indicates definite database corruption
+//    and is irrecoverable.
+func (db *ffkv) tryRepair(storeName string, currentLocation []byte) error {
+	return db.flatFileDB.RecoverTail(storeName, currentLocation)
+}
@@ -1,22 +1,21 @@
-package ffldb
+package ffkv
 
 import (
 	"github.com/kaspanet/kaspad/database"
 	"github.com/kaspanet/kaspad/database/ffldb/ff"
-	"github.com/kaspanet/kaspad/database/ffldb/ldb"
 	"github.com/pkg/errors"
 )
 
-// transaction is an ffldb transaction.
+// transaction is an ffkv transaction.
 //
 // Note: Transactions provide data consistency over the state of
 // the database as it was when the transaction started. There is
 // NO guarantee that if one puts data into the transaction then
 // it will be available to get within the same transaction.
 type transaction struct {
-	ldbTx    *ldb.LevelDBTransaction
-	ffdb     *ff.FlatFileDB
-	isClosed bool
+	keyValueTx KeyValueTransaction
+	ffdb       *ff.FlatFileDB
+	isClosed   bool
 }
 
 // Put sets the value for the given key. It overwrites
@@ -27,7 +26,7 @@ func (tx *transaction) Put(key *database.Key, value []byte) error {
 		return errors.New("cannot put into a closed transaction")
 	}
 
-	return tx.ldbTx.Put(key, value)
+	return tx.keyValueTx.Put(key, value)
 }
 
 // Get gets the value for the given key. It returns
@@ -38,7 +37,7 @@ func (tx *transaction) Get(key *database.Key) ([]byte, error) {
 		return nil, errors.New("cannot get from a closed transaction")
 	}
 
-	return tx.ldbTx.Get(key)
+	return tx.keyValueTx.Get(key)
 }
 
 // Has returns true if the database does contains the
@@ -49,7 +48,7 @@ func (tx *transaction) Has(key *database.Key) (bool, error) {
 		return false, errors.New("cannot has from a closed transaction")
 	}
 
-	return tx.ldbTx.Has(key)
+	return tx.keyValueTx.Has(key)
 }
 
 // Delete deletes the value for the given key. Will not
@@ -60,7 +59,7 @@ func (tx *transaction) Delete(key *database.Key) error {
 		return errors.New("cannot delete from a closed transaction")
 	}
 
-	return tx.ldbTx.Delete(key)
+	return tx.keyValueTx.Delete(key)
 }
 
 // AppendToStore appends the given data to the flat
@@ -97,7 +96,7 @@ func (tx *transaction) Cursor(bucket *database.Bucket) (database.Cursor, error)
 		return nil, errors.New("cannot open a cursor from a closed transaction")
 	}
 
-	return tx.ldbTx.Cursor(bucket)
+	return tx.keyValueTx.Cursor(bucket)
 }
 
 // Rollback rolls back whatever changes were made to the
@@ -109,7 +108,7 @@ func (tx *transaction) Rollback() error {
 	}
 	tx.isClosed = true
 
-	return tx.ldbTx.Rollback()
+	return tx.keyValueTx.Rollback()
 }
 
 // Commit commits whatever changes were made to the database
@@ -121,7 +120,7 @@ func (tx *transaction) Commit() error {
 	}
 	tx.isClosed = true
 
-	return tx.ldbTx.Commit()
+	return tx.keyValueTx.Commit()
 }
 
 // RollbackUnlessClosed rolls back changes that were made to
@@ -133,5 +132,5 @@ func (tx *transaction) RollbackUnlessClosed() error {
 	}
 	tx.isClosed = true
 
-	return tx.ldbTx.RollbackUnlessClosed()
+	return tx.keyValueTx.RollbackUnlessClosed()
 }
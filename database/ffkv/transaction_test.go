@@ -1,14 +1,19 @@
-package ffldb
+package ffkv_test
 
 import (
 	"bytes"
 	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffkv"
 	"strings"
 	"testing"
 )
 
-func TestTransactionCommitForLevelDBMethods(t *testing.T) {
-	db, teardownFunc := prepareDatabaseForTest(t, "TestTransactionCommitForLevelDBMethods")
+func TestTransactionCommitForKeyValueMethods(t *testing.T) {
+	testForAllEngines(t, "TestTransactionCommitForKeyValueMethods", testTransactionCommitForKeyValueMethods)
+}
+
+func testTransactionCommitForKeyValueMethods(t *testing.T, engine ffkv.Engine, testName string) {
+	db, teardownFunc := prepareDatabaseForTest(t, engine)
 	defer teardownFunc()
 
 	// Put a value into the database
@@ -16,63 +21,63 @@ func TestTransactionCommitForLevelDBMethods(t *testing.T) {
 	value1 := []byte("value1")
 	err := db.Put(key1, value1)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Put "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Put "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Begin a new transaction
 	dbTx, err := db.Begin()
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Begin "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Begin "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	defer func() {
 		err := dbTx.RollbackUnlessClosed()
 		if err != nil {
-			t.Fatalf("TestTransactionCommitForLevelDBMethods: RollbackUnlessClosed "+
-				"unexpectedly failed: %s", err)
+			t.Fatalf("%s: RollbackUnlessClosed "+
+				"unexpectedly failed: %s", testName, err)
 		}
 	}()
 
 	// Make sure that Has returns that the original value exists
 	exists, err := dbTx.Has(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Has "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Has "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !exists {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Has " +
-			"unexpectedly returned that the value does not exist")
+		t.Fatalf("%s: Has "+
+			"unexpectedly returned that the value does not exist", testName)
 	}
 
 	// Get the existing value and make sure it's equal to the original
 	existingValue, err := dbTx.Get(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Get "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !bytes.Equal(existingValue, value1) {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get "+
-			"returned unexpected value. Want: %s, got: %s",
+		t.Fatalf("%s: Get "+
+			"returned unexpected value. Want: %s, got: %s", testName,
 			string(value1), string(existingValue))
 	}
 
 	// Delete the existing value
 	err = dbTx.Delete(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Delete "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Delete "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Try to get a value that does not exist and make sure it returns ErrNotFound
 	_, err = dbTx.Get(database.MakeBucket().Key([]byte("doesn't exist")))
 	if err == nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get " +
-			"unexpectedly succeeded")
+		t.Fatalf("%s: Get "+
+			"unexpectedly succeeded", testName)
 	}
 	if !database.IsNotFoundError(err) {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get "+
-			"returned unexpected error: %s", err)
+		t.Fatalf("%s: Get "+
+			"returned unexpected error: %s", testName, err)
 	}
 
 	// Put a new value
@@ -80,65 +85,69 @@ func TestTransactionCommitForLevelDBMethods(t *testing.T) {
 	value2 := []byte("value2")
 	err = dbTx.Put(key2, value2)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Put "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Put "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Commit the transaction
 	err = dbTx.Commit()
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Commit "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Commit "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Make sure that Has returns that the original value does NOT exist
 	exists, err = db.Has(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Has "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Has "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if exists {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Has " +
-			"unexpectedly returned that the value exists")
+		t.Fatalf("%s: Has "+
+			"unexpectedly returned that the value exists", testName)
 	}
 
 	// Try to Get the existing value and make sure an ErrNotFound is returned
 	_, err = db.Get(key1)
 	if err == nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get " +
-			"unexpectedly succeeded")
+		t.Fatalf("%s: Get "+
+			"unexpectedly succeeded", testName)
 	}
 	if !database.IsNotFoundError(err) {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get "+
-			"returned unexpected err: %s", err)
+		t.Fatalf("%s: Get "+
+			"returned unexpected err: %s", testName, err)
 	}
 
 	// Make sure that Has returns that the new value exists
 	exists, err = db.Has(key2)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Has "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Has "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !exists {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Has " +
-			"unexpectedly returned that the value does not exist")
+		t.Fatalf("%s: Has "+
+			"unexpectedly returned that the value does not exist", testName)
 	}
 
 	// Get the new value and make sure it's equal to the original
 	existingValue, err = db.Get(key2)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Get "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !bytes.Equal(existingValue, value2) {
-		t.Fatalf("TestTransactionCommitForLevelDBMethods: Get "+
-			"returned unexpected value. Want: %s, got: %s",
+		t.Fatalf("%s: Get "+
+			"returned unexpected value. Want: %s, got: %s", testName,
 			string(value2), string(existingValue))
 	}
 }
 
-func TestTransactionRollbackForLevelDBMethods(t *testing.T) {
-	db, teardownFunc := prepareDatabaseForTest(t, "TestTransactionRollbackForLevelDBMethods")
+func TestTransactionRollbackForKeyValueMethods(t *testing.T) {
+	testForAllEngines(t, "TestTransactionRollbackForKeyValueMethods", testTransactionRollbackForKeyValueMethods)
+}
+
+func testTransactionRollbackForKeyValueMethods(t *testing.T, engine ffkv.Engine, testName string) {
+	db, teardownFunc := prepareDatabaseForTest(t, engine)
 	defer teardownFunc()
 
 	// Put a value into the database
@@ -146,52 +155,52 @@ func TestTransactionRollbackForLevelDBMethods(t *testing.T) {
 	value1 := []byte("value1")
 	err := db.Put(key1, value1)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Put "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Put "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Begin a new transaction
 	dbTx, err := db.Begin()
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Begin "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Begin "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	defer func() {
 		err := dbTx.RollbackUnlessClosed()
 		if err != nil {
-			t.Fatalf("TestTransactionRollbackForLevelDBMethods: RollbackUnlessClosed "+
-				"unexpectedly failed: %s", err)
+			t.Fatalf("%s: RollbackUnlessClosed "+
+				"unexpectedly failed: %s", testName, err)
 		}
 	}()
 
 	// Make sure that Has returns that the original value exists
 	exists, err := dbTx.Has(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Has "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Has "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !exists {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Has " +
-			"unexpectedly returned that the value does not exist")
+		t.Fatalf("%s: Has "+
+			"unexpectedly returned that the value does not exist", testName)
 	}
 
 	// Get the existing value and make sure it's equal to the original
 	existingValue, err := dbTx.Get(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Get "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Get "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !bytes.Equal(existingValue, value1) {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Get "+
-			"returned unexpected value. Want: %s, got: %s",
+		t.Fatalf("%s: Get "+
+			"returned unexpected value. Want: %s, got: %s", testName,
 			string(value1), string(existingValue))
 	}
 
 	// Delete the existing value
 	err = dbTx.Delete(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Delete "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Delete "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Put a new value
@@ -199,64 +208,68 @@ func TestTransactionRollbackForLevelDBMethods(t *testing.T) {
 	value2 := []byte("value2")
 	err = dbTx.Put(key2, value2)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Put "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Put "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Rollback the transaction
 	err = dbTx.Rollback()
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Rollback "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Rollback "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Make sure that Has returns that the original value still exists
 	exists, err = db.Has(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Has "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Has "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !exists {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Has " +
-			"unexpectedly returned that the value does not exist")
+		t.Fatalf("%s: Has "+
+			"unexpectedly returned that the value does not exist", testName)
 	}
 
 	// Get the existing value and make sure it is still returned
 	existingValue, err = db.Get(key1)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Get "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Get "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !bytes.Equal(existingValue, value1) {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Get "+
-			"returned unexpected value. Want: %s, got: %s",
+		t.Fatalf("%s: Get "+
+			"returned unexpected value. Want: %s, got: %s", testName,
 			string(value1), string(existingValue))
 	}
 
 	// Make sure that Has returns that the new value does NOT exist
 	exists, err = db.Has(key2)
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Has "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Has "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if exists {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Has " +
-			"unexpectedly returned that the value exists")
+		t.Fatalf("%s: Has "+
+			"unexpectedly returned that the value exists", testName)
 	}
 
 	// Try to Get the new value and make sure it returns an ErrNotFound
 	_, err = db.Get(key2)
 	if err == nil {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Get " +
-			"unexpectedly succeeded")
+		t.Fatalf("%s: Get "+
+			"unexpectedly succeeded", testName)
 	}
 	if !database.IsNotFoundError(err) {
-		t.Fatalf("TestTransactionRollbackForLevelDBMethods: Get "+
-			"returned unexpected error: %s", err)
+		t.Fatalf("%s: Get "+
+			"returned unexpected error: %s", testName, err)
 	}
 }
 
 func TestTransactionCloseErrors(t *testing.T) {
+	testForAllEngines(t, "TestTransactionCloseErrors", testTransactionCloseErrors)
+}
+
+func testTransactionCloseErrors(t *testing.T, engine ffkv.Engine, testName string) {
 	tests := []struct {
 		name              string
 		function          func(dbTx database.Transaction) error
@@ -341,49 +354,49 @@ func TestTransactionCloseErrors(t *testing.T) {
 
 	for _, test := range tests {
 		func() {
-			db, teardownFunc := prepareDatabaseForTest(t, "TestTransactionCloseErrors")
+			db, teardownFunc := prepareDatabaseForTest(t, engine)
 			defer teardownFunc()
 
 			// Begin a new transaction to test Commit
 			commitTx, err := db.Begin()
 			if err != nil {
-				t.Fatalf("TestTransactionCloseErrors: Begin "+
-					"unexpectedly failed: %s", err)
+				t.Fatalf("%s: Begin "+
+					"unexpectedly failed: %s", testName, err)
 			}
 			defer func() {
 				err := commitTx.RollbackUnlessClosed()
 				if err != nil {
-					t.Fatalf("TestTransactionCloseErrors: RollbackUnlessClosed "+
-						"unexpectedly failed: %s", err)
+					t.Fatalf("%s: RollbackUnlessClosed "+
+						"unexpectedly failed: %s", testName, err)
 				}
 			}()
 
 			// Commit the Commit test transaction
 			err = commitTx.Commit()
 			if err != nil {
-				t.Fatalf("TestTransactionCloseErrors: Commit "+
-					"unexpectedly failed: %s", err)
+				t.Fatalf("%s: Commit "+
+					"unexpectedly failed: %s", testName, err)
 			}
 
 			// Begin a new transaction to test Rollback
 			rollbackTx, err := db.Begin()
 			if err != nil {
-				t.Fatalf("TestTransactionCloseErrors: Begin "+
-					"unexpectedly failed: %s", err)
+				t.Fatalf("%s: Begin "+
+					"unexpectedly failed: %s", testName, err)
 			}
 			defer func() {
 				err := rollbackTx.RollbackUnlessClosed()
 				if err != nil {
-					t.Fatalf("TestTransactionCloseErrors: RollbackUnlessClosed "+
-						"unexpectedly failed: %s", err)
+					t.Fatalf("%s: RollbackUnlessClosed "+
+						"unexpectedly failed: %s", testName, err)
 				}
 			}()
 
 			// Rollback the Rollback test transaction
 			err = rollbackTx.Rollback()
 			if err != nil {
-				t.Fatalf("TestTransactionCloseErrors: Rollback "+
-					"unexpectedly failed: %s", err)
+				t.Fatalf("%s: Rollback "+
+					"unexpectedly failed: %s", testName, err)
 			}
 
 			expectedErrContainsString := "closed transaction"
@@ -394,18 +407,18 @@ func TestTransactionCloseErrors(t *testing.T) {
 				err = test.function(closedTx)
 				if test.shouldReturnError {
 					if err == nil {
-						t.Fatalf("TestTransactionCloseErrors: %s "+
-							"unexpectedly succeeded", test.name)
+						t.Fatalf("%s: %s "+
+							"unexpectedly succeeded", testName, test.name)
 					}
 					if !strings.Contains(err.Error(), expectedErrContainsString) {
-						t.Fatalf("TestTransactionCloseErrors: %s "+
-							"returned wrong error. Want: %s, got: %s",
+						t.Fatalf("%s: %s "+
+							"returned wrong error. Want: %s, got: %s", testName,
 							test.name, expectedErrContainsString, err)
 					}
 				} else {
 					if err != nil {
-						t.Fatalf("TestTransactionCloseErrors: %s "+
-							"unexpectedly failed: %s", test.name, err)
+						t.Fatalf("%s: %s "+
+							"unexpectedly failed: %s", testName, test.name, err)
 					}
 				}
 			}
@@ -414,26 +427,34 @@ func TestTransactionCloseErrors(t *testing.T) {
 }
 
 func TestTransactionRollbackUnlessClosed(t *testing.T) {
-	db, teardownFunc := prepareDatabaseForTest(t, "TestTransactionRollbackUnlessClosed")
+	testForAllEngines(t, "TestTransactionRollbackUnlessClosed", testTransactionRollbackUnlessClosed)
+}
+
+func testTransactionRollbackUnlessClosed(t *testing.T, engine ffkv.Engine, testName string) {
+	db, teardownFunc := prepareDatabaseForTest(t, engine)
 	defer teardownFunc()
 
 	// Begin a new transaction
 	dbTx, err := db.Begin()
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackUnlessClosed: Begin "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Begin "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Roll it back
 	err = dbTx.RollbackUnlessClosed()
 	if err != nil {
-		t.Fatalf("TestTransactionRollbackUnlessClosed: RollbackUnlessClosed "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: RollbackUnlessClosed "+
+			"unexpectedly failed: %s", testName, err)
 	}
 }
 
 func TestTransactionCommitForFlatFileMethods(t *testing.T) {
-	db, teardownFunc := prepareDatabaseForTest(t, "TestTransactionCommitForFlatFileMethods")
+	testForAllEngines(t, "TestTransactionCommitForFlatFileMethods", testTransactionCommitForFlatFileMethods)
+}
+
+func testTransactionCommitForFlatFileMethods(t *testing.T, engine ffkv.Engine, testName string) {
+	db, teardownFunc := prepareDatabaseForTest(t, engine)
 	defer teardownFunc()
 
 	// Put a value into the database
@@ -441,33 +462,33 @@ func TestTransactionCommitForFlatFileMethods(t *testing.T) {
 	value1 := []byte("value1")
 	location1, err := db.AppendToStore(store, value1)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: AppendToStore "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: AppendToStore "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Begin a new transaction
 	dbTx, err := db.Begin()
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: Begin "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Begin "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	defer func() {
 		err := dbTx.RollbackUnlessClosed()
 		if err != nil {
-			t.Fatalf("TestTransactionCommitForFlatFileMethods: RollbackUnlessClosed "+
-				"unexpectedly failed: %s", err)
+			t.Fatalf("%s: RollbackUnlessClosed "+
+				"unexpectedly failed: %s", testName, err)
 		}
 	}()
 
 	// Retrieve the existing value and make sure it's equal to the original
 	existingValue, err := dbTx.RetrieveFromStore(store, location1)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: RetrieveFromStore "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: RetrieveFromStore "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !bytes.Equal(existingValue, value1) {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: RetrieveFromStore "+
-			"returned unexpected value. Want: %s, got: %s",
+		t.Fatalf("%s: RetrieveFromStore "+
+			"returned unexpected value. Want: %s, got: %s", testName,
 			string(value1), string(existingValue))
 	}
 
@@ -475,26 +496,26 @@ func TestTransactionCommitForFlatFileMethods(t *testing.T) {
 	value2 := []byte("value2")
 	location2, err := dbTx.AppendToStore(store, value2)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: AppendToStore "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: AppendToStore "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Commit the transaction
 	err = dbTx.Commit()
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: Commit "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: Commit "+
+			"unexpectedly failed: %s", testName, err)
 	}
 
 	// Retrieve the new value and make sure it's equal to the original
 	newValue, err := db.RetrieveFromStore(store, location2)
 	if err != nil {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: RetrieveFromStore "+
-			"unexpectedly failed: %s", err)
+		t.Fatalf("%s: RetrieveFromStore "+
+			"unexpectedly failed: %s", testName, err)
 	}
 	if !bytes.Equal(newValue, value2) {
-		t.Fatalf("TestTransactionCommitForFlatFileMethods: RetrieveFromStore "+
-			"returned unexpected value. Want: %s, got: %s",
+		t.Fatalf("%s: RetrieveFromStore "+
+			"returned unexpected value. Want: %s, got: %s", testName,
 			string(value2), string(newValue))
 	}
 }
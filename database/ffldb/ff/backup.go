@@ -0,0 +1,112 @@
+package ff
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// Backup copies the data of the flat-file store defined by storeName,
+// up to the location defined by the given serialized location handle,
+// into the directory at destinationPath. The store may keep being
+// written to while it's backed up, as long as the given location is
+// not past its current location. See CurrentLocation for further
+// details.
+func (ffdb *FlatFileDB) Backup(storeName string, serializedLocation []byte, destinationPath string) error {
+	store, err := ffdb.store(storeName)
+	if err != nil {
+		return err
+	}
+	location, err := deserializeLocation(serializedLocation)
+	if err != nil {
+		return err
+	}
+	return store.backup(location, destinationPath)
+}
+
+// IsAtLocation returns whether the flat files of the store defined
+// by storeName end exactly at the location defined by the given
+// serialized location handle. It is mainly used to validate backups,
+// which are expected to match the current locations stored along
+// with them.
+func (ffdb *FlatFileDB) IsAtLocation(storeName string, serializedLocation []byte) (bool, error) {
+	store, err := ffdb.store(storeName)
+	if err != nil {
+		return false, err
+	}
+	location, err := deserializeLocation(serializedLocation)
+	if err != nil {
+		return false, err
+	}
+	currentLocation := store.currentLocation()
+	return currentLocation.fileNumber == location.fileNumber &&
+		currentLocation.fileOffset == location.fileOffset, nil
+}
+
+// backup copies all the flat files of the store up to the given
+// location into destinationPath. Every file before the location's
+// file is copied in full, and the location's file is copied up to
+// the location's offset. Since flat files are append-only, data
+// written after the location was taken is never copied.
+func (s *flatFileStore) backup(location *flatFileLocation, destinationPath string) error {
+	if s.isClosed {
+		return errors.Errorf("cannot backup a closed store %s",
+			s.storeName)
+	}
+
+	currentLocation := s.currentLocation()
+	if currentLocation.fileNumber < location.fileNumber ||
+		(currentLocation.fileNumber == location.fileNumber && currentLocation.fileOffset < location.fileOffset) {
+		return errors.Errorf("backup location (file %d, offset %d) of store '%s' is "+
+			"greater than the current write cursor (file %d, offset %d)", location.fileNumber,
+			location.fileOffset, s.storeName, currentLocation.fileNumber, currentLocation.fileOffset)
+	}
+
+	for fileNumber := uint32(0); fileNumber <= location.fileNumber; fileNumber++ {
+		sourcePath := flatFilePath(s.basePath, s.storeName, fileNumber)
+		length := int64(-1)
+		if fileNumber == location.fileNumber {
+			length = int64(location.fileOffset)
+		}
+		err := copyFlatFile(sourcePath, flatFilePath(destinationPath, s.storeName, fileNumber), length)
+		if err != nil {
+			return errors.Wrapf(err, "failed to backup file %d in store '%s'",
+				fileNumber, s.storeName)
+		}
+	}
+	return nil
+}
+
+// copyFlatFile copies the first length bytes of the file at sourcePath
+// into a new file at destinationPath, or all of it if length is negative.
+func copyFlatFile(sourcePath string, destinationPath string, length int64) error {
+	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer destination.Close()
+
+	// The store's current file may not have been created
+	// yet if nothing was written to it, in which case an
+	// empty file is enough.
+	if length == 0 {
+		return errors.WithStack(destination.Sync())
+	}
+
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer source.Close()
+
+	if length < 0 {
+		_, err = io.Copy(destination, source)
+	} else {
+		_, err = io.CopyN(destination, source, length)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(destination.Sync())
+}
@@ -0,0 +1,72 @@
+package ff
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// TestBackupWhileWriting makes sure that a store can be backed up while
+// it's being written to, and that every backup ends exactly at the
+// location it was taken at.
+func TestBackupWhileWriting(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestBackupWhileWriting")
+	if err != nil {
+		t.Fatalf("TestBackupWhileWriting: TempDir unexpectedly failed: %s", err)
+	}
+	defer os.RemoveAll(path)
+	ffdb := NewFlatFileDB(path)
+	defer func() {
+		err := ffdb.Close()
+		if err != nil {
+			t.Fatalf("TestBackupWhileWriting: Close unexpectedly failed: %s", err)
+		}
+	}()
+
+	storeName := "test"
+	writeErrChan := make(chan error)
+	go func() {
+		for i := 0; i < 1000; i++ {
+			_, err := ffdb.Write(storeName, []byte("Hello world!"))
+			if err != nil {
+				writeErrChan <- err
+				return
+			}
+		}
+		writeErrChan <- nil
+	}()
+
+	for i := 0; i < 10; i++ {
+		location, err := ffdb.CurrentLocation(storeName)
+		if err != nil {
+			t.Fatalf("TestBackupWhileWriting: CurrentLocation unexpectedly failed: %s", err)
+		}
+		backupPath, err := ioutil.TempDir("", "TestBackupWhileWriting-backup")
+		if err != nil {
+			t.Fatalf("TestBackupWhileWriting: TempDir unexpectedly failed: %s", err)
+		}
+		defer os.RemoveAll(backupPath)
+		err = ffdb.Backup(storeName, location, backupPath)
+		if err != nil {
+			t.Fatalf("TestBackupWhileWriting: Backup unexpectedly failed: %s", err)
+		}
+
+		backupFFDB := NewFlatFileDB(backupPath)
+		isAtLocation, err := backupFFDB.IsAtLocation(storeName, location)
+		if err != nil {
+			t.Fatalf("TestBackupWhileWriting: IsAtLocation unexpectedly failed: %s", err)
+		}
+		err = backupFFDB.Close()
+		if err != nil {
+			t.Fatalf("TestBackupWhileWriting: Close unexpectedly failed: %s", err)
+		}
+		if !isAtLocation {
+			t.Fatalf("TestBackupWhileWriting: backup %d doesn't end at the location it was taken at", i)
+		}
+	}
+
+	err = <-writeErrChan
+	if err != nil {
+		t.Fatalf("TestBackupWhileWriting: Write unexpectedly failed: %s", err)
+	}
+}
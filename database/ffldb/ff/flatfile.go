@@ -185,7 +185,17 @@ func (s *flatFileStore) Close() error {
 	return nil
 }
 
+// currentLocation returns the location at which the next data will
+// be written. It may be called concurrently with write: the write
+// cursor lock protects the current file number, and the current file
+// lock protects the current offset, which write advances while
+// holding it.
 func (s *flatFileStore) currentLocation() *flatFileLocation {
+	s.writeCursor.RLock()
+	defer s.writeCursor.RUnlock()
+	s.writeCursor.currentFile.RLock()
+	defer s.writeCursor.currentFile.RUnlock()
+
 	return &flatFileLocation{
 		fileNumber: s.writeCursor.currentFileNumber,
 		fileOffset: s.writeCursor.currentOffset,
@@ -1,11 +1,18 @@
 package ff
 
+import "sync"
+
 // FlatFileDB is a flat-file database. It supports opening
 // multiple flat-file stores. See flatFileStore for further
 // details.
 type FlatFileDB struct {
-	path           string
-	flatFileStores map[string]*flatFileStore
+	path string
+
+	// flatFileStoresLock protects flatFileStores, since stores
+	// may be opened concurrently, e.g. by a backup running
+	// alongside a write transaction.
+	flatFileStoresLock sync.Mutex
+	flatFileStores     map[string]*flatFileStore
 }
 
 // NewFlatFileDB opens the flat-file database defined by
@@ -19,6 +26,9 @@ func NewFlatFileDB(path string) *FlatFileDB {
 
 // Close closes the flat-file database.
 func (ffdb *FlatFileDB) Close() error {
+	ffdb.flatFileStoresLock.Lock()
+	defer ffdb.flatFileStoresLock.Unlock()
+
 	for _, store := range ffdb.flatFileStores {
 		err := store.Close()
 		if err != nil {
@@ -89,7 +99,28 @@ func (ffdb *FlatFileDB) Rollback(storeName string, serializedLocation []byte) er
 	return store.rollback(location)
 }
 
+// RecoverTail brings the flat-file store defined by the given
+// storeName back to the last committed location defined by the
+// given serialized location handle, discarding any uncommitted
+// and partially written data beyond it. It returns ErrCorruption
+// if the store ends before that location.
+// See flatFileStore.recoverTail() for further details.
+func (ffdb *FlatFileDB) RecoverTail(storeName string, serializedLocation []byte) error {
+	store, err := ffdb.store(storeName)
+	if err != nil {
+		return err
+	}
+	location, err := deserializeLocation(serializedLocation)
+	if err != nil {
+		return err
+	}
+	return store.recoverTail(location)
+}
+
 func (ffdb *FlatFileDB) store(storeName string) (*flatFileStore, error) {
+	ffdb.flatFileStoresLock.Lock()
+	defer ffdb.flatFileStoresLock.Unlock()
+
 	store, ok := ffdb.flatFileStores[storeName]
 	if !ok {
 		var err error
@@ -4,6 +4,7 @@ import (
 	"github.com/kaspanet/kaspad/database"
 	"github.com/pkg/errors"
 	"hash/crc32"
+	"io"
 	"os"
 )
 
@@ -36,22 +37,45 @@ func (s *flatFileStore) read(location *flatFileLocation) ([]byte, error) {
 	flatFile.RLock()
 	defer flatFile.RUnlock()
 
+	if location.dataLength < uint32(dataLengthLength+crc32ChecksumLength) {
+		return nil, errors.Wrapf(database.ErrCorruption, "location in store '%s' "+
+			"has an invalid data length %d", s.storeName, location.dataLength)
+	}
+
 	data := make([]byte, location.dataLength)
 	n, err := flatFile.file.ReadAt(data, int64(location.fileOffset))
 	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.Wrapf(database.ErrCorruption, "data in store '%s' "+
+				"in file %d, offset %d is truncated - got %d bytes, want %d",
+				s.storeName, location.fileNumber, location.fileOffset, n,
+				location.dataLength)
+		}
 		return nil, errors.Wrapf(err, "failed to read data in store '%s' "+
 			"from file %d, offset %d", s.storeName, location.fileNumber,
 			location.fileOffset)
 	}
 
+	// Make sure that the serialized data length matches the location,
+	// so that data read from a wrong offset isn't mistaken for valid data.
+	serializedDataLength := byteOrder.Uint32(data[:dataLengthLength])
+	expectedDataLength := location.dataLength - uint32(dataLengthLength+crc32ChecksumLength)
+	if serializedDataLength != expectedDataLength {
+		return nil, errors.Wrapf(database.ErrCorruption, "data length in store '%s' "+
+			"in file %d, offset %d does not match its location - got %d, want %d",
+			s.storeName, location.fileNumber, location.fileOffset,
+			serializedDataLength, expectedDataLength)
+	}
+
 	// Calculate the checksum of the read data and ensure it matches the
 	// serialized checksum.
 	serializedChecksum := crc32ByteOrder.Uint32(data[n-crc32ChecksumLength:])
 	calculatedChecksum := crc32.Checksum(data[:n-crc32ChecksumLength], castagnoli)
 	if serializedChecksum != calculatedChecksum {
-		return nil, errors.Errorf("data in store '%s' does not match "+
-			"checksum - got %x, want %x", s.storeName, calculatedChecksum,
-			serializedChecksum)
+		return nil, errors.Wrapf(database.ErrCorruption, "data in store '%s' "+
+			"in file %d, offset %d does not match checksum - got %x, want %x",
+			s.storeName, location.fileNumber, location.fileOffset,
+			calculatedChecksum, serializedChecksum)
 	}
 
 	// The data excludes the length of the data and the checksum.
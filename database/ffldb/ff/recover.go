@@ -0,0 +1,125 @@
+package ff
+
+import (
+	"bufio"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+)
+
+// recoverTail brings the store back to the given committed location,
+// which is the last location the database recorded as the end of the
+// store. It is meant to be called once, when the database is opened.
+//
+// Any data beyond the committed location was written by a transaction
+// that never committed, and might have been only partially written if
+// the process crashed while writing it. recoverTail scans that data to
+// report what is discarded, and then truncates it.
+//
+// If the store ends before the committed location, data that had been
+// committed is missing, and ErrCorruption is returned.
+func (s *flatFileStore) recoverTail(committedLocation *flatFileLocation) error {
+	if s.isClosed {
+		return errors.Errorf("cannot recover a closed store %s",
+			s.storeName)
+	}
+
+	cursor := s.writeCursor
+	if cursor.currentFileNumber < committedLocation.fileNumber ||
+		(cursor.currentFileNumber == committedLocation.fileNumber &&
+			cursor.currentOffset < committedLocation.fileOffset) {
+		return errors.Wrapf(database.ErrCorruption, "store '%s' ends at "+
+			"file %d, offset %d, before its last committed location at "+
+			"file %d, offset %d", s.storeName, cursor.currentFileNumber,
+			cursor.currentOffset, committedLocation.fileNumber,
+			committedLocation.fileOffset)
+	}
+	if cursor.currentFileNumber == committedLocation.fileNumber &&
+		cursor.currentOffset == committedLocation.fileOffset {
+		return nil
+	}
+
+	completeRecordCount := 0
+	partialRecordLength := uint32(0)
+	for fileNumber := committedLocation.fileNumber; fileNumber <= cursor.currentFileNumber; fileNumber++ {
+		fromOffset := uint32(0)
+		if fileNumber == committedLocation.fileNumber {
+			fromOffset = committedLocation.fileOffset
+		}
+		recordCount, trailingLength, err := s.scanRecords(fileNumber, fromOffset)
+		if err != nil {
+			return err
+		}
+		completeRecordCount += recordCount
+		partialRecordLength += trailingLength
+	}
+	log.Warnf("Discarding %d uncommitted records and %d bytes of partially "+
+		"written data beyond the last committed location of store '%s'",
+		completeRecordCount, partialRecordLength, s.storeName)
+
+	return s.rollback(committedLocation)
+}
+
+// scanRecords reads the records in the given flat file starting at
+// fromOffset, and returns the number of complete records that match
+// their checksum, as well as the length of the data that follows
+// them, which is either partially written or corrupted.
+func (s *flatFileStore) scanRecords(fileNumber uint32, fromOffset uint32) (
+	recordCount int, trailingLength uint32, err error) {
+
+	filePath := flatFilePath(s.basePath, s.storeName, fileNumber)
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	fileSize := uint32(stat.Size())
+	if fromOffset >= fileSize {
+		return 0, 0, nil
+	}
+	_, err = file.Seek(int64(fromOffset), io.SeekStart)
+	if err != nil {
+		return 0, 0, errors.WithStack(err)
+	}
+	reader := bufio.NewReader(file)
+
+	offset := fromOffset
+	var scratch [4]byte
+	for {
+		_, err := io.ReadFull(reader, scratch[:dataLengthLength])
+		if err != nil {
+			break
+		}
+		dataLength := byteOrder.Uint32(scratch[:dataLengthLength])
+		fullLength := uint64(dataLengthLength) + uint64(dataLength) + uint64(crc32ChecksumLength)
+		if uint64(offset)+fullLength > uint64(fileSize) {
+			break
+		}
+
+		hasher := crc32.New(castagnoli)
+		_, _ = hasher.Write(scratch[:dataLengthLength])
+		_, err = io.CopyN(hasher, reader, int64(dataLength))
+		if err != nil {
+			break
+		}
+		_, err = io.ReadFull(reader, scratch[:crc32ChecksumLength])
+		if err != nil {
+			break
+		}
+		if crc32ByteOrder.Uint32(scratch[:crc32ChecksumLength]) != hasher.Sum32() {
+			break
+		}
+
+		recordCount++
+		offset += uint32(fullLength)
+	}
+	return recordCount, fileSize - offset, nil
+}
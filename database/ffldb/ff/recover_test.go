@@ -0,0 +1,163 @@
+package ff
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+)
+
+func TestReadCorruptedData(t *testing.T) {
+	store, teardownFunc := prepareStoreForTest(t, "TestReadCorruptedData")
+	defer teardownFunc()
+
+	location, err := store.write([]byte("Hello world!"))
+	if err != nil {
+		t.Fatalf("TestReadCorruptedData: write returned "+
+			"unexpected error: %s", err)
+	}
+	filePath := flatFilePath(store.basePath, store.storeName, location.fileNumber)
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("TestReadCorruptedData: OpenFile returned "+
+			"unexpected error: %s", err)
+	}
+	defer file.Close()
+
+	// Flip a byte of the data
+	_, err = file.WriteAt([]byte{'J'}, int64(location.fileOffset)+int64(dataLengthLength))
+	if err != nil {
+		t.Fatalf("TestReadCorruptedData: WriteAt returned "+
+			"unexpected error: %s", err)
+	}
+	_, err = store.read(location)
+	if !database.IsCorruptionError(err) {
+		t.Fatalf("TestReadCorruptedData: expected read of data that doesn't "+
+			"match its checksum to return ErrCorruption, but got: %v", err)
+	}
+
+	// Read from an offset that doesn't point at the start of the record
+	shiftedLocation := *location
+	shiftedLocation.fileOffset++
+	shiftedLocation.dataLength--
+	_, err = store.read(&shiftedLocation)
+	if !database.IsCorruptionError(err) {
+		t.Fatalf("TestReadCorruptedData: expected read of a wrong offset "+
+			"to return ErrCorruption, but got: %v", err)
+	}
+
+	// Truncate the record, as if it was partially written
+	err = file.Truncate(int64(location.fileOffset) + 6)
+	if err != nil {
+		t.Fatalf("TestReadCorruptedData: Truncate returned "+
+			"unexpected error: %s", err)
+	}
+	_, err = store.read(location)
+	if !database.IsCorruptionError(err) {
+		t.Fatalf("TestReadCorruptedData: expected read of truncated data "+
+			"to return ErrCorruption, but got: %v", err)
+	}
+}
+
+func TestRecoverTail(t *testing.T) {
+	// The store is closed and reopened below, so it's closed
+	// without the teardown function
+	store, _ := prepareStoreForTest(t, "TestRecoverTail")
+
+	// Write a committed record, an uncommitted one, and some
+	// bytes of a partially written record after them
+	committedData := []byte("committed")
+	committedRecordLocation, err := store.write(committedData)
+	if err != nil {
+		t.Fatalf("TestRecoverTail: write returned "+
+			"unexpected error: %s", err)
+	}
+	committedLocation := store.currentLocation()
+	uncommittedRecordLocation, err := store.write([]byte("uncommitted"))
+	if err != nil {
+		t.Fatalf("TestRecoverTail: write returned "+
+			"unexpected error: %s", err)
+	}
+	partialRecord := []byte{100, 0, 0, 0, 1}
+	partialRecordLocation := store.currentLocation()
+	filePath := flatFilePath(store.basePath, store.storeName, partialRecordLocation.fileNumber)
+	file, err := os.OpenFile(filePath, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("TestRecoverTail: OpenFile returned "+
+			"unexpected error: %s", err)
+	}
+	_, err = file.WriteAt(partialRecord, int64(partialRecordLocation.fileOffset))
+	if err != nil {
+		t.Fatalf("TestRecoverTail: WriteAt returned "+
+			"unexpected error: %s", err)
+	}
+	err = file.Close()
+	if err != nil {
+		t.Fatalf("TestRecoverTail: Close returned "+
+			"unexpected error: %s", err)
+	}
+
+	// Reopen the store, as if the process crashed
+	err = store.Close()
+	if err != nil {
+		t.Fatalf("TestRecoverTail: Close returned "+
+			"unexpected error: %s", err)
+	}
+	store, err = openFlatFileStore(store.basePath, store.storeName)
+	if err != nil {
+		t.Fatalf("TestRecoverTail: openFlatFileStore returned "+
+			"unexpected error: %s", err)
+	}
+	defer func() {
+		err := store.Close()
+		if err != nil {
+			t.Fatalf("TestRecoverTail: Close returned "+
+				"unexpected error: %s", err)
+		}
+	}()
+
+	recordCount, trailingLength, err := store.scanRecords(
+		committedLocation.fileNumber, committedLocation.fileOffset)
+	if err != nil {
+		t.Fatalf("TestRecoverTail: scanRecords returned "+
+			"unexpected error: %s", err)
+	}
+	if recordCount != 1 || trailingLength != uint32(len(partialRecord)) {
+		t.Fatalf("TestRecoverTail: scanRecords returned unexpected results. "+
+			"Want: (1, %d), got: (%d, %d)", len(partialRecord), recordCount, trailingLength)
+	}
+
+	err = store.recoverTail(committedLocation)
+	if err != nil {
+		t.Fatalf("TestRecoverTail: recoverTail returned "+
+			"unexpected error: %s", err)
+	}
+	currentLocation := store.currentLocation()
+	if *currentLocation != *committedLocation {
+		t.Fatalf("TestRecoverTail: unexpected current location. "+
+			"Want: %v, got: %v", committedLocation, currentLocation)
+	}
+	data, err := store.read(committedRecordLocation)
+	if err != nil {
+		t.Fatalf("TestRecoverTail: read returned "+
+			"unexpected error: %s", err)
+	}
+	if !bytes.Equal(data, committedData) {
+		t.Fatalf("TestRecoverTail: read returned unexpected data. "+
+			"Want: %s, got: %s", committedData, data)
+	}
+	_, err = store.read(uncommittedRecordLocation)
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("TestRecoverTail: expected read of uncommitted data "+
+			"to return ErrNotFound, but got: %v", err)
+	}
+
+	// A committed location beyond the end of the store means
+	// that committed data is missing
+	err = store.recoverTail(partialRecordLocation)
+	if !database.IsCorruptionError(err) {
+		t.Fatalf("TestRecoverTail: expected recoverTail to a location beyond "+
+			"the end of the store to return ErrCorruption, but got: %v", err)
+	}
+}
@@ -33,10 +33,11 @@ func (s *flatFileStore) write(data []byte) (*flatFileLocation, error) {
 	// even though it isn't possible currently, numbers might change in
 	// the future to make it possible.
 	//
-	// NOTE: The writeCursor.currentOffset field isn't protected by the
-	// mutex since it's only read/changed during this function which can
-	// only be called during a write transaction, of which there can be
-	// only one at a time.
+	// NOTE: The writeCursor.currentOffset field is only changed during
+	// this function which can only be called during a write transaction,
+	// of which there can be only one at a time, so it's read here without
+	// locking. Other readers, such as backups, must hold the current file
+	// lock, under which it's advanced below.
 	cursor := s.writeCursor
 	finalOffset := cursor.currentOffset + fullLength
 	if finalOffset < cursor.currentOffset || finalOffset > maxFileSize {
@@ -80,8 +80,10 @@ func (c *LevelDBCursor) Key() (*database.Key, error) {
 		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
 			"key of an exhausted cursor")
 	}
+	// Copy the suffix, since the iterator's key buffer may be reused
+	// on the next call to Next.
 	suffix := bytes.TrimPrefix(fullKeyPath, c.bucket.Path())
-	return c.bucket.Key(suffix), nil
+	return c.bucket.Key(append([]byte(nil), suffix...)), nil
 }
 
 // Value returns the value of the current key/value pair, or ErrNotFound if done.
@@ -5,6 +5,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	ldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
 )
 
 // LevelDB defines a thin wrapper around leveldb.
@@ -43,6 +44,27 @@ func NewLevelDB(path string) (*LevelDB, error) {
 	return db, nil
 }
 
+// NewReadOnlyLevelDB opens an existing leveldb instance defined by the
+// given path in read-only mode. Unlike NewLevelDB, it does not attempt to
+// recover from corruption, since recovering requires writing to it.
+func NewReadOnlyLevelDB(path string) (*LevelDB, error) {
+	options := opt.Options{}
+	if defaultOptions := Options(); defaultOptions != nil {
+		options = *defaultOptions
+	}
+	options.ReadOnly = true
+	options.ErrorIfMissing = true
+	ldb, err := leveldb.OpenFile(path, &options)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	db := &LevelDB{
+		ldb: ldb,
+	}
+	return db, nil
+}
+
 // Close closes the leveldb instance.
 func (db *LevelDB) Close() error {
 	err := db.ldb.Close()
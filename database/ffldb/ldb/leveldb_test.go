@@ -150,3 +150,60 @@ func TestLevelDBTransactionSanity(t *testing.T) {
 			"returned unexpected error: %s", err)
 	}
 }
+
+func TestReadOnlyLevelDB(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestReadOnlyLevelDB")
+	if err != nil {
+		t.Fatalf("TestReadOnlyLevelDB: TempDir unexpectedly "+
+			"failed: %s", err)
+	}
+
+	// Opening a missing database in read-only mode should fail
+	_, err = NewReadOnlyLevelDB(path)
+	if err == nil {
+		t.Fatalf("TestReadOnlyLevelDB: NewReadOnlyLevelDB " +
+			"unexpectedly succeeded for a missing database")
+	}
+
+	ldb, err := NewLevelDB(path)
+	if err != nil {
+		t.Fatalf("TestReadOnlyLevelDB: NewLevelDB unexpectedly "+
+			"failed: %s", err)
+	}
+	key := database.MakeBucket().Key([]byte("key"))
+	putData := []byte("Hello world!")
+	err = ldb.Put(key, putData)
+	if err != nil {
+		t.Fatalf("TestReadOnlyLevelDB: Put returned "+
+			"unexpected error: %s", err)
+	}
+	err = ldb.Close()
+	if err != nil {
+		t.Fatalf("TestReadOnlyLevelDB: Close unexpectedly "+
+			"failed: %s", err)
+	}
+
+	readOnlyLDB, err := NewReadOnlyLevelDB(path)
+	if err != nil {
+		t.Fatalf("TestReadOnlyLevelDB: NewReadOnlyLevelDB unexpectedly "+
+			"failed: %s", err)
+	}
+	defer readOnlyLDB.Close()
+
+	getData, err := readOnlyLDB.Get(key)
+	if err != nil {
+		t.Fatalf("TestReadOnlyLevelDB: Get returned "+
+			"unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(getData, putData) {
+		t.Fatalf("TestReadOnlyLevelDB: get data and "+
+			"put data are not equal. Put: %s, got: %s",
+			string(putData), string(getData))
+	}
+
+	err = readOnlyLDB.Put(key, []byte("Goodbye world!"))
+	if err == nil {
+		t.Fatalf("TestReadOnlyLevelDB: Put unexpectedly " +
+			"succeeded in a read-only database")
+	}
+}
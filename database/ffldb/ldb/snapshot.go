@@ -0,0 +1,139 @@
+package ldb
+
+import (
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// backupBatchSize is the number of key/value pairs written to
+// the destination database in a single batch during CopyTo.
+const backupBatchSize = 10000
+
+// LevelDBSnapshot is a thin wrapper around native leveldb
+// snapshots. It provides a consistent, read-only view of the
+// database at the time it was taken, regardless of any writes
+// that happen afterwards.
+type LevelDBSnapshot struct {
+	snapshot   *leveldb.Snapshot
+	isReleased bool
+}
+
+// Snapshot takes a snapshot of the current state of the database.
+// The returned snapshot must be released once it's no longer used.
+func (db *LevelDB) Snapshot() (*LevelDBSnapshot, error) {
+	snapshot, err := db.ldb.GetSnapshot()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &LevelDBSnapshot{
+		snapshot:   snapshot,
+		isReleased: false,
+	}, nil
+}
+
+// Get gets the value for the given key as it was when the snapshot
+// was taken. It returns ErrNotFound if the given key did not exist.
+func (s *LevelDBSnapshot) Get(key *database.Key) ([]byte, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot get from a released snapshot")
+	}
+	data, err := s.snapshot.Get(key.Bytes(), nil)
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return nil, errors.Wrapf(database.ErrNotFound,
+				"key %s not found", key)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return data, nil
+}
+
+// CopyTo copies every key/value pair in the snapshot into
+// a new leveldb instance at the given path. The path must
+// not contain an existing database.
+func (s *LevelDBSnapshot) CopyTo(path string) error {
+	if s.isReleased {
+		return errors.New("cannot copy a released snapshot")
+	}
+
+	options := opt.Options{}
+	if defaultOptions := Options(); defaultOptions != nil {
+		options = *defaultOptions
+	}
+	options.ErrorIfExist = true
+	destination, err := leveldb.OpenFile(path, &options)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = s.copyTo(destination)
+	if err != nil {
+		closeErr := destination.Close()
+		if closeErr != nil {
+			return errors.Wrapf(err, "error occurred during leveldb close: %s", closeErr)
+		}
+		return err
+	}
+	return errors.WithStack(destination.Close())
+}
+
+func (s *LevelDBSnapshot) copyTo(destination *leveldb.DB) error {
+	iterator := s.snapshot.NewIterator(nil, nil)
+	defer iterator.Release()
+
+	batch := new(leveldb.Batch)
+	for iterator.Next() {
+		batch.Put(iterator.Key(), iterator.Value())
+		if batch.Len() >= backupBatchSize {
+			err := destination.Write(batch, nil)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			batch.Reset()
+		}
+	}
+	err := iterator.Error()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if batch.Len() > 0 {
+		err := destination.Write(batch, nil)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// ForEach calls fn with every raw key/value pair in the snapshot,
+// in key order. The key and value passed to fn are only valid
+// until fn returns. ForEach stops and returns the error if fn
+// returns one.
+func (s *LevelDBSnapshot) ForEach(fn func(key []byte, value []byte) error) error {
+	if s.isReleased {
+		return errors.New("cannot iterate a released snapshot")
+	}
+
+	iterator := s.snapshot.NewIterator(nil, nil)
+	defer iterator.Release()
+
+	for iterator.Next() {
+		err := fn(iterator.Key(), iterator.Value())
+		if err != nil {
+			return err
+		}
+	}
+	return errors.WithStack(iterator.Error())
+}
+
+// Release releases the snapshot. Any operations on a released
+// snapshot will fail.
+func (s *LevelDBSnapshot) Release() {
+	if s.isReleased {
+		return
+	}
+	s.isReleased = true
+	s.snapshot.Release()
+}
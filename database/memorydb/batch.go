@@ -0,0 +1,32 @@
+package memorydb
+
+import "github.com/kaspanet/kaspad/database"
+
+// batch is a set of writes that are applied to the
+// database together. Later writes to a key override
+// earlier ones.
+type batch struct {
+	pendingValues map[string]*pendingValue
+}
+
+// pendingValue is a write to a key that was not applied
+// to the database yet. If isDeleted is true, the write
+// deletes the key, and value is ignored.
+type pendingValue struct {
+	value     []byte
+	isDeleted bool
+}
+
+func newBatch() *batch {
+	return &batch{
+		pendingValues: make(map[string]*pendingValue),
+	}
+}
+
+func (b *batch) put(key *database.Key, value []byte) {
+	b.pendingValues[string(key.Bytes())] = &pendingValue{value: copyBytes(value)}
+}
+
+func (b *batch) delete(key *database.Key) {
+	b.pendingValues[string(key.Bytes())] = &pendingValue{isDeleted: true}
+}
@@ -0,0 +1,110 @@
+package memorydb
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+)
+
+// cursor iterates over a copy of the entries of a bucket, as
+// they were when the cursor was created.
+type cursor struct {
+	bucket *database.Bucket
+	keys   []string
+	values [][]byte
+
+	// index is the index of the current entry. It starts out
+	// as -1, before the first entry, the same way as leveldb
+	// iterators do.
+	index int
+
+	isClosed bool
+}
+
+func newCursor(bucket *database.Bucket, keys []string, values [][]byte) *cursor {
+	return &cursor{
+		bucket:   bucket,
+		keys:     keys,
+		values:   values,
+		index:    -1,
+		isClosed: false,
+	}
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *cursor) Next() bool {
+	if c.isClosed {
+		panic("cannot call next on a closed cursor")
+	}
+	if c.index < len(c.keys) {
+		c.index++
+	}
+	return c.index < len(c.keys)
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *cursor) First() bool {
+	if c.isClosed {
+		panic("cannot call first on a closed cursor")
+	}
+	c.index = 0
+	return len(c.keys) > 0
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *cursor) Seek(key *database.Key) error {
+	if c.isClosed {
+		return errors.New("cannot seek a closed cursor")
+	}
+
+	keyString := string(key.Bytes())
+	c.index = sort.SearchStrings(c.keys, keyString)
+	if c.index == len(c.keys) || c.keys[c.index] != keyString {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+	return nil
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+// Note that the key is trimmed to not include the prefix the cursor was opened
+// with.
+func (c *cursor) Key() (*database.Key, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the key of a closed cursor")
+	}
+	if c.index < 0 || c.index >= len(c.keys) {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"key of an exhausted cursor")
+	}
+	suffix := strings.TrimPrefix(c.keys[c.index], string(c.bucket.Path()))
+	return c.bucket.Key([]byte(suffix)), nil
+}
+
+// Value returns the value of the current key/value pair, or ErrNotFound if done.
+func (c *cursor) Value() ([]byte, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the value of a closed cursor")
+	}
+	if c.index < 0 || c.index >= len(c.keys) {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"value of an exhausted cursor")
+	}
+	return c.values[c.index], nil
+}
+
+// Close releases associated resources.
+func (c *cursor) Close() error {
+	if c.isClosed {
+		return errors.New("cannot close an already closed cursor")
+	}
+	c.isClosed = true
+	c.keys = nil
+	c.values = nil
+	return nil
+}
@@ -0,0 +1,365 @@
+package memorydb
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+)
+
+// memoryDB is a database that keeps all of its key-value data
+// and stores in memory. It is meant for tests and for ephemeral
+// nodes, and everything in it is lost once it is closed.
+//
+// Every write to the database is assigned a sequence number, and
+// every key keeps the versions of its value that may still be
+// visible to an open snapshot. This allows transactions to read
+// the database as it was when they began, the same way as ffldb
+// transactions do.
+type memoryDB struct {
+	// mutex protects all the fields below it.
+	mutex sync.RWMutex
+
+	data     map[string][]*version
+	sequence uint64
+	stores   map[string]*store
+
+	// snapshots is the set of open snapshots, and keysWithHistory
+	// is the set of keys that have more than one version. Once no
+	// snapshot requires the older versions of these keys, they
+	// are pruned.
+	snapshots       map[*snapshot]struct{}
+	keysWithHistory map[string]struct{}
+
+	isClosed bool
+}
+
+// version is a value of a key as it was written at the given
+// sequence number. If isDeleted is true, the key was deleted
+// at that sequence number.
+type version struct {
+	sequence  uint64
+	value     []byte
+	isDeleted bool
+}
+
+// snapshot is a frozen view of the database as it was at
+// the given sequence number.
+type snapshot struct {
+	sequence uint64
+}
+
+// Open opens a new, empty in-memory database.
+func Open() database.Database {
+	return &memoryDB{
+		data:            make(map[string][]*version),
+		stores:          make(map[string]*store),
+		snapshots:       make(map[*snapshot]struct{}),
+		keysWithHistory: make(map[string]struct{}),
+	}
+}
+
+// Close closes the database and discards all of its data.
+// This method is part of the Database interface.
+func (db *memoryDB) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isClosed {
+		return errors.New("cannot close a closed database")
+	}
+	db.isClosed = true
+	db.data = nil
+	db.stores = nil
+	return nil
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+// This method is part of the DataAccessor interface.
+func (db *memoryDB) Put(key *database.Key, value []byte) error {
+	batch := newBatch()
+	batch.put(key, value)
+	return db.write(batch)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+// This method is part of the DataAccessor interface.
+func (db *memoryDB) Get(key *database.Key) ([]byte, error) {
+	return db.get(key, nil)
+}
+
+// Has returns true if the database does contains the
+// given key.
+// This method is part of the DataAccessor interface.
+func (db *memoryDB) Has(key *database.Key) (bool, error) {
+	return db.has(key, nil)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+// This method is part of the DataAccessor interface.
+func (db *memoryDB) Delete(key *database.Key) error {
+	batch := newBatch()
+	batch.delete(key)
+	return db.write(batch)
+}
+
+// AppendToStore appends the given data to the store
+// defined by storeName. This function returns a serialized
+// location handle that's meant to be stored and later used
+// when querying the data that has just now been inserted.
+// This method is part of the DataAccessor interface.
+func (db *memoryDB) AppendToStore(storeName string, data []byte) ([]byte, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isClosed {
+		return nil, errors.New("cannot append to store on a closed database")
+	}
+	store, ok := db.stores[storeName]
+	if !ok {
+		store = newStore()
+		db.stores[storeName] = store
+	}
+	return store.append(data), nil
+}
+
+// RetrieveFromStore retrieves data from the store defined by
+// storeName using the given serialized location handle. It
+// returns ErrNotFound if the location does not exist. See
+// AppendToStore for further details.
+// This method is part of the DataAccessor interface.
+func (db *memoryDB) RetrieveFromStore(storeName string, location []byte) ([]byte, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if db.isClosed {
+		return nil, errors.New("cannot retrieve from store on a closed database")
+	}
+	store, ok := db.stores[storeName]
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound,
+			"store %s not found", storeName)
+	}
+	return store.read(location)
+}
+
+// Cursor begins a new cursor over the given bucket.
+// This method is part of the DataAccessor interface.
+func (db *memoryDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return db.cursor(bucket, nil)
+}
+
+// Begin begins a new transaction.
+// This method is part of the Database interface.
+func (db *memoryDB) Begin() (database.Transaction, error) {
+	snapshot, err := db.takeSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &transaction{
+		db:       db,
+		snapshot: snapshot,
+		batch:    newBatch(),
+		isClosed: false,
+	}
+	return transaction, nil
+}
+
+// Backup is not supported by the in-memory database.
+// This method is part of the Database interface.
+func (db *memoryDB) Backup(destinationPath string) error {
+	return errors.New("backups are not supported by the in-memory database")
+}
+
+// takeSnapshot takes a snapshot of the current state of the
+// database. The returned snapshot must be released once it's
+// no longer used.
+func (db *memoryDB) takeSnapshot() (*snapshot, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isClosed {
+		return nil, errors.New("cannot take a snapshot of a closed database")
+	}
+	snapshot := &snapshot{sequence: db.sequence}
+	db.snapshots[snapshot] = struct{}{}
+	return snapshot, nil
+}
+
+// releaseSnapshot releases the given snapshot and prunes the
+// versions of values that are no longer visible to any snapshot.
+func (db *memoryDB) releaseSnapshot(snapshot *snapshot) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isClosed {
+		return
+	}
+	delete(db.snapshots, snapshot)
+	for key := range db.keysWithHistory {
+		db.prune(key)
+	}
+}
+
+// write applies the given batch to the database, as a single
+// write with its own sequence number.
+func (db *memoryDB) write(batch *batch) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isClosed {
+		return errors.New("cannot write to a closed database")
+	}
+	db.sequence++
+	for key, pendingValue := range batch.pendingValues {
+		db.data[key] = append(db.data[key], &version{
+			sequence:  db.sequence,
+			value:     pendingValue.value,
+			isDeleted: pendingValue.isDeleted,
+		})
+		db.prune(key)
+	}
+	return nil
+}
+
+// prune removes the versions of the given key that are not
+// visible to any open snapshot, nor to new readers.
+// This function MUST be called with the mutex held for writes.
+func (db *memoryDB) prune(key string) {
+	oldestVisibleSequence := db.sequence
+	for snapshot := range db.snapshots {
+		if snapshot.sequence < oldestVisibleSequence {
+			oldestVisibleSequence = snapshot.sequence
+		}
+	}
+
+	// Keep the newest version that is visible at the oldest
+	// visible sequence number, and every version after it.
+	versions := db.data[key]
+	firstKept := 0
+	for i, version := range versions {
+		if version.sequence <= oldestVisibleSequence {
+			firstKept = i
+		}
+	}
+	versions = versions[firstKept:]
+
+	if len(versions) == 1 && versions[0].isDeleted {
+		delete(db.data, key)
+		delete(db.keysWithHistory, key)
+		return
+	}
+	db.data[key] = versions
+	if len(versions) > 1 {
+		db.keysWithHistory[key] = struct{}{}
+	} else {
+		delete(db.keysWithHistory, key)
+	}
+}
+
+// valueAt returns the value of the given key as it was at the
+// given snapshot, or as it currently is if snapshot is nil. The
+// returned boolean is false if the key did not exist.
+// This function MUST be called with the mutex held.
+func (db *memoryDB) valueAt(key string, snapshot *snapshot) ([]byte, bool) {
+	sequence := db.sequence
+	if snapshot != nil {
+		sequence = snapshot.sequence
+	}
+	versions := db.data[key]
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].sequence <= sequence {
+			if versions[i].isDeleted {
+				return nil, false
+			}
+			return versions[i].value, true
+		}
+	}
+	return nil, false
+}
+
+func (db *memoryDB) get(key *database.Key, snapshot *snapshot) ([]byte, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if db.isClosed {
+		return nil, errors.New("cannot get from a closed database")
+	}
+	value, ok := db.valueAt(string(key.Bytes()), snapshot)
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound,
+			"key %s not found", key)
+	}
+	return copyBytes(value), nil
+}
+
+func (db *memoryDB) has(key *database.Key, snapshot *snapshot) (bool, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if db.isClosed {
+		return false, errors.New("cannot has from a closed database")
+	}
+	_, ok := db.valueAt(string(key.Bytes()), snapshot)
+	return ok, nil
+}
+
+// cursor begins a new cursor over the given bucket, as it was
+// at the given snapshot, or as it currently is if snapshot is
+// nil. The cursor iterates over a copy of the bucket's entries,
+// so later writes don't affect it.
+func (db *memoryDB) cursor(bucket *database.Bucket, snapshot *snapshot) (database.Cursor, error) {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if db.isClosed {
+		return nil, errors.New("cannot open a cursor on a closed database")
+	}
+
+	prefix := string(bucket.Path())
+	var keys []string
+	for key := range db.data {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if _, ok := db.valueAt(key, snapshot); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		value, _ := db.valueAt(key, snapshot)
+		values[i] = copyBytes(value)
+	}
+	return newCursor(bucket, keys, values), nil
+}
+
+// removeFromStores removes the data at the given locations from
+// their stores.
+func (db *memoryDB) removeFromStores(locations map[string][][]byte) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isClosed {
+		return
+	}
+	for storeName, storeLocations := range locations {
+		for _, location := range storeLocations {
+			db.stores[storeName].remove(location)
+		}
+	}
+}
+
+func copyBytes(bytes []byte) []byte {
+	if bytes == nil {
+		return nil
+	}
+	return append([]byte{}, bytes...)
+}
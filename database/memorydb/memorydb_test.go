@@ -0,0 +1,106 @@
+package memorydb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+)
+
+func TestRollbackRemovesAppendedData(t *testing.T) {
+	db := Open()
+	defer db.Close()
+
+	storeName := "test"
+	dbTx1, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin unexpectedly failed: %s", err)
+	}
+	dbTx2, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin unexpectedly failed: %s", err)
+	}
+	location1, err := dbTx1.AppendToStore(storeName, []byte("data1"))
+	if err != nil {
+		t.Fatalf("AppendToStore unexpectedly failed: %s", err)
+	}
+	location2, err := dbTx2.AppendToStore(storeName, []byte("data2"))
+	if err != nil {
+		t.Fatalf("AppendToStore unexpectedly failed: %s", err)
+	}
+
+	// Rolling back the first transaction must not affect the data
+	// appended within the second one
+	err = dbTx1.Rollback()
+	if err != nil {
+		t.Fatalf("Rollback unexpectedly failed: %s", err)
+	}
+	err = dbTx2.Commit()
+	if err != nil {
+		t.Fatalf("Commit unexpectedly failed: %s", err)
+	}
+
+	_, err = db.RetrieveFromStore(storeName, location1)
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("Expected RetrieveFromStore of rolled back data to "+
+			"return ErrNotFound, but got: %v", err)
+	}
+	data, err := db.RetrieveFromStore(storeName, location2)
+	if err != nil {
+		t.Fatalf("RetrieveFromStore unexpectedly failed: %s", err)
+	}
+	if !bytes.Equal(data, []byte("data2")) {
+		t.Fatalf("RetrieveFromStore returned unexpected data %s", data)
+	}
+}
+
+func TestPruneVersions(t *testing.T) {
+	db := Open()
+	defer db.Close()
+	memoryDBInstance := db.(*memoryDB)
+
+	key := database.MakeBucket().Key([]byte("key"))
+	err := db.Put(key, []byte("value1"))
+	if err != nil {
+		t.Fatalf("Put unexpectedly failed: %s", err)
+	}
+
+	// The old value must be kept as long as a transaction
+	// may still read it
+	dbTx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin unexpectedly failed: %s", err)
+	}
+	err = db.Put(key, []byte("value2"))
+	if err != nil {
+		t.Fatalf("Put unexpectedly failed: %s", err)
+	}
+	value, err := dbTx.Get(key)
+	if err != nil {
+		t.Fatalf("Get unexpectedly failed: %s", err)
+	}
+	if !bytes.Equal(value, []byte("value1")) {
+		t.Fatalf("Expected the transaction to read value1, but got %s", value)
+	}
+	if versionCount := len(memoryDBInstance.data[string(key.Bytes())]); versionCount != 2 {
+		t.Fatalf("Expected 2 versions of the key, but got %d", versionCount)
+	}
+
+	err = dbTx.Rollback()
+	if err != nil {
+		t.Fatalf("Rollback unexpectedly failed: %s", err)
+	}
+	if versionCount := len(memoryDBInstance.data[string(key.Bytes())]); versionCount != 1 {
+		t.Fatalf("Expected 1 version of the key after the transaction "+
+			"was closed, but got %d", versionCount)
+	}
+
+	// A deleted key is removed completely once no snapshot may read it
+	err = db.Delete(key)
+	if err != nil {
+		t.Fatalf("Delete unexpectedly failed: %s", err)
+	}
+	if _, ok := memoryDBInstance.data[string(key.Bytes())]; ok {
+		t.Fatalf("Expected the deleted key to be removed")
+	}
+}
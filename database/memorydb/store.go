@@ -0,0 +1,81 @@
+package memorydb
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+)
+
+// storeLocationSerializedSize is the size in bytes of a serialized
+// store location. See store.append for further details.
+const storeLocationSerializedSize = 12
+
+// store is an in-memory counterpart of a flat-file store.
+// It is an append-only list of data entries, in which an
+// entry is located by its index. Entries that were appended
+// within a transaction that was rolled back are removed,
+// and read as nil.
+type store struct {
+	entries [][]byte
+}
+
+func newStore() *store {
+	return &store{}
+}
+
+// append appends the given data to the store and returns its
+// serialized location. The serialized location format is:
+//
+//  [0:8]  Entry index (8 bytes)
+//  [8:12] Data length (4 bytes)
+func (s *store) append(data []byte) []byte {
+	location := make([]byte, storeLocationSerializedSize)
+	binary.LittleEndian.PutUint64(location[0:8], uint64(len(s.entries)))
+	binary.LittleEndian.PutUint32(location[8:12], uint32(len(data)))
+	s.entries = append(s.entries, append([]byte{}, data...))
+	return location
+}
+
+// read returns the data at the given serialized location. It
+// returns ErrNotFound if the location does not exist.
+func (s *store) read(location []byte) ([]byte, error) {
+	index, ok, err := s.index(location)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Wrapf(database.ErrNotFound,
+			"location %x not found", location)
+	}
+	return copyBytes(s.entries[index]), nil
+}
+
+// remove removes the data at the given serialized location,
+// as well as any removed entries at the end of the store.
+func (s *store) remove(location []byte) {
+	index, ok, err := s.index(location)
+	if err != nil || !ok {
+		return
+	}
+	s.entries[index] = nil
+	for len(s.entries) > 0 && s.entries[len(s.entries)-1] == nil {
+		s.entries = s.entries[:len(s.entries)-1]
+	}
+}
+
+// index returns the index of the entry at the given serialized
+// location. The returned boolean is false if there's no such entry.
+func (s *store) index(location []byte) (uint64, bool, error) {
+	if len(location) != storeLocationSerializedSize {
+		return 0, false, errors.Errorf("unexpected serializedLocation length: %d",
+			len(location))
+	}
+	index := binary.LittleEndian.Uint64(location[0:8])
+	dataLength := binary.LittleEndian.Uint32(location[8:12])
+	if index >= uint64(len(s.entries)) || s.entries[index] == nil ||
+		uint32(len(s.entries[index])) != dataLength {
+		return 0, false, nil
+	}
+	return index, true, nil
+}
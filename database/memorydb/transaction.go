@@ -0,0 +1,157 @@
+package memorydb
+
+import (
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+)
+
+// transaction is a memoryDB transaction. It reads from a
+// snapshot of the database taken when it began, and its
+// writes are kept in a batch that is applied to the database
+// only once it's committed. Data appended to stores within it
+// is appended to the stores immediately, the same way as it is
+// in ffldb, and is removed from them if it's rolled back.
+//
+// Note: Transactions provide data consistency over the state of
+// the database as it was when the transaction started. As it's
+// currently implemented, if one puts data into the transaction
+// then it will not be available to get within the same transaction.
+type transaction struct {
+	db       *memoryDB
+	snapshot *snapshot
+	batch    *batch
+
+	// appendedLocations are the locations of the data appended
+	// to every store within the transaction. They are used to
+	// remove the data if the transaction is rolled back.
+	appendedLocations map[string][][]byte
+
+	isClosed bool
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+// This method is part of the DataAccessor interface.
+func (tx *transaction) Put(key *database.Key, value []byte) error {
+	if tx.isClosed {
+		return errors.New("cannot put into a closed transaction")
+	}
+
+	tx.batch.put(key, value)
+	return nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+// This method is part of the DataAccessor interface.
+func (tx *transaction) Get(key *database.Key) ([]byte, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot get from a closed transaction")
+	}
+
+	return tx.db.get(key, tx.snapshot)
+}
+
+// Has returns true if the database does contains the
+// given key.
+// This method is part of the DataAccessor interface.
+func (tx *transaction) Has(key *database.Key) (bool, error) {
+	if tx.isClosed {
+		return false, errors.New("cannot has from a closed transaction")
+	}
+
+	return tx.db.has(key, tx.snapshot)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+// This method is part of the DataAccessor interface.
+func (tx *transaction) Delete(key *database.Key) error {
+	if tx.isClosed {
+		return errors.New("cannot delete from a closed transaction")
+	}
+
+	tx.batch.delete(key)
+	return nil
+}
+
+// AppendToStore appends the given data to the store
+// defined by storeName. This function returns a serialized
+// location handle that's meant to be stored and later used
+// when querying the data that has just now been inserted.
+// This method is part of the DataAccessor interface.
+func (tx *transaction) AppendToStore(storeName string, data []byte) ([]byte, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot append to store on a closed transaction")
+	}
+
+	location, err := tx.db.AppendToStore(storeName, data)
+	if err != nil {
+		return nil, err
+	}
+	if tx.appendedLocations == nil {
+		tx.appendedLocations = make(map[string][][]byte)
+	}
+	tx.appendedLocations[storeName] = append(tx.appendedLocations[storeName], location)
+	return location, nil
+}
+
+// RetrieveFromStore retrieves data from the store defined by
+// storeName using the given serialized location handle. It
+// returns ErrNotFound if the location does not exist. See
+// AppendToStore for further details.
+// This method is part of the DataAccessor interface.
+func (tx *transaction) RetrieveFromStore(storeName string, location []byte) ([]byte, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot retrieve from store on a closed transaction")
+	}
+
+	return tx.db.RetrieveFromStore(storeName, location)
+}
+
+// Cursor begins a new cursor over the given bucket.
+// This method is part of the DataAccessor interface.
+func (tx *transaction) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	if tx.isClosed {
+		return nil, errors.New("cannot open a cursor from a closed transaction")
+	}
+
+	return tx.db.cursor(bucket, tx.snapshot)
+}
+
+// Rollback rolls back whatever changes were made to the
+// database within this transaction.
+// This method is part of the Transaction interface.
+func (tx *transaction) Rollback() error {
+	if tx.isClosed {
+		return errors.New("cannot rollback a closed transaction")
+	}
+	tx.isClosed = true
+
+	tx.db.releaseSnapshot(tx.snapshot)
+	tx.db.removeFromStores(tx.appendedLocations)
+	return nil
+}
+
+// Commit commits whatever changes were made to the database
+// within this transaction.
+// This method is part of the Transaction interface.
+func (tx *transaction) Commit() error {
+	if tx.isClosed {
+		return errors.New("cannot commit a closed transaction")
+	}
+	tx.isClosed = true
+
+	tx.db.releaseSnapshot(tx.snapshot)
+	return tx.db.write(tx.batch)
+}
+
+// RollbackUnlessClosed rolls back changes that were made to
+// the database within the transaction, unless the transaction
+// had already been closed using either Rollback or Commit.
+func (tx *transaction) RollbackUnlessClosed() error {
+	if tx.isClosed {
+		return nil
+	}
+	return tx.Rollback()
+}
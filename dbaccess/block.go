@@ -83,6 +83,10 @@ func FetchBlock(context Context, hash *daghash.Hash) ([]byte, error) {
 	}
 	bytes, err := accessor.RetrieveFromStore(blockStoreName, blockLocation)
 	if err != nil {
+		if database.IsCorruptionError(err) {
+			return nil, errors.Wrapf(err,
+				"block %s is corrupted", hash)
+		}
 		return nil, err
 	}
 
@@ -2,9 +2,40 @@ package dbaccess
 
 import (
 	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/database/ffbolt"
 	"github.com/kaspanet/kaspad/database/ffldb"
+	"github.com/kaspanet/kaspad/database/memorydb"
+	"github.com/pkg/errors"
 )
 
+const (
+	// FFLDBType is the type of the ffldb database, which stores
+	// its data on disk.
+	FFLDBType = "ffldb"
+
+	// FFBoltDBType is the type of the ffbolt database, which stores
+	// its data on disk using bolt instead of LevelDB.
+	FFBoltDBType = "ffbolt"
+
+	// MemoryDBType is the type of the in-memory database, whose
+	// data is lost once it's closed.
+	MemoryDBType = "memory"
+)
+
+// SupportedDBTypes is the list of the supported database types.
+var SupportedDBTypes = []string{FFLDBType, FFBoltDBType, MemoryDBType}
+
+// DirectoryName returns the name of the directory, within the data
+// directory, in which a database of the given type is kept. Every
+// on-disk type other than ffldb has a directory of its own, so that
+// a database is never opened as a database of another type.
+func DirectoryName(dbType string) string {
+	if dbType == FFLDBType {
+		return "db"
+	}
+	return "db-" + dbType
+}
+
 // DatabaseContext represents a context in which all database queries run
 type DatabaseContext struct {
 	db database.Database
@@ -17,6 +48,70 @@ func New(path string) (*DatabaseContext, error) {
 	if err != nil {
 		return nil, err
 	}
+	return newDatabaseContext(db)
+}
+
+// NewFFBolt creates a new DatabaseContext with an ffbolt database in
+// the specified `path`.
+func NewFFBolt(path string) (*DatabaseContext, error) {
+	db, err := ffbolt.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return newDatabaseContext(db)
+}
+
+// NewInMemory creates a new DatabaseContext with an empty in-memory database.
+func NewInMemory() *DatabaseContext {
+	databaseContext, err := newDatabaseContext(memorydb.Open())
+	if err != nil {
+		// A new in-memory database is open and empty, so
+		// storing its schema version can't fail
+		panic(err)
+	}
+	return databaseContext
+}
+
+// newDatabaseContext creates a new DatabaseContext with the given
+// writable database. If the database is empty, the current schema
+// version is stored in it, so that whatever creates it, it's never
+// mistaken later for a database created before the schema version
+// was stored.
+func newDatabaseContext(db database.Database) (*DatabaseContext, error) {
+	databaseContext := &DatabaseContext{db: db}
+	databaseContext.noTxContext = &noTxContext{backend: databaseContext}
+
+	err := databaseContext.initializeSchemaVersion()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return databaseContext, nil
+}
+
+// Open creates a new DatabaseContext with a database of the given type.
+// For database types that store their data on disk, the database is
+// in the specified `path`.
+func Open(dbType string, path string) (*DatabaseContext, error) {
+	switch dbType {
+	case FFLDBType:
+		return New(path)
+	case FFBoltDBType:
+		return NewFFBolt(path)
+	case MemoryDBType:
+		return NewInMemory(), nil
+	default:
+		return nil, errors.Errorf("unsupported database type %s", dbType)
+	}
+}
+
+// NewReadOnly creates a new DatabaseContext with an existing database in the
+// specified `path`, which is opened in read-only mode.
+func NewReadOnly(path string) (*DatabaseContext, error) {
+	db, err := ffldb.OpenReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
 
 	databaseContext := &DatabaseContext{db: db}
 	databaseContext.noTxContext = &noTxContext{backend: databaseContext}
@@ -24,7 +119,49 @@ func New(path string) (*DatabaseContext, error) {
 	return databaseContext, nil
 }
 
+// OpenReadOnly creates a new DatabaseContext with an existing database
+// of the given type in the specified `path`, which is opened in
+// read-only mode.
+func OpenReadOnly(dbType string, path string) (*DatabaseContext, error) {
+	switch dbType {
+	case FFLDBType:
+		return NewReadOnly(path)
+	case FFBoltDBType:
+		db, err := ffbolt.OpenReadOnly(path)
+		if err != nil {
+			return nil, err
+		}
+
+		databaseContext := &DatabaseContext{db: db}
+		databaseContext.noTxContext = &noTxContext{backend: databaseContext}
+
+		return databaseContext, nil
+	default:
+		return nil, errors.Errorf("database type %s can't be opened in read-only mode", dbType)
+	}
+}
+
 // Close closes the DatabaseContext's connection, if it's open
 func (ctx *DatabaseContext) Close() error {
 	return ctx.db.Close()
 }
+
+// Backup writes a consistent copy of the database into the directory
+// at destinationPath, which must either not exist or be empty. The
+// database may keep being used while it's backed up.
+func (ctx *DatabaseContext) Backup(destinationPath string) error {
+	return ctx.db.Backup(destinationPath)
+}
+
+// ValidateBackup checks that the directory at path contains a complete
+// backup of a database of the given type, as written by Backup.
+func ValidateBackup(dbType string, path string) error {
+	switch dbType {
+	case FFLDBType:
+		return ffldb.ValidateBackup(path)
+	case FFBoltDBType:
+		return ffbolt.ValidateBackup(path)
+	default:
+		return errors.Errorf("database type %s does not support backups", dbType)
+	}
+}
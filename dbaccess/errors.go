@@ -6,3 +6,8 @@ import "github.com/kaspanet/kaspad/database"
 func IsNotFoundError(err error) bool {
 	return database.IsNotFoundError(err)
 }
+
+// IsCorruptionError checks whether an error is an ErrCorruption.
+func IsCorruptionError(err error) bool {
+	return database.IsCorruptionError(err)
+}
@@ -0,0 +1,74 @@
+package dbaccess
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+var feeDeltaBucket = database.MakeBucket([]byte("fee-deltas"))
+
+func feeDeltaKey(txID *daghash.TxID) *database.Key {
+	return feeDeltaBucket.Key(txID[:])
+}
+
+// StoreFeeDelta stores the fee delta a transaction was prioritised by.
+func StoreFeeDelta(context Context, txID *daghash.TxID, feeDelta int64) error {
+	accessor, err := context.accessor()
+	if err != nil {
+		return err
+	}
+
+	var serializedFeeDelta [8]byte
+	binary.LittleEndian.PutUint64(serializedFeeDelta[:], uint64(feeDelta))
+	return accessor.Put(feeDeltaKey(txID), serializedFeeDelta[:])
+}
+
+// RemoveFeeDelta removes the fee delta of a transaction.
+func RemoveFeeDelta(context Context, txID *daghash.TxID) error {
+	accessor, err := context.accessor()
+	if err != nil {
+		return err
+	}
+
+	return accessor.Delete(feeDeltaKey(txID))
+}
+
+// FetchFeeDeltas returns the fee deltas of all the prioritised
+// transactions.
+func FetchFeeDeltas(context Context) (map[daghash.TxID]int64, error) {
+	accessor, err := context.accessor()
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := accessor.Cursor(feeDeltaBucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	feeDeltas := make(map[daghash.TxID]int64)
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		txID, err := daghash.NewTxID(key.Suffix())
+		if err != nil {
+			return nil, err
+		}
+		serializedFeeDelta, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+		if len(serializedFeeDelta) != 8 {
+			return nil, errors.Wrapf(database.ErrCorruption, "unexpected fee "+
+				"delta length %d for transaction %s", len(serializedFeeDelta), txID)
+		}
+		feeDeltas[*txID] = int64(binary.LittleEndian.Uint64(serializedFeeDelta))
+	}
+	return feeDeltas, nil
+}
@@ -0,0 +1,151 @@
+package dbaccess
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Migration is a change to the format of the data in the database.
+// Migrate is given a transaction in which it should make the change,
+// and a function to report its progress with, which logs it every
+// progressLogInterval.
+type Migration struct {
+	// Version is the schema version of the database once the
+	// migration is done.
+	Version uint32
+
+	// Description is a short description of the change, for logging.
+	Description string
+
+	Migrate func(dbTx *TxContext, logProgress func(processed uint64, total uint64)) error
+}
+
+// migrations is the registry of all the migrations, ordered by their
+// version. The version of every migration must be exactly one more
+// than the version of the one before it, and the version of the first
+// migration must be baselineSchemaVersion+1.
+//
+// To change the format of the database, append a migration here.
+var migrations = []*Migration{}
+
+// progressLogInterval is the minimum time between two progress
+// logs of a migration.
+const progressLogInterval = 10 * time.Second
+
+// ErrNewerSchemaVersion denotes that the database has a schema
+// version that is newer than the current one, that is, it was
+// written by a newer version of kaspad.
+var ErrNewerSchemaVersion = errors.New("database schema version is newer " +
+	"than the supported version")
+
+// MigrateSchema brings the database to CurrentSchemaVersion by running
+// all the pending migrations in order. Every migration runs in a
+// transaction of its own, together with the update of the stored
+// schema version, so that an interrupted migration is run again from
+// its start the next time. It returns ErrNewerSchemaVersion if the
+// database has a newer schema version, in which case it must not be
+// used.
+//
+// If dryRun is true, every pending migration runs and is then rolled
+// back, so nothing is changed. Note that since each migration is
+// rolled back, migrations after the first one run on the data as it
+// was before the migrations that precede them, rather than after.
+func (ctx *DatabaseContext) MigrateSchema(dryRun bool) error {
+	err := validateMigrations(migrations)
+	if err != nil {
+		return err
+	}
+
+	version, isStored, err := ctx.schemaVersion()
+	if err != nil {
+		return err
+	}
+	currentVersion := CurrentSchemaVersion()
+	if version > currentVersion {
+		return errors.Wrapf(ErrNewerSchemaVersion, "the database has schema version %d, "+
+			"but this version of kaspad supports up to version %d", version, currentVersion)
+	}
+
+	pendingMigrations := migrations[len(migrations)-int(currentVersion-version):]
+	if len(pendingMigrations) == 0 {
+		if dryRun {
+			log.Infof("The database is at schema version %d. No migrations are pending", version)
+			return nil
+		}
+		if !isStored {
+			return StoreSchemaVersion(ctx, version)
+		}
+		return nil
+	}
+
+	log.Infof("Migrating the database from schema version %d to %d", version, currentVersion)
+	for i, migration := range pendingMigrations {
+		log.Infof("Running migration %d/%d to schema version %d: %s",
+			i+1, len(pendingMigrations), migration.Version, migration.Description)
+		start := time.Now()
+		err := ctx.runMigration(migration, dryRun)
+		if err != nil {
+			return errors.Wrapf(err, "migration to schema version %d failed", migration.Version)
+		}
+		log.Infof("Migration to schema version %d finished in %s",
+			migration.Version, time.Since(start).Round(time.Millisecond))
+	}
+	if dryRun {
+		log.Infof("Dry run done. All %d pending migrations succeeded and were "+
+			"rolled back", len(pendingMigrations))
+	}
+	return nil
+}
+
+func (ctx *DatabaseContext) runMigration(migration *Migration, dryRun bool) error {
+	dbTx, err := ctx.NewTx()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	lastLog := time.Now()
+	logProgress := func(processed uint64, total uint64) {
+		if time.Since(lastLog) < progressLogInterval {
+			return
+		}
+		lastLog = time.Now()
+		if total == 0 {
+			log.Infof("Migration to schema version %d: processed %d",
+				migration.Version, processed)
+			return
+		}
+		log.Infof("Migration to schema version %d: processed %d/%d (%.1f%%)",
+			migration.Version, processed, total, float64(processed)*100/float64(total))
+	}
+
+	err = migration.Migrate(dbTx, logProgress)
+	if err != nil {
+		return err
+	}
+	err = StoreSchemaVersion(dbTx, migration.Version)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return dbTx.Rollback()
+	}
+	return dbTx.Commit()
+}
+
+// validateMigrations makes sure that the given migrations are ordered
+// as described in the documentation of the migrations registry.
+func validateMigrations(migrations []*Migration) error {
+	expectedVersion := uint32(baselineSchemaVersion + 1)
+	for _, migration := range migrations {
+		if migration.Version != expectedVersion {
+			return errors.Errorf("migration '%s' has schema version %d, "+
+				"but version %d was expected", migration.Description,
+				migration.Version, expectedVersion)
+		}
+		expectedVersion++
+	}
+	return nil
+}
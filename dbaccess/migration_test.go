@@ -0,0 +1,241 @@
+package dbaccess
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+)
+
+var migrationTestBucket = database.MakeBucket([]byte("migration-test"))
+
+// setMigrationsForTest replaces the migrations registry with
+// migrations that each put a key of their version, and returns
+// a function that restores the original registry.
+func setMigrationsForTest(failingVersion uint32) (restore func()) {
+	originalMigrations := migrations
+	migrations = nil
+	for version := uint32(baselineSchemaVersion + 1); version <= baselineSchemaVersion+2; version++ {
+		version := version
+		migrations = append(migrations, &Migration{
+			Version:     version,
+			Description: "test migration",
+			Migrate: func(dbTx *TxContext, logProgress func(processed uint64, total uint64)) error {
+				if version == failingVersion {
+					return errors.New("test migration failure")
+				}
+				logProgress(1, 1)
+				accessor, err := dbTx.accessor()
+				if err != nil {
+					return err
+				}
+				return accessor.Put(migrationTestBucket.Key([]byte{byte(version)}), []byte{})
+			},
+		})
+	}
+	return func() {
+		migrations = originalMigrations
+	}
+}
+
+// prepareBaselineDatabaseForTest returns a database that has a DAG state
+// but no stored schema version, as databases created before the schema
+// version was stored do.
+func prepareBaselineDatabaseForTest(t *testing.T) *DatabaseContext {
+	databaseContext := NewInMemory()
+	err := StoreDAGState(databaseContext, []byte("dag-state"))
+	if err != nil {
+		t.Fatalf("StoreDAGState unexpectedly failed: %s", err)
+	}
+	err = databaseContext.db.Delete(schemaVersionKey)
+	if err != nil {
+		t.Fatalf("Delete unexpectedly failed: %s", err)
+	}
+	return databaseContext
+}
+
+func hasMigrationTestKey(t *testing.T, databaseContext *DatabaseContext, version uint32) bool {
+	exists, err := databaseContext.db.Has(migrationTestBucket.Key([]byte{byte(version)}))
+	if err != nil {
+		t.Fatalf("Has unexpectedly failed: %s", err)
+	}
+	return exists
+}
+
+func TestMigrateSchemaOfEmptyDatabase(t *testing.T) {
+	restore := setMigrationsForTest(0)
+	defer restore()
+
+	databaseContext := NewInMemory()
+	defer databaseContext.Close()
+
+	err := databaseContext.MigrateSchema(false)
+	if err != nil {
+		t.Fatalf("MigrateSchema unexpectedly failed: %s", err)
+	}
+
+	// An empty database needs no migrations
+	version, err := FetchSchemaVersion(databaseContext)
+	if err != nil {
+		t.Fatalf("FetchSchemaVersion unexpectedly failed: %s", err)
+	}
+	if version != CurrentSchemaVersion() {
+		t.Fatalf("unexpected schema version. Want: %d, got: %d",
+			CurrentSchemaVersion(), version)
+	}
+	if hasMigrationTestKey(t, databaseContext, baselineSchemaVersion+1) {
+		t.Fatalf("migration unexpectedly ran on an empty database")
+	}
+}
+
+// TestOpenStoresSchemaVersion makes sure that opening a new database
+// stores the current schema version in it, and that opening a database
+// created before the schema version was stored doesn't.
+func TestOpenStoresSchemaVersion(t *testing.T) {
+	for _, dbType := range []string{FFLDBType, FFBoltDBType} {
+		path, err := ioutil.TempDir("", "TestOpenStoresSchemaVersion")
+		if err != nil {
+			t.Fatalf("%s: TempDir unexpectedly failed: %s", dbType, err)
+		}
+		defer os.RemoveAll(path)
+
+		databaseContext, err := Open(dbType, path)
+		if err != nil {
+			t.Fatalf("%s: Open unexpectedly failed: %s", dbType, err)
+		}
+		version, err := FetchSchemaVersion(databaseContext)
+		if err != nil {
+			t.Fatalf("%s: FetchSchemaVersion unexpectedly failed: %s", dbType, err)
+		}
+		if version != CurrentSchemaVersion() {
+			t.Fatalf("%s: unexpected schema version. Want: %d, got: %d",
+				dbType, CurrentSchemaVersion(), version)
+		}
+
+		// Make the database look like one created before the
+		// schema version was stored, and reopen it
+		err = StoreDAGState(databaseContext, []byte("dag-state"))
+		if err != nil {
+			t.Fatalf("%s: StoreDAGState unexpectedly failed: %s", dbType, err)
+		}
+		err = databaseContext.db.Delete(schemaVersionKey)
+		if err != nil {
+			t.Fatalf("%s: Delete unexpectedly failed: %s", dbType, err)
+		}
+		err = databaseContext.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly failed: %s", dbType, err)
+		}
+		databaseContext, err = Open(dbType, path)
+		if err != nil {
+			t.Fatalf("%s: Open unexpectedly failed: %s", dbType, err)
+		}
+		_, err = FetchSchemaVersion(databaseContext)
+		if !database.IsNotFoundError(err) {
+			t.Fatalf("%s: expected the schema version of an existing database "+
+				"to stay missing, but got: %v", dbType, err)
+		}
+		err = databaseContext.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly failed: %s", dbType, err)
+		}
+	}
+}
+
+func TestMigrateSchema(t *testing.T) {
+	restore := setMigrationsForTest(0)
+	defer restore()
+
+	databaseContext := prepareBaselineDatabaseForTest(t)
+	defer databaseContext.Close()
+
+	// A dry run must not change anything
+	err := databaseContext.MigrateSchema(true)
+	if err != nil {
+		t.Fatalf("MigrateSchema unexpectedly failed: %s", err)
+	}
+	_, err = FetchSchemaVersion(databaseContext)
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("expected the schema version to be missing after a dry run, "+
+			"but got: %v", err)
+	}
+	for version := uint32(baselineSchemaVersion + 1); version <= CurrentSchemaVersion(); version++ {
+		if hasMigrationTestKey(t, databaseContext, version) {
+			t.Fatalf("migration to version %d unexpectedly wasn't rolled back", version)
+		}
+	}
+
+	err = databaseContext.MigrateSchema(false)
+	if err != nil {
+		t.Fatalf("MigrateSchema unexpectedly failed: %s", err)
+	}
+	version, err := FetchSchemaVersion(databaseContext)
+	if err != nil {
+		t.Fatalf("FetchSchemaVersion unexpectedly failed: %s", err)
+	}
+	if version != CurrentSchemaVersion() {
+		t.Fatalf("unexpected schema version. Want: %d, got: %d",
+			CurrentSchemaVersion(), version)
+	}
+	for version := uint32(baselineSchemaVersion + 1); version <= CurrentSchemaVersion(); version++ {
+		if !hasMigrationTestKey(t, databaseContext, version) {
+			t.Fatalf("migration to version %d unexpectedly didn't run", version)
+		}
+	}
+}
+
+func TestMigrateSchemaFailure(t *testing.T) {
+	restore := setMigrationsForTest(baselineSchemaVersion + 2)
+	defer restore()
+
+	databaseContext := prepareBaselineDatabaseForTest(t)
+	defer databaseContext.Close()
+
+	err := databaseContext.MigrateSchema(false)
+	if err == nil {
+		t.Fatalf("MigrateSchema unexpectedly succeeded")
+	}
+
+	// The migrations before the failing one stay committed
+	version, err := FetchSchemaVersion(databaseContext)
+	if err != nil {
+		t.Fatalf("FetchSchemaVersion unexpectedly failed: %s", err)
+	}
+	if version != baselineSchemaVersion+1 {
+		t.Fatalf("unexpected schema version. Want: %d, got: %d",
+			baselineSchemaVersion+1, version)
+	}
+}
+
+func TestMigrateSchemaOfNewerDatabase(t *testing.T) {
+	databaseContext := NewInMemory()
+	defer databaseContext.Close()
+
+	err := StoreSchemaVersion(databaseContext, CurrentSchemaVersion()+1)
+	if err != nil {
+		t.Fatalf("StoreSchemaVersion unexpectedly failed: %s", err)
+	}
+	err = databaseContext.MigrateSchema(false)
+	if !errors.Is(err, ErrNewerSchemaVersion) {
+		t.Fatalf("expected MigrateSchema to return ErrNewerSchemaVersion, "+
+			"but got: %v", err)
+	}
+}
+
+func TestValidateMigrations(t *testing.T) {
+	err := validateMigrations(migrations)
+	if err != nil {
+		t.Fatalf("the migrations registry is invalid: %s", err)
+	}
+
+	outOfOrderMigrations := []*Migration{
+		{Version: baselineSchemaVersion + 2},
+		{Version: baselineSchemaVersion + 1},
+	}
+	err = validateMigrations(outOfOrderMigrations)
+	if err == nil {
+		t.Fatalf("validateMigrations of out of order migrations unexpectedly succeeded")
+	}
+}
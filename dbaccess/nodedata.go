@@ -0,0 +1,74 @@
+package dbaccess
+
+import "github.com/kaspanet/kaspad/database"
+
+var (
+	// nodeDataKeys and nodeDataBuckets hold the data of the node
+	// itself, rather than data derived from the blocks, so it can't
+	// be rebuilt by reprocessing the blocks.
+	nodeDataKeys    = []*database.Key{peersKey, peersBucketingKeyKey}
+	nodeDataBuckets = []*database.Bucket{peerAddressesBucket, feeDeltaBucket}
+)
+
+// CopyNodeData copies the data of the node itself, such as the known
+// peer addresses and the fee deltas of prioritised transactions, from
+// sourceContext to targetContext. This is the data that's lost if a
+// database is rebuilt by reprocessing its blocks.
+func CopyNodeData(sourceContext Context, targetContext Context) error {
+	sourceAccessor, err := sourceContext.accessor()
+	if err != nil {
+		return err
+	}
+	targetAccessor, err := targetContext.accessor()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range nodeDataKeys {
+		value, err := sourceAccessor.Get(key)
+		if IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = targetAccessor.Put(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, bucket := range nodeDataBuckets {
+		err := copyBucket(sourceAccessor, targetAccessor, bucket)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyBucket(sourceAccessor database.DataAccessor, targetAccessor database.DataAccessor,
+	bucket *database.Bucket) error {
+
+	cursor, err := sourceAccessor.Cursor(bucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	for cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		err = targetAccessor.Put(key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
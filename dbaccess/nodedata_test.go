@@ -0,0 +1,68 @@
+package dbaccess
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+func TestCopyNodeData(t *testing.T) {
+	sourceContext := NewInMemory()
+	defer sourceContext.Close()
+	targetContext := NewInMemory()
+	defer targetContext.Close()
+
+	err := StorePeersBucketingKey(sourceContext, []byte("bucketing-key"))
+	if err != nil {
+		t.Fatalf("StorePeersBucketingKey unexpectedly failed: %s", err)
+	}
+	err = StorePeerAddress(sourceContext, []byte("address-key"), []byte("address"))
+	if err != nil {
+		t.Fatalf("StorePeerAddress unexpectedly failed: %s", err)
+	}
+	feeDeltas := map[daghash.TxID]int64{{1}: 100, {2}: -200}
+	for txID, feeDelta := range feeDeltas {
+		txID := txID
+		err = StoreFeeDelta(sourceContext, &txID, feeDelta)
+		if err != nil {
+			t.Fatalf("StoreFeeDelta unexpectedly failed: %s", err)
+		}
+	}
+	// Data derived from the blocks must not be copied
+	err = StoreDAGState(sourceContext, []byte("dag-state"))
+	if err != nil {
+		t.Fatalf("StoreDAGState unexpectedly failed: %s", err)
+	}
+
+	err = CopyNodeData(sourceContext, targetContext)
+	if err != nil {
+		t.Fatalf("CopyNodeData unexpectedly failed: %s", err)
+	}
+
+	bucketingKey, err := FetchPeersBucketingKey(targetContext)
+	if err != nil {
+		t.Fatalf("FetchPeersBucketingKey unexpectedly failed: %s", err)
+	}
+	if string(bucketingKey) != "bucketing-key" {
+		t.Fatalf("unexpected bucketing key %q", bucketingKey)
+	}
+	address, err := targetContext.db.Get(peerAddressKey([]byte("address-key")))
+	if err != nil {
+		t.Fatalf("Get unexpectedly failed: %s", err)
+	}
+	if string(address) != "address" {
+		t.Fatalf("unexpected peer address %q", address)
+	}
+	copiedFeeDeltas, err := FetchFeeDeltas(targetContext)
+	if err != nil {
+		t.Fatalf("FetchFeeDeltas unexpectedly failed: %s", err)
+	}
+	if !reflect.DeepEqual(copiedFeeDeltas, feeDeltas) {
+		t.Fatalf("unexpected fee deltas. Want: %v, got: %v", feeDeltas, copiedFeeDeltas)
+	}
+	_, err = FetchDAGState(targetContext)
+	if !IsNotFoundError(err) {
+		t.Fatalf("expected the DAG state not to be copied, but got: %v", err)
+	}
+}
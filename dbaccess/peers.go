@@ -3,24 +3,82 @@ package dbaccess
 import "github.com/kaspanet/kaspad/database"
 
 var (
+	// peersKey is where the peers state used to be stored as a
+	// single blob, before addresses were stored separately
 	peersKey = database.MakeBucket().Key([]byte("peers"))
+
+	peersBucketingKeyKey = database.MakeBucket().Key([]byte("peers-bucketing-key"))
+	peerAddressesBucket  = database.MakeBucket([]byte("peer-addresses"))
 )
 
-// StorePeersState stores the peers state in the database.
-func StorePeersState(context Context, peersState []byte) error {
+func peerAddressKey(addressKey []byte) *database.Key {
+	return peerAddressesBucket.Key(addressKey)
+}
+
+// FetchPeersState retrieves the legacy peers state blob from the database.
+// Returns ErrNotFound if the state is missing from the database.
+func FetchPeersState(context Context) ([]byte, error) {
+	accessor, err := context.accessor()
+	if err != nil {
+		return nil, err
+	}
+	return accessor.Get(peersKey)
+}
+
+// RemovePeersState removes the legacy peers state blob from the database.
+func RemovePeersState(context Context) error {
 	accessor, err := context.accessor()
 	if err != nil {
 		return err
 	}
-	return accessor.Put(peersKey, peersState)
+	return accessor.Delete(peersKey)
 }
 
-// FetchPeersState retrieves the peers state from the database.
-// Returns ErrNotFound if the state is missing from the database.
-func FetchPeersState(context Context) ([]byte, error) {
+// StorePeersBucketingKey stores the secret key the address manager uses
+// to assign addresses to buckets.
+func StorePeersBucketingKey(context Context, bucketingKey []byte) error {
+	accessor, err := context.accessor()
+	if err != nil {
+		return err
+	}
+	return accessor.Put(peersBucketingKeyKey, bucketingKey)
+}
+
+// FetchPeersBucketingKey retrieves the secret key the address manager uses
+// to assign addresses to buckets.
+// Returns ErrNotFound if the key is missing from the database.
+func FetchPeersBucketingKey(context Context) ([]byte, error) {
 	accessor, err := context.accessor()
 	if err != nil {
 		return nil, err
 	}
-	return accessor.Get(peersKey)
+	return accessor.Get(peersBucketingKeyKey)
+}
+
+// StorePeerAddress stores a single known peer address by its address key.
+func StorePeerAddress(context Context, addressKey []byte, serializedAddress []byte) error {
+	accessor, err := context.accessor()
+	if err != nil {
+		return err
+	}
+	return accessor.Put(peerAddressKey(addressKey), serializedAddress)
+}
+
+// RemovePeerAddress removes a single known peer address by its address key.
+func RemovePeerAddress(context Context, addressKey []byte) error {
+	accessor, err := context.accessor()
+	if err != nil {
+		return err
+	}
+	return accessor.Delete(peerAddressKey(addressKey))
+}
+
+// PeerAddressesCursor opens a cursor over all the known
+// peer addresses.
+func PeerAddressesCursor(context Context) (database.Cursor, error) {
+	accessor, err := context.accessor()
+	if err != nil {
+		return nil, err
+	}
+	return accessor.Cursor(peerAddressesBucket)
 }
@@ -0,0 +1,96 @@
+package dbaccess
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/database"
+	"github.com/pkg/errors"
+)
+
+var (
+	schemaVersionKey = database.MakeBucket().Key([]byte("schema-version"))
+)
+
+// baselineSchemaVersion is the schema version of databases that were
+// created before the schema version was stored in the database.
+const baselineSchemaVersion = 1
+
+// StoreSchemaVersion stores the schema version of the database.
+func StoreSchemaVersion(context Context, version uint32) error {
+	accessor, err := context.accessor()
+	if err != nil {
+		return err
+	}
+	var serializedVersion [4]byte
+	binary.LittleEndian.PutUint32(serializedVersion[:], version)
+	return accessor.Put(schemaVersionKey, serializedVersion[:])
+}
+
+// FetchSchemaVersion retrieves the schema version of the database.
+// Returns ErrNotFound if the version is missing from the database.
+func FetchSchemaVersion(context Context) (uint32, error) {
+	accessor, err := context.accessor()
+	if err != nil {
+		return 0, err
+	}
+	serializedVersion, err := accessor.Get(schemaVersionKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(serializedVersion) != 4 {
+		return 0, errors.Wrapf(database.ErrCorruption, "unexpected schema "+
+			"version length %d", len(serializedVersion))
+	}
+	return binary.LittleEndian.Uint32(serializedVersion), nil
+}
+
+// CurrentSchemaVersion returns the schema version that this version
+// of kaspad writes, which is the version of the last registered
+// migration.
+func CurrentSchemaVersion() uint32 {
+	if len(migrations) == 0 {
+		return baselineSchemaVersion
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// schemaVersion returns the schema version of the database. A
+// database without a stored version is either empty, in which case
+// its version is the current one, or was created before the version
+// was stored, in which case its version is baselineSchemaVersion.
+func (ctx *DatabaseContext) schemaVersion() (version uint32, isStored bool, err error) {
+	version, err = FetchSchemaVersion(ctx)
+	if err == nil {
+		return version, true, nil
+	}
+	if !database.IsNotFoundError(err) {
+		return 0, false, err
+	}
+
+	hasDAGState, err := ctx.db.Has(dagStateKey)
+	if err != nil {
+		return 0, false, err
+	}
+	if !hasDAGState {
+		return CurrentSchemaVersion(), false, nil
+	}
+	return baselineSchemaVersion, false, nil
+}
+
+// initializeSchemaVersion stores the current schema version if the
+// database is empty.
+func (ctx *DatabaseContext) initializeSchemaVersion() error {
+	_, err := FetchSchemaVersion(ctx)
+	if !database.IsNotFoundError(err) {
+		return err
+	}
+
+	hasDAGState, err := ctx.db.Has(dagStateKey)
+	if err != nil {
+		return err
+	}
+	if hasDAGState {
+		return nil
+	}
+	return StoreSchemaVersion(ctx, CurrentSchemaVersion())
+}
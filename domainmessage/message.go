@@ -47,6 +47,8 @@ const (
 	CmdRequestNextIBDBlocks
 	CmdDoneIBDBlocks
 	CmdTransactionNotFound
+	CmdRequestIBDHeaders
+	CmdIBDHeaders
 )
 
 // MessageCommandToString maps all MessageCommands to their string representation
@@ -72,6 +74,8 @@ var MessageCommandToString = map[MessageCommand]string{
 	CmdRequestNextIBDBlocks: "RequestNextIBDBlocks",
 	CmdDoneIBDBlocks:        "DoneIBDBlocks",
 	CmdTransactionNotFound:  "TransactionNotFound",
+	CmdRequestIBDHeaders:    "RequestIBDHeaders",
+	CmdIBDHeaders:           "IBDHeaders",
 }
 
 // Message is an interface that describes a kaspa message. A type that
@@ -0,0 +1,28 @@
+package domainmessage
+
+// MaxBlockHeadersPerMsg is the maximum number of block headers allowed
+// per message.
+const MaxBlockHeadersPerMsg = 2000
+
+// MsgIBDHeaders implements the Message interface and represents a kaspa
+// IBDHeaders message. It is used to deliver block headers, ordered by blue
+// score, in response to a RequestIBDHeaders message (MsgRequestIBDHeaders).
+// A peer that can't provide the requested headers responds with no headers.
+type MsgIBDHeaders struct {
+	baseMessage
+	Headers []*BlockHeader
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgIBDHeaders) Command() MessageCommand {
+	return CmdIBDHeaders
+}
+
+// NewMsgIBDHeaders returns a new kaspa IBDHeaders message that conforms to
+// the Message interface. See MsgIBDHeaders for details.
+func NewMsgIBDHeaders(headers []*BlockHeader) *MsgIBDHeaders {
+	return &MsgIBDHeaders{
+		Headers: headers,
+	}
+}
@@ -0,0 +1,25 @@
+package domainmessage
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestIBDHeaders tests the MsgIBDHeaders API.
+func TestIBDHeaders(t *testing.T) {
+	headers := []*BlockHeader{&blockOne.Header}
+
+	// Ensure we get the same data back out.
+	msg := NewMsgIBDHeaders(headers)
+	if !reflect.DeepEqual(msg.Headers, headers) {
+		t.Errorf("NewMsgIBDHeaders: wrong headers - got %v, want %v",
+			msg.Headers, headers)
+	}
+
+	// Ensure the command is expected value.
+	wantCmd := MessageCommand(22)
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgIBDHeaders: wrong command - got %v want %v",
+			cmd, wantCmd)
+	}
+}
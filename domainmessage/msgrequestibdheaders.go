@@ -0,0 +1,30 @@
+package domainmessage
+
+import (
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// MsgRequestIBDHeaders implements the Message interface and represents a kaspa
+// RequestIBDHeaders message. It is used to request the headers of the blocks
+// starting after the low hash and until the high hash, such as the headers of
+// the blocks in the past of the assume-valid block.
+type MsgRequestIBDHeaders struct {
+	baseMessage
+	LowHash  *daghash.Hash
+	HighHash *daghash.Hash
+}
+
+// Command returns the protocol command string for the message. This is part
+// of the Message interface implementation.
+func (msg *MsgRequestIBDHeaders) Command() MessageCommand {
+	return CmdRequestIBDHeaders
+}
+
+// NewMsgRequestIBDHeaders returns a new kaspa RequestIBDHeaders message that
+// conforms to the Message interface using the passed parameters.
+func NewMsgRequestIBDHeaders(lowHash, highHash *daghash.Hash) *MsgRequestIBDHeaders {
+	return &MsgRequestIBDHeaders{
+		LowHash:  lowHash,
+		HighHash: highHash,
+	}
+}
@@ -0,0 +1,31 @@
+package domainmessage
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// TestRequestIBDHeaders tests the MsgRequestIBDHeaders API.
+func TestRequestIBDHeaders(t *testing.T) {
+	lowHash := &daghash.Hash{1}
+	highHash := &daghash.Hash{2}
+
+	// Ensure we get the same data back out.
+	msg := NewMsgRequestIBDHeaders(lowHash, highHash)
+	if !msg.LowHash.IsEqual(lowHash) {
+		t.Errorf("NewMsgRequestIBDHeaders: wrong low hash - got %v, want %v",
+			msg.LowHash, lowHash)
+	}
+	if !msg.HighHash.IsEqual(highHash) {
+		t.Errorf("NewMsgRequestIBDHeaders: wrong high hash - got %v, want %v",
+			msg.HighHash, highHash)
+	}
+
+	// Ensure the command is expected value.
+	wantCmd := MessageCommand(21)
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgRequestIBDHeaders: wrong command - got %v want %v",
+			cmd, wantCmd)
+	}
+}
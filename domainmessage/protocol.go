@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
 )
 
 // XXX pedro: we will probably need to bump this.
@@ -114,7 +117,7 @@ const (
 )
 
 // bnStrings is a map of kaspa networks back to their constant names for
-// pretty printing.
+// pretty printing. Custom networks are added to it by RegisterKaspaNet.
 var bnStrings = map[KaspaNet]string{
 	Mainnet: "Mainnet",
 	Testnet: "Testnet",
@@ -123,8 +126,32 @@ var bnStrings = map[KaspaNet]string{
 	Devnet:  "Devnet",
 }
 
+// bnStringsLock protects bnStrings.
+var bnStringsLock sync.RWMutex
+
+// RegisterKaspaNet registers the magic bytes of a custom network under the
+// given name, so that different custom networks can't use the same magic.
+// Registering a magic again under the same name does nothing, and
+// registering it under a different name returns an error.
+func RegisterKaspaNet(net KaspaNet, name string) error {
+	bnStringsLock.Lock()
+	defer bnStringsLock.Unlock()
+
+	if existingName, ok := bnStrings[net]; ok {
+		if existingName != name {
+			return errors.Errorf("kaspa network magic 0x%08x is already used by %s", uint32(net), existingName)
+		}
+		return nil
+	}
+	bnStrings[net] = name
+	return nil
+}
+
 // String returns the KaspaNet in human-readable form.
 func (n KaspaNet) String() string {
+	bnStringsLock.RLock()
+	defer bnStringsLock.RUnlock()
+
 	if s, ok := bnStrings[n]; ok {
 		return s
 	}
@@ -56,3 +56,32 @@ func TestKaspaNetStringer(t *testing.T) {
 		}
 	}
 }
+
+// TestRegisterKaspaNet tests registering the magic bytes of custom networks.
+func TestRegisterKaspaNet(t *testing.T) {
+	const customNet KaspaNet = 0x12345678
+
+	err := RegisterKaspaNet(customNet, "Custom")
+	if err != nil {
+		t.Fatalf("RegisterKaspaNet: %s", err)
+	}
+	if customNet.String() != "Custom" {
+		t.Errorf("expected the custom network to be named Custom but got %s", customNet)
+	}
+
+	// Registering the same network again is allowed.
+	err = RegisterKaspaNet(customNet, "Custom")
+	if err != nil {
+		t.Errorf("RegisterKaspaNet: unexpected error when registering a network again: %s", err)
+	}
+
+	// A magic can't be used by two networks.
+	err = RegisterKaspaNet(customNet, "Other")
+	if err == nil {
+		t.Errorf("RegisterKaspaNet: expected an error when registering a used magic under a different name")
+	}
+	err = RegisterKaspaNet(Simnet, "Other")
+	if err == nil {
+		t.Errorf("RegisterKaspaNet: expected an error when registering the magic of a default network")
+	}
+}
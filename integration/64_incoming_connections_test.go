@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kaspanet/kaspad/integration/localnet"
 	"github.com/kaspanet/kaspad/util/locks"
 
 	"github.com/kaspanet/kaspad/domainmessage"
@@ -14,13 +15,13 @@ import (
 func Test64IncomingConnections(t *testing.T) {
 	// Much more than 64 hosts creates a risk of running out of available file descriptors for leveldb
 	const numBullies = 64
-	harnessesParams := make([]*harnessParams, numBullies+1)
+	harnessesParams := make([]*localnet.Params, numBullies+1)
 	for i := 0; i < numBullies+1; i++ {
-		harnessesParams[i] = &harnessParams{
-			p2pAddress:              fmt.Sprintf("127.0.0.1:%d", 12345+i),
-			rpcAddress:              fmt.Sprintf("127.0.0.1:%d", 22345+i),
-			miningAddress:           miningAddress1,
-			miningAddressPrivateKey: miningAddress1PrivateKey,
+		harnessesParams[i] = &localnet.Params{
+			P2PAddress:              fmt.Sprintf("127.0.0.1:%d", 12345+i),
+			RPCAddress:              fmt.Sprintf("127.0.0.1:%d", 22345+i),
+			MiningAddress:           miningAddress1,
+			MiningAddressPrivateKey: miningAddress1PrivateKey,
 		}
 	}
 
@@ -36,19 +37,14 @@ func Test64IncomingConnections(t *testing.T) {
 	blockAddedWG := sync.WaitGroup{}
 	blockAddedWG.Add(numBullies)
 	for _, bully := range bullies {
-		err := bully.rpcClient.NotifyBlocks()
-		if err != nil {
-			t.Fatalf("Error from NotifyBlocks: %+v", err)
-		}
-
 		blockAdded := false
-		bully.rpcClient.onBlockAdded = func(header *domainmessage.BlockHeader) {
+		setOnBlockAddedHandler(t, bully, func(header *domainmessage.BlockHeader) {
 			if blockAdded {
 				t.Fatalf("Single bully reported block added twice")
 			}
 			blockAdded = true
 			blockAddedWG.Done()
-		}
+		})
 	}
 
 	_ = mineNextBlock(t, victim)
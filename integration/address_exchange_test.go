@@ -9,7 +9,7 @@ func TestAddressExchange(t *testing.T) {
 	defer teardown()
 
 	testAddress := "1.2.3.4:6789"
-	err := appHarness1.app.AddressManager().AddAddressByIP(testAddress, nil)
+	err := appHarness1.App().AddressManager().AddAddressByIP(testAddress, nil)
 	if err != nil {
 		t.Fatalf("Error adding address to addressManager: %+v", err)
 	}
@@ -17,7 +17,7 @@ func TestAddressExchange(t *testing.T) {
 	connect(t, appHarness1, appHarness2)
 	connect(t, appHarness2, appHarness3)
 
-	peerAddresses, err := appHarness3.rpcClient.GetPeerAddresses()
+	peerAddresses, err := appHarness3.RPCClient().GetPeerAddresses()
 	if err != nil {
 		t.Fatalf("Error getting peer addresses: %+v", err)
 	}
@@ -1,12 +1,10 @@
 package integration
 
 import (
-	"io/ioutil"
-	"testing"
 	"time"
 
 	"github.com/kaspanet/kaspad/config"
-	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/integration/localnet"
 )
 
 const (
@@ -18,9 +16,6 @@ const (
 	rpcAddress2 = "127.0.0.1:12346"
 	rpcAddress3 = "127.0.0.1:12347"
 
-	rpcUser = "user"
-	rpcPass = "pass"
-
 	miningAddress1           = "kaspasim:qzmdkk8ay8sgvp8cnwts8gtdylz9j7572slwdh85qv"
 	miningAddress1PrivateKey = "be9e9884f03e687166479e22d21b064db7903d69b5a46878aae66521c01a6094"
 
@@ -33,33 +28,15 @@ const (
 	defaultTimeout = 10 * time.Second
 )
 
-func setConfig(t *testing.T, harness *appHarness) {
-	harness.config = commonConfig()
-	harness.config.DataDir = randomDirectory(t)
-	harness.config.Listeners = []string{harness.p2pAddress}
-	harness.config.RPCListeners = []string{harness.rpcAddress}
-}
-
-func commonConfig() *config.Config {
-	commonConfig := config.DefaultConfig()
-
-	*commonConfig.ActiveNetParams = dagconfig.SimnetParams // Copy so that we can make changes safely
-	commonConfig.ActiveNetParams.BlockCoinbaseMaturity = 10
-	commonConfig.TargetOutboundPeers = 0
-	commonConfig.DisableDNSSeed = true
-	commonConfig.RPCUser = rpcUser
-	commonConfig.RPCPass = rpcPass
-	commonConfig.DisableTLS = true
-	commonConfig.Simnet = true
-
-	return commonConfig
-}
-
-func randomDirectory(t *testing.T) string {
-	dir, err := ioutil.TempDir("", "integration-test")
-	if err != nil {
-		t.Fatalf("Error creating temporary directory for test: %+v", err)
+// withCommonConfig returns a copy of params that applies the configuration
+// common to all the integration tests before its own configuration override
+func withCommonConfig(params *localnet.Params) *localnet.Params {
+	paramsCopy := *params
+	paramsCopy.OverrideConfig = func(cfg *config.Config) {
+		cfg.ActiveNetParams.BlockCoinbaseMaturity = 10
+		if params.OverrideConfig != nil {
+			params.OverrideConfig(cfg)
+		}
 	}
-
-	return dir
+	return &paramsCopy
 }
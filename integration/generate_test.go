@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/integration/localnet"
+	"github.com/kaspanet/kaspad/util"
+)
+
+func TestIntegrationGenerate(t *testing.T) {
+	harness, teardown := setupHarness(t, &localnet.Params{
+		P2PAddress:              p2pAddress1,
+		RPCAddress:              rpcAddress1,
+		MiningAddress:           miningAddress1,
+		MiningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const numBlocks = 5
+	blockHashes, err := harness.RPCClient().Generate(numBlocks)
+	if err != nil {
+		t.Fatalf("Error generating blocks: %s", err)
+	}
+	if len(blockHashes) != numBlocks {
+		t.Fatalf("Expected %d block hashes, but got %d", numBlocks, len(blockHashes))
+	}
+
+	miningAddress, err := util.DecodeAddress(harness.MiningAddress(), harness.Config().NetParams().Prefix)
+	if err != nil {
+		t.Fatalf("Error decoding mining address: %s", err)
+	}
+	addressBlockHashes, err := harness.RPCClient().GenerateToAddress(1, miningAddress)
+	if err != nil {
+		t.Fatalf("Error generating block to address: %s", err)
+	}
+	blockHashes = append(blockHashes, addressBlockHashes...)
+
+	// Every generated block should be in the DAG, and since the blocks are
+	// generated one after the other, the last one should be the selected tip
+	for _, blockHash := range blockHashes {
+		_, err := harness.RPCClient().GetBlock(blockHash, nil)
+		if err != nil {
+			t.Errorf("Error getting generated block %s: %s", blockHash, err)
+		}
+	}
+	selectedTipHash, err := harness.RPCClient().GetSelectedTipHash()
+	if err != nil {
+		t.Fatalf("Error getting selected tip hash: %s", err)
+	}
+	lastBlockHash := blockHashes[len(blockHashes)-1]
+	if !selectedTipHash.IsEqual(lastBlockHash) {
+		t.Errorf("Expected selected tip %s, but got %s", lastBlockHash, selectedTipHash)
+	}
+}
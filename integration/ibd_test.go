@@ -36,11 +36,11 @@ func TestIBD(t *testing.T) {
 	case <-locks.ReceiveFromChanWhenDone(func() { blockAddedWG.Wait() }):
 	}
 
-	tip1, err := syncer.rpcClient.GetSelectedTip()
+	tip1, err := syncer.RPCClient().GetSelectedTip()
 	if err != nil {
 		t.Fatalf("Error getting tip for syncer")
 	}
-	tip2, err := syncee.rpcClient.GetSelectedTip()
+	tip2, err := syncee.RPCClient().GetSelectedTip()
 	if err != nil {
 		t.Fatalf("Error getting tip for syncee")
 	}
@@ -48,4 +48,21 @@ func TestIBD(t *testing.T) {
 	if tip1.Hash != tip2.Hash {
 		t.Errorf("Tips of syncer: '%s' and syncee '%s' are not equal", tip1.Hash, tip2.Hash)
 	}
+
+	netStats, err := syncer.RPCClient().GetNetStats()
+	if err != nil {
+		t.Fatalf("Error getting net stats for syncer: %+v", err)
+	}
+	if netStats.IBDUpload.BytesUsed == 0 {
+		t.Errorf("Expected the syncer to account for the IBD blocks it served")
+	}
+	var ibdBlocksSent uint64
+	for _, messageStats := range netStats.MessageStats {
+		if messageStats.Command == domainmessage.CmdIBDBlock.String() {
+			ibdBlocksSent = messageStats.MessagesSent
+		}
+	}
+	if ibdBlocksSent < numBlocks {
+		t.Errorf("Expected the syncer to send at least %d IBD blocks, but got %d", numBlocks, ibdBlocksSent)
+	}
 }
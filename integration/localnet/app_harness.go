@@ -0,0 +1,187 @@
+package localnet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kaspanet/kaspad/app"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/pkg/errors"
+)
+
+// AppHarness is a kaspad node that runs in-process
+type AppHarness struct {
+	app             *app.App
+	rpcClient       *RPCClient
+	params          *Params
+	config          *config.Config
+	databaseContext *dbaccess.DatabaseContext
+	temporaryDir    string
+}
+
+// NewAppHarness starts an in-process node with the given parameters
+func NewAppHarness(params *Params) (harness *AppHarness, err error) {
+	harness = &AppHarness{
+		params: params,
+	}
+	defer func() {
+		if err != nil {
+			harness.removeTemporaryDir()
+		}
+	}()
+
+	err = harness.setConfig()
+	if err != nil {
+		return nil, err
+	}
+	if params.OverrideConfig != nil {
+		params.OverrideConfig(harness.config)
+	}
+
+	harness.databaseContext, err = openDB(harness.config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening database")
+	}
+	harness.app, err = app.New(harness.config, harness.databaseContext, make(chan struct{}))
+	if err != nil {
+		harness.databaseContext.Close()
+		return nil, errors.Wrap(err, "error creating app")
+	}
+	harness.app.Start()
+
+	harness.rpcClient, err = newRPCClient(params.RPCAddress)
+	if err != nil {
+		harness.stop()
+		return nil, errors.Wrap(err, "error getting RPC client")
+	}
+
+	return harness, nil
+}
+
+// NewAppHarnesses starts an in-process node for each of the given parameters.
+// If any of them fails to start, the ones that already started are torn down.
+func NewAppHarnesses(paramsList []*Params) ([]*AppHarness, error) {
+	harnesses := make([]*AppHarness, 0, len(paramsList))
+	for _, params := range paramsList {
+		harness, err := NewAppHarness(params)
+		if err != nil {
+			for _, startedHarness := range harnesses {
+				startedHarness.Teardown()
+			}
+			return nil, err
+		}
+		harnesses = append(harnesses, harness)
+	}
+	return harnesses, nil
+}
+
+func (harness *AppHarness) setConfig() error {
+	harness.config = config.DefaultConfig()
+	harness.config.NetworkFlags = harness.params.NetworkFlags
+	if harness.config.ActiveNetParams == nil {
+		harness.config.Simnet = true
+		harness.config.ActiveNetParams = &dagconfig.SimnetParams
+	}
+	// Copy the network parameters, so that the configuration overrides
+	// don't change the parameters of the network itself
+	netParams := *harness.config.ActiveNetParams
+	harness.config.ActiveNetParams = &netParams
+
+	harness.config.DataDir = harness.params.DataDir
+	if harness.config.DataDir == "" {
+		var err error
+		harness.temporaryDir, err = ioutil.TempDir("", "localnet")
+		if err != nil {
+			return errors.Wrap(err, "error creating the data directory")
+		}
+		harness.config.DataDir = harness.temporaryDir
+	}
+
+	harness.config.Listeners = []string{harness.params.P2PAddress}
+	harness.config.RPCListeners = []string{harness.params.RPCAddress}
+	harness.config.NetLatency = harness.params.NetLatency
+	harness.config.TargetOutboundPeers = 0
+	harness.config.DisableDNSSeed = true
+	harness.config.RPCUser = RPCUser
+	harness.config.RPCPass = RPCPass
+	harness.config.DisableTLS = true
+	return nil
+}
+
+func openDB(cfg *config.Config) (*dbaccess.DatabaseContext, error) {
+	dbPath := filepath.Join(cfg.DataDir, "db")
+	return dbaccess.Open(cfg.DbType, dbPath)
+}
+
+// Teardown stops the node, and removes its data directory if it's temporary
+func (harness *AppHarness) Teardown() error {
+	harness.rpcClient.Shutdown()
+	err := harness.stop()
+	harness.removeTemporaryDir()
+	return err
+}
+
+func (harness *AppHarness) stop() error {
+	err := harness.app.Stop()
+	if err != nil {
+		return errors.Wrap(err, "error stopping app")
+	}
+
+	harness.app.WaitForShutdown()
+
+	err = harness.databaseContext.Close()
+	if err != nil {
+		return errors.Wrap(err, "error closing database context")
+	}
+	return nil
+}
+
+func (harness *AppHarness) removeTemporaryDir() {
+	if harness.temporaryDir != "" {
+		os.RemoveAll(harness.temporaryDir)
+	}
+}
+
+// P2PAddress returns the address the node listens on for P2P connections
+func (harness *AppHarness) P2PAddress() string {
+	return harness.params.P2PAddress
+}
+
+// RPCAddress returns the address the node listens on for RPC connections
+func (harness *AppHarness) RPCAddress() string {
+	return harness.params.RPCAddress
+}
+
+// RPCClient returns a client connected to the RPC server of the node
+func (harness *AppHarness) RPCClient() *RPCClient {
+	return harness.rpcClient
+}
+
+// NodeID returns the ID of the node in the P2P network
+func (harness *AppHarness) NodeID() string {
+	return harness.app.P2PNodeID().String()
+}
+
+// MiningAddress returns the mining address in the parameters of the node
+func (harness *AppHarness) MiningAddress() string {
+	return harness.params.MiningAddress
+}
+
+// MiningAddressPrivateKey returns the private key of the mining address in
+// the parameters of the node
+func (harness *AppHarness) MiningAddressPrivateKey() string {
+	return harness.params.MiningAddressPrivateKey
+}
+
+// App returns the app of the node, for direct access to its services
+func (harness *AppHarness) App() *app.App {
+	return harness.app
+}
+
+// Config returns the configuration the node runs with
+func (harness *AppHarness) Config() *config.Config {
+	return harness.config
+}
@@ -0,0 +1,89 @@
+package localnet
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// connectionPollInterval is how often the peers of nodes are polled while
+// waiting for them to connect or disconnect
+const connectionPollInterval = 10 * time.Millisecond
+
+// Connect makes outgoing connect to incoming, and waits until both of them
+// are connected to each other.
+func Connect(incoming, outgoing Node) error {
+	err := outgoing.RPCClient().ConnectNode(incoming.P2PAddress())
+	if err != nil {
+		return errors.Wrapf(err, "error connecting %s to %s", outgoing.P2PAddress(), incoming.P2PAddress())
+	}
+
+	return waitForConnection(incoming, outgoing, true)
+}
+
+// Disconnect makes outgoing disconnect from incoming, and waits until neither
+// of them is connected to the other.
+func Disconnect(incoming, outgoing Node) error {
+	err := outgoing.RPCClient().DisconnectNode(incoming.P2PAddress())
+	if err != nil {
+		return errors.Wrapf(err, "error disconnecting %s from %s", outgoing.P2PAddress(), incoming.P2PAddress())
+	}
+
+	return waitForConnection(incoming, outgoing, false)
+}
+
+func waitForConnection(node1, node2 Node, expectedConnected bool) error {
+	ticker := time.NewTicker(connectionPollInterval)
+	defer ticker.Stop()
+	timeout := time.After(DefaultTimeout)
+
+	for {
+		isConnected, err := IsConnected(node1, node2)
+		if err != nil {
+			return err
+		}
+		if isConnected == expectedConnected {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			if expectedConnected {
+				return errors.Errorf("timed out waiting for %s and %s to connect",
+					node1.P2PAddress(), node2.P2PAddress())
+			}
+			return errors.Errorf("timed out waiting for %s and %s to disconnect",
+				node1.P2PAddress(), node2.P2PAddress())
+		}
+	}
+}
+
+// IsConnected returns whether both of the given nodes are connected to each
+// other.
+func IsConnected(node1, node2 Node) (bool, error) {
+	isConnected1, err := hasPeer(node1, node2)
+	if err != nil {
+		return false, err
+	}
+	isConnected2, err := hasPeer(node2, node1)
+	if err != nil {
+		return false, err
+	}
+	return isConnected1 && isConnected2, nil
+}
+
+func hasPeer(node, peer Node) (bool, error) {
+	connectedPeerInfo, err := node.RPCClient().GetConnectedPeerInfo()
+	if err != nil {
+		return false, errors.Wrapf(err, "error getting connected peer info of %s", node.P2PAddress())
+	}
+
+	peerID := peer.NodeID()
+	for _, connectedPeer := range connectedPeerInfo {
+		if connectedPeer.ID == peerID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
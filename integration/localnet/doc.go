@@ -0,0 +1,30 @@
+/*
+Package localnet runs networks of kaspad nodes on the local machine, for
+integration tests and for reproducible scenario testing.
+
+A node runs either in-process, as an AppHarness, or as a child kaspad
+process, as a ProcessHarness. Both are controlled over RPC through the Node
+interface, so that the same scenario can run against either of them.
+
+A Network connects its nodes according to a Topology, and may be split into
+partitions that can't reach each other and healed back:
+
+	network := localnet.NewNetwork(nodes)
+	edges, err := localnet.TopologyRing.Edges(len(nodes), rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+	err = network.Connect(edges)
+	if err != nil {
+		return err
+	}
+	err = network.Partition([]int{0, 1}, []int{2, 3})
+	...
+	err = network.Heal()
+
+Network latency is simulated by setting NetLatency in the Params of a node,
+which delays every P2P message that the node receives.
+
+The kaspanet-local command runs such networks from the command line.
+*/
+package localnet
@@ -0,0 +1,31 @@
+package localnet
+
+import (
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+// MineBlock makes the node mine a block that pays to miningAddress, and
+// returns it. If miningAddress is nil, the block pays to an
+// anyone-can-spend address. The network of the node must allow the
+// generate RPCs, as simnet, regtest and devnet do.
+func MineBlock(node Node, miningAddress util.Address) (*util.Block, error) {
+	var blockHashes []*daghash.Hash
+	var err error
+	if miningAddress == nil {
+		blockHashes, err = node.RPCClient().Generate(1)
+	} else {
+		blockHashes, err = node.RPCClient().GenerateToAddress(1, miningAddress)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error generating a block on %s", node.P2PAddress())
+	}
+
+	msgBlock, err := node.RPCClient().GetBlock(blockHashes[0], nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting the generated block from %s", node.P2PAddress())
+	}
+
+	return util.NewBlock(msgBlock), nil
+}
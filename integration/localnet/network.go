@@ -0,0 +1,124 @@
+package localnet
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Network is a set of nodes that are connected to each other, and may be
+// split into partitions that can't reach each other.
+type Network struct {
+	Nodes []Node
+
+	edges             []Edge
+	disconnectedEdges []Edge
+}
+
+// NewNetwork returns a Network of the given nodes, which aren't connected
+// to each other yet.
+func NewNetwork(nodes []Node) *Network {
+	return &Network{
+		Nodes: nodes,
+	}
+}
+
+// Connect connects the nodes of the network along the given edges, and waits
+// until all of them are connected.
+func (network *Network) Connect(edges []Edge) error {
+	for _, edge := range edges {
+		err := network.checkEdge(edge)
+		if err != nil {
+			return err
+		}
+		err = Connect(network.Nodes[edge.Incoming], network.Nodes[edge.Outgoing])
+		if err != nil {
+			return err
+		}
+		network.edges = append(network.edges, edge)
+	}
+	return nil
+}
+
+func (network *Network) checkEdge(edge Edge) error {
+	if edge.Outgoing < 0 || edge.Outgoing >= len(network.Nodes) ||
+		edge.Incoming < 0 || edge.Incoming >= len(network.Nodes) || edge.Outgoing == edge.Incoming {
+
+		return errors.Errorf("invalid edge %d -> %d in a network of %d nodes",
+			edge.Outgoing, edge.Incoming, len(network.Nodes))
+	}
+	return nil
+}
+
+// Edges returns the edges of the network that are currently connected
+func (network *Network) Edges() []Edge {
+	return append([]Edge(nil), network.edges...)
+}
+
+// Partition splits the network into the given groups of node indexes, by
+// disconnecting every edge between nodes of different groups. The nodes that
+// aren't in any of the groups form one more group. The network must not be
+// partitioned already.
+func (network *Network) Partition(groups ...[]int) error {
+	if len(network.disconnectedEdges) > 0 {
+		return errors.New("the network is already partitioned")
+	}
+
+	nodeGroups := make(map[int]int)
+	for groupIndex, group := range groups {
+		for _, nodeIndex := range group {
+			if nodeIndex < 0 || nodeIndex >= len(network.Nodes) {
+				return errors.Errorf("invalid node %d in a network of %d nodes", nodeIndex, len(network.Nodes))
+			}
+			if _, ok := nodeGroups[nodeIndex]; ok {
+				return errors.Errorf("node %d is in more than one group", nodeIndex)
+			}
+			// Group indexes start from 1, so that the nodes that aren't
+			// in any group are in group 0
+			nodeGroups[nodeIndex] = groupIndex + 1
+		}
+	}
+
+	var keptEdges []Edge
+	for i, edge := range network.edges {
+		if nodeGroups[edge.Outgoing] == nodeGroups[edge.Incoming] {
+			keptEdges = append(keptEdges, edge)
+			continue
+		}
+		err := Disconnect(network.Nodes[edge.Incoming], network.Nodes[edge.Outgoing])
+		if err != nil {
+			// Keep track of the edges that are still connected, so that
+			// Heal reconnects only the ones that were disconnected
+			network.edges = append(keptEdges, network.edges[i:]...)
+			return err
+		}
+		network.disconnectedEdges = append(network.disconnectedEdges, edge)
+	}
+	network.edges = keptEdges
+	return nil
+}
+
+// Heal reconnects the edges that were disconnected by Partition
+func (network *Network) Heal() error {
+	for len(network.disconnectedEdges) > 0 {
+		edge := network.disconnectedEdges[0]
+		err := Connect(network.Nodes[edge.Incoming], network.Nodes[edge.Outgoing])
+		if err != nil {
+			return err
+		}
+		network.edges = append(network.edges, edge)
+		network.disconnectedEdges = network.disconnectedEdges[1:]
+	}
+	return nil
+}
+
+// Teardown tears down all the nodes of the network, and returns the first
+// error it encountered, if any.
+func (network *Network) Teardown() error {
+	var firstErr error
+	for _, node := range network.Nodes {
+		err := node.Teardown()
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
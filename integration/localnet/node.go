@@ -0,0 +1,20 @@
+package localnet
+
+// Node is a kaspad node run by this package, either in-process or as a
+// child process.
+type Node interface {
+	// P2PAddress returns the address the node listens on for P2P connections
+	P2PAddress() string
+
+	// RPCAddress returns the address the node listens on for RPC connections
+	RPCAddress() string
+
+	// RPCClient returns a client connected to the RPC server of the node
+	RPCClient() *RPCClient
+
+	// NodeID returns the ID of the node in the P2P network
+	NodeID() string
+
+	// Teardown stops the node and releases its resources
+	Teardown() error
+}
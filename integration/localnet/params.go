@@ -0,0 +1,47 @@
+package localnet
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/config"
+)
+
+const (
+	// RPCUser is the RPC username of the nodes run by this package
+	RPCUser = "user"
+
+	// RPCPass is the RPC password of the nodes run by this package
+	RPCPass = "pass"
+
+	// DefaultTimeout is how long this package waits for nodes to start,
+	// connect and disconnect
+	DefaultTimeout = 10 * time.Second
+)
+
+// Params defines a node to run
+type Params struct {
+	P2PAddress string
+	RPCAddress string
+
+	// MiningAddress and MiningAddressPrivateKey are for the use of the
+	// tests that run the node. They're optional.
+	MiningAddress           string
+	MiningAddressPrivateKey string
+
+	// DataDir is the data directory of the node. If it's empty, a
+	// temporary directory is used, and removed when the node is torn down.
+	DataDir string
+
+	// NetworkFlags selects the network of the node. If no network is
+	// selected, the node runs on simnet.
+	NetworkFlags config.NetworkFlags
+
+	// NetLatency is the simulated network latency by which every P2P
+	// message that the node receives is delayed.
+	NetLatency time.Duration
+
+	// OverrideConfig, if set, is called with the configuration of an
+	// in-process node before it's started. It isn't supported by child
+	// process nodes.
+	OverrideConfig func(cfg *config.Config)
+}
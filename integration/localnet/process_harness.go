@@ -0,0 +1,200 @@
+package localnet
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/kaspanet/kaspad/config"
+	"github.com/pkg/errors"
+)
+
+// processStopTimeout is how long a child process node is given to shut down
+// gracefully after it's interrupted, before it's killed.
+const processStopTimeout = 30 * time.Second
+
+// ProcessHarness is a kaspad node that runs as a child process
+type ProcessHarness struct {
+	command      *exec.Cmd
+	exitChan     chan error
+	outputFile   *os.File
+	rpcClient    *RPCClient
+	params       *Params
+	nodeID       string
+	temporaryDir string
+}
+
+// NewProcessHarness starts the kaspad executable at kaspadPath as a node with
+// the given parameters. extraArgs are added to the command line arguments of
+// the node. The output of the process is written to kaspad.out in its data
+// directory.
+func NewProcessHarness(kaspadPath string, params *Params, extraArgs ...string) (harness *ProcessHarness, err error) {
+	if params.OverrideConfig != nil {
+		return nil, errors.New("OverrideConfig isn't supported by child process nodes")
+	}
+
+	harness = &ProcessHarness{
+		params: params,
+	}
+	defer func() {
+		if err != nil {
+			harness.removeTemporaryDir()
+		}
+	}()
+
+	dataDir := params.DataDir
+	if dataDir == "" {
+		harness.temporaryDir, err = ioutil.TempDir("", "localnet")
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the data directory")
+		}
+		dataDir = harness.temporaryDir
+	} else {
+		err = os.MkdirAll(dataDir, 0700)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the data directory")
+		}
+	}
+	harness.outputFile, err = os.Create(filepath.Join(dataDir, "kaspad.out"))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the output file")
+	}
+
+	args := append(processArgs(params, dataDir), extraArgs...)
+	harness.command = exec.Command(kaspadPath, args...)
+	harness.command.Stdout = harness.outputFile
+	harness.command.Stderr = harness.outputFile
+	err = harness.command.Start()
+	if err != nil {
+		harness.outputFile.Close()
+		return nil, errors.Wrapf(err, "error starting %s", kaspadPath)
+	}
+	harness.exitChan = make(chan error, 1)
+	go func() {
+		harness.exitChan <- harness.command.Wait()
+		close(harness.exitChan)
+	}()
+
+	harness.rpcClient, err = connectRPCClient(params.RPCAddress, DefaultTimeout)
+	if err != nil {
+		harness.stop()
+		return nil, err
+	}
+	info, err := harness.rpcClient.GetInfo()
+	if err != nil {
+		harness.rpcClient.Shutdown()
+		harness.stop()
+		return nil, errors.Wrap(err, "error getting the node info")
+	}
+	harness.nodeID = info.ID
+
+	return harness, nil
+}
+
+func processArgs(params *Params, dataDir string) []string {
+	args := []string{
+		"--datadir=" + filepath.Join(dataDir, "data"),
+		"--logdir=" + filepath.Join(dataDir, "logs"),
+		"--listen=" + params.P2PAddress,
+		"--rpclisten=" + params.RPCAddress,
+		"--rpcuser=" + RPCUser,
+		"--rpcpass=" + RPCPass,
+		"--notls",
+		"--nodnsseed",
+		"--outpeers=0",
+	}
+	if params.NetLatency > 0 {
+		args = append(args, "--netlatency="+params.NetLatency.String())
+	}
+	return append(args, networkArgs(&params.NetworkFlags)...)
+}
+
+// networkArgs returns the command line arguments that select the network
+// of networkFlags, which is simnet if no network is selected.
+func networkArgs(networkFlags *config.NetworkFlags) []string {
+	var args []string
+	if networkFlags.Testnet {
+		args = append(args, "--testnet")
+	}
+	if networkFlags.RegressionTest {
+		args = append(args, "--regtest")
+	}
+	if networkFlags.Simnet {
+		args = append(args, "--simnet")
+	}
+	if networkFlags.Devnet {
+		args = append(args, "--devnet")
+	}
+	if networkFlags.NetParamsFile != "" {
+		args = append(args, "--netparams="+networkFlags.NetParamsFile)
+	}
+	if len(args) == 0 {
+		args = append(args, "--simnet")
+	}
+	return args
+}
+
+// Teardown stops the node, and removes its data directory if it's temporary
+func (harness *ProcessHarness) Teardown() error {
+	harness.rpcClient.Shutdown()
+	err := harness.stop()
+	harness.removeTemporaryDir()
+	return err
+}
+
+// stop interrupts the process and waits for it to exit, killing it if it
+// doesn't exit in time.
+func (harness *ProcessHarness) stop() error {
+	defer harness.outputFile.Close()
+
+	err := harness.command.Process.Signal(os.Interrupt)
+	if err != nil {
+		select {
+		case <-harness.exitChan:
+			// The process already exited
+			return nil
+		default:
+		}
+		return errors.Wrap(err, "error interrupting the kaspad process")
+	}
+
+	select {
+	case <-harness.exitChan:
+		return nil
+	case <-time.After(processStopTimeout):
+		err := harness.command.Process.Kill()
+		<-harness.exitChan
+		if err != nil {
+			return errors.Wrap(err, "error killing the kaspad process")
+		}
+		return errors.Errorf("the kaspad process didn't stop within %s and was killed", processStopTimeout)
+	}
+}
+
+func (harness *ProcessHarness) removeTemporaryDir() {
+	if harness.temporaryDir != "" {
+		os.RemoveAll(harness.temporaryDir)
+	}
+}
+
+// P2PAddress returns the address the node listens on for P2P connections
+func (harness *ProcessHarness) P2PAddress() string {
+	return harness.params.P2PAddress
+}
+
+// RPCAddress returns the address the node listens on for RPC connections
+func (harness *ProcessHarness) RPCAddress() string {
+	return harness.params.RPCAddress
+}
+
+// RPCClient returns a client connected to the RPC server of the node
+func (harness *ProcessHarness) RPCClient() *RPCClient {
+	return harness.rpcClient
+}
+
+// NodeID returns the ID of the node in the P2P network
+func (harness *ProcessHarness) NodeID() string {
+	return harness.nodeID
+}
@@ -0,0 +1,64 @@
+package localnet
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+	rpcclient "github.com/kaspanet/kaspad/rpc/client"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// RPCClient is an RPC client of a node run by this package
+type RPCClient struct {
+	*rpcclient.Client
+	onBlockAdded func(*domainmessage.BlockHeader)
+}
+
+func newRPCClient(rpcAddress string) (*RPCClient, error) {
+	client := &RPCClient{}
+	notificationHandlers := &rpcclient.NotificationHandlers{
+		OnFilteredBlockAdded: func(height uint64, header *domainmessage.BlockHeader, txs []*util.Tx) {
+			if client.onBlockAdded != nil {
+				client.onBlockAdded(header)
+			}
+		},
+	}
+
+	connConfig := &rpcclient.ConnConfig{
+		Host:           rpcAddress,
+		Endpoint:       "ws",
+		User:           RPCUser,
+		Pass:           RPCPass,
+		DisableTLS:     true,
+		RequestTimeout: DefaultTimeout,
+	}
+
+	var err error
+	client.Client, err = rpcclient.New(connConfig, notificationHandlers)
+	return client, err
+}
+
+// connectRPCClient connects to the RPC server at rpcAddress, retrying until
+// the timeout expires, to give a node that was just started time to listen.
+func connectRPCClient(rpcAddress string, timeout time.Duration) (*RPCClient, error) {
+	const retryInterval = 100 * time.Millisecond
+	deadline := time.Now().Add(timeout)
+	for {
+		client, err := newRPCClient(rpcAddress)
+		if err == nil {
+			return client, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Wrapf(err, "timed out connecting to the RPC server at %s", rpcAddress)
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
+// SetOnBlockAddedHandler registers for block added notifications, and
+// calls handler with the header of every block the node adds to its DAG.
+func (c *RPCClient) SetOnBlockAddedHandler(handler func(header *domainmessage.BlockHeader)) error {
+	c.onBlockAdded = handler
+	return c.NotifyBlocks()
+}
@@ -0,0 +1,104 @@
+package localnet
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// Edge is a connection between two nodes of a network, identified by their
+// indexes, that's initiated by the Outgoing node.
+type Edge struct {
+	Outgoing int
+	Incoming int
+}
+
+// Topology is a way of connecting the nodes of a network
+type Topology string
+
+// The supported topologies
+const (
+	// TopologyLine connects every node to the next one
+	TopologyLine Topology = "line"
+
+	// TopologyRing connects every node to the next one, and the last node
+	// to the first one
+	TopologyRing Topology = "ring"
+
+	// TopologyStar connects every node to the first one
+	TopologyStar Topology = "star"
+
+	// TopologyRandom connects every node to a random node before it, so
+	// that all the nodes are reachable, and adds a random edge for every
+	// two nodes
+	TopologyRandom Topology = "random"
+)
+
+// Edges returns the edges that connect numNodes nodes in the topology. random
+// is only used by TopologyRandom, so that the same seed always results in
+// the same edges.
+func (topology Topology) Edges(numNodes int, random *rand.Rand) ([]Edge, error) {
+	if numNodes < 0 {
+		return nil, errors.Errorf("the number of nodes must not be negative, but got %d", numNodes)
+	}
+
+	var edges []Edge
+	switch topology {
+	case TopologyLine:
+		for i := 1; i < numNodes; i++ {
+			edges = append(edges, Edge{Outgoing: i - 1, Incoming: i})
+		}
+	case TopologyRing:
+		for i := 1; i < numNodes; i++ {
+			edges = append(edges, Edge{Outgoing: i - 1, Incoming: i})
+		}
+		// With less than three nodes, the ring is a line
+		if numNodes >= 3 {
+			edges = append(edges, Edge{Outgoing: numNodes - 1, Incoming: 0})
+		}
+	case TopologyStar:
+		for i := 1; i < numNodes; i++ {
+			edges = append(edges, Edge{Outgoing: i, Incoming: 0})
+		}
+	case TopologyRandom:
+		edges = randomEdges(numNodes, random)
+	default:
+		return nil, errors.Errorf("unknown topology %s", topology)
+	}
+	return edges, nil
+}
+
+func randomEdges(numNodes int, random *rand.Rand) []Edge {
+	var edges []Edge
+	connected := make(map[Edge]struct{})
+	addEdge := func(edge Edge) bool {
+		reverse := Edge{Outgoing: edge.Incoming, Incoming: edge.Outgoing}
+		if _, ok := connected[edge]; ok || edge.Outgoing == edge.Incoming {
+			return false
+		}
+		if _, ok := connected[reverse]; ok {
+			return false
+		}
+		connected[edge] = struct{}{}
+		edges = append(edges, edge)
+		return true
+	}
+
+	for i := 1; i < numNodes; i++ {
+		addEdge(Edge{Outgoing: i, Incoming: random.Intn(i)})
+	}
+
+	// The number of extra edges is capped by the number of node pairs that
+	// aren't connected yet
+	maxEdges := numNodes * (numNodes - 1) / 2
+	numExtraEdges := numNodes / 2
+	if len(edges)+numExtraEdges > maxEdges {
+		numExtraEdges = maxEdges - len(edges)
+	}
+	for added := 0; added < numExtraEdges; {
+		if addEdge(Edge{Outgoing: random.Intn(numNodes), Incoming: random.Intn(numNodes)}) {
+			added++
+		}
+	}
+	return edges
+}
@@ -0,0 +1,84 @@
+package localnet
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestTopologyEdges(t *testing.T) {
+	tests := []struct {
+		topology      Topology
+		numNodes      int
+		expectedEdges []Edge
+	}{
+		{TopologyLine, 1, nil},
+		{TopologyLine, 3, []Edge{{0, 1}, {1, 2}}},
+		{TopologyRing, 2, []Edge{{0, 1}}},
+		{TopologyRing, 4, []Edge{{0, 1}, {1, 2}, {2, 3}, {3, 0}}},
+		{TopologyStar, 4, []Edge{{1, 0}, {2, 0}, {3, 0}}},
+	}
+
+	for _, test := range tests {
+		edges, err := test.topology.Edges(test.numNodes, nil)
+		if err != nil {
+			t.Fatalf("%s with %d nodes: Edges: %s", test.topology, test.numNodes, err)
+		}
+		if !reflect.DeepEqual(edges, test.expectedEdges) {
+			t.Errorf("%s with %d nodes: expected the edges %v but got %v",
+				test.topology, test.numNodes, test.expectedEdges, edges)
+		}
+	}
+
+	_, err := Topology("mesh").Edges(3, nil)
+	if err == nil {
+		t.Errorf("Edges: expected an error for an unknown topology")
+	}
+}
+
+func TestTopologyRandomEdges(t *testing.T) {
+	for numNodes := 0; numNodes <= 10; numNodes++ {
+		edges, err := TopologyRandom.Edges(numNodes, rand.New(rand.NewSource(int64(numNodes))))
+		if err != nil {
+			t.Fatalf("Edges: %s", err)
+		}
+
+		// The same seed must result in the same edges
+		sameSeedEdges, err := TopologyRandom.Edges(numNodes, rand.New(rand.NewSource(int64(numNodes))))
+		if err != nil {
+			t.Fatalf("Edges: %s", err)
+		}
+		if !reflect.DeepEqual(edges, sameSeedEdges) {
+			t.Errorf("%d nodes: the same seed resulted in the edges %v and %v", numNodes, edges, sameSeedEdges)
+		}
+
+		// Every node must be reachable from the first one, and no two
+		// nodes may be connected twice
+		pairs := make(map[[2]int]struct{})
+		reachable := map[int]bool{0: true}
+		for _, edge := range edges {
+			pair := [2]int{edge.Outgoing, edge.Incoming}
+			if pair[0] > pair[1] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+			if _, ok := pairs[pair]; ok || pair[0] == pair[1] {
+				t.Errorf("%d nodes: invalid or duplicate edge %v", numNodes, edge)
+			}
+			pairs[pair] = struct{}{}
+		}
+		for changed := true; changed; {
+			changed = false
+			for _, edge := range edges {
+				if reachable[edge.Outgoing] != reachable[edge.Incoming] {
+					reachable[edge.Outgoing], reachable[edge.Incoming] = true, true
+					changed = true
+				}
+			}
+		}
+		for i := 0; i < numNodes; i++ {
+			if !reachable[i] {
+				t.Errorf("%d nodes: node %d isn't reachable in %v", numNodes, i, edges)
+			}
+		}
+	}
+}
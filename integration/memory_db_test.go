@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/integration/localnet"
+)
+
+func TestIntegrationInMemoryDatabase(t *testing.T) {
+	useInMemoryDatabase := func(cfg *config.Config) {
+		cfg.DbType = dbaccess.MemoryDBType
+	}
+	harnesses, teardown := setupHarnesses(t, []*localnet.Params{
+		{
+			P2PAddress:              p2pAddress1,
+			RPCAddress:              rpcAddress1,
+			MiningAddress:           miningAddress1,
+			MiningAddressPrivateKey: miningAddress1PrivateKey,
+			OverrideConfig:          useInMemoryDatabase,
+		},
+		{
+			P2PAddress:              p2pAddress2,
+			RPCAddress:              rpcAddress2,
+			MiningAddress:           miningAddress2,
+			MiningAddressPrivateKey: miningAddress2PrivateKey,
+			OverrideConfig:          useInMemoryDatabase,
+		},
+	})
+	defer teardown()
+	appHarness1, appHarness2 := harnesses[0], harnesses[1]
+
+	connect(t, appHarness1, appHarness2)
+
+	app2OnBlockAddedChan := make(chan *domainmessage.BlockHeader)
+	setOnBlockAddedHandler(t, appHarness2, func(header *domainmessage.BlockHeader) {
+		app2OnBlockAddedChan <- header
+	})
+
+	block := mineNextBlock(t, appHarness1)
+
+	var header *domainmessage.BlockHeader
+	select {
+	case header = <-app2OnBlockAddedChan:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timeout waiting for block added notification")
+	}
+
+	if !header.BlockHash().IsEqual(block.Hash()) {
+		t.Errorf("Expected block with hash '%s', but got '%s'", block.Hash(), header.BlockHash())
+	}
+
+	// The block must also be retrievable from the in-memory
+	// database of the node that received it
+	_, err := appHarness2.RPCClient().GetBlock(block.Hash(), nil)
+	if err != nil {
+		t.Errorf("Error getting block from the in-memory database: %s", err)
+	}
+}
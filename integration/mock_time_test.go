@@ -0,0 +1,53 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/integration/localnet"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func TestIntegrationMockTime(t *testing.T) {
+	harness, teardown := setupHarness(t, &localnet.Params{
+		P2PAddress:              p2pAddress1,
+		RPCAddress:              rpcAddress1,
+		MiningAddress:           miningAddress1,
+		MiningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	err := harness.RPCClient().AdvanceMockTime(time.Minute)
+	if err == nil {
+		t.Fatalf("AdvanceMockTime: expected an error when the mock time isn't set")
+	}
+
+	// Blocks are mined with the mock time, since it's later than the
+	// past median time of the DAG.
+	mockTime := mstime.Now().Add(time.Hour)
+	err = harness.RPCClient().SetMockTime(mockTime)
+	if err != nil {
+		t.Fatalf("SetMockTime: %s", err)
+	}
+	block := mineNextBlock(t, harness)
+	if block.Timestamp().UnixMilliseconds() != mockTime.UnixMilliseconds() {
+		t.Errorf("Expected a block timestamp of %s, but got %s", mockTime, block.Timestamp())
+	}
+
+	err = harness.RPCClient().AdvanceMockTime(time.Minute)
+	if err != nil {
+		t.Fatalf("AdvanceMockTime: %s", err)
+	}
+	block = mineNextBlock(t, harness)
+	expectedTimestamp := mockTime.Add(time.Minute)
+	if block.Timestamp().UnixMilliseconds() != expectedTimestamp.UnixMilliseconds() {
+		t.Errorf("Expected a block timestamp of %s, but got %s", expectedTimestamp, block.Timestamp())
+	}
+
+	// Going back to the local time must not prevent mining.
+	err = harness.RPCClient().SetMockTime(mstime.Time{})
+	if err != nil {
+		t.Fatalf("SetMockTime: %s", err)
+	}
+	mineNextBlock(t, harness)
+}
@@ -4,12 +4,12 @@ import (
 	"testing"
 
 	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/integration/localnet"
 )
 
-func setOnBlockAddedHandler(t *testing.T, harness *appHarness, handler func(header *domainmessage.BlockHeader)) {
-	err := harness.rpcClient.NotifyBlocks()
+func setOnBlockAddedHandler(t *testing.T, harness *localnet.AppHarness, handler func(header *domainmessage.BlockHeader)) {
+	err := harness.RPCClient().SetOnBlockAddedHandler(handler)
 	if err != nil {
 		t.Fatalf("Error from NotifyBlocks: %s", err)
 	}
-	harness.rpcClient.onBlockAdded = handler
 }
@@ -0,0 +1,165 @@
+package integration
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/integration/localnet"
+	"github.com/kaspanet/kaspad/netadapter/server/grpcserver"
+)
+
+func TestP2PEncryption(t *testing.T) {
+	tests := []struct {
+		name               string
+		incomingEncryption string
+		outgoingEncryption string
+		expectedEncrypted  bool
+	}{
+		{
+			name:               "both required",
+			incomingEncryption: config.P2PEncryptionRequired,
+			outgoingEncryption: config.P2PEncryptionRequired,
+			expectedEncrypted:  true,
+		},
+		{
+			name:               "opportunistic to required",
+			incomingEncryption: config.P2PEncryptionRequired,
+			outgoingEncryption: config.P2PEncryptionOpportunistic,
+			expectedEncrypted:  true,
+		},
+		{
+			name:               "none to opportunistic",
+			incomingEncryption: config.P2PEncryptionOpportunistic,
+			outgoingEncryption: config.P2PEncryptionNone,
+			expectedEncrypted:  false,
+		},
+		{
+			name:               "opportunistic to none",
+			incomingEncryption: config.P2PEncryptionNone,
+			outgoingEncryption: config.P2PEncryptionOpportunistic,
+			expectedEncrypted:  false,
+		},
+	}
+
+	for _, test := range tests {
+		incoming, outgoing, teardown := setupEncryptionHarnesses(t,
+			func(cfg *config.Config) { cfg.P2PEncryption = test.incomingEncryption },
+			func(cfg *config.Config, _ *config.Config) { cfg.P2PEncryption = test.outgoingEncryption })
+
+		connect(t, incoming, outgoing)
+
+		for _, harness := range []*localnet.AppHarness{incoming, outgoing} {
+			peerInfo, err := harness.RPCClient().GetConnectedPeerInfo()
+			if err != nil {
+				t.Fatalf("%s: Error getting connected peer info: %+v", test.name, err)
+			}
+			if len(peerInfo) != 1 {
+				t.Fatalf("%s: Expected 1 connected peer, got %d", test.name, len(peerInfo))
+			}
+			if peerInfo[0].IsEncrypted != test.expectedEncrypted {
+				t.Errorf("%s: Expected isEncrypted to be %t, got %t", test.name,
+					test.expectedEncrypted, peerInfo[0].IsEncrypted)
+			}
+		}
+
+		teardown()
+	}
+}
+
+func TestP2PEncryptionRequiredRefusesPlaintext(t *testing.T) {
+	incoming, outgoing, teardown := setupEncryptionHarnesses(t,
+		func(cfg *config.Config) { cfg.P2PEncryption = config.P2PEncryptionRequired },
+		func(cfg *config.Config, _ *config.Config) { cfg.P2PEncryption = config.P2PEncryptionNone })
+	defer teardown()
+
+	requireNotConnected(t, incoming, outgoing)
+}
+
+func TestP2PPinnedPeer(t *testing.T) {
+	// A peer presenting the pinned certificate gets connected
+	incoming, outgoing, teardown := setupEncryptionHarnesses(t,
+		func(cfg *config.Config) { cfg.P2PEncryption = config.P2PEncryptionOpportunistic },
+		func(cfg *config.Config, incomingConfig *config.Config) {
+			incomingFingerprint := readP2PFingerprint(t, filepath.Join(incomingConfig.DataDir, "p2p.cert"))
+			cfg.P2PPinnedPeers = map[string]string{p2pAddress1: incomingFingerprint}
+		})
+	connect(t, incoming, outgoing)
+	teardown()
+
+	// A peer presenting any other certificate is refused
+	incoming, outgoing, teardown = setupEncryptionHarnesses(t,
+		func(cfg *config.Config) { cfg.P2PEncryption = config.P2PEncryptionOpportunistic },
+		func(cfg *config.Config, incomingConfig *config.Config) {
+			cfg.P2PPinnedPeers = map[string]string{p2pAddress1: strings.Repeat("00", sha256.Size)}
+		})
+	defer teardown()
+	requireNotConnected(t, incoming, outgoing)
+}
+
+// setupEncryptionHarnesses sets up two harnesses, the incoming one on p2pAddress1. The
+// outgoing config override is called after the incoming harness is already running.
+func setupEncryptionHarnesses(t *testing.T, overrideIncomingConfig func(cfg *config.Config),
+	overrideOutgoingConfig func(cfg *config.Config, incomingConfig *config.Config)) (
+	incoming, outgoing *localnet.AppHarness, teardownFunc func()) {
+
+	var incomingConfig *config.Config
+	harnesses, teardown := setupHarnesses(t, []*localnet.Params{
+		{
+			P2PAddress:              p2pAddress1,
+			RPCAddress:              rpcAddress1,
+			MiningAddress:           miningAddress1,
+			MiningAddressPrivateKey: miningAddress1PrivateKey,
+			OverrideConfig: func(cfg *config.Config) {
+				overrideIncomingConfig(cfg)
+				incomingConfig = cfg
+			},
+		},
+		{
+			P2PAddress:              p2pAddress2,
+			RPCAddress:              rpcAddress2,
+			MiningAddress:           miningAddress2,
+			MiningAddressPrivateKey: miningAddress2PrivateKey,
+			OverrideConfig: func(cfg *config.Config) {
+				overrideOutgoingConfig(cfg, incomingConfig)
+			},
+		},
+	})
+	return harnesses[0], harnesses[1], teardown
+}
+
+func requireNotConnected(t *testing.T, incoming, outgoing *localnet.AppHarness) {
+	err := outgoing.RPCClient().ConnectNode(incoming.P2PAddress())
+	if err != nil {
+		t.Fatalf("Error connecting the nodes")
+	}
+
+	// Give the nodes time to connect, and make sure they didn't
+	const waitTime = 2 * time.Second
+	time.Sleep(waitTime)
+	if isConnected(t, incoming, outgoing) {
+		t.Fatalf("Expected the nodes not to be connected")
+	}
+}
+
+func readP2PFingerprint(t *testing.T, certFile string) string {
+	pemBytes, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		t.Fatalf("Error reading P2P certificate: %+v", err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	if block == nil || !strings.Contains(block.Type, "CERTIFICATE") {
+		t.Fatalf("Error decoding P2P certificate")
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("Error parsing P2P certificate: %+v", err)
+	}
+	return grpcserver.CertificateFingerprint(certificate)
+}
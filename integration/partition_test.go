@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/integration/localnet"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+func TestIntegrationPartition(t *testing.T) {
+	appHarness1, appHarness2, appHarness3, teardown := standardSetup(t)
+	defer teardown()
+
+	network := localnet.NewNetwork([]localnet.Node{appHarness1, appHarness2, appHarness3})
+	edges, err := localnet.TopologyLine.Edges(len(network.Nodes), nil)
+	if err != nil {
+		t.Fatalf("Edges: %s", err)
+	}
+	err = network.Connect(edges)
+	if err != nil {
+		t.Fatalf("Connect: %+v", err)
+	}
+
+	// Split appHarness3 off the network
+	err = network.Partition([]int{0, 1}, []int{2})
+	if err != nil {
+		t.Fatalf("Partition: %+v", err)
+	}
+	if isConnected(t, appHarness2, appHarness3) {
+		t.Fatalf("Expected the partitioned nodes not to be connected")
+	}
+	if !isConnected(t, appHarness1, appHarness2) {
+		t.Fatalf("Expected the nodes in the same partition to stay connected")
+	}
+
+	block1 := mineNextBlock(t, appHarness1)
+	block3 := mineNextBlock(t, appHarness3)
+	waitForBlock(t, appHarness2, block1.Hash())
+
+	// Give the block time to cross the partition, and make sure it didn't
+	time.Sleep(time.Second)
+	_, err = appHarness3.RPCClient().GetBlock(block1.Hash(), nil)
+	if err == nil {
+		t.Fatalf("Expected the block of the other partition not to reach appHarness3")
+	}
+
+	err = network.Heal()
+	if err != nil {
+		t.Fatalf("Heal: %+v", err)
+	}
+
+	// The blocks mined after the partition is healed make each side
+	// request the blocks the other side mined during the partition
+	mergeBlock1 := mineNextBlock(t, appHarness1)
+	mergeBlock3 := mineNextBlock(t, appHarness3)
+	for _, harness := range []*localnet.AppHarness{appHarness1, appHarness2, appHarness3} {
+		for _, block := range []*util.Block{block1, block3, mergeBlock1, mergeBlock3} {
+			waitForBlock(t, harness, block.Hash())
+		}
+	}
+}
+
+func TestIntegrationNetLatency(t *testing.T) {
+	const netLatency = 500 * time.Millisecond
+
+	harnesses, teardown := setupHarnesses(t, []*localnet.Params{
+		{
+			P2PAddress:              p2pAddress1,
+			RPCAddress:              rpcAddress1,
+			MiningAddress:           miningAddress1,
+			MiningAddressPrivateKey: miningAddress1PrivateKey,
+		},
+		{
+			P2PAddress:              p2pAddress2,
+			RPCAddress:              rpcAddress2,
+			MiningAddress:           miningAddress2,
+			MiningAddressPrivateKey: miningAddress2PrivateKey,
+			NetLatency:              netLatency,
+		},
+	})
+	defer teardown()
+	appHarness1, appHarness2 := harnesses[0], harnesses[1]
+
+	connect(t, appHarness1, appHarness2)
+
+	start := time.Now()
+	block := mineNextBlock(t, appHarness1)
+	waitForBlock(t, appHarness2, block.Hash())
+	if elapsed := time.Since(start); elapsed < netLatency {
+		t.Errorf("Expected the block to take at least %s to propagate, but it took %s", netLatency, elapsed)
+	}
+}
+
+// waitForBlock waits until the node of harness has the block with the given hash
+func waitForBlock(t *testing.T, harness *localnet.AppHarness, blockHash *daghash.Hash) {
+	const pollInterval = 10 * time.Millisecond
+	timeout := time.After(defaultTimeout)
+	for {
+		_, err := harness.RPCClient().GetBlock(blockHash, nil)
+		if err == nil {
+			return
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-timeout:
+			t.Fatalf("Timed out waiting for %s to get block %s", harness.P2PAddress(), blockHash)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kaspanet/go-secp256k1"
 	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/integration/localnet"
 	"github.com/kaspanet/kaspad/txscript"
 	"github.com/kaspanet/kaspad/util"
 )
@@ -33,13 +34,13 @@ func TestTxRelay(t *testing.T) {
 	waitForPayeeToReceiveBlock(t, payeeBlockAddedChan)
 
 	// Mine BlockCoinbaseMaturity more blocks for our money to mature
-	for i := uint64(0); i < payer.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+	for i := uint64(0); i < payer.Config().ActiveNetParams.BlockCoinbaseMaturity; i++ {
 		mineNextBlock(t, payer)
 		waitForPayeeToReceiveBlock(t, payeeBlockAddedChan)
 	}
 
 	tx := generateTx(t, secondBlock.CoinbaseTransaction().MsgTx(), payer, payee)
-	txID, err := payer.rpcClient.SendRawTransaction(tx, true)
+	txID, err := payer.RPCClient().SendRawTransaction(tx, true)
 	if err != nil {
 		t.Fatalf("Error submitting transaction: %+v", err)
 	}
@@ -51,7 +52,7 @@ func TestTxRelay(t *testing.T) {
 		defer ticker.Stop()
 
 		for range ticker.C {
-			_, err := payee.rpcClient.GetMempoolEntry(txID.String())
+			_, err := payee.RPCClient().GetMempoolEntry(txID.String())
 			if err != nil {
 				if strings.Contains(err.Error(), "-32603: transaction is not in the pool") {
 					continue
@@ -78,11 +79,11 @@ func waitForPayeeToReceiveBlock(t *testing.T, payeeBlockAddedChan chan *domainme
 	}
 }
 
-func generateTx(t *testing.T, firstBlockCoinbase *domainmessage.MsgTx, payer, payee *appHarness) *domainmessage.MsgTx {
+func generateTx(t *testing.T, firstBlockCoinbase *domainmessage.MsgTx, payer, payee *localnet.AppHarness) *domainmessage.MsgTx {
 	txIns := make([]*domainmessage.TxIn, 1)
 	txIns[0] = domainmessage.NewTxIn(domainmessage.NewOutpoint(firstBlockCoinbase.TxID(), 0), []byte{})
 
-	payeeAddress, err := util.DecodeAddress(payee.miningAddress, util.Bech32PrefixKaspaSim)
+	payeeAddress, err := util.DecodeAddress(payee.MiningAddress(), util.Bech32PrefixKaspaSim)
 	if err != nil {
 		t.Fatalf("Error decoding payeeAddress: %+v", err)
 	}
@@ -97,7 +98,7 @@ func generateTx(t *testing.T, firstBlockCoinbase *domainmessage.MsgTx, payer, pa
 
 	tx := domainmessage.NewNativeMsgTx(domainmessage.TxVersion, txIns, txOuts)
 
-	privateKeyBytes, err := hex.DecodeString(payer.miningAddressPrivateKey)
+	privateKeyBytes, err := hex.DecodeString(payer.MiningAddressPrivateKey())
 	if err != nil {
 		t.Fatalf("Error decoding private key: %+v", err)
 	}
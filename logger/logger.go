@@ -54,6 +54,7 @@ var (
 	dnssLog = BackendLog.Logger("DNSS")
 	snvrLog = BackendLog.Logger("SNVR")
 	ibdsLog = BackendLog.Logger("IBDS")
+	strmLog = BackendLog.Logger("STRM")
 )
 
 // SubsystemTags is an enum of all sub system tags
@@ -83,7 +84,8 @@ var SubsystemTags = struct {
 	NTAR,
 	DNSS,
 	SNVR,
-	IBDS string
+	IBDS,
+	STRM string
 }{
 	ADXR: "ADXR",
 	AMGR: "AMGR",
@@ -111,6 +113,7 @@ var SubsystemTags = struct {
 	DNSS: "DNSS",
 	SNVR: "SNVR",
 	IBDS: "IBDS",
+	STRM: "STRM",
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -141,6 +144,7 @@ var subsystemLoggers = map[string]*logs.Logger{
 	SubsystemTags.DNSS: dnssLog,
 	SubsystemTags.SNVR: snvrLog,
 	SubsystemTags.IBDS: ibdsLog,
+	SubsystemTags.STRM: strmLog,
 }
 
 // InitLog attaches log file and error log file to the backend log.
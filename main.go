@@ -76,12 +76,6 @@ func kaspadMain(startedChan chan<- struct{}) error {
 		defer pprof.StopCPUProfile()
 	}
 
-	// Perform upgrades to kaspad as new versions require it.
-	if err := doUpgrades(); err != nil {
-		log.Errorf("%s", err)
-		return err
-	}
-
 	// Return now if an interrupt signal was triggered.
 	if signal.InterruptRequested(interrupt) {
 		return nil
@@ -95,10 +89,22 @@ func kaspadMain(startedChan chan<- struct{}) error {
 		}
 	}
 
+	if cfg.RestoreDatabase != "" {
+		err := restoreDatabase(cfg)
+		if err != nil {
+			log.Errorf("%s", err)
+			return err
+		}
+	}
+
 	// Open the database
 	databaseContext, err := openDB(cfg)
 	if err != nil {
 		log.Errorf("%s", err)
+		if dbaccess.IsCorruptionError(err) {
+			log.Errorf("The database is corrupted. Restart with --reset-db " +
+				"to resync from scratch, or with --restore-db to restore a backup")
+		}
 		return err
 	}
 	defer func() {
@@ -109,6 +115,15 @@ func kaspadMain(startedChan chan<- struct{}) error {
 		}
 	}()
 
+	// Perform upgrades to the database as new versions require it.
+	if err := doUpgrades(cfg, databaseContext); err != nil {
+		log.Errorf("%s", err)
+		return err
+	}
+	if cfg.DryRunMigration {
+		return nil
+	}
+
 	// Return now if an interrupt signal was triggered.
 	if signal.InterruptRequested(interrupt) {
 		return nil
@@ -180,10 +195,18 @@ func blockDbPath(cfg *config.Config) string {
 	return dbPath
 }
 
+func databasePath(cfg *config.Config) string {
+	return filepath.Join(cfg.DataDir, dbaccess.DirectoryName(cfg.DbType))
+}
+
 func openDB(cfg *config.Config) (*dbaccess.DatabaseContext, error) {
-	dbPath := filepath.Join(cfg.DataDir, "db")
-	log.Infof("Loading database from '%s'", dbPath)
-	return dbaccess.New(dbPath)
+	dbPath := databasePath(cfg)
+	if cfg.DbType == dbaccess.MemoryDBType {
+		log.Infof("Using an in-memory database")
+	} else {
+		log.Infof("Loading database from '%s'", dbPath)
+	}
+	return dbaccess.Open(cfg.DbType, dbPath)
 }
 
 func main() {
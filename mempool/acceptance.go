@@ -0,0 +1,186 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/mining"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+// poolView is the state of the memory pool that transactions are
+// checked against before they are accepted. It is either the memory
+// pool itself, or the memory pool together with transactions that
+// are treated as if they were in it, without actually adding them.
+type poolView struct {
+	mp      *TxPool
+	utxoSet blockdag.UTXOSet
+
+	// pendingTxs and pendingOutpoints are the transactions that are
+	// treated as if they were in the pool, and the outpoints they spend.
+	pendingTxs       map[daghash.TxID]*TxDesc
+	pendingOutpoints map[domainmessage.Outpoint]*util.Tx
+}
+
+// poolView returns the view of the memory pool itself.
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) poolView() *poolView {
+	return &poolView{
+		mp:      mp,
+		utxoSet: mp.mpUTXOSet,
+	}
+}
+
+// isTransactionInPool returns whether the passed transaction is in
+// the main pool or is one of the pending transactions of the view.
+func (view *poolView) isTransactionInPool(txID *daghash.TxID) bool {
+	if _, exists := view.pendingTxs[*txID]; exists {
+		return true
+	}
+	return view.mp.isTransactionInPool(txID)
+}
+
+// fetchTxDesc returns the descriptor of a transaction in the main pool
+// or of one of the pending transactions of the view.
+func (view *poolView) fetchTxDesc(txID *daghash.TxID) (*TxDesc, bool) {
+	if txDesc, exists := view.pendingTxs[*txID]; exists {
+		return txDesc, true
+	}
+	return view.mp.fetchTxDesc(txID)
+}
+
+// redeemer returns the transaction in the main pool, or the pending
+// transaction of the view, that spends the passed outpoint.
+func (view *poolView) redeemer(outpoint domainmessage.Outpoint) (*util.Tx, bool) {
+	if tx, exists := view.pendingOutpoints[outpoint]; exists {
+		return tx, true
+	}
+	tx, exists := view.mp.outpoints[outpoint]
+	return tx, exists
+}
+
+// checkPoolDoubleSpend is the same as TxPool.checkPoolDoubleSpend,
+// except that it also checks the outpoints spent by the pending
+// transactions of the view.
+func (view *poolView) checkPoolDoubleSpend(tx *util.Tx) error {
+	for _, txIn := range tx.MsgTx().TxIn {
+		if txR, exists := view.pendingOutpoints[txIn.PreviousOutpoint]; exists {
+			str := fmt.Sprintf("output %s already spent by "+
+				"transaction %s in the same package",
+				txIn.PreviousOutpoint, txR.ID())
+			return txRuleError(RejectDuplicate, str)
+		}
+	}
+	return view.mp.checkPoolDoubleSpend(tx)
+}
+
+// addPendingTx treats the given transaction as if it were in the pool.
+// The view's UTXO set must not be the memory pool's own UTXO set, since
+// it's modified.
+func (view *poolView) addPendingTx(tx *util.Tx, fee uint64, mass uint64) error {
+	if isAccepted, err := view.utxoSet.AddTx(tx.MsgTx(), blockdag.UnacceptedBlueScore); err != nil {
+		return err
+	} else if !isAccepted {
+		return errors.Errorf("unexpectedly failed to add tx %s to the package utxo set", tx.ID())
+	}
+	view.pendingTxs[*tx.ID()] = &TxDesc{
+		TxDesc: mining.TxDesc{
+			Tx:  tx,
+			Fee: fee,
+		},
+		Mass: mass,
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		view.pendingOutpoints[txIn.PreviousOutpoint] = tx
+	}
+	return nil
+}
+
+// AcceptanceResult is the result of checking whether the memory pool
+// would accept a transaction. See CheckAcceptance for further details.
+type AcceptanceResult struct {
+	Tx *util.Tx
+
+	// Err is the reason the transaction would be rejected, or nil
+	// if it would be accepted. It is a RuleError whenever the
+	// transaction breaks a rule.
+	Err error
+
+	// Fee and Mass are only set if the transaction would be accepted.
+	Fee  uint64
+	Mass uint64
+}
+
+// CheckAcceptance checks whether the memory pool would accept each of
+// the given transactions, with the same checks as ProcessTransaction,
+// without adding them to the pool or relaying them.
+//
+// The transactions are checked as a package, in the given order: every
+// transaction that would be accepted is treated as if it were in the
+// pool when the transactions that come after it are checked, so a
+// transaction may spend the outputs of transactions before it in the
+// package. Orphans are always rejected.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) CheckAcceptance(txs []*util.Tx) ([]*AcceptanceResult, error) {
+	// Protect concurrent access.
+	mp.cfg.DAG.RLock()
+	defer mp.cfg.DAG.RUnlock()
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	view := mp.poolView()
+	if len(txs) > 1 {
+		// Copy the UTXO set, so that the transactions of the package
+		// can be added to it without changing the pool's own.
+		utxoSet, err := mp.mpUTXOSet.WithDiff(blockdag.NewUTXODiff())
+		if err != nil {
+			return nil, err
+		}
+		view.utxoSet = utxoSet
+		view.pendingTxs = make(map[daghash.TxID]*TxDesc)
+		view.pendingOutpoints = make(map[domainmessage.Outpoint]*util.Tx)
+	}
+
+	results := make([]*AcceptanceResult, len(txs))
+	for i, tx := range txs {
+		result := &AcceptanceResult{Tx: tx}
+		results[i] = result
+
+		acceptance, err := mp.checkTransactionAcceptance(tx, true, view)
+		if err != nil {
+			var ruleErr RuleError
+			if !errors.As(err, &ruleErr) {
+				return nil, err
+			}
+			result.Err = err
+			continue
+		}
+		if len(acceptance.missingParents) > 0 {
+			str := fmt.Sprintf("orphan transaction %s references "+
+				"outputs of unknown or fully-spent "+
+				"transaction %s", tx.ID(), acceptance.missingParents[0])
+			result.Err = txRuleError(RejectDuplicate, str)
+			continue
+		}
+
+		mass, err := blockdag.CalcTxMassFromUTXOSet(tx, view.utxoSet)
+		if err != nil {
+			return nil, err
+		}
+		result.Fee = acceptance.fee
+		result.Mass = mass
+
+		if i < len(txs)-1 {
+			err := view.addPendingTx(tx, acceptance.fee, mass)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
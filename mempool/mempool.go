@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/dbaccess"
 	"github.com/kaspanet/kaspad/domainmessage"
 	"github.com/kaspanet/kaspad/logger"
 	"github.com/kaspanet/kaspad/mining"
@@ -69,6 +70,11 @@ type Config struct {
 
 	// DAG is the BlockDAG we want to use (mainly for UTXO checks)
 	DAG *blockdag.BlockDAG
+
+	// DatabaseContext is the database the fee deltas of prioritised
+	// transactions are stored in. If it's nil, fee deltas are only
+	// kept in memory.
+	DatabaseContext *dbaccess.DatabaseContext
 }
 
 // Policy houses the policy (configuration parameters) which is used to
@@ -96,6 +102,18 @@ type Policy struct {
 	// MinRelayTxFee defines the minimum transaction fee in KAS/kB to be
 	// considered a non-zero fee.
 	MinRelayTxFee util.Amount
+
+	// MaxAncestorCount and MaxAncestorMass are the maximum number and
+	// total mass of the unmined ancestors of a transaction in the pool,
+	// including itself. Zero means no limit.
+	MaxAncestorCount uint64
+	MaxAncestorMass  uint64
+
+	// MaxDescendantCount and MaxDescendantMass are the maximum number and
+	// total mass of the unmined descendants of a transaction in the pool,
+	// including itself. Zero means no limit.
+	MaxDescendantCount uint64
+	MaxDescendantMass  uint64
 }
 
 // TxDesc is a descriptor containing a transaction in the mempool along with
@@ -103,12 +121,43 @@ type Policy struct {
 type TxDesc struct {
 	mining.TxDesc
 
+	// Mass is the mass of the transaction.
+	Mass uint64
+
+	// FeeDelta is the amount the transaction was prioritised by. See
+	// PrioritiseTransaction for further details.
+	FeeDelta int64
+
+	// AncestorCount, AncestorFee and AncestorMass are the number of
+	// transactions, the total modified fee and the total mass of the
+	// transaction together with its ancestors in the pool, that is, the
+	// transactions in the pool it depends on, directly or indirectly.
+	AncestorCount uint64
+	AncestorFee   uint64
+	AncestorMass  uint64
+
+	// DescendantCount, DescendantFee and DescendantMass are the same as
+	// the ancestor stats, for the transaction together with the
+	// transactions in the pool that depend on it.
+	DescendantCount uint64
+	DescendantFee   uint64
+	DescendantMass  uint64
+
 	// depCount is not 0 for dependent transaction. Dependent transaction is
 	// one that is accepted to pool, but cannot be mined in next block because it
 	// depends on outputs of accepted, but still not mined transaction
 	depCount int
 }
 
+// ModifiedFee returns the fee of the transaction with its fee delta
+// applied. It's never negative.
+func (txD *TxDesc) ModifiedFee() uint64 {
+	if txD.FeeDelta < 0 && uint64(-txD.FeeDelta) > txD.Fee {
+		return 0
+	}
+	return uint64(int64(txD.Fee) + txD.FeeDelta)
+}
+
 // orphanTx is normal transaction that references an ancestor transaction
 // that is not yet available. It also contains additional information related
 // to it such as an expiration time to help prevent caching the orphan forever.
@@ -141,6 +190,10 @@ type TxPool struct {
 	nextExpireScan mstime.Time
 
 	mpUTXOSet blockdag.UTXOSet
+
+	// feeDeltas are the fee deltas of the prioritised transactions,
+	// including ones that aren't in the pool.
+	feeDeltas map[daghash.TxID]int64
 }
 
 // Ensure the TxPool type implements the mining.TxSource interface.
@@ -222,7 +275,7 @@ func (mp *TxPool) limitNumOrphans() error {
 	// Scan through the orphan pool and remove any expired orphans when it's
 	// time. This is done for efficiency so the scan only happens
 	// periodically instead of on every orphan added to the pool.
-	if now := mstime.Now(); now.After(mp.nextExpireScan) {
+	if now := mp.cfg.DAG.Now(); now.After(mp.nextExpireScan) {
 		origNumOrphans := len(mp.orphans)
 		for _, otx := range mp.orphans {
 			if now.After(otx.expiration) {
@@ -284,7 +337,7 @@ func (mp *TxPool) addOrphan(tx *util.Tx, tag Tag) {
 	mp.orphans[*tx.ID()] = &orphanTx{
 		tx:         tx,
 		tag:        tag,
-		expiration: mstime.Now().Add(orphanTTL),
+		expiration: mp.cfg.DAG.Now().Add(orphanTTL),
 	}
 	for _, txIn := range tx.MsgTx().TxIn {
 		if _, exists := mp.orphansByPrev[txIn.PreviousOutpoint]; !exists {
@@ -516,6 +569,9 @@ func (mp *TxPool) removeTransaction(tx *util.Tx, removeDependants bool, restoreI
 func (mp *TxPool) removeTransactionWithDiff(tx *util.Tx, diff *blockdag.UTXODiff, restoreInputs bool) error {
 	txID := tx.ID()
 
+	txDesc, _ := mp.fetchTxDesc(txID)
+	mp.removeFromPackageStats(txDesc)
+
 	err := mp.removeTransactionUTXOEntriesFromDiff(tx, diff)
 	if err != nil {
 		return errors.Errorf("could not remove UTXOEntry from diff: %s", err)
@@ -526,7 +582,6 @@ func (mp *TxPool) removeTransactionWithDiff(tx *util.Tx, diff *blockdag.UTXODiff
 		return errors.Errorf("could not mark transaction output as unspent: %s", err)
 	}
 
-	txDesc, _ := mp.fetchTxDesc(txID)
 	if txDesc.depCount == 0 {
 		delete(mp.pool, *txID)
 	} else {
@@ -678,8 +733,11 @@ func (mp *TxPool) addTransaction(tx *util.Tx, fee uint64, parentsInPool []*domai
 			Fee:            fee,
 			FeePerMegaGram: fee * 1e6 / mass,
 		},
+		Mass:     mass,
+		FeeDelta: mp.feeDeltas[*tx.ID()],
 		depCount: len(parentsInPool),
 	}
+	mp.addToPackageStats(txD)
 
 	if len(parentsInPool) == 0 {
 		mp.pool[*tx.ID()] = txD
@@ -745,7 +803,7 @@ func (mp *TxPool) fetchTxDesc(txID *daghash.TxID) (*TxDesc, bool) {
 	return txDesc, exists
 }
 
-// FetchTxDesc returns the requested TxDesc from the transaction pool.
+// FetchTxDesc returns a copy of the requested TxDesc from the transaction pool.
 // This only fetches from the main transaction pool and does not include
 // orphans.
 // returns false in the second return parameter if transaction was not found
@@ -756,7 +814,8 @@ func (mp *TxPool) FetchTxDesc(txID *daghash.TxID) (*TxDesc, bool) {
 	defer mp.mtx.RUnlock()
 
 	if txDesc, exists := mp.fetchTxDesc(txID); exists {
-		return txDesc, true
+		txDescCopy := *txDesc
+		return &txDescCopy, true
 	}
 
 	return nil, false
@@ -803,17 +862,60 @@ func checkTransactionMassSanity(tx *util.Tx) error {
 //
 // This function MUST be called with the mempool lock held (for writes).
 func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]*daghash.TxID, *TxDesc, error) {
+	acceptance, err := mp.checkTransactionAcceptance(tx, rejectDupOrphans, mp.poolView())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(acceptance.missingParents) > 0 {
+		return acceptance.missingParents, nil, nil
+	}
+
+	// Add to transaction pool.
+	txD, err := mp.addTransaction(tx, acceptance.fee, acceptance.parentsInPool)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Debugf("Accepted transaction %s (pool size: %d)", tx.ID(),
+		len(mp.pool))
+
+	return nil, txD, nil
+}
+
+// txAcceptance is the result of checkTransactionAcceptance.
+type txAcceptance struct {
+	// missingParents are the IDs of the transactions whose outputs
+	// the transaction spends, but are not available. If there are
+	// any, the transaction is an orphan and the rest of the fields
+	// are not set.
+	missingParents []*daghash.TxID
+
+	// parentsInPool are the outpoints the transaction spends that
+	// belong to transactions in the pool.
+	parentsInPool []*domainmessage.Outpoint
+
+	fee uint64
+}
+
+// checkTransactionAcceptance performs all the checks that maybeAcceptTransaction
+// performs before adding a transaction to the memory pool, against the given
+// view of the memory pool, without modifying it.
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) checkTransactionAcceptance(tx *util.Tx, rejectDupOrphans bool,
+	view *poolView) (*txAcceptance, error) {
+
 	txID := tx.ID()
 
 	// Don't accept the transaction if it already exists in the pool. This
 	// applies to orphan transactions as well when the reject duplicate
 	// orphans flag is set. This check is intended to be a quick check to
 	// weed out duplicates.
-	if mp.isTransactionInPool(txID) || (rejectDupOrphans &&
+	if view.isTransactionInPool(txID) || (rejectDupOrphans &&
 		mp.isOrphanInPool(txID)) {
 
 		str := fmt.Sprintf("already have transaction %s", txID)
-		return nil, nil, txRuleError(RejectDuplicate, str)
+		return nil, txRuleError(RejectDuplicate, str)
 	}
 
 	// Don't accept the transaction if it's from an incompatible subnetwork.
@@ -821,20 +923,20 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	if !tx.MsgTx().IsSubnetworkCompatible(subnetworkID) {
 		str := fmt.Sprintf("tx %s belongs to an invalid subnetwork %s, DAG subnetwork %s", tx.ID(),
 			tx.MsgTx().SubnetworkID, subnetworkID)
-		return nil, nil, txRuleError(RejectInvalid, str)
+		return nil, txRuleError(RejectInvalid, str)
 	}
 
 	// Disallow non-native/coinbase subnetworks in networks that don't allow them
 	if !mp.cfg.DAG.Params.EnableNonNativeSubnetworks {
 		if !(tx.MsgTx().SubnetworkID.IsEqual(subnetworkid.SubnetworkIDNative) ||
 			tx.MsgTx().SubnetworkID.IsEqual(subnetworkid.SubnetworkIDCoinbase)) {
-			return nil, nil, txRuleError(RejectInvalid, "non-native/coinbase subnetworks are not allowed")
+			return nil, txRuleError(RejectInvalid, "non-native/coinbase subnetworks are not allowed")
 		}
 	}
 
 	err := checkTransactionMassSanity(tx)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Perform preliminary sanity checks on the transaction. This makes
@@ -844,9 +946,9 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	if err != nil {
 		var ruleErr blockdag.RuleError
 		if ok := errors.As(err, &ruleErr); ok {
-			return nil, nil, dagRuleError(ruleErr)
+			return nil, dagRuleError(ruleErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Check that transaction does not overuse GAS
@@ -854,12 +956,12 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	if !msgTx.SubnetworkID.IsBuiltInOrNative() {
 		gasLimit, err := mp.cfg.DAG.GasLimit(&msgTx.SubnetworkID)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		if msgTx.Gas > gasLimit {
 			str := fmt.Sprintf("transaction wants more gas %d, than allowed %d",
 				msgTx.Gas, gasLimit)
-			return nil, nil, dagRuleError(blockdag.RuleError{
+			return nil, dagRuleError(blockdag.RuleError{
 				ErrorCode:   blockdag.ErrInvalidGas,
 				Description: str})
 		}
@@ -869,7 +971,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	if tx.IsCoinBase() {
 		str := fmt.Sprintf("transaction %s is an individual coinbase transaction",
 			txID)
-		return nil, nil, txRuleError(RejectInvalid, str)
+		return nil, txRuleError(RejectInvalid, str)
 	}
 
 	// We take the blue score of the current virtual block to validate
@@ -893,7 +995,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 			}
 			str := fmt.Sprintf("transaction %s is not standard: %s",
 				txID, err)
-			return nil, nil, txRuleError(rejectCode, str)
+			return nil, txRuleError(rejectCode, str)
 		}
 	}
 
@@ -905,9 +1007,9 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	// at this point. There is a more in-depth check that happens later
 	// after fetching the referenced transaction inputs from the DAG
 	// which examines the actual spend data and prevents double spends.
-	err = mp.checkPoolDoubleSpend(tx)
+	err = view.checkPoolDoubleSpend(tx)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Don't allow the transaction if it exists in the DAG and is
@@ -915,9 +1017,9 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	prevOut := domainmessage.Outpoint{TxID: *txID}
 	for txOutIdx := range tx.MsgTx().TxOut {
 		prevOut.Index = uint32(txOutIdx)
-		_, ok := mp.mpUTXOSet.Get(prevOut)
+		_, ok := view.utxoSet.Get(prevOut)
 		if ok {
-			return nil, nil, txRuleError(RejectDuplicate,
+			return nil, txRuleError(RejectDuplicate,
 				"transaction already exists")
 		}
 	}
@@ -929,7 +1031,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	var missingParents []*daghash.TxID
 	var parentsInPool []*domainmessage.Outpoint
 	for _, txIn := range tx.MsgTx().TxIn {
-		if _, ok := mp.mpUTXOSet.Get(txIn.PreviousOutpoint); !ok {
+		if _, ok := view.utxoSet.Get(txIn.PreviousOutpoint); !ok {
 			// Must make a copy of the hash here since the iterator
 			// is replaced and taking its address directly would
 			// result in all of the entries pointing to the same
@@ -937,40 +1039,40 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 			txIDCopy := txIn.PreviousOutpoint.TxID
 			missingParents = append(missingParents, &txIDCopy)
 		}
-		if mp.isTransactionInPool(&txIn.PreviousOutpoint.TxID) {
+		if view.isTransactionInPool(&txIn.PreviousOutpoint.TxID) {
 			parentsInPool = append(parentsInPool, &txIn.PreviousOutpoint)
 		}
 	}
 	if len(missingParents) > 0 {
-		return missingParents, nil, nil
+		return &txAcceptance{missingParents: missingParents}, nil
 	}
 
 	// Don't allow the transaction into the mempool unless its sequence
 	// lock is active, meaning that it'll be allowed into the next block
 	// with respect to its defined relative lock times.
-	sequenceLock, err := mp.cfg.CalcSequenceLockNoLock(tx, mp.mpUTXOSet)
+	sequenceLock, err := mp.cfg.CalcSequenceLockNoLock(tx, view.utxoSet)
 	if err != nil {
 		var dagRuleErr blockdag.RuleError
 		if ok := errors.As(err, &dagRuleErr); ok {
-			return nil, nil, dagRuleError(dagRuleErr)
+			return nil, dagRuleError(dagRuleErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 	if !blockdag.SequenceLockActive(sequenceLock, nextBlockBlueScore,
 		medianTimePast) {
-		return nil, nil, txRuleError(RejectNonstandard,
+		return nil, txRuleError(RejectNonstandard,
 			"transaction's sequence locks on inputs not met")
 	}
 
 	// Don't allow transactions that exceed the maximum allowed
 	// transaction mass.
-	err = blockdag.ValidateTxMass(tx, mp.mpUTXOSet)
+	err = blockdag.ValidateTxMass(tx, view.utxoSet)
 	if err != nil {
 		var ruleError blockdag.RuleError
 		if ok := errors.As(err, &ruleError); ok {
-			return nil, nil, dagRuleError(ruleError)
+			return nil, dagRuleError(ruleError)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Perform several checks on the transaction inputs using the invariant
@@ -978,19 +1080,19 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	// Also returns the fees associated with the transaction which will be
 	// used later.
 	txFee, err := blockdag.CheckTransactionInputsAndCalulateFee(tx, nextBlockBlueScore,
-		mp.mpUTXOSet, mp.cfg.DAG.Params, false)
+		view.utxoSet, mp.cfg.DAG.Params, false)
 	if err != nil {
 		var dagRuleErr blockdag.RuleError
 		if ok := errors.As(err, &dagRuleErr); ok {
-			return nil, nil, dagRuleError(dagRuleErr)
+			return nil, dagRuleError(dagRuleErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Don't allow transactions with non-standard inputs if the network
 	// parameters forbid their acceptance.
 	if !mp.cfg.Policy.AcceptNonStd {
-		err := checkInputsStandard(tx, mp.mpUTXOSet)
+		err := checkInputsStandard(tx, view.utxoSet)
 		if err != nil {
 			// Attempt to extract a reject code from the error so
 			// it can be retained. When not possible, fall back to
@@ -1001,7 +1103,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 			}
 			str := fmt.Sprintf("transaction %s has a non-standard "+
 				"input: %s", txID, err)
-			return nil, nil, txRuleError(rejectCode, str)
+			return nil, txRuleError(rejectCode, str)
 		}
 	}
 
@@ -1012,7 +1114,7 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 	// Don't allow transactions with 0 fees.
 	if txFee == 0 {
 		str := fmt.Sprintf("transaction %s has 0 fees", txID)
-		return nil, nil, txRuleError(RejectInsufficientFee, str)
+		return nil, txRuleError(RejectInsufficientFee, str)
 	}
 
 	// Don't allow transactions with fees too low to get into a mined block.
@@ -1033,31 +1135,37 @@ func (mp *TxPool) maybeAcceptTransaction(tx *util.Tx, rejectDupOrphans bool) ([]
 		str := fmt.Sprintf("transaction %s has %d fees which is under "+
 			"the required amount of %d", txID, txFee,
 			minFee)
-		return nil, nil, txRuleError(RejectInsufficientFee, str)
+		return nil, txRuleError(RejectInsufficientFee, str)
+	}
+
+	// Don't allow transactions that would make a chain of unmined
+	// transactions longer or heavier than the policy allows, since
+	// such chains are expensive to reprocess whenever a block is added.
+	mass, err := blockdag.CalcTxMassFromUTXOSet(tx, view.utxoSet)
+	if err != nil {
+		return nil, err
+	}
+	err = view.checkChainLimits(tx, mass)
+	if err != nil {
+		return nil, err
 	}
 
 	// Verify crypto signatures for each input and reject the transaction if
 	// any don't verify.
-	err = blockdag.ValidateTransactionScripts(tx, mp.mpUTXOSet,
+	err = blockdag.ValidateTransactionScripts(tx, view.utxoSet,
 		txscript.StandardVerifyFlags, mp.cfg.SigCache)
 	if err != nil {
 		var dagRuleErr blockdag.RuleError
 		if ok := errors.As(err, &dagRuleErr); ok {
-			return nil, nil, dagRuleError(dagRuleErr)
+			return nil, dagRuleError(dagRuleErr)
 		}
-		return nil, nil, err
-	}
-
-	// Add to transaction pool.
-	txD, err := mp.addTransaction(tx, txFee, parentsInPool)
-	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	log.Debugf("Accepted transaction %s (pool size: %d)", txID,
-		len(mp.pool))
-
-	return nil, txD, nil
+	return &txAcceptance{
+		parentsInPool: parentsInPool,
+		fee:           txFee,
+	}, nil
 }
 
 // processOrphans is the internal function which implements the public
@@ -1240,6 +1348,13 @@ func (mp *TxPool) Count() int {
 	return count
 }
 
+// Policy returns the policy the pool was configured with.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) Policy() Policy {
+	return mp.cfg.Policy
+}
+
 // DepCount returns the number of dependent transactions in the main pool. It does not
 // include the orphan pool.
 //
@@ -1268,8 +1383,8 @@ func (mp *TxPool) TxIDs() []*daghash.TxID {
 	return ids
 }
 
-// TxDescs returns a slice of descriptors for all the transactions in the pool.
-// The descriptors are to be treated as read only.
+// TxDescs returns a slice of copies of the descriptors for all the
+// transactions in the pool.
 //
 // This function is safe for concurrent access.
 func (mp *TxPool) TxDescs() []*TxDesc {
@@ -1278,7 +1393,8 @@ func (mp *TxPool) TxDescs() []*TxDesc {
 	descs := make([]*TxDesc, len(mp.pool))
 	i := 0
 	for _, desc := range mp.pool {
-		descs[i] = desc
+		descCopy := *desc
+		descs[i] = &descCopy
 		i++
 	}
 
@@ -1286,7 +1402,8 @@ func (mp *TxPool) TxDescs() []*TxDesc {
 }
 
 // MiningDescs returns a slice of mining descriptors for all the transactions
-// in the pool.
+// in the pool, along with the best package each of them can be the first to
+// be mined of.
 //
 // This is part of the mining.TxSource interface implementation and is safe for
 // concurrent access as required by the interface contract.
@@ -1296,7 +1413,9 @@ func (mp *TxPool) MiningDescs() []*mining.TxDesc {
 	descs := make([]*mining.TxDesc, len(mp.pool))
 	i := 0
 	for _, desc := range mp.pool {
-		descs[i] = &desc.TxDesc
+		miningDesc := desc.TxDesc
+		miningDesc.PackageFee, miningDesc.PackageMass = mp.bestPackage(desc)
+		descs[i] = &miningDesc
 		i++
 	}
 
@@ -1379,6 +1498,10 @@ func (mp *TxPool) HandleNewBlock(block *util.Block) ([]*util.Tx, error) {
 		for _, acceptedOrphan := range acceptedOrphans {
 			acceptedTxs = append(acceptedTxs, acceptedOrphan.Tx)
 		}
+		err = mp.removeFeeDelta(tx.ID())
+		if err != nil {
+			return nil, err
+		}
 	}
 	return acceptedTxs, nil
 }
@@ -1395,8 +1518,9 @@ func New(cfg *Config) *TxPool {
 		dependsByPrev:  make(map[domainmessage.Outpoint]map[daghash.TxID]*TxDesc),
 		orphans:        make(map[daghash.TxID]*orphanTx),
 		orphansByPrev:  make(map[domainmessage.Outpoint]map[daghash.TxID]*util.Tx),
-		nextExpireScan: mstime.Now().Add(orphanExpireScanInterval),
+		nextExpireScan: cfg.DAG.Now().Add(orphanExpireScanInterval),
 		outpoints:      make(map[domainmessage.Outpoint]*util.Tx),
 		mpUTXOSet:      mpUTXO,
+		feeDeltas:      make(map[daghash.TxID]int64),
 	}
 }
@@ -863,6 +863,301 @@ func TestDoubleSpendsFromDAG(t *testing.T) {
 	testPoolMembership(tc, tx, false, false, false)
 }
 
+// TestCheckAcceptance checks that CheckAcceptance reports whether
+// transactions would be accepted without adding them to the pool, and
+// that it checks the transactions it's given as a package.
+func TestCheckAcceptance(t *testing.T) {
+	tc, spendableOuts, teardownFunc, err := newPoolHarness(t, &dagconfig.SimnetParams, 2, "TestCheckAcceptance")
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	defer teardownFunc()
+	harness := tc.harness
+
+	parentTx, err := harness.createTx(spendableOuts[0], uint64(txRelayFeeForTest), 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	childTx, err := harness.createTx(txOutToSpendableOutpoint(parentTx, 0), uint64(txRelayFeeForTest)+1, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	doubleSpendTx, err := harness.createTx(spendableOuts[0], uint64(txRelayFeeForTest)+2, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+
+	// A transaction that spends the UTXO set is accepted, and is left
+	// out of the pool.
+	results, err := harness.txPool.CheckAcceptance([]*util.Tx{parentTx})
+	if err != nil {
+		t.Fatalf("CheckAcceptance: %s", err)
+	}
+	if results[0].Err != nil {
+		t.Fatalf("CheckAcceptance: unexpectedly rejected parentTx: %s", results[0].Err)
+	}
+	if results[0].Fee != uint64(txRelayFeeForTest) {
+		t.Errorf("CheckAcceptance: expected fee %d but got %d", txRelayFeeForTest, results[0].Fee)
+	}
+	if results[0].Mass == 0 {
+		t.Errorf("CheckAcceptance: expected a non-zero mass")
+	}
+	testPoolMembership(tc, parentTx, false, false, false)
+
+	// On its own, the child is an orphan, which is rejected.
+	results, err = harness.txPool.CheckAcceptance([]*util.Tx{childTx})
+	if err != nil {
+		t.Fatalf("CheckAcceptance: %s", err)
+	}
+	if results[0].Err == nil {
+		t.Fatalf("CheckAcceptance: expected childTx to be rejected")
+	}
+	testPoolMembership(tc, childTx, false, false, false)
+
+	// As a package, the child spends the output of the parent, and the
+	// transaction that double spends the parent is rejected.
+	results, err = harness.txPool.CheckAcceptance([]*util.Tx{parentTx, childTx, doubleSpendTx})
+	if err != nil {
+		t.Fatalf("CheckAcceptance: %s", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("CheckAcceptance: unexpectedly rejected parentTx: %s", results[0].Err)
+	}
+	if results[1].Err != nil {
+		t.Errorf("CheckAcceptance: unexpectedly rejected childTx: %s", results[1].Err)
+	}
+	if code, _ := extractRejectCode(results[2].Err); code != RejectDuplicate {
+		t.Errorf("Unexpected error code. Expected %v but got %v", RejectDuplicate, code)
+	}
+	testPoolMembership(tc, parentTx, false, false, false)
+	testPoolMembership(tc, childTx, false, false, false)
+
+	// The package didn't leave anything behind, so the parent is still
+	// accepted into the pool.
+	_, err = harness.txPool.ProcessTransaction(parentTx, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: %s", err)
+	}
+
+	// Now that the parent is in the pool, the transaction that double
+	// spends it is rejected on its own too.
+	results, err = harness.txPool.CheckAcceptance([]*util.Tx{doubleSpendTx})
+	if err != nil {
+		t.Fatalf("CheckAcceptance: %s", err)
+	}
+	if code, _ := extractRejectCode(results[0].Err); code != RejectDuplicate {
+		t.Errorf("Unexpected error code. Expected %v but got %v", RejectDuplicate, code)
+	}
+}
+
+// TestPackageStats checks that the ancestor and descendant stats of the
+// transactions in the pool are kept up to date, and that MiningDescs values
+// a low-fee parent by the package of its high-fee child.
+func TestPackageStats(t *testing.T) {
+	tc, spendableOuts, teardownFunc, err := newPoolHarness(t, &dagconfig.SimnetParams, 1, "TestPackageStats")
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	defer teardownFunc()
+	harness := tc.harness
+
+	parentFee := uint64(txRelayFeeForTest)
+	childFee := 100 * uint64(txRelayFeeForTest)
+	parentTx, err := harness.createTx(spendableOuts[0], parentFee, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	childTx, err := harness.createTx(txOutToSpendableOutpoint(parentTx, 0), childFee, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	for _, tx := range []*util.Tx{parentTx, childTx} {
+		_, err = harness.txPool.ProcessTransaction(tx, true, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: %s", err)
+		}
+	}
+
+	parentDesc, ok := harness.txPool.FetchTxDesc(parentTx.ID())
+	if !ok {
+		t.Fatalf("FetchTxDesc: parentTx is not in the pool")
+	}
+	childDesc, ok := harness.txPool.FetchTxDesc(childTx.ID())
+	if !ok {
+		t.Fatalf("FetchTxDesc: childTx is not in the pool")
+	}
+	totalMass := parentDesc.Mass + childDesc.Mass
+
+	if parentDesc.AncestorCount != 1 || parentDesc.AncestorFee != parentFee || parentDesc.AncestorMass != parentDesc.Mass {
+		t.Errorf("unexpected parent ancestor stats: count %d, fee %d, mass %d",
+			parentDesc.AncestorCount, parentDesc.AncestorFee, parentDesc.AncestorMass)
+	}
+	if parentDesc.DescendantCount != 2 || parentDesc.DescendantFee != parentFee+childFee || parentDesc.DescendantMass != totalMass {
+		t.Errorf("unexpected parent descendant stats: count %d, fee %d, mass %d",
+			parentDesc.DescendantCount, parentDesc.DescendantFee, parentDesc.DescendantMass)
+	}
+	if childDesc.AncestorCount != 2 || childDesc.AncestorFee != parentFee+childFee || childDesc.AncestorMass != totalMass {
+		t.Errorf("unexpected child ancestor stats: count %d, fee %d, mass %d",
+			childDesc.AncestorCount, childDesc.AncestorFee, childDesc.AncestorMass)
+	}
+	if childDesc.DescendantCount != 1 || childDesc.DescendantFee != childFee || childDesc.DescendantMass != childDesc.Mass {
+		t.Errorf("unexpected child descendant stats: count %d, fee %d, mass %d",
+			childDesc.DescendantCount, childDesc.DescendantFee, childDesc.DescendantMass)
+	}
+
+	// Only the parent can be mined, and it's valued by the package of
+	// its child.
+	miningDescs := harness.txPool.MiningDescs()
+	if len(miningDescs) != 1 {
+		t.Fatalf("MiningDescs: expected 1 transaction but got %d", len(miningDescs))
+	}
+	if miningDescs[0].PackageFee != parentFee+childFee || miningDescs[0].PackageMass != totalMass {
+		t.Errorf("MiningDescs: unexpected package: fee %d, mass %d",
+			miningDescs[0].PackageFee, miningDescs[0].PackageMass)
+	}
+
+	// Once the parent is mined, the child has no ancestors in the pool.
+	tc.mineTransactions([]*util.Tx{parentTx}, 1)
+	childDesc, ok = harness.txPool.FetchTxDesc(childTx.ID())
+	if !ok {
+		t.Fatalf("FetchTxDesc: childTx is not in the pool")
+	}
+	if childDesc.AncestorCount != 1 || childDesc.AncestorFee != childFee || childDesc.AncestorMass != childDesc.Mass {
+		t.Errorf("unexpected child ancestor stats after mining the parent: count %d, fee %d, mass %d",
+			childDesc.AncestorCount, childDesc.AncestorFee, childDesc.AncestorMass)
+	}
+
+	// A new grandchild is added to the descendant stats of the child, and
+	// removing it restores them.
+	grandchildTx, err := harness.createTx(txOutToSpendableOutpoint(childTx, 0), uint64(txRelayFeeForTest), 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(grandchildTx, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: %s", err)
+	}
+	childDesc, _ = harness.txPool.FetchTxDesc(childTx.ID())
+	if childDesc.DescendantCount != 2 || childDesc.DescendantFee != childFee+uint64(txRelayFeeForTest) {
+		t.Errorf("unexpected child descendant stats after adding the grandchild: count %d, fee %d",
+			childDesc.DescendantCount, childDesc.DescendantFee)
+	}
+	err = harness.txPool.RemoveTransaction(grandchildTx, true, true)
+	if err != nil {
+		t.Fatalf("RemoveTransaction: %s", err)
+	}
+	childDesc, _ = harness.txPool.FetchTxDesc(childTx.ID())
+	if childDesc.DescendantCount != 1 || childDesc.DescendantFee != childFee || childDesc.DescendantMass != childDesc.Mass {
+		t.Errorf("unexpected child descendant stats after removing the grandchild: count %d, fee %d, mass %d",
+			childDesc.DescendantCount, childDesc.DescendantFee, childDesc.DescendantMass)
+	}
+}
+
+// TestChainLimits checks that transactions that would make a chain of
+// unmined transactions exceed the limits of the policy are rejected.
+func TestChainLimits(t *testing.T) {
+	tc, spendableOuts, teardownFunc, err := newPoolHarness(t, &dagconfig.SimnetParams, 3, "TestChainLimits")
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	defer teardownFunc()
+	harness := tc.harness
+	policy := &harness.txPool.cfg.Policy
+
+	expectChainLimitError := func(err error, expectedSubstring string) {
+		if err == nil {
+			t.Fatalf("expected a chain limit error, but got nil")
+		}
+		if code, _ := extractRejectCode(err); code != RejectNonstandard {
+			t.Errorf("Unexpected error code. Expected %v but got %v", RejectNonstandard, code)
+		}
+		if !strings.Contains(err.Error(), expectedSubstring) {
+			t.Errorf("expected the error to contain %q, but got: %s", expectedSubstring, err)
+		}
+	}
+
+	// A chain of 3 transactions fits a limit of 3 ancestors, but the
+	// fourth transaction doesn't.
+	policy.MaxAncestorCount = 3
+	chainedTxns, err := harness.CreateTxChain(spendableOuts[0], 4)
+	if err != nil {
+		t.Fatalf("harness.CreateTxChain: unexpected error: %v", err)
+	}
+	for _, tx := range chainedTxns[:3] {
+		_, err = harness.txPool.ProcessTransaction(tx, true, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: %s", err)
+		}
+	}
+	_, err = harness.txPool.ProcessTransaction(chainedTxns[3], true, 0)
+	expectChainLimitError(err, "unmined ancestors")
+	testPoolMembership(tc, chainedTxns[3], false, false, false)
+	policy.MaxAncestorCount = 0
+
+	// A parent with two children exceeds a limit of 2 descendants, both
+	// in the pool and in a package checked by CheckAcceptance.
+	policy.MaxDescendantCount = 2
+	parentTx, err := harness.createTx(spendableOuts[1], uint64(txRelayFeeForTest), 2)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	child1, err := harness.createTx(txOutToSpendableOutpoint(parentTx, 0), uint64(txRelayFeeForTest), 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	child2, err := harness.createTx(txOutToSpendableOutpoint(parentTx, 1), uint64(txRelayFeeForTest), 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	results, err := harness.txPool.CheckAcceptance([]*util.Tx{parentTx, child1, child2})
+	if err != nil {
+		t.Fatalf("CheckAcceptance: %s", err)
+	}
+	if results[0].Err != nil || results[1].Err != nil {
+		t.Errorf("CheckAcceptance: unexpectedly rejected: %v, %v", results[0].Err, results[1].Err)
+	}
+	expectChainLimitError(results[2].Err, "unmined descendants")
+
+	for _, tx := range []*util.Tx{parentTx, child1} {
+		_, err = harness.txPool.ProcessTransaction(tx, true, 0)
+		if err != nil {
+			t.Fatalf("ProcessTransaction: %s", err)
+		}
+	}
+	_, err = harness.txPool.ProcessTransaction(child2, true, 0)
+	expectChainLimitError(err, "unmined descendants")
+	policy.MaxDescendantCount = 0
+
+	// The mass limits are checked against the total mass of the chain.
+	massParentTx, err := harness.createTx(spendableOuts[2], uint64(txRelayFeeForTest), 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	massChildTx, err := harness.createTx(txOutToSpendableOutpoint(massParentTx, 0), uint64(txRelayFeeForTest), 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(massParentTx, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: %s", err)
+	}
+	massParentDesc, _ := harness.txPool.FetchTxDesc(massParentTx.ID())
+	policy.MaxAncestorMass = massParentDesc.Mass + 1
+	_, err = harness.txPool.ProcessTransaction(massChildTx, true, 0)
+	expectChainLimitError(err, "total mass")
+	policy.MaxAncestorMass = 0
+
+	policy.MaxDescendantMass = massParentDesc.Mass + 1
+	_, err = harness.txPool.ProcessTransaction(massChildTx, true, 0)
+	expectChainLimitError(err, "total mass")
+	policy.MaxDescendantMass = 0
+
+	_, err = harness.txPool.ProcessTransaction(massChildTx, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: %s", err)
+	}
+}
+
 // TestFetchTransaction checks that FetchTransaction
 // returns only transaction from the main pool and not from the orphan pool
 func TestFetchTransaction(t *testing.T) {
@@ -0,0 +1,208 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// txAncestors returns the transactions in the pool that the passed
+// transaction depends on, directly or indirectly.
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) txAncestors(txD *TxDesc) map[daghash.TxID]*TxDesc {
+	return mp.poolView().txAncestors(txD.Tx)
+}
+
+// txDescendants returns the transactions in the pool that depend on the
+// passed transaction, directly or indirectly.
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) txDescendants(txD *TxDesc) map[daghash.TxID]*TxDesc {
+	return mp.poolView().txDescendants(txD.Tx)
+}
+
+// txAncestors returns the transactions in the view that the passed
+// transaction depends on, directly or indirectly.
+func (view *poolView) txAncestors(tx *util.Tx) map[daghash.TxID]*TxDesc {
+	ancestors := make(map[daghash.TxID]*TxDesc)
+	queue := []*util.Tx{tx}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, txIn := range current.MsgTx().TxIn {
+			parentID := txIn.PreviousOutpoint.TxID
+			if _, visited := ancestors[parentID]; visited {
+				continue
+			}
+			parent, exists := view.fetchTxDesc(&parentID)
+			if !exists {
+				continue
+			}
+			ancestors[parentID] = parent
+			queue = append(queue, parent.Tx)
+		}
+	}
+	return ancestors
+}
+
+// txDescendants returns the transactions in the view that depend on the
+// passed transaction, directly or indirectly.
+func (view *poolView) txDescendants(tx *util.Tx) map[daghash.TxID]*TxDesc {
+	descendants := make(map[daghash.TxID]*TxDesc)
+	queue := []*util.Tx{tx}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		outpoint := domainmessage.Outpoint{TxID: *current.ID()}
+		for i := range current.MsgTx().TxOut {
+			outpoint.Index = uint32(i)
+			redeemer, exists := view.redeemer(outpoint)
+			if !exists {
+				continue
+			}
+			if _, visited := descendants[*redeemer.ID()]; visited {
+				continue
+			}
+			child, exists := view.fetchTxDesc(redeemer.ID())
+			if !exists {
+				continue
+			}
+			descendants[*redeemer.ID()] = child
+			queue = append(queue, child.Tx)
+		}
+	}
+	return descendants
+}
+
+// checkChainLimits checks that adding the passed transaction to the view
+// wouldn't make its chain of unmined ancestors, or the chain of unmined
+// descendants of any of its ancestors, exceed the limits of the policy.
+func (view *poolView) checkChainLimits(tx *util.Tx, mass uint64) error {
+	policy := &view.mp.cfg.Policy
+	ancestors := view.txAncestors(tx)
+
+	ancestorCount, ancestorMass := uint64(len(ancestors))+1, mass
+	for _, ancestor := range ancestors {
+		ancestorMass += ancestor.Mass
+	}
+	if policy.MaxAncestorCount != 0 && ancestorCount > policy.MaxAncestorCount {
+		str := fmt.Sprintf("transaction %s has %d unmined ancestors including "+
+			"itself, which is more than the limit of %d", tx.ID(),
+			ancestorCount, policy.MaxAncestorCount)
+		return txRuleError(RejectNonstandard, str)
+	}
+	if policy.MaxAncestorMass != 0 && ancestorMass > policy.MaxAncestorMass {
+		str := fmt.Sprintf("transaction %s has unmined ancestors with a total "+
+			"mass of %d including itself, which is more than the limit of %d",
+			tx.ID(), ancestorMass, policy.MaxAncestorMass)
+		return txRuleError(RejectNonstandard, str)
+	}
+
+	if policy.MaxDescendantCount == 0 && policy.MaxDescendantMass == 0 {
+		return nil
+	}
+	for ancestorID, ancestor := range ancestors {
+		descendants := view.txDescendants(ancestor.Tx)
+		descendantCount, descendantMass := uint64(len(descendants))+2, ancestor.Mass+mass
+		for _, descendant := range descendants {
+			descendantMass += descendant.Mass
+		}
+		if policy.MaxDescendantCount != 0 && descendantCount > policy.MaxDescendantCount {
+			str := fmt.Sprintf("transaction %s would give its ancestor %s %d "+
+				"unmined descendants including itself, which is more than the "+
+				"limit of %d", tx.ID(), ancestorID, descendantCount,
+				policy.MaxDescendantCount)
+			return txRuleError(RejectNonstandard, str)
+		}
+		if policy.MaxDescendantMass != 0 && descendantMass > policy.MaxDescendantMass {
+			str := fmt.Sprintf("transaction %s would give its ancestor %s "+
+				"unmined descendants with a total mass of %d including itself, "+
+				"which is more than the limit of %d", tx.ID(), ancestorID,
+				descendantMass, policy.MaxDescendantMass)
+			return txRuleError(RejectNonstandard, str)
+		}
+	}
+	return nil
+}
+
+// addToPackageStats initializes the ancestor and descendant stats of a
+// transaction that is being added to the pool, and adds it to the
+// descendant stats of its ancestors. The transaction's parents must
+// already be in the pool.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) addToPackageStats(txD *TxDesc) {
+	fee := txD.ModifiedFee()
+	txD.AncestorCount, txD.AncestorFee, txD.AncestorMass = 1, fee, txD.Mass
+	txD.DescendantCount, txD.DescendantFee, txD.DescendantMass = 1, fee, txD.Mass
+
+	for _, ancestor := range mp.txAncestors(txD) {
+		txD.AncestorCount++
+		txD.AncestorFee += ancestor.ModifiedFee()
+		txD.AncestorMass += ancestor.Mass
+
+		ancestor.DescendantCount++
+		ancestor.DescendantFee += fee
+		ancestor.DescendantMass += txD.Mass
+	}
+}
+
+// removeFromPackageStats removes a transaction that is being removed from
+// the pool from the stats of its ancestors and descendants. It must be
+// called while the transaction is still linked to them.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) removeFromPackageStats(txD *TxDesc) {
+	fee := txD.ModifiedFee()
+	for _, ancestor := range mp.txAncestors(txD) {
+		ancestor.DescendantCount--
+		ancestor.DescendantFee -= fee
+		ancestor.DescendantMass -= txD.Mass
+	}
+	for _, descendant := range mp.txDescendants(txD) {
+		descendant.AncestorCount--
+		descendant.AncestorFee -= fee
+		descendant.AncestorMass -= txD.Mass
+	}
+}
+
+// setFeeDelta changes the fee delta of a transaction in the pool, and
+// updates the fee stats of the transaction, its ancestors and its
+// descendants accordingly.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) setFeeDelta(txD *TxDesc, feeDelta int64) {
+	oldFee := txD.ModifiedFee()
+	txD.FeeDelta = feeDelta
+	newFee := txD.ModifiedFee()
+
+	txD.AncestorFee = txD.AncestorFee - oldFee + newFee
+	txD.DescendantFee = txD.DescendantFee - oldFee + newFee
+	for _, ancestor := range mp.txAncestors(txD) {
+		ancestor.DescendantFee = ancestor.DescendantFee - oldFee + newFee
+	}
+	for _, descendant := range mp.txDescendants(txD) {
+		descendant.AncestorFee = descendant.AncestorFee - oldFee + newFee
+	}
+}
+
+// bestPackage returns the total fee and mass of the package with the
+// highest fee per mass that the passed transaction can be the first to
+// be mined of: either the transaction itself, or one of its descendants
+// together with all of that descendant's ancestors.
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) bestPackage(txD *TxDesc) (fee uint64, mass uint64) {
+	fee, mass = txD.AncestorFee, txD.AncestorMass
+	for _, descendant := range mp.txDescendants(txD) {
+		if float64(descendant.AncestorFee)/float64(descendant.AncestorMass) >
+			float64(fee)/float64(mass) {
+
+			fee, mass = descendant.AncestorFee, descendant.AncestorMass
+		}
+	}
+	return fee, mass
+}
@@ -0,0 +1,82 @@
+package mempool
+
+import (
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// PrioritiseTransaction sets the fee delta of the transaction with the
+// given ID. The fee delta is a virtual amount that is added to the fee
+// of the transaction when it's valued for mining and when its ancestor
+// and descendant fees are calculated, without changing the fee it
+// actually pays. A zero fee delta removes the prioritisation.
+//
+// The transaction doesn't have to be in the pool: the fee delta applies
+// once it's added, and is kept until the transaction is mined. Fee deltas
+// are stored in the database, if the pool has one, so that they survive
+// restarts.
+//
+// This function is safe for concurrent access.
+func (mp *TxPool) PrioritiseTransaction(txID *daghash.TxID, feeDelta int64) error {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	if feeDelta == 0 {
+		return mp.removeFeeDelta(txID)
+	}
+
+	if mp.cfg.DatabaseContext != nil {
+		err := dbaccess.StoreFeeDelta(mp.cfg.DatabaseContext, txID, feeDelta)
+		if err != nil {
+			return err
+		}
+	}
+	mp.feeDeltas[*txID] = feeDelta
+	if txDesc, exists := mp.fetchTxDesc(txID); exists {
+		mp.setFeeDelta(txDesc, feeDelta)
+	}
+	return nil
+}
+
+// removeFeeDelta removes the fee delta of the transaction with the given
+// ID, if it has one.
+//
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) removeFeeDelta(txID *daghash.TxID) error {
+	if _, exists := mp.feeDeltas[*txID]; !exists {
+		return nil
+	}
+
+	if mp.cfg.DatabaseContext != nil {
+		err := dbaccess.RemoveFeeDelta(mp.cfg.DatabaseContext, txID)
+		if err != nil {
+			return err
+		}
+	}
+	delete(mp.feeDeltas, *txID)
+	if txDesc, exists := mp.fetchTxDesc(txID); exists {
+		mp.setFeeDelta(txDesc, 0)
+	}
+	return nil
+}
+
+// LoadFeeDeltas loads the fee deltas of the prioritised transactions from
+// the database. It's meant to be called once, before any transaction is
+// added to the pool.
+func (mp *TxPool) LoadFeeDeltas() error {
+	if mp.cfg.DatabaseContext == nil {
+		return nil
+	}
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	feeDeltas, err := dbaccess.FetchFeeDeltas(mp.cfg.DatabaseContext)
+	if err != nil {
+		return err
+	}
+	mp.feeDeltas = feeDeltas
+
+	log.Infof("Loaded %d transaction fee deltas from the database", len(feeDeltas))
+	return nil
+}
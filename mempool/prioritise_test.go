@@ -0,0 +1,117 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/dagconfig"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// TestPrioritiseTransaction checks that fee deltas are applied to the fee
+// stats of transactions in the pool and of transactions that are added
+// later, that they're persisted, and that they're removed once they're set
+// to zero or the transaction is mined.
+func TestPrioritiseTransaction(t *testing.T) {
+	tc, spendableOuts, teardownFunc, err := newPoolHarness(t, &dagconfig.SimnetParams, 1, "TestPrioritiseTransaction")
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	defer teardownFunc()
+	harness := tc.harness
+
+	databaseContext := dbaccess.NewInMemory()
+	defer databaseContext.Close()
+	harness.txPool.cfg.DatabaseContext = databaseContext
+
+	fee := uint64(txRelayFeeForTest)
+	parentTx, err := harness.createTx(spendableOuts[0], fee, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	childTx, err := harness.createTx(txOutToSpendableOutpoint(parentTx, 0), fee, 1)
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(parentTx, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: %s", err)
+	}
+
+	// The child is prioritised before it's in the pool, and the parent
+	// is deprioritised below zero.
+	const childFeeDelta = 5000
+	err = harness.txPool.PrioritiseTransaction(childTx.ID(), childFeeDelta)
+	if err != nil {
+		t.Fatalf("PrioritiseTransaction: %s", err)
+	}
+	err = harness.txPool.PrioritiseTransaction(parentTx.ID(), -int64(2*fee))
+	if err != nil {
+		t.Fatalf("PrioritiseTransaction: %s", err)
+	}
+	_, err = harness.txPool.ProcessTransaction(childTx, true, 0)
+	if err != nil {
+		t.Fatalf("ProcessTransaction: %s", err)
+	}
+
+	parentDesc, _ := harness.txPool.FetchTxDesc(parentTx.ID())
+	if parentDesc.ModifiedFee() != 0 {
+		t.Errorf("expected the parent's modified fee to be 0 but got %d", parentDesc.ModifiedFee())
+	}
+	if parentDesc.DescendantFee != fee+childFeeDelta {
+		t.Errorf("expected the parent's descendant fee to be %d but got %d",
+			fee+childFeeDelta, parentDesc.DescendantFee)
+	}
+	childDesc, _ := harness.txPool.FetchTxDesc(childTx.ID())
+	if childDesc.FeeDelta != childFeeDelta || childDesc.ModifiedFee() != fee+childFeeDelta {
+		t.Errorf("unexpected child fee delta %d and modified fee %d", childDesc.FeeDelta, childDesc.ModifiedFee())
+	}
+	if childDesc.AncestorFee != fee+childFeeDelta {
+		t.Errorf("expected the child's ancestor fee to be %d but got %d",
+			fee+childFeeDelta, childDesc.AncestorFee)
+	}
+	miningDescs := harness.txPool.MiningDescs()
+	if len(miningDescs) != 1 || miningDescs[0].PackageFee != fee+childFeeDelta {
+		t.Errorf("MiningDescs: expected the parent to be valued by a package fee of %d", fee+childFeeDelta)
+	}
+
+	// A new pool that uses the same database loads the fee deltas.
+	newPool := New(&harness.txPool.cfg)
+	err = newPool.LoadFeeDeltas()
+	if err != nil {
+		t.Fatalf("LoadFeeDeltas: %s", err)
+	}
+	if len(newPool.feeDeltas) != 2 || newPool.feeDeltas[*childTx.ID()] != childFeeDelta {
+		t.Errorf("LoadFeeDeltas: unexpected fee deltas %v", newPool.feeDeltas)
+	}
+
+	// Removing the prioritisation of the parent restores its fee.
+	err = harness.txPool.PrioritiseTransaction(parentTx.ID(), 0)
+	if err != nil {
+		t.Fatalf("PrioritiseTransaction: %s", err)
+	}
+	childDesc, _ = harness.txPool.FetchTxDesc(childTx.ID())
+	if childDesc.AncestorFee != 2*fee+childFeeDelta {
+		t.Errorf("expected the child's ancestor fee to be %d but got %d",
+			2*fee+childFeeDelta, childDesc.AncestorFee)
+	}
+
+	// Once the child is mined, its fee delta is removed.
+	block := util.NewBlock(&domainmessage.MsgBlock{
+		Header:       dummyBlock.Header,
+		Transactions: []*domainmessage.MsgTx{dummyBlock.Transactions[0], parentTx.MsgTx(), childTx.MsgTx()},
+	})
+	_, err = harness.txPool.HandleNewBlock(block)
+	if err != nil {
+		t.Fatalf("HandleNewBlock: %s", err)
+	}
+	feeDeltas, err := dbaccess.FetchFeeDeltas(databaseContext)
+	if err != nil {
+		t.Fatalf("FetchFeeDeltas: %s", err)
+	}
+	if len(harness.txPool.feeDeltas) != 0 || len(feeDeltas) != 0 {
+		t.Errorf("expected no fee deltas after mining the child, but got %v in the pool "+
+			"and %v in the database", harness.txPool.feeDeltas, feeDeltas)
+	}
+}
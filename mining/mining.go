@@ -37,6 +37,16 @@ type TxDesc struct {
 
 	// FeePerMegaGram is the fee the transaction pays in sompi per million gram.
 	FeePerMegaGram uint64
+
+	// PackageFee and PackageMass are the total fee and mass of the
+	// package of transactions with the highest fee per mass that the
+	// transaction can be the first to be mined of: either the transaction
+	// itself, or one of its descendants in the source pool together with
+	// all of that descendant's ancestors. This lets a high-fee child pay
+	// for its low-fee parent. If PackageMass is zero, the transaction is
+	// valued on its own.
+	PackageFee  uint64
+	PackageMass uint64
 }
 
 // TxSource represents a source of transactions to consider for inclusion in
@@ -185,6 +195,7 @@ func (g *BlkTmplGenerator) NewBlockTemplate(payToAddress util.Address, extraNonc
 	if err != nil {
 		return nil, err
 	}
+	msgBlock.Header.Version = g.blockVersion(msgBlock.Header.Version)
 
 	// Finally, perform a full check on the created block against the DAG
 	// consensus rules to ensure it properly connects to the DAG with no
@@ -207,6 +218,22 @@ func (g *BlkTmplGenerator) NewBlockTemplate(payToAddress util.Address, extraNonc
 	}, nil
 }
 
+// blockVersion applies the block version override and the deployment
+// signalling preferences of the policy to the given block version.
+func (g *BlkTmplGenerator) blockVersion(version int32) int32 {
+	if g.policy.BlockVersion != 0 {
+		return g.policy.BlockVersion
+	}
+	for bit, signal := range g.policy.SignalDeployments {
+		if signal {
+			version |= 1 << bit
+		} else {
+			version &^= 1 << bit
+		}
+	}
+	return version
+}
+
 // UpdateBlockTime updates the timestamp in the header of the passed block to
 // the current time while taking into account the median time of the last
 // several blocks to ensure the new time is after that time per the DAG
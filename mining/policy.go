@@ -11,4 +11,14 @@ type Policy struct {
 	// BlockMaxMass is the maximum block mass to be used when generating a
 	// block template.
 	BlockMaxMass uint64
+
+	// BlockVersion, if non-zero, overrides the version of block templates
+	// that is otherwise calculated from the state of the rule change
+	// deployments.
+	BlockVersion int32
+
+	// SignalDeployments overrides whether block templates signal for the
+	// rule change deployments with the given bit numbers: true always sets
+	// the deployment's bit, and false never sets it.
+	SignalDeployments map[uint8]bool
 }
@@ -167,7 +167,7 @@ func (g *BlkTmplGenerator) collectCandidatesTxs(sourceTxs []*TxDesc) []*candidat
 		}
 
 		// Calculate the tx value
-		txValue, err := g.calcTxValue(tx, txDesc.Fee)
+		txValue, err := g.calcTxValue(txDesc, txMass)
 		if err != nil {
 			log.Warnf("Skipping tx %s due to error in "+
 				"calcTxValue: %s", tx.ID(), err)
@@ -194,14 +194,19 @@ func (g *BlkTmplGenerator) collectCandidatesTxs(sourceTxs []*TxDesc) []*candidat
 // calcTxValue calculates a value to be used in transaction selection.
 // The higher the number the more likely it is that the transaction will be
 // included in the block.
-func (g *BlkTmplGenerator) calcTxValue(tx *util.Tx, fee uint64) (float64, error) {
-	mass, err := blockdag.CalcTxMassFromUTXOSet(tx, g.dag.UTXOSet())
-	if err != nil {
-		return 0, err
+//
+// The value is the fee per mass of the transaction's package, so a
+// transaction whose descendants pay high fees is valued as if it paid
+// them itself. The descendants can't be included in the same block as
+// the transaction, but including it makes them eligible for the next one.
+func (g *BlkTmplGenerator) calcTxValue(txDesc *TxDesc, txMass uint64) (float64, error) {
+	fee, mass := txDesc.Fee, txMass
+	if txDesc.PackageMass != 0 {
+		fee, mass = txDesc.PackageFee, txDesc.PackageMass
 	}
 	massLimit := g.policy.BlockMaxMass
 
-	msgTx := tx.MsgTx()
+	msgTx := txDesc.Tx.MsgTx()
 	if msgTx.SubnetworkID.IsBuiltInOrNative() {
 		return float64(fee) / (float64(mass) / float64(massLimit)), nil
 	}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/kaspanet/kaspad/config"
 	"github.com/kaspanet/kaspad/domainmessage"
@@ -42,7 +43,11 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	if err != nil {
 		return nil, err
 	}
-	s, err := grpcserver.NewGRPCServer(cfg.Listeners)
+	security, err := loadTransportSecurity(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := grpcserver.NewGRPCServer(cfg.Listeners, security)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +60,7 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 	}
 
 	adapter.server.SetOnConnectedHandler(adapter.onConnectedHandler)
+	adapter.server.SetLatency(cfg.NetLatency)
 
 	return &adapter, nil
 }
@@ -98,6 +104,18 @@ func (na *NetAdapter) Connections() []*NetConnection {
 	return netConnections
 }
 
+// TrafficStats returns the traffic sent and received over all the
+// connections since the NetAdapter was created
+func (na *NetAdapter) TrafficStats() *server.TrafficStats {
+	return na.server.TrafficStats()
+}
+
+// SetLatency sets the simulated network latency by which every incoming
+// message is delayed. A latency of 0 disables the delay.
+func (na *NetAdapter) SetLatency(latency time.Duration) {
+	na.server.SetLatency(latency)
+}
+
 // ConnectionCount returns the count of the connected connections
 func (na *NetAdapter) ConnectionCount() int {
 	na.connectionsLock.RLock()
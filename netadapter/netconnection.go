@@ -71,6 +71,16 @@ func (c *NetConnection) IsOutbound() bool {
 	return c.connection.IsOutbound()
 }
 
+// IsEncrypted returns whether the connection is encrypted
+func (c *NetConnection) IsEncrypted() bool {
+	return c.connection.IsEncrypted()
+}
+
+// TrafficStats returns the traffic sent and received over this connection
+func (c *NetConnection) TrafficStats() *server.TrafficStats {
+	return c.connection.TrafficStats()
+}
+
 // NetAddress returns the NetAddress associated with this connection
 func (c *NetConnection) NetAddress() *domainmessage.NetAddress {
 	return domainmessage.NewNetAddress(c.connection.Address(), 0)
@@ -36,6 +36,10 @@ type Route struct {
 	closed    bool
 	closeLock sync.Mutex
 
+	// closeChan is closed once the route is closed, so that it can be
+	// waited on along with other channels
+	closeChan chan struct{}
+
 	onCapacityReachedHandler onCapacityReachedHandler
 }
 
@@ -46,8 +50,9 @@ func NewRoute() *Route {
 
 func newRouteWithCapacity(capacity int) *Route {
 	return &Route{
-		channel: make(chan domainmessage.Message, capacity),
-		closed:  false,
+		channel:   make(chan domainmessage.Message, capacity),
+		closed:    false,
+		closeChan: make(chan struct{}),
 	}
 }
 
@@ -100,4 +105,10 @@ func (r *Route) Close() {
 
 	r.closed = true
 	close(r.channel)
+	close(r.closeChan)
+}
+
+// CloseChan returns a channel that is closed once this route is closed
+func (r *Route) CloseChan() <-chan struct{} {
+	return r.closeChan
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/golang/protobuf/proto"
 	"github.com/kaspanet/kaspad/logger"
 
 	"github.com/kaspanet/kaspad/netadapter/server/grpcserver/protowire"
@@ -57,6 +58,9 @@ func (c *gRPCConnection) sendLoop() error {
 			return err
 		}
 
+		messageSize := uint64(proto.Size(messageProto))
+		c.trafficStats.AddSent(message.Command(), messageSize)
+		c.server.trafficStats.AddSent(message.Command(), messageSize)
 	}
 	return nil
 }
@@ -77,10 +81,25 @@ func (c *gRPCConnection) receiveLoop() error {
 			return err
 		}
 
+		messageSize := uint64(proto.Size(protoMessage))
+		c.trafficStats.AddReceived(message.Command(), messageSize)
+		c.server.trafficStats.AddReceived(message.Command(), messageSize)
+
 		messageNumber++
 		message.SetMessageNumber(messageNumber)
 		message.SetReceivedAt(time.Now())
 
+		// Messages are delayed relative to the time they were received at,
+		// so that the simulated latency doesn't limit the throughput.
+		latency := c.server.latencyDuration()
+		if latency > 0 {
+			select {
+			case <-time.After(time.Until(message.ReceivedAt().Add(latency))):
+			case <-c.stopChan:
+				return nil
+			}
+		}
+
 		log.Debugf("incoming '%s' message from %s (message number %d)", message.Command(), c,
 			message.MessageNumber())
 
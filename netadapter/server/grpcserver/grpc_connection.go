@@ -12,11 +12,14 @@ import (
 )
 
 type gRPCConnection struct {
-	server     *gRPCServer
-	address    *net.TCPAddr
-	isOutbound bool
-	stream     grpcStream
-	router     *router.Router
+	server      *gRPCServer
+	address     *net.TCPAddr
+	isOutbound  bool
+	isEncrypted bool
+	stream      grpcStream
+	router      *router.Router
+
+	trafficStats *server.TrafficStats
 
 	stopChan                chan struct{}
 	clientConn              grpc.ClientConn
@@ -26,14 +29,19 @@ type gRPCConnection struct {
 	isConnected uint32
 }
 
-func newConnection(server *gRPCServer, address *net.TCPAddr, isOutbound bool, stream grpcStream) *gRPCConnection {
+func newConnection(grpcServer *gRPCServer, address *net.TCPAddr, isOutbound bool, isEncrypted bool,
+	stream grpcStream) *gRPCConnection {
+
 	connection := &gRPCConnection{
-		server:      server,
+		server:      grpcServer,
 		address:     address,
 		isOutbound:  isOutbound,
+		isEncrypted: isEncrypted,
 		stream:      stream,
 		stopChan:    make(chan struct{}),
 		isConnected: 1,
+
+		trafficStats: server.NewTrafficStats(),
 	}
 
 	return connection
@@ -70,6 +78,10 @@ func (c *gRPCConnection) IsOutbound() bool {
 	return c.isOutbound
 }
 
+func (c *gRPCConnection) IsEncrypted() bool {
+	return c.isEncrypted
+}
+
 // Disconnect disconnects the connection
 // Calling this function a second time doesn't do anything
 //
@@ -94,6 +106,13 @@ func (c *gRPCConnection) Disconnect() {
 	}
 }
 
+// TrafficStats returns the traffic of this connection
+//
+// This is part of the Connection interface
+func (c *gRPCConnection) TrafficStats() *server.TrafficStats {
+	return c.trafficStats
+}
+
 func (c *gRPCConnection) Address() *net.TCPAddr {
 	return c.address
 }
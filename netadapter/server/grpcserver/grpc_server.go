@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"google.golang.org/grpc/encoding/gzip"
 	"net"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc/peer"
@@ -20,16 +21,29 @@ type gRPCServer struct {
 	onConnectedHandler server.OnConnectedHandler
 	listeningAddrs     []string
 	server             *grpc.Server
+	security           *TransportSecurity
+	trafficStats       *server.TrafficStats
+
+	// latency is the simulated network latency by which incoming
+	// messages are delayed, in nanoseconds. It's accessed atomically.
+	latency int64
 }
 
 const maxMessageSize = 1024 * 1024 * 10 // 10MB
 
 // NewGRPCServer creates and starts a gRPC server, listening on the
-// provided addresses/ports
-func NewGRPCServer(listeningAddrs []string) (server.Server, error) {
+// provided addresses/ports and encrypting connections according to
+// the provided security settings
+func NewGRPCServer(listeningAddrs []string, security *TransportSecurity) (server.Server, error) {
+	serverOptions := []grpc.ServerOption{grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)}
+	if security.Mode != EncryptionDisabled {
+		serverOptions = append(serverOptions, grpc.Creds(newServerCredentials(security)))
+	}
 	s := &gRPCServer{
-		server:         grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)),
+		server:         grpc.NewServer(serverOptions...),
 		listeningAddrs: listeningAddrs,
+		security:       security,
+		trafficStats:   server.NewTrafficStats(),
 	}
 	protowire.RegisterP2PServer(s.server, newP2PServer(s))
 
@@ -71,6 +85,25 @@ func (s *gRPCServer) Stop() error {
 	return nil
 }
 
+// TrafficStats returns the traffic of all the connections of this server
+//
+// This is part of the Server interface
+func (s *gRPCServer) TrafficStats() *server.TrafficStats {
+	return s.trafficStats
+}
+
+// SetLatency sets the simulated network latency by which every message
+// received by the connections of this server is delayed
+//
+// This is part of the Server interface
+func (s *gRPCServer) SetLatency(latency time.Duration) {
+	atomic.StoreInt64(&s.latency, int64(latency))
+}
+
+func (s *gRPCServer) latencyDuration() time.Duration {
+	return time.Duration(atomic.LoadInt64(&s.latency))
+}
+
 // SetOnConnectedHandler sets the peer connected handler
 // function for the server
 func (s *gRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnectedHandler) {
@@ -82,11 +115,27 @@ func (s *gRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnected
 func (s *gRPCServer) Connect(address string) (server.Connection, error) {
 	log.Infof("Dialing to %s", address)
 
+	expectedFingerprint, isPinned := s.security.PinnedPeers[address]
+	if isPinned || s.security.Mode == EncryptionRequired {
+		return s.dial(address, grpc.WithTransportCredentials(newClientCredentials(s.security, expectedFingerprint)))
+	}
+	if s.security.Mode == EncryptionOpportunistic {
+		connection, err := s.dial(address, grpc.WithTransportCredentials(newClientCredentials(s.security, "")))
+		if err == nil {
+			return connection, nil
+		}
+		log.Debugf("Encrypted connection to %s failed, falling back to plaintext: %s", address, err)
+	}
+	return s.dial(address, grpc.WithInsecure())
+}
+
+func (s *gRPCServer) dial(address string, securityOption grpc.DialOption) (server.Connection, error) {
 	const dialTimeout = 30 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	gRPCConnection, err := grpc.DialContext(ctx, address, securityOption, grpc.WithBlock(),
+		grpc.FailOnNonTempDialError(true))
 	if err != nil {
 		return nil, errors.Wrapf(err, "error connecting to %s", address)
 	}
@@ -106,14 +155,14 @@ func (s *gRPCServer) Connect(address string) (server.Connection, error) {
 		return nil, errors.Errorf("non-tcp addresses are not supported")
 	}
 
-	connection := newConnection(s, tcpAddress, true, stream)
+	connection := newConnection(s, tcpAddress, true, isEncrypted(peerInfo.AuthInfo), stream)
 
 	err = s.onConnectedHandler(connection)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Infof("Connected to %s", address)
+	log.Infof("Connected to %s (encrypted: %t)", address, connection.IsEncrypted())
 
 	return connection, nil
 }
@@ -29,14 +29,14 @@ func (p *p2pServer) MessageStream(stream protowire.P2P_MessageStreamServer) erro
 		return errors.Errorf("non-tcp connections are not supported")
 	}
 
-	connection := newConnection(p.server, tcpAddress, false, stream)
+	connection := newConnection(p.server, tcpAddress, false, isEncrypted(peerInfo.AuthInfo), stream)
 
 	err := p.server.onConnectedHandler(connection)
 	if err != nil {
 		return err
 	}
 
-	log.Infof("Incoming connection from %s", peerInfo.Addr)
+	log.Infof("Incoming connection from %s (encrypted: %t)", peerInfo.Addr, connection.IsEncrypted())
 
 	<-connection.stopChan
 
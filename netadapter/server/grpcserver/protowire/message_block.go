@@ -21,42 +21,14 @@ func (x *BlockMessage) toDomainMessage() (domainmessage.Message, error) {
 			"[count %d, max %d]", len(x.Transactions), domainmessage.MaxTxPerBlock)
 	}
 
-	protoBlockHeader := x.Header
-	if protoBlockHeader == nil {
+	if x.Header == nil {
 		return nil, errors.New("block header field cannot be nil")
 	}
-
-	parentHashes, err := protoHashesToWire(protoBlockHeader.ParentHashes)
-	if err != nil {
-		return nil, err
-	}
-
-	hashMerkleRoot, err := protoBlockHeader.HashMerkleRoot.toWire()
-	if err != nil {
-		return nil, err
-	}
-
-	acceptedIDMerkleRoot, err := protoBlockHeader.AcceptedIDMerkleRoot.toWire()
-	if err != nil {
-		return nil, err
-	}
-
-	utxoCommitment, err := protoBlockHeader.UtxoCommitment.toWire()
+	header, err := x.Header.toWire()
 	if err != nil {
 		return nil, err
 	}
 
-	header := domainmessage.BlockHeader{
-		Version:              protoBlockHeader.Version,
-		ParentHashes:         parentHashes,
-		HashMerkleRoot:       hashMerkleRoot,
-		AcceptedIDMerkleRoot: acceptedIDMerkleRoot,
-		UTXOCommitment:       utxoCommitment,
-		Timestamp:            mstime.UnixMilliseconds(protoBlockHeader.Timestamp),
-		Bits:                 protoBlockHeader.Bits,
-		Nonce:                protoBlockHeader.Nonce,
-	}
-
 	transactions := make([]*domainmessage.MsgTx, len(x.Transactions))
 	for i, protoTx := range x.Transactions {
 		msgTx, err := protoTx.toDomainMessage()
@@ -67,7 +39,7 @@ func (x *BlockMessage) toDomainMessage() (domainmessage.Message, error) {
 	}
 
 	return &domainmessage.MsgBlock{
-		Header:       header,
+		Header:       *header,
 		Transactions: transactions,
 	}, nil
 }
@@ -78,17 +50,6 @@ func (x *BlockMessage) fromDomainMessage(msgBlock *domainmessage.MsgBlock) error
 			"[count %d, max %d]", len(msgBlock.Transactions), domainmessage.MaxTxPerBlock)
 	}
 
-	header := msgBlock.Header
-	protoHeader := &BlockHeader{
-		Version:              header.Version,
-		ParentHashes:         wireHashesToProto(header.ParentHashes),
-		HashMerkleRoot:       wireHashToProto(header.HashMerkleRoot),
-		AcceptedIDMerkleRoot: wireHashToProto(header.AcceptedIDMerkleRoot),
-		UtxoCommitment:       wireHashToProto(header.UTXOCommitment),
-		Timestamp:            header.Timestamp.UnixMilliseconds(),
-		Bits:                 header.Bits,
-		Nonce:                header.Nonce,
-	}
 	protoTransactions := make([]*TransactionMessage, len(msgBlock.Transactions))
 	for i, tx := range msgBlock.Transactions {
 		protoTx := new(TransactionMessage)
@@ -96,8 +57,54 @@ func (x *BlockMessage) fromDomainMessage(msgBlock *domainmessage.MsgBlock) error
 		protoTransactions[i] = protoTx
 	}
 	*x = BlockMessage{
-		Header:       protoHeader,
+		Header:       wireBlockHeaderToProto(&msgBlock.Header),
 		Transactions: protoTransactions,
 	}
 	return nil
 }
+
+func (x *BlockHeader) toWire() (*domainmessage.BlockHeader, error) {
+	parentHashes, err := protoHashesToWire(x.ParentHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	hashMerkleRoot, err := x.HashMerkleRoot.toWire()
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedIDMerkleRoot, err := x.AcceptedIDMerkleRoot.toWire()
+	if err != nil {
+		return nil, err
+	}
+
+	utxoCommitment, err := x.UtxoCommitment.toWire()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domainmessage.BlockHeader{
+		Version:              x.Version,
+		ParentHashes:         parentHashes,
+		HashMerkleRoot:       hashMerkleRoot,
+		AcceptedIDMerkleRoot: acceptedIDMerkleRoot,
+		UTXOCommitment:       utxoCommitment,
+		Timestamp:            mstime.UnixMilliseconds(x.Timestamp),
+		Bits:                 x.Bits,
+		Nonce:                x.Nonce,
+	}, nil
+}
+
+func wireBlockHeaderToProto(header *domainmessage.BlockHeader) *BlockHeader {
+	return &BlockHeader{
+		Version:              header.Version,
+		ParentHashes:         wireHashesToProto(header.ParentHashes),
+		HashMerkleRoot:       wireHashToProto(header.HashMerkleRoot),
+		AcceptedIDMerkleRoot: wireHashToProto(header.AcceptedIDMerkleRoot),
+		UtxoCommitment:       wireHashToProto(header.UTXOCommitment),
+		Timestamp:            header.Timestamp.UnixMilliseconds(),
+		Bits:                 header.Bits,
+		Nonce:                header.Nonce,
+	}
+}
@@ -0,0 +1,40 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_IbdHeaders) toDomainMessage() (domainmessage.Message, error) {
+	if len(x.IbdHeaders.Headers) > domainmessage.MaxBlockHeadersPerMsg {
+		return nil, errors.Errorf("too many block headers for message "+
+			"[count %d, max %d]", len(x.IbdHeaders.Headers), domainmessage.MaxBlockHeadersPerMsg)
+	}
+	headers := make([]*domainmessage.BlockHeader, len(x.IbdHeaders.Headers))
+	for i, protoHeader := range x.IbdHeaders.Headers {
+		if protoHeader == nil {
+			return nil, errors.New("block header field cannot be nil")
+		}
+		var err error
+		headers[i], err = protoHeader.toWire()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &domainmessage.MsgIBDHeaders{Headers: headers}, nil
+}
+
+func (x *KaspadMessage_IbdHeaders) fromDomainMessage(msgIBDHeaders *domainmessage.MsgIBDHeaders) error {
+	if len(msgIBDHeaders.Headers) > domainmessage.MaxBlockHeadersPerMsg {
+		return errors.Errorf("too many block headers for message "+
+			"[count %d, max %d]", len(msgIBDHeaders.Headers), domainmessage.MaxBlockHeadersPerMsg)
+	}
+	protoHeaders := make([]*BlockHeader, len(msgIBDHeaders.Headers))
+	for i, header := range msgIBDHeaders.Headers {
+		protoHeaders[i] = wireBlockHeaderToProto(header)
+	}
+	x.IbdHeaders = &IBDHeadersMessage{
+		Headers: protoHeaders,
+	}
+	return nil
+}
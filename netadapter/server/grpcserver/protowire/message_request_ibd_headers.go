@@ -0,0 +1,28 @@
+package protowire
+
+import "github.com/kaspanet/kaspad/domainmessage"
+
+func (x *KaspadMessage_RequestIBDHeaders) toDomainMessage() (domainmessage.Message, error) {
+	lowHash, err := x.RequestIBDHeaders.LowHash.toWire()
+	if err != nil {
+		return nil, err
+	}
+
+	highHash, err := x.RequestIBDHeaders.HighHash.toWire()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domainmessage.MsgRequestIBDHeaders{
+		LowHash:  lowHash,
+		HighHash: highHash,
+	}, nil
+}
+
+func (x *KaspadMessage_RequestIBDHeaders) fromDomainMessage(msgRequestIBDHeaders *domainmessage.MsgRequestIBDHeaders) error {
+	x.RequestIBDHeaders = &RequestIBDHeadersMessage{
+		LowHash:  wireHashToProto(msgRequestIBDHeaders.LowHash),
+		HighHash: wireHashToProto(msgRequestIBDHeaders.HighHash),
+	}
+	return nil
+}
@@ -83,6 +83,20 @@ func toPayload(message domainmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *domainmessage.MsgRequestIBDHeaders:
+		payload := new(KaspadMessage_RequestIBDHeaders)
+		err := payload.fromDomainMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *domainmessage.MsgIBDHeaders:
+		payload := new(KaspadMessage_IbdHeaders)
+		err := payload.fromDomainMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	case *domainmessage.MsgRequestRelayBlocks:
 		payload := new(KaspadMessage_RequestRelayBlocks)
 		err := payload.fromDomainMessage(message)
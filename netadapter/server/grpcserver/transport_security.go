@@ -0,0 +1,219 @@
+package grpcserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+)
+
+// EncryptionMode defines whether P2P connections are encrypted
+type EncryptionMode int
+
+const (
+	// EncryptionDisabled means that all connections are plaintext
+	EncryptionDisabled EncryptionMode = iota
+
+	// EncryptionOpportunistic means that connections are encrypted
+	// whenever the remote peer supports it, and are plaintext otherwise
+	EncryptionOpportunistic
+
+	// EncryptionRequired means that plaintext connections are refused
+	EncryptionRequired
+)
+
+// TransportSecurity holds the settings of P2P transport encryption
+type TransportSecurity struct {
+	Mode EncryptionMode
+
+	// Certificate is the certificate this node presents to its peers.
+	// It is ignored if Mode is EncryptionDisabled.
+	Certificate tls.Certificate
+
+	// PinnedPeers maps peer addresses to the expected fingerprints of
+	// their certificates. Connections to these addresses are always
+	// encrypted, and are refused if the fingerprint doesn't match.
+	PinnedPeers map[string]string
+}
+
+// tlsRecordTypeHandshake is the first byte of any TLS ClientHello. A
+// plaintext gRPC client always starts with the HTTP/2 preface instead,
+// which lets the server tell the two apart.
+const tlsRecordTypeHandshake = 0x16
+
+// CertificateFingerprint returns the fingerprint that identifies a node
+// by its certificate: the hex-encoded SHA256 of its public key.
+func CertificateFingerprint(certificate *x509.Certificate) string {
+	fingerprint := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// Fingerprint returns the fingerprint of this node's certificate
+func (ts *TransportSecurity) Fingerprint() (string, error) {
+	if len(ts.Certificate.Certificate) == 0 {
+		return "", errors.New("no certificate is set")
+	}
+	certificate, err := x509.ParseCertificate(ts.Certificate.Certificate[0])
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return CertificateFingerprint(certificate), nil
+}
+
+// insecureAuthInfo is the AuthInfo of plaintext connections
+type insecureAuthInfo struct {
+	credentials.CommonAuthInfo
+}
+
+func (insecureAuthInfo) AuthType() string {
+	return "insecure"
+}
+
+// handshakeError is a handshake error that gRPC must not retry, so
+// that a failed encrypted dial can quickly fall back to plaintext
+type handshakeError struct {
+	error
+}
+
+func (handshakeError) Temporary() bool {
+	return false
+}
+
+// transportCredentials implements credentials.TransportCredentials
+// for P2P connections.
+// Certificates are self-signed, so instead of being verified against
+// a CA they are, where configured, matched against pinned fingerprints.
+type transportCredentials struct {
+	security            *TransportSecurity
+	expectedFingerprint string
+}
+
+func newServerCredentials(security *TransportSecurity) credentials.TransportCredentials {
+	return &transportCredentials{security: security}
+}
+
+func newClientCredentials(security *TransportSecurity, expectedFingerprint string) credentials.TransportCredentials {
+	return &transportCredentials{security: security, expectedFingerprint: expectedFingerprint}
+}
+
+func (c *transportCredentials) ClientHandshake(ctx context.Context, _ string, rawConn net.Conn) (
+	net.Conn, credentials.AuthInfo, error) {
+
+	tlsConfig := &tls.Config{
+		Certificates:          []tls.Certificate{c.security.Certificate},
+		MinVersion:            tls.VersionTLS12,
+		InsecureSkipVerify:    true, // Certificates are self-signed. See verifyFingerprint
+		VerifyPeerCertificate: c.verifyFingerprint,
+	}
+	conn := tls.Client(rawConn, tlsConfig)
+
+	errChan := make(chan error, 1)
+	spawn("transportCredentials.ClientHandshake", func() { errChan <- conn.Handshake() })
+	select {
+	case err := <-errChan:
+		if err != nil {
+			rawConn.Close()
+			return nil, nil, handshakeError{errors.Wrapf(err, "TLS handshake with %s failed", rawConn.RemoteAddr())}
+		}
+	case <-ctx.Done():
+		rawConn.Close()
+		return nil, nil, ctx.Err()
+	}
+
+	return conn, credentials.TLSInfo{
+		State:          conn.ConnectionState(),
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+	}, nil
+}
+
+func (c *transportCredentials) verifyFingerprint(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if c.expectedFingerprint == "" {
+		return nil
+	}
+	if len(rawCerts) == 0 {
+		return errors.New("peer did not present a certificate")
+	}
+	certificate, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	fingerprint := CertificateFingerprint(certificate)
+	if fingerprint != c.expectedFingerprint {
+		return errors.Errorf("peer certificate fingerprint %s does not match the pinned %s",
+			fingerprint, c.expectedFingerprint)
+	}
+	return nil
+}
+
+func (c *transportCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	conn := newPeekedConn(rawConn)
+	firstByte, err := conn.reader.Peek(1)
+	if err != nil {
+		rawConn.Close()
+		return nil, nil, errors.WithStack(err)
+	}
+
+	if firstByte[0] != tlsRecordTypeHandshake {
+		if c.security.Mode == EncryptionRequired {
+			rawConn.Close()
+			return nil, nil, errors.Errorf("refusing plaintext connection from %s", rawConn.RemoteAddr())
+		}
+		return conn, insecureAuthInfo{credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}}, nil
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{c.security.Certificate},
+		MinVersion:   tls.VersionTLS12,
+		ClientAuth:   tls.RequestClientCert,
+	}
+	tlsConn := tls.Server(conn, tlsConfig)
+	err = tlsConn.Handshake()
+	if err != nil {
+		rawConn.Close()
+		return nil, nil, errors.Wrapf(err, "TLS handshake with %s failed", rawConn.RemoteAddr())
+	}
+
+	return tlsConn, credentials.TLSInfo{
+		State:          tlsConn.ConnectionState(),
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+	}, nil
+}
+
+func (c *transportCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c *transportCredentials) Clone() credentials.TransportCredentials {
+	clone := *c
+	return &clone
+}
+
+func (c *transportCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// peekedConn is a net.Conn that allows looking at incoming data
+// without consuming it
+type peekedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func newPeekedConn(conn net.Conn) *peekedConn {
+	return &peekedConn{Conn: conn, reader: bufio.NewReader(conn)}
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+func isEncrypted(authInfo credentials.AuthInfo) bool {
+	_, ok := authInfo.(credentials.TLSInfo)
+	return ok
+}
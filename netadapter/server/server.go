@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -28,6 +29,8 @@ type Server interface {
 	Start() error
 	Stop() error
 	SetOnConnectedHandler(onConnectedHandler OnConnectedHandler)
+	TrafficStats() *TrafficStats
+	SetLatency(latency time.Duration)
 }
 
 // Connection represents a p2p server connection.
@@ -37,9 +40,11 @@ type Connection interface {
 	Disconnect()
 	IsConnected() bool
 	IsOutbound() bool
+	IsEncrypted() bool
 	SetOnDisconnectedHandler(onDisconnectedHandler OnDisconnectedHandler)
 	SetOnInvalidMessageHandler(onInvalidMessageHandler OnInvalidMessageHandler)
 	Address() *net.TCPAddr
+	TrafficStats() *TrafficStats
 }
 
 // ErrNetwork is an error related to the internals of the connection, and not an error that
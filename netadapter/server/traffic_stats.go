@@ -0,0 +1,98 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+)
+
+// MessageTraffic holds the number of messages of a single
+// message command and their total size in bytes
+type MessageTraffic struct {
+	Messages uint64
+	Bytes    uint64
+}
+
+// TrafficStats counts the messages and bytes sent and received,
+// broken down by message command. It is safe for concurrent access.
+type TrafficStats struct {
+	sent     map[domainmessage.MessageCommand]*MessageTraffic
+	received map[domainmessage.MessageCommand]*MessageTraffic
+	lock     sync.RWMutex
+}
+
+// NewTrafficStats returns a new, empty TrafficStats
+func NewTrafficStats() *TrafficStats {
+	return &TrafficStats{
+		sent:     make(map[domainmessage.MessageCommand]*MessageTraffic),
+		received: make(map[domainmessage.MessageCommand]*MessageTraffic),
+	}
+}
+
+// AddSent counts a sent message of the given command and size
+func (s *TrafficStats) AddSent(command domainmessage.MessageCommand, bytes uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	addTraffic(s.sent, command, bytes)
+}
+
+// AddReceived counts a received message of the given command and size
+func (s *TrafficStats) AddReceived(command domainmessage.MessageCommand, bytes uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	addTraffic(s.received, command, bytes)
+}
+
+func addTraffic(traffic map[domainmessage.MessageCommand]*MessageTraffic,
+	command domainmessage.MessageCommand, bytes uint64) {
+
+	commandTraffic, ok := traffic[command]
+	if !ok {
+		commandTraffic = &MessageTraffic{}
+		traffic[command] = commandTraffic
+	}
+	commandTraffic.Messages++
+	commandTraffic.Bytes += bytes
+}
+
+// Sent returns a copy of the sent traffic, per message command
+func (s *TrafficStats) Sent() map[domainmessage.MessageCommand]MessageTraffic {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return copyTraffic(s.sent)
+}
+
+// Received returns a copy of the received traffic, per message command
+func (s *TrafficStats) Received() map[domainmessage.MessageCommand]MessageTraffic {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return copyTraffic(s.received)
+}
+
+func copyTraffic(
+	traffic map[domainmessage.MessageCommand]*MessageTraffic) map[domainmessage.MessageCommand]MessageTraffic {
+
+	trafficCopy := make(map[domainmessage.MessageCommand]MessageTraffic, len(traffic))
+	for command, commandTraffic := range traffic {
+		trafficCopy[command] = *commandTraffic
+	}
+	return trafficCopy
+}
+
+// Totals returns the total number of bytes sent and received
+func (s *TrafficStats) Totals() (bytesSent uint64, bytesReceived uint64) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, commandTraffic := range s.sent {
+		bytesSent += commandTraffic.Bytes
+	}
+	for _, commandTraffic := range s.received {
+		bytesReceived += commandTraffic.Bytes
+	}
+	return bytesSent, bytesReceived
+}
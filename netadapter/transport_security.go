@@ -0,0 +1,110 @@
+package netadapter
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultP2PCertFilename = "p2p.cert"
+	defaultP2PKeyFilename  = "p2p.key"
+)
+
+var encryptionModes = map[string]grpcserver.EncryptionMode{
+	"":                                grpcserver.EncryptionDisabled,
+	config.P2PEncryptionNone:          grpcserver.EncryptionDisabled,
+	config.P2PEncryptionOpportunistic: grpcserver.EncryptionOpportunistic,
+	config.P2PEncryptionRequired:      grpcserver.EncryptionRequired,
+}
+
+// loadTransportSecurity builds the P2P transport security settings out of
+// the given config, generating a certificate for this node if required
+func loadTransportSecurity(cfg *config.Config) (*grpcserver.TransportSecurity, error) {
+	mode, ok := encryptionModes[cfg.P2PEncryption]
+	if !ok {
+		return nil, errors.Errorf("unknown P2P encryption mode %s", cfg.P2PEncryption)
+	}
+	security := &grpcserver.TransportSecurity{
+		Mode:        mode,
+		PinnedPeers: cfg.P2PPinnedPeers,
+	}
+	if mode == grpcserver.EncryptionDisabled && len(cfg.P2PPinnedPeers) == 0 {
+		return security, nil
+	}
+
+	certFile := cfg.P2PCert
+	if certFile == "" {
+		certFile = filepath.Join(cfg.DataDir, defaultP2PCertFilename)
+	}
+	keyFile := cfg.P2PKey
+	if keyFile == "" {
+		keyFile = filepath.Join(cfg.DataDir, defaultP2PKeyFilename)
+	}
+
+	if !fileExists(certFile) && !fileExists(keyFile) {
+		err := generateP2PCertificate(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading the P2P certificate")
+	}
+	security.Certificate = certificate
+
+	fingerprint, err := security.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("P2P certificate fingerprint: %s", fingerprint)
+
+	return security, nil
+}
+
+// generateP2PCertificate generates a self-signed certificate that
+// identifies this node to its peers
+func generateP2PCertificate(certFile, keyFile string) error {
+	log.Infof("Generating P2P certificate...")
+
+	org := "kaspad autogenerated P2P cert"
+	validUntil := time.Now().Add(10 * 365 * 24 * time.Hour)
+	cert, key, err := util.NewTLSCertPair(org, validUntil, nil)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(certFile), 0700)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = os.MkdirAll(filepath.Dir(keyFile), 0700)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = ioutil.WriteFile(certFile, cert, 0666)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = ioutil.WriteFile(keyFile, key, 0600)
+	if err != nil {
+		os.Remove(certFile)
+		return errors.WithStack(err)
+	}
+
+	log.Infof("Done generating P2P certificate")
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
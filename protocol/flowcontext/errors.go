@@ -12,11 +12,14 @@ import (
 // HandleError handles an error from a flow,
 // It sends the error to errChan if isStopping == 0 and increments isStopping
 //
-// If this is ErrRouteClosed - ignores the error
+// If this is ErrRouteClosed - sends it without logging, so that the peer is removed
 // If this is ProtocolError - logs the error
 // Otherwise - panics
 func (*FlowContext) HandleError(err error, flowName string, isStopping *uint32, errChan chan<- error) {
 	if errors.Is(err, router.ErrRouteClosed) {
+		if atomic.AddUint32(isStopping, 1) == 1 {
+			errChan <- err
+		}
 		return
 	}
 
@@ -12,6 +12,7 @@ import (
 	"github.com/kaspanet/kaspad/netadapter"
 	"github.com/kaspanet/kaspad/netadapter/id"
 	"github.com/kaspanet/kaspad/protocol/flows/blockrelay"
+	"github.com/kaspanet/kaspad/protocol/flows/ibd"
 	"github.com/kaspanet/kaspad/protocol/flows/relaytransactions"
 	peerpkg "github.com/kaspanet/kaspad/protocol/peer"
 	"github.com/kaspanet/kaspad/util"
@@ -35,9 +36,10 @@ type FlowContext struct {
 
 	sharedRequestedBlocks *blockrelay.SharedRequestedBlocks
 
-	isInIBD       uint32
-	startIBDMutex sync.Mutex
-	ibdPeer       *peerpkg.Peer
+	isInIBD          uint32
+	startIBDMutex    sync.Mutex
+	ibdPeer          *peerpkg.Peer
+	ibdUploadLimiter *ibd.UploadLimiter
 
 	peers      map[*id.ID]*peerpkg.Peer
 	peersMutex sync.RWMutex
@@ -57,6 +59,7 @@ func New(cfg *config.Config, dag *blockdag.BlockDAG, addressManager *addressmana
 		txPool:                      txPool,
 		sharedRequestedTransactions: relaytransactions.NewSharedRequestedTransactions(),
 		sharedRequestedBlocks:       blockrelay.NewSharedRequestedBlocks(),
+		ibdUploadLimiter:            ibd.NewUploadLimiter(cfg.MaxIBDUpload*bytesPerMegabyte, ibdUploadTimeFrame),
 		peers:                       make(map[*id.ID]*peerpkg.Peer),
 		transactionsToRebroadcast:   make(map[daghash.TxID]*util.Tx),
 	}
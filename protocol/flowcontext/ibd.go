@@ -5,9 +5,18 @@ import (
 	"time"
 
 	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/protocol/flows/ibd"
 	peerpkg "github.com/kaspanet/kaspad/protocol/peer"
 )
 
+const (
+	bytesPerMegabyte = 1024 * 1024
+
+	// ibdUploadTimeFrame is the time frame in which at most
+	// --maxibdupload megabytes of blocks are served to syncing peers
+	ibdUploadTimeFrame = 24 * time.Hour
+)
+
 // StartIBDIfRequired selects a peer and starts IBD against it
 // if required
 func (f *FlowContext) StartIBDIfRequired() {
@@ -81,3 +90,9 @@ func (f *FlowContext) IBDPeer() *peerpkg.Peer {
 	}
 	return f.ibdPeer
 }
+
+// IBDUploadLimiter returns the limiter that caps the
+// number of bytes of blocks served to syncing peers
+func (f *FlowContext) IBDUploadLimiter() *ibd.UploadLimiter {
+	return f.ibdUploadLimiter
+}
@@ -33,6 +33,17 @@ func (f *FlowContext) AddToPeers(peer *peerpkg.Peer) error {
 	return nil
 }
 
+// RemoveFromPeers removes this peer from the ready peers list, once its
+// connection is closed.
+func (f *FlowContext) RemoveFromPeers(peer *peerpkg.Peer) {
+	f.peersMutex.Lock()
+	defer f.peersMutex.Unlock()
+
+	if f.peers[peer.ID()] == peer {
+		delete(f.peers, peer.ID())
+	}
+}
+
 // readyPeerConnections returns the NetConnections of all the ready peers.
 func (f *FlowContext) readyPeerConnections() []*netadapter.NetConnection {
 	f.peersMutex.RLock()
@@ -64,3 +75,24 @@ func (f *FlowContext) Peers() []*peerpkg.Peer {
 	}
 	return peers
 }
+
+// PeerQuality returns the quality of the peer associated with the given
+// connection, and false if the connection does not have a ready peer.
+// This is part of the connmanager.PeerQualityFunc signature.
+func (f *FlowContext) PeerQuality(connection *netadapter.NetConnection) (*connmanager.PeerQuality, bool) {
+	f.peersMutex.RLock()
+	defer f.peersMutex.RUnlock()
+
+	for _, peer := range f.peers {
+		if peer.Connection() != connection {
+			continue
+		}
+		return &connmanager.PeerQuality{
+			LastPingDuration:         peer.LastPingDuration(),
+			LastNovelBlockTime:       peer.LastNovelBlockTime(),
+			LastNovelTransactionTime: peer.LastNovelTransactionTime(),
+			TimeConnected:            peer.TimeConnected(),
+		}, true
+	}
+	return nil, false
+}
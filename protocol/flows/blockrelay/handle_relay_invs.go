@@ -220,6 +220,8 @@ func (flow *handleRelayInvsFlow) processAndRelayBlock(requestQueue *hashesQueueS
 		}
 		return nil
 	}
+	flow.peer.MarkNovelBlock()
+
 	err = blocklogger.LogBlock(block)
 	if err != nil {
 		return err
@@ -14,6 +14,7 @@ const ibdBatchSize = router.DefaultMaxMessages
 // RequestIBDBlocksContext is the interface for the context needed for the HandleRequestIBDBlocks flow.
 type RequestIBDBlocksContext interface {
 	DAG() *blockdag.BlockDAG
+	IBDUploadLimiter() *UploadLimiter
 }
 
 type handleRequestBlocksFlow struct {
@@ -52,7 +53,7 @@ func (flow *handleRequestBlocksFlow) start() error {
 			blocksToSend := msgIBDBlocks[offset:end]
 			err = flow.sendMsgIBDBlocks(blocksToSend)
 			if err != nil {
-				return nil
+				return err
 			}
 
 			// Exit the loop and don't wait for the GetNextIBDBlocks message if the last batch was
@@ -117,6 +118,10 @@ func (flow *handleRequestBlocksFlow) buildMsgIBDBlocks(lowHash *daghash.Hash,
 
 func (flow *handleRequestBlocksFlow) sendMsgIBDBlocks(msgIBDBlocks []*domainmessage.MsgIBDBlock) error {
 	for _, msgIBDBlock := range msgIBDBlocks {
+		if !flow.IBDUploadLimiter().Reserve(uint64(msgIBDBlock.SerializeSize())) {
+			return protocolerrors.Errorf(false, "the IBD upload cap of %d bytes "+
+				"was reached", flow.IBDUploadLimiter().Stats().MaxBytes)
+		}
 		err := flow.outgoingRoute.Enqueue(msgIBDBlock)
 		if err != nil {
 			return err
@@ -0,0 +1,58 @@
+package ibd
+
+import (
+	"errors"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/netadapter/router"
+)
+
+// RequestIBDHeadersContext is the interface for the context needed for the HandleRequestIBDHeaders flow.
+type RequestIBDHeadersContext interface {
+	DAG() *blockdag.BlockDAG
+}
+
+type handleRequestIBDHeadersFlow struct {
+	RequestIBDHeadersContext
+	incomingRoute, outgoingRoute *router.Route
+}
+
+// HandleRequestIBDHeaders handles requestIBDHeaders messages
+func HandleRequestIBDHeaders(context RequestIBDHeadersContext, incomingRoute *router.Route,
+	outgoingRoute *router.Route) error {
+
+	flow := &handleRequestIBDHeadersFlow{
+		RequestIBDHeadersContext: context,
+		incomingRoute:            incomingRoute,
+		outgoingRoute:            outgoingRoute,
+	}
+	return flow.start()
+}
+
+func (flow *handleRequestIBDHeadersFlow) start() error {
+	for {
+		message, err := flow.incomingRoute.Dequeue()
+		if err != nil {
+			return err
+		}
+		msgRequestIBDHeaders := message.(*domainmessage.MsgRequestIBDHeaders)
+
+		// The requesting peer can't know whether we have the blocks it
+		// asks about, so if we can't provide their headers we respond
+		// with none.
+		headers, err := flow.DAG().AntiPastHeadersBetween(msgRequestIBDHeaders.LowHash,
+			msgRequestIBDHeaders.HighHash, domainmessage.MaxBlockHeadersPerMsg)
+		if err != nil {
+			if !errors.Is(err, blockdag.ErrInvalidParameter) {
+				return err
+			}
+			headers = nil
+		}
+
+		err = flow.outgoingRoute.Enqueue(domainmessage.NewMsgIBDHeaders(headers))
+		if err != nil {
+			return err
+		}
+	}
+}
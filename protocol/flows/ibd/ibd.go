@@ -62,6 +62,11 @@ func (flow *handleIBDFlow) runIBD() error {
 			"below the finality point", flow.peer, highestSharedBlockHash)
 	}
 
+	err = flow.proveAssumeValidPast(highestSharedBlockHash)
+	if err != nil {
+		return err
+	}
+
 	return flow.downloadBlocks(highestSharedBlockHash, peerSelectedTipHash)
 }
 
@@ -114,6 +119,80 @@ func (flow *handleIBDFlow) receiveBlockLocator() (blockLocatorHashes []*daghash.
 	return msgBlockLocator.BlockLocatorHashes, nil
 }
 
+// proveAssumeValidPast downloads the headers of the blocks between the
+// highest shared block and the assume-valid block, so that the blocks in the
+// past of the assume-valid block are proven to be in it before they are
+// received. It does nothing if the past of the assume-valid block doesn't
+// need to be proven, or if the peer doesn't have the assume-valid block.
+func (flow *handleIBDFlow) proveAssumeValidPast(highestSharedBlockHash *daghash.Hash) error {
+	assumeValid := flow.DAG().AssumeValidToProve()
+	if assumeValid == nil {
+		return nil
+	}
+
+	var headers []*domainmessage.BlockHeader
+	receivedHashes := make(map[daghash.Hash]struct{})
+	lowHash := highestSharedBlockHash
+	for {
+		err := flow.outgoingRoute.Enqueue(domainmessage.NewMsgRequestIBDHeaders(lowHash, assumeValid))
+		if err != nil {
+			return err
+		}
+
+		msgIBDHeaders, err := flow.receiveIBDHeaders()
+		if err != nil {
+			return err
+		}
+		if len(msgIBDHeaders.Headers) == 0 {
+			log.Debugf("Peer %s can't prove the past of the assume-valid block %s",
+				flow.peer, assumeValid)
+			return nil
+		}
+
+		hasNewHeaders := false
+		for _, header := range msgIBDHeaders.Headers {
+			hash := header.BlockHash()
+			if _, ok := receivedHashes[*hash]; ok {
+				continue
+			}
+			receivedHashes[*hash] = struct{}{}
+			headers = append(headers, header)
+			hasNewHeaders = true
+		}
+		if !hasNewHeaders {
+			return protocolerrors.Errorf(true, "received no new headers in the "+
+				"past of the assume-valid block %s", assumeValid)
+		}
+		if _, ok := receivedHashes[*assumeValid]; ok {
+			break
+		}
+		lowHash = msgIBDHeaders.Headers[len(msgIBDHeaders.Headers)-1].BlockHash()
+	}
+
+	err := flow.DAG().ProveAssumeValidPast(headers)
+	if err != nil {
+		return protocolerrors.Wrapf(true, err, "the headers received from peer %s "+
+			"don't prove the past of the assume-valid block %s", flow.peer, assumeValid)
+	}
+	log.Infof("Proved the past of the assume-valid block %s from %d headers "+
+		"received from peer %s", assumeValid, len(headers), flow.peer)
+	return nil
+}
+
+func (flow *handleIBDFlow) receiveIBDHeaders() (*domainmessage.MsgIBDHeaders, error) {
+	message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	msgIBDHeaders, ok := message.(*domainmessage.MsgIBDHeaders)
+	if !ok {
+		return nil,
+			protocolerrors.Errorf(true, "received unexpected message type. "+
+				"expected: %s, got: %s", domainmessage.CmdIBDHeaders, message.Command())
+	}
+	return msgIBDHeaders, nil
+}
+
 func (flow *handleIBDFlow) downloadBlocks(highestSharedBlockHash *daghash.Hash,
 	peerSelectedTipHash *daghash.Hash) error {
 
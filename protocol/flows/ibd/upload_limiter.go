@@ -0,0 +1,79 @@
+package ibd
+
+import (
+	"sync"
+	"time"
+)
+
+// UploadLimiter caps the number of bytes of blocks served to syncing
+// peers within a recurring time frame. It is shared between all the
+// peers, and is safe for concurrent access.
+type UploadLimiter struct {
+	maxBytes  uint64
+	timeFrame time.Duration
+
+	bytesUsed      uint64
+	timeFrameStart time.Time
+	now            func() time.Time
+	lock           sync.Mutex
+}
+
+// UploadLimiterStats describes the state of an UploadLimiter
+type UploadLimiterStats struct {
+	MaxBytes       uint64
+	BytesUsed      uint64
+	TimeFrame      time.Duration
+	TimeFrameStart time.Time
+}
+
+// NewUploadLimiter returns a new UploadLimiter that allows serving
+// up to maxBytes every timeFrame. A maxBytes of 0 means unlimited.
+func NewUploadLimiter(maxBytes uint64, timeFrame time.Duration) *UploadLimiter {
+	return &UploadLimiter{
+		maxBytes:       maxBytes,
+		timeFrame:      timeFrame,
+		timeFrameStart: time.Now(),
+		now:            time.Now,
+	}
+}
+
+// Reserve accounts for the upload of the given number of bytes.
+// Returns false, without accounting for them, if uploading them
+// would exceed the cap of the current time frame.
+func (l *UploadLimiter) Reserve(bytes uint64) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.startNewTimeFrameIfRequired()
+
+	if l.maxBytes != 0 && l.bytesUsed+bytes > l.maxBytes {
+		return false
+	}
+	l.bytesUsed += bytes
+	return true
+}
+
+// Stats returns the state of the current time frame
+func (l *UploadLimiter) Stats() *UploadLimiterStats {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.startNewTimeFrameIfRequired()
+
+	return &UploadLimiterStats{
+		MaxBytes:       l.maxBytes,
+		BytesUsed:      l.bytesUsed,
+		TimeFrame:      l.timeFrame,
+		TimeFrameStart: l.timeFrameStart,
+	}
+}
+
+func (l *UploadLimiter) startNewTimeFrameIfRequired() {
+	now := l.now()
+	if now.Sub(l.timeFrameStart) < l.timeFrame {
+		return
+	}
+	elapsedTimeFrames := now.Sub(l.timeFrameStart) / l.timeFrame
+	l.timeFrameStart = l.timeFrameStart.Add(elapsedTimeFrames * l.timeFrame)
+	l.bytesUsed = 0
+}
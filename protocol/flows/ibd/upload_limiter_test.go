@@ -0,0 +1,48 @@
+package ibd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUploadLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewUploadLimiter(1000, 24*time.Hour)
+	limiter.now = func() time.Time { return now }
+	limiter.timeFrameStart = now
+
+	if !limiter.Reserve(600) {
+		t.Fatalf("Expected the first reservation to succeed")
+	}
+	if limiter.Reserve(500) {
+		t.Fatalf("Expected a reservation exceeding the cap to fail")
+	}
+	if !limiter.Reserve(400) {
+		t.Fatalf("Expected a reservation reaching exactly the cap to succeed")
+	}
+	if stats := limiter.Stats(); stats.BytesUsed != 1000 {
+		t.Fatalf("Expected 1000 bytes to be used, but got %d", stats.BytesUsed)
+	}
+
+	// Once the time frame is over, the cap is available again
+	now = now.Add(25 * time.Hour)
+	if !limiter.Reserve(1000) {
+		t.Fatalf("Expected a reservation in a new time frame to succeed")
+	}
+	stats := limiter.Stats()
+	if stats.BytesUsed != 1000 {
+		t.Fatalf("Expected 1000 bytes to be used, but got %d", stats.BytesUsed)
+	}
+	if expectedStart := now.Add(-time.Hour); !stats.TimeFrameStart.Equal(expectedStart) {
+		t.Fatalf("Expected the time frame to start at %s, but got %s", expectedStart, stats.TimeFrameStart)
+	}
+}
+
+func TestUploadLimiterUnlimited(t *testing.T) {
+	limiter := NewUploadLimiter(0, 24*time.Hour)
+	for i := 0; i < 10; i++ {
+		if !limiter.Reserve(1 << 40) {
+			t.Fatalf("Expected an unlimited limiter to allow every reservation")
+		}
+	}
+}
@@ -3,16 +3,19 @@ package ping
 import (
 	"time"
 
+	"github.com/kaspanet/kaspad/blockdag"
 	"github.com/kaspanet/kaspad/domainmessage"
 	"github.com/kaspanet/kaspad/netadapter/router"
 	"github.com/kaspanet/kaspad/protocol/common"
 	peerpkg "github.com/kaspanet/kaspad/protocol/peer"
 	"github.com/kaspanet/kaspad/protocol/protocolerrors"
 	"github.com/kaspanet/kaspad/util/random"
+	"github.com/pkg/errors"
 )
 
 // SendPingsContext is the interface for the context needed for the SendPings flow.
 type SendPingsContext interface {
+	DAG() *blockdag.BlockDAG
 }
 
 type sendPingsFlow struct {
@@ -36,10 +39,16 @@ func SendPings(context SendPingsContext, incomingRoute *router.Route, outgoingRo
 
 func (flow *sendPingsFlow) start() error {
 	const pingInterval = 2 * time.Minute
-	ticker := time.NewTicker(pingInterval)
-	defer ticker.Stop()
 
-	for range ticker.C {
+	// The interval is measured by the time source of the DAG, so that
+	// pings can be triggered by advancing the mock time.
+	timeSource := flow.DAG().TimeSource()
+	for {
+		err := flow.waitForPingInterval(timeSource, pingInterval)
+		if err != nil {
+			return err
+		}
+
 		nonce, err := random.Uint64()
 		if err != nil {
 			return err
@@ -62,5 +71,18 @@ func (flow *sendPingsFlow) start() error {
 		}
 		flow.peer.SetPingIdle()
 	}
-	return nil
+}
+
+// waitForPingInterval waits until pingInterval passes by the given time
+// source. It returns ErrRouteClosed if the incoming route is closed in the
+// meantime, since the peer was disconnected.
+func (flow *sendPingsFlow) waitForPingInterval(timeSource blockdag.TimeSource, pingInterval time.Duration) error {
+	after := timeSource.After(pingInterval)
+	select {
+	case <-after:
+		return nil
+	case <-flow.incomingRoute.CloseChan():
+		timeSource.CancelAfter(after)
+		return errors.WithStack(router.ErrRouteClosed)
+	}
 }
@@ -0,0 +1,40 @@
+package ping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/netadapter/router"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// TestWaitForPingIntervalRouteClosed makes sure that the SendPings flow
+// stops waiting for the next ping once its peer is disconnected.
+func TestWaitForPingIntervalRouteClosed(t *testing.T) {
+	timeSource := blockdag.NewTimeSource()
+	timeSource.SetMockTime(mstime.UnixMilliseconds(1000000))
+	flow := &sendPingsFlow{incomingRoute: router.NewRoute()}
+
+	errChan := make(chan error)
+	go func() {
+		errChan <- flow.waitForPingInterval(timeSource, time.Minute)
+	}()
+
+	select {
+	case err := <-errChan:
+		t.Fatalf("waitForPingInterval returned before the route was closed: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	flow.incomingRoute.Close()
+	select {
+	case err := <-errChan:
+		if !errors.Is(err, router.ErrRouteClosed) {
+			t.Fatalf("waitForPingInterval returned %v, but expected %s", err, router.ErrRouteClosed)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("waitForPingInterval didn't return after the route was closed")
+	}
+}
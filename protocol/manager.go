@@ -9,7 +9,10 @@ import (
 	"github.com/kaspanet/kaspad/connmanager"
 	"github.com/kaspanet/kaspad/mempool"
 	"github.com/kaspanet/kaspad/netadapter"
+	"github.com/kaspanet/kaspad/netadapter/id"
+	"github.com/kaspanet/kaspad/netadapter/server"
 	"github.com/kaspanet/kaspad/protocol/flowcontext"
+	"github.com/kaspanet/kaspad/protocol/flows/ibd"
 	peerpkg "github.com/kaspanet/kaspad/protocol/peer"
 	"github.com/kaspanet/kaspad/util"
 )
@@ -28,6 +31,7 @@ func NewManager(cfg *config.Config, dag *blockdag.BlockDAG, netAdapter *netadapt
 		context: flowcontext.New(cfg, dag, addressManager, txPool, netAdapter, connectionManager),
 	}
 	netAdapter.SetRouterInitializer(manager.routerInitializer)
+	connectionManager.SetPeerQualityFunc(manager.context.PeerQuality)
 	return &manager, nil
 }
 
@@ -41,6 +45,11 @@ func (m *Manager) Stop() error {
 	return m.context.NetAdapter().Stop()
 }
 
+// NodeID returns the ID of this node in the P2P network
+func (m *Manager) NodeID() *id.ID {
+	return m.context.NetAdapter().ID()
+}
+
 // Peers returns the currently active peers
 func (m *Manager) Peers() []*peerpkg.Peer {
 	return m.context.Peers()
@@ -52,6 +61,17 @@ func (m *Manager) IBDPeer() *peerpkg.Peer {
 	return m.context.IBDPeer()
 }
 
+// TrafficStats returns the traffic sent and received over all the P2P connections
+func (m *Manager) TrafficStats() *server.TrafficStats {
+	return m.context.NetAdapter().TrafficStats()
+}
+
+// IBDUploadStats returns the state of the cap on
+// the blocks served to syncing peers
+func (m *Manager) IBDUploadStats() *ibd.UploadLimiterStats {
+	return m.context.IBDUploadLimiter().Stats()
+}
+
 // AddTransaction adds transaction to the mempool and propagates it.
 func (m *Manager) AddTransaction(tx *util.Tx) error {
 	return m.context.AddTransaction(tx)
@@ -62,6 +82,10 @@ func (m *Manager) AddBlock(block *util.Block, flags blockdag.BehaviorFlags) erro
 	return m.context.AddBlock(block, flags)
 }
 
+// runFlows runs the flows of the given peer until one of them stops, and
+// then removes the peer from the ready peers list. A flow stops once its
+// peer is disconnected, since its routes are closed, so disconnected peers
+// don't linger in the list.
 func (m *Manager) runFlows(flows []*flow, peer *peerpkg.Peer, errChan <-chan error) error {
 	for _, flow := range flows {
 		executeFunc := flow.executeFunc // extract to new variable so that it's not overwritten
@@ -70,5 +94,7 @@ func (m *Manager) runFlows(flows []*flow, peer *peerpkg.Peer, errChan <-chan err
 		})
 	}
 
-	return <-errChan
+	err := <-errChan
+	m.context.RemoveFromPeers(peer)
+	return err
 }
@@ -0,0 +1,149 @@
+package protocol
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/netadapter"
+	"github.com/kaspanet/kaspad/netadapter/id"
+	routerpkg "github.com/kaspanet/kaspad/netadapter/router"
+	"github.com/kaspanet/kaspad/protocol/flowcontext"
+	peerpkg "github.com/kaspanet/kaspad/protocol/peer"
+	"github.com/pkg/errors"
+)
+
+// TestDisconnectedPeerIsRemoved makes sure that once a peer is
+// disconnected, its flows exit and it's removed from the ready peers list.
+func TestDisconnectedPeerIsRemoved(t *testing.T) {
+	serverAdapter, serverAddress := newTestNetAdapter(t)
+	defer serverAdapter.Stop()
+	clientAdapter, _ := newTestNetAdapter(t)
+	defer clientAdapter.Stop()
+
+	m := &Manager{
+		context: flowcontext.New(config.DefaultConfig(), nil, nil, nil, serverAdapter, nil),
+	}
+
+	// Every flow waits for messages until its route is closed, and
+	// signals that it exited through its channel in flowExitChans.
+	flowMessageTypes := [][]domainmessage.MessageCommand{
+		{domainmessage.CmdPing},
+		{domainmessage.CmdPong},
+	}
+	flowExitChans := make([]chan struct{}, len(flowMessageTypes))
+	for i := range flowExitChans {
+		flowExitChans[i] = make(chan struct{})
+	}
+	runFlowsErrChan := make(chan error)
+	serverAdapter.SetRouterInitializer(func(router *routerpkg.Router, netConnection *netadapter.NetConnection) {
+		isStopping := uint32(0)
+		errChan := make(chan error)
+
+		flows := make([]*flow, len(flowMessageTypes))
+		for i, messageTypes := range flowMessageTypes {
+			flowExitChan := flowExitChans[i]
+			flows[i] = m.registerFlow("TestFlow", router, messageTypes, &isStopping, errChan,
+				func(route *routerpkg.Route, peer *peerpkg.Peer) error {
+					defer close(flowExitChan)
+					for {
+						_, err := route.Dequeue()
+						if err != nil {
+							return err
+						}
+					}
+				},
+			)
+		}
+
+		spawn("TestDisconnectedPeerIsRemoved-runFlows", func() {
+			peerID, err := id.GenerateID()
+			if err != nil {
+				runFlowsErrChan <- err
+				return
+			}
+			netConnection.SetID(peerID)
+			peer := peerpkg.New(netConnection)
+			err = m.context.AddToPeers(peer)
+			if err != nil {
+				runFlowsErrChan <- err
+				return
+			}
+			runFlowsErrChan <- m.runFlows(flows, peer, errChan)
+		})
+	})
+
+	clientConnectionChan := make(chan *netadapter.NetConnection, 1)
+	clientAdapter.SetRouterInitializer(func(router *routerpkg.Router, netConnection *netadapter.NetConnection) {
+		clientConnectionChan <- netConnection
+	})
+	err := clientAdapter.Connect(serverAddress)
+	if err != nil {
+		t.Fatalf("TestDisconnectedPeerIsRemoved: Connect unexpectedly failed: %s", err)
+	}
+	clientConnection := <-clientConnectionChan
+
+	waitForPeerCount(t, m, 1)
+
+	clientConnection.Disconnect()
+
+	select {
+	case err := <-runFlowsErrChan:
+		if !errors.Is(err, routerpkg.ErrRouteClosed) {
+			t.Fatalf("TestDisconnectedPeerIsRemoved: runFlows returned %v, but expected %s",
+				err, routerpkg.ErrRouteClosed)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("TestDisconnectedPeerIsRemoved: runFlows didn't return after the peer was disconnected")
+	}
+	for i, flowExitChan := range flowExitChans {
+		select {
+		case <-flowExitChan:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("TestDisconnectedPeerIsRemoved: flow %d didn't exit after the peer was disconnected", i)
+		}
+	}
+	if len(m.Peers()) != 0 {
+		t.Fatalf("TestDisconnectedPeerIsRemoved: the disconnected peer wasn't removed from the peers")
+	}
+}
+
+// newTestNetAdapter starts a net adapter that listens on a free local port,
+// and returns it along with its listening address.
+func newTestNetAdapter(t *testing.T) (*netadapter.NetAdapter, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("newTestNetAdapter: Listen unexpectedly failed: %s", err)
+	}
+	address := listener.Addr().String()
+	err = listener.Close()
+	if err != nil {
+		t.Fatalf("newTestNetAdapter: Close unexpectedly failed: %s", err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.Listeners = []string{address}
+	netAdapter, err := netadapter.NewNetAdapter(cfg)
+	if err != nil {
+		t.Fatalf("newTestNetAdapter: NewNetAdapter unexpectedly failed: %s", err)
+	}
+	netAdapter.SetRouterInitializer(func(*routerpkg.Router, *netadapter.NetConnection) {})
+	err = netAdapter.Start()
+	if err != nil {
+		t.Fatalf("newTestNetAdapter: Start unexpectedly failed: %s", err)
+	}
+	return netAdapter, address
+}
+
+func waitForPeerCount(t *testing.T, m *Manager, expectedCount int) {
+	timeout := time.After(10 * time.Second)
+	for len(m.Peers()) != expectedCount {
+		select {
+		case <-timeout:
+			t.Fatalf("waitForPeerCount: got %d peers, but expected %d", len(m.Peers()), expectedCount)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
@@ -36,6 +36,10 @@ type Peer struct {
 	lastPingTime     time.Time     // Time we sent last ping
 	lastPingDuration time.Duration // Time for last ping to return
 
+	noveltyLock              sync.RWMutex
+	lastNovelBlockTime       time.Time // Time the peer last relayed a new block
+	lastNovelTransactionTime time.Time // Time the peer last relayed a new transaction
+
 	isSelectedTipRequested uint32
 	selectedTipRequestChan chan struct{}
 	lastSelectedTipRequest mstime.Time
@@ -205,3 +209,40 @@ func (p *Peer) LastPingDuration() time.Duration {
 
 	return p.lastPingDuration
 }
+
+// MarkNovelBlock records that this peer has just relayed to us
+// a valid block we didn't know about
+func (p *Peer) MarkNovelBlock() {
+	p.noveltyLock.Lock()
+	defer p.noveltyLock.Unlock()
+
+	p.lastNovelBlockTime = time.Now()
+}
+
+// LastNovelBlockTime returns the last time this peer relayed to us
+// a valid block we didn't know about, or the zero time if it never has
+func (p *Peer) LastNovelBlockTime() time.Time {
+	p.noveltyLock.RLock()
+	defer p.noveltyLock.RUnlock()
+
+	return p.lastNovelBlockTime
+}
+
+// MarkNovelTransaction records that this peer has just relayed to us
+// a transaction that was accepted to the mempool
+func (p *Peer) MarkNovelTransaction() {
+	p.noveltyLock.Lock()
+	defer p.noveltyLock.Unlock()
+
+	p.lastNovelTransactionTime = time.Now()
+}
+
+// LastNovelTransactionTime returns the last time this peer relayed to us
+// a transaction that was accepted to the mempool, or the zero time if
+// it never has
+func (p *Peer) LastNovelTransactionTime() time.Time {
+	p.noveltyLock.RLock()
+	defer p.noveltyLock.RUnlock()
+
+	return p.lastNovelTransactionTime
+}
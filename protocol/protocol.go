@@ -49,6 +49,11 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 			return
 		}
 
+		if !netConnection.IsOutbound() && !m.context.ConnectionManager().AdmitIncomingConnection(netConnection) {
+			netConnection.Disconnect()
+			return
+		}
+
 		netConnection.SetOnInvalidMessageHandler(func(err error) {
 			if atomic.AddUint32(&isStopping, 1) == 1 {
 				errChan <- protocolerrors.Wrap(true, err, "received bad message")
@@ -165,7 +170,7 @@ func (m *Manager) registerIBDFlows(router *routerpkg.Router, isStopping *uint32,
 
 	return []*flow{
 		m.registerFlow("HandleIBD", router, []domainmessage.MessageCommand{domainmessage.CmdBlockLocator, domainmessage.CmdIBDBlock,
-			domainmessage.CmdDoneIBDBlocks}, isStopping, errChan,
+			domainmessage.CmdDoneIBDBlocks, domainmessage.CmdIBDHeaders}, isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
 				return ibd.HandleIBD(m.context, incomingRoute, outgoingRoute, peer)
 			},
@@ -194,6 +199,12 @@ func (m *Manager) registerIBDFlows(router *routerpkg.Router, isStopping *uint32,
 				return ibd.HandleRequestIBDBlocks(m.context, incomingRoute, outgoingRoute)
 			},
 		),
+
+		m.registerFlow("HandleRequestIBDHeaders", router, []domainmessage.MessageCommand{domainmessage.CmdRequestIBDHeaders}, isStopping, errChan,
+			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
+				return ibd.HandleRequestIBDHeaders(m.context, incomingRoute, outgoingRoute)
+			},
+		),
 	}
 }
 
@@ -204,7 +215,7 @@ func (m *Manager) registerTransactionRelayFlow(router *routerpkg.Router, isStopp
 		m.registerFlow("HandleRelayedTransactions", router,
 			[]domainmessage.MessageCommand{domainmessage.CmdInvTransaction, domainmessage.CmdTx, domainmessage.CmdTransactionNotFound}, isStopping, errChan,
 			func(incomingRoute *routerpkg.Route, peer *peerpkg.Peer) error {
-				return relaytransactions.HandleRelayedTransactions(m.context, incomingRoute, outgoingRoute)
+				return relaytransactions.HandleRelayedTransactions(m.context, incomingRoute, outgoingRoute, peer)
 			},
 		),
 		m.registerFlow("HandleRequestTransactions", router,
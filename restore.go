@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/dbaccess"
+	"github.com/pkg/errors"
+)
+
+// restoreDatabase validates the database backup at cfg.RestoreDatabase
+// and copies it in place of the database. The replaced database, if any,
+// is renamed rather than removed, so that it can be recovered manually.
+func restoreDatabase(cfg *config.Config) error {
+	backupPath := cfg.RestoreDatabase
+	log.Infof("Validating the database backup at '%s'", backupPath)
+	err := dbaccess.ValidateBackup(cfg.DbType, backupPath)
+	if err != nil {
+		return errors.Wrapf(err, "invalid database backup at '%s'", backupPath)
+	}
+
+	dbPath := databasePath(cfg)
+	if _, err := os.Stat(dbPath); err == nil {
+		replacedPath := fmt.Sprintf("%s-replaced-%d", dbPath, time.Now().Unix())
+		log.Infof("Moving the current database to '%s'", replacedPath)
+		err := os.Rename(dbPath, replacedPath)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.WithStack(err)
+	}
+
+	log.Infof("Restoring the database from '%s'", backupPath)
+	return copyDirectory(backupPath, dbPath)
+}
+
+// copyDirectory copies the regular files in the directory at
+// sourcePath into a new directory at destinationPath.
+func copyDirectory(sourcePath string, destinationPath string) error {
+	entries, err := ioutil.ReadDir(sourcePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = os.MkdirAll(destinationPath, 0700)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() {
+			continue
+		}
+		err := copyFile(filepath.Join(sourcePath, entry.Name()), filepath.Join(destinationPath, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(sourcePath string, destinationPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer source.Close()
+
+	destination, err := os.OpenFile(destinationPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer destination.Close()
+
+	_, err = io.Copy(destination, source)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(destination.Sync())
+}
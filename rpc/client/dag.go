@@ -9,6 +9,9 @@ import (
 	"bytes"
 	"encoding/hex"
 	"encoding/json"
+	"time"
+
+	"github.com/kaspanet/kaspad/util/mstime"
 	"github.com/kaspanet/kaspad/util/pointers"
 
 	"github.com/pkg/errors"
@@ -364,6 +367,76 @@ func (c *Client) GetBlockDAGInfo() (*model.GetBlockDAGInfoResult, error) {
 	return c.GetBlockDAGInfoAsync().Receive()
 }
 
+// FutureGetInfoResult is a promise to deliver the result of a
+// GetInfoAsync RPC invocation (or an applicable error).
+type FutureGetInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// node info provided by the server.
+func (r FutureGetInfoResult) Receive() (*model.InfoDAGResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var info model.InfoDAGResult
+	if err := json.Unmarshal(res, &info); err != nil {
+		return nil, errors.Wrap(err, "couldn't decode getInfo response")
+	}
+	return &info, nil
+}
+
+// GetInfoAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GetInfo for the blocking version and more details.
+func (c *Client) GetInfoAsync() FutureGetInfoResult {
+	cmd := model.NewGetInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetInfo returns various state info of the node, such as its ID in the
+// P2P network and its number of connected peers.
+func (c *Client) GetInfo() (*model.InfoDAGResult, error) {
+	return c.GetInfoAsync().Receive()
+}
+
+// FutureGetDeploymentInfoResult is a promise to deliver the result of a
+// GetDeploymentInfoAsync RPC invocation (or an applicable error).
+type FutureGetDeploymentInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// deployment info provided by the server.
+func (r FutureGetDeploymentInfoResult) Receive() (*model.GetDeploymentInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var deploymentInfo model.GetDeploymentInfoResult
+	if err := json.Unmarshal(res, &deploymentInfo); err != nil {
+		return nil, errors.Wrap(err, "couldn't decode getDeploymentInfo response")
+	}
+	return &deploymentInfo, nil
+}
+
+// GetDeploymentInfoAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetDeploymentInfo for the blocking version and more details.
+func (c *Client) GetDeploymentInfoAsync() FutureGetDeploymentInfoResult {
+	cmd := model.NewGetDeploymentInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetDeploymentInfo returns the state of every rule change deployment of
+// the network, and the signalling for it in the current confirmation window.
+func (c *Client) GetDeploymentInfo() (*model.GetDeploymentInfoResult, error) {
+	return c.GetDeploymentInfoAsync().Receive()
+}
+
 // FutureGetBlockHashResult is a future promise to deliver the result of a
 // GetBlockHashAsync RPC invocation (or an applicable error).
 type FutureGetBlockHashResult chan *response
@@ -746,3 +819,169 @@ func (c *Client) RescanBlocksAsync(blockHashes []*daghash.Hash) FutureRescanBloc
 func (c *Client) RescanBlocks(blockHashes []*daghash.Hash) ([]model.RescannedBlock, error) {
 	return c.RescanBlocksAsync(blockHashes).Receive()
 }
+
+// FutureInvalidateBlockResult is a future promise to deliver the result of an
+// InvalidateBlockAsync RPC invocation (or an applicable error).
+type FutureInvalidateBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if
+// any occurred when invalidating the block.
+func (r FutureInvalidateBlockResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// InvalidateBlockAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See InvalidateBlock for the blocking version and more details.
+func (c *Client) InvalidateBlockAsync(blockHash *daghash.Hash) FutureInvalidateBlockResult {
+	cmd := model.NewInvalidateBlockCmd(blockHash.String())
+	return c.sendCmd(cmd)
+}
+
+// InvalidateBlock marks the block with the given hash, and all the blocks
+// in its future, as invalid.
+func (c *Client) InvalidateBlock(blockHash *daghash.Hash) error {
+	return c.InvalidateBlockAsync(blockHash).Receive()
+}
+
+// FutureReconsiderBlockResult is a future promise to deliver the result of a
+// ReconsiderBlockAsync RPC invocation (or an applicable error).
+type FutureReconsiderBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if
+// any occurred when reconsidering the block.
+func (r FutureReconsiderBlockResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ReconsiderBlockAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See ReconsiderBlock for the blocking version and more details.
+func (c *Client) ReconsiderBlockAsync(blockHash *daghash.Hash) FutureReconsiderBlockResult {
+	cmd := model.NewReconsiderBlockCmd(blockHash.String())
+	return c.sendCmd(cmd)
+}
+
+// ReconsiderBlock removes the invalidity status of the block with the given
+// hash, and validates it and the blocks related to it again.
+func (c *Client) ReconsiderBlock(blockHash *daghash.Hash) error {
+	return c.ReconsiderBlockAsync(blockHash).Receive()
+}
+
+// FutureBackupDatabaseResult is a future promise to deliver the result of a
+// BackupDatabaseAsync RPC invocation (or an applicable error).
+type FutureBackupDatabaseResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if
+// any occurred when backing up the database.
+func (r FutureBackupDatabaseResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// BackupDatabaseAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See BackupDatabase for the blocking version and more details.
+func (c *Client) BackupDatabaseAsync(destDir string) FutureBackupDatabaseResult {
+	cmd := model.NewBackupDatabaseCmd(destDir)
+	return c.sendCmd(cmd)
+}
+
+// BackupDatabase makes the server write a consistent copy of its database
+// into the given directory on the server's host.
+func (c *Client) BackupDatabase(destDir string) error {
+	return c.BackupDatabaseAsync(destDir).Receive()
+}
+
+// FutureGetDAGSubgraphResult is a promise to deliver the result of a
+// GetDAGSubgraphAsync RPC invocation (or an applicable error).
+type FutureGetDAGSubgraphResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// DAG subgraph provided by the server.
+func (r FutureGetDAGSubgraphResult) Receive() (*model.GetDAGSubgraphResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var subgraph model.GetDAGSubgraphResult
+	if err := json.Unmarshal(res, &subgraph); err != nil {
+		return nil, errors.Wrap(err, "couldn't decode getDAGSubgraph response")
+	}
+	return &subgraph, nil
+}
+
+// GetDAGSubgraphAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function
+// on the returned instance.
+//
+// See GetDAGSubgraph for the blocking version and more details.
+func (c *Client) GetDAGSubgraphAsync(lowHash, highHash *daghash.Hash) FutureGetDAGSubgraphResult {
+	cmd := model.NewGetDAGSubgraphCmd(lowHash.String(), highHash.String())
+	return c.sendCmd(cmd)
+}
+
+// GetDAGSubgraph returns the blocks in the past of highHash that aren't in
+// the past of lowHash, annotated with their GHOSTDAG coloring and position
+// in the DAG.
+func (c *Client) GetDAGSubgraph(lowHash, highHash *daghash.Hash) (*model.GetDAGSubgraphResult, error) {
+	return c.GetDAGSubgraphAsync(lowHash, highHash).Receive()
+}
+
+// FutureSetMockTimeResult is a future promise to deliver the result of a
+// SetMockTimeAsync or AdvanceMockTimeAsync RPC invocation (or an applicable
+// error).
+type FutureSetMockTimeResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if
+// any occurred when changing the mock time.
+func (r FutureSetMockTimeResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetMockTimeAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See SetMockTime for the blocking version and more details.
+func (c *Client) SetMockTimeAsync(mockTime mstime.Time) FutureSetMockTimeResult {
+	var timestamp int64
+	if !mockTime.IsZero() {
+		timestamp = mockTime.UnixMilliseconds()
+	}
+	cmd := model.NewSetMockTimeCmd(timestamp)
+	return c.sendCmd(cmd)
+}
+
+// SetMockTime makes the server use the given time instead of its local time.
+// The zero time makes the server go back to its local time. It's only
+// supported on networks that enable mock time, such as regtest and simnet.
+func (c *Client) SetMockTime(mockTime mstime.Time) error {
+	return c.SetMockTimeAsync(mockTime).Receive()
+}
+
+// AdvanceMockTimeAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See AdvanceMockTime for the blocking version and more details.
+func (c *Client) AdvanceMockTimeAsync(d time.Duration) FutureSetMockTimeResult {
+	cmd := model.NewAdvanceMockTimeCmd(d.Milliseconds())
+	return c.sendCmd(cmd)
+}
+
+// AdvanceMockTime moves the mock time of the server, which must have been
+// set with SetMockTime, forward by the given duration.
+func (c *Client) AdvanceMockTime(d time.Duration) error {
+	return c.AdvanceMockTimeAsync(d).Receive()
+}
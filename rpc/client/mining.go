@@ -67,6 +67,69 @@ func (c *Client) SubmitBlock(block *util.Block, options *model.SubmitBlockOption
 	return c.SubmitBlockAsync(block, options).Receive()
 }
 
+// FutureGenerateResult is a future promise to deliver the result of a
+// GenerateAsync or GenerateToAddressAsync RPC invocation (or an applicable
+// error).
+type FutureGenerateResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+// hashes of the generated blocks.
+func (r FutureGenerateResult) Receive() ([]*daghash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockHashStrs []string
+	err = json.Unmarshal(res, &blockHashStrs)
+	if err != nil {
+		return nil, err
+	}
+
+	blockHashes := make([]*daghash.Hash, len(blockHashStrs))
+	for i, blockHashStr := range blockHashStrs {
+		blockHashes[i], err = daghash.NewHashFromStr(blockHashStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return blockHashes, nil
+}
+
+// GenerateAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See Generate for the blocking version and more details.
+func (c *Client) GenerateAsync(numBlocks uint32) FutureGenerateResult {
+	cmd := model.NewGenerateCmd(numBlocks)
+	return c.sendCmd(cmd)
+}
+
+// Generate makes the server mine and submit the given number of blocks that
+// pay to an anyone-can-spend address, and returns their hashes. It's only
+// supported on networks with a low difficulty, such as regtest and simnet.
+func (c *Client) Generate(numBlocks uint32) ([]*daghash.Hash, error) {
+	return c.GenerateAsync(numBlocks).Receive()
+}
+
+// GenerateToAddressAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See GenerateToAddress for the blocking version and more details.
+func (c *Client) GenerateToAddressAsync(numBlocks uint32, address util.Address) FutureGenerateResult {
+	cmd := model.NewGenerateToAddressCmd(numBlocks, address.EncodeAddress())
+	return c.sendCmd(cmd)
+}
+
+// GenerateToAddress makes the server mine and submit the given number of
+// blocks that pay to the given address, and returns their hashes. It's only
+// supported on networks with a low difficulty, such as regtest and simnet.
+func (c *Client) GenerateToAddress(numBlocks uint32, address util.Address) ([]*daghash.Hash, error) {
+	return c.GenerateToAddressAsync(numBlocks, address).Receive()
+}
+
 // FutureGetBlockTemplateResult is a future promise to deliver the result of a
 // GetBlockTemplate RPC invocation (or an applicable error).
 type FutureGetBlockTemplateResult chan *response
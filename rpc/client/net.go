@@ -46,6 +46,22 @@ func (c *Client) ConnectNode(host string) error {
 	return c.ConnectNodeAsync(host).Receive()
 }
 
+// DisconnectNodeAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See DisconnectNode for the blocking version and more details.
+func (c *Client) DisconnectNodeAsync(host string) FutureAddNodeResult {
+	cmd := model.NewDisconnectCmd(host)
+	return c.sendCmd(cmd)
+}
+
+// DisconnectNode disconnects the peer with the given address, and stops
+// the connection attempts to it if it's a persistent peer.
+func (c *Client) DisconnectNode(host string) error {
+	return c.DisconnectNodeAsync(host).Receive()
+}
+
 // FutureGetConnectionCountResult is a future promise to deliver the result
 // of a GetConnectionCountAsync RPC invocation (or an applicable error).
 type FutureGetConnectionCountResult chan *response
@@ -223,6 +239,44 @@ func (c *Client) GetNetTotals() (*model.GetNetTotalsResult, error) {
 	return c.GetNetTotalsAsync().Receive()
 }
 
+// FutureGetNetStatsResult is a future promise to deliver the result of a
+// GetNetStatsAsync RPC invocation (or an applicable error).
+type FutureGetNetStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns network
+// traffic statistics broken down by message type.
+func (r FutureGetNetStatsResult) Receive() (*model.GetNetStatsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal result as a getNetStats result object.
+	var stats model.GetNetStatsResult
+	err = json.Unmarshal(res, &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetNetStatsAsync returns an instance of a type that can be used to get the
+// result of the RPC at some future time by invoking the Receive function on the
+// returned instance.
+//
+// See GetNetStats for the blocking version and more details.
+func (c *Client) GetNetStatsAsync() FutureGetNetStatsResult {
+	cmd := model.NewGetNetStatsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetNetStats returns network traffic statistics broken down by message
+// type, and the state of the cap on the blocks served to syncing peers.
+func (c *Client) GetNetStats() (*model.GetNetStatsResult, error) {
+	return c.GetNetStatsAsync().Receive()
+}
+
 // FutureDebugLevelResult is a future promise to deliver the result of a
 // DebugLevelAsync RPC invocation (or an applicable error).
 type FutureDebugLevelResult chan *response
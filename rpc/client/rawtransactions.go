@@ -61,3 +61,80 @@ func (c *Client) SendRawTransactionAsync(tx *domainmessage.MsgTx, allowHighFees
 func (c *Client) SendRawTransaction(tx *domainmessage.MsgTx, allowHighFees bool) (*daghash.TxID, error) {
 	return c.SendRawTransactionAsync(tx, allowHighFees).Receive()
 }
+
+// FutureTestMempoolAcceptResult is a future promise to deliver the result
+// of a TestMempoolAcceptAsync RPC invocation (or an applicable error).
+type FutureTestMempoolAcceptResult chan *response
+
+// Receive waits for the response promised by the future and returns whether
+// the server's memory pool would accept each of the transactions.
+func (r FutureTestMempoolAcceptResult) Receive() ([]model.TestMempoolAcceptResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []model.TestMempoolAcceptResult
+	err = json.Unmarshal(res, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// TestMempoolAcceptAsync returns an instance of a type that can be used to get
+// the result of the RPC at some future time by invoking the Receive function on
+// the returned instance.
+//
+// See TestMempoolAccept for the blocking version and more details.
+func (c *Client) TestMempoolAcceptAsync(txs []*domainmessage.MsgTx) FutureTestMempoolAcceptResult {
+	txHexes := make([]string, len(txs))
+	for i, tx := range txs {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		txHexes[i] = hex.EncodeToString(buf.Bytes())
+	}
+
+	cmd := model.NewTestMempoolAcceptCmd(txHexes)
+	return c.sendCmd(cmd)
+}
+
+// TestMempoolAccept returns whether the server's memory pool would accept
+// each of the given transactions, without adding them to it or relaying
+// them. The transactions are checked in order as a package, so a transaction
+// may spend the outputs of the transactions before it.
+func (c *Client) TestMempoolAccept(txs []*domainmessage.MsgTx) ([]model.TestMempoolAcceptResult, error) {
+	return c.TestMempoolAcceptAsync(txs).Receive()
+}
+
+// FuturePrioritiseTransactionResult is a future promise to deliver the result
+// of a PrioritiseTransactionAsync RPC invocation (or an applicable error).
+type FuturePrioritiseTransactionResult chan *response
+
+// Receive waits for the response promised by the future and returns an error
+// if any occurred when prioritising the transaction.
+func (r FuturePrioritiseTransactionResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// PrioritiseTransactionAsync returns an instance of a type that can be used to
+// get the result of the RPC at some future time by invoking the Receive
+// function on the returned instance.
+//
+// See PrioritiseTransaction for the blocking version and more details.
+func (c *Client) PrioritiseTransactionAsync(txID *daghash.TxID, feeDelta int64) FuturePrioritiseTransactionResult {
+	cmd := model.NewPrioritiseTransactionCmd(txID.String(), feeDelta)
+	return c.sendCmd(cmd)
+}
+
+// PrioritiseTransaction sets a virtual fee delta, in sompis, that the server
+// adds to the fee of the transaction when it selects transactions for mining.
+// A fee delta of 0 removes the prioritisation.
+func (c *Client) PrioritiseTransaction(txID *daghash.TxID, feeDelta int64) error {
+	return c.PrioritiseTransactionAsync(txID, feeDelta).Receive()
+}
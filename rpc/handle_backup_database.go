@@ -0,0 +1,28 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/rpc/model"
+)
+
+// handleBackupDatabase implements the backupDatabase command.
+func handleBackupDatabase(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.BackupDatabaseCmd)
+
+	if c.DestDir == "" {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCInvalidParameter,
+			Message: "destDir must not be empty",
+		}
+	}
+
+	log.Infof("Backing up the database into %s", c.DestDir)
+	err := s.databaseContext.Backup(c.DestDir)
+	if err != nil {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCMisc,
+			Message: err.Error(),
+		}
+	}
+	log.Infof("Finished backing up the database into %s", c.DestDir)
+	return nil, nil
+}
@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/mining"
+	"github.com/kaspanet/kaspad/rpc/model"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/kaspanet/kaspad/util/random"
+)
+
+// handleGenerate implements the generate command.
+func handleGenerate(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.GenerateCmd)
+
+	// Without an explicit address, the blocks pay to an anyone-can-spend
+	// address so that their outputs can be used by any test.
+	payAddr, err := mining.OpTrueAddress(s.dag.Params.Prefix)
+	if err != nil {
+		return nil, internalRPCError(err.Error(), "Could not create the pay address")
+	}
+	return generateBlocks(s, c.NumBlocks, payAddr, closeChan)
+}
+
+// handleGenerateToAddress implements the generateToAddress command.
+func handleGenerateToAddress(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.GenerateToAddressCmd)
+
+	payAddr, err := util.DecodeAddress(c.Address, s.dag.Params.Prefix)
+	if err != nil {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCInvalidAddressOrKey,
+			Message: fmt.Sprintf("Invalid address: %s", err),
+		}
+	}
+	return generateBlocks(s, c.NumBlocks, payAddr, closeChan)
+}
+
+// generateBlocks mines the given number of blocks that pay to payAddr one
+// after the other, submits each of them to the DAG, and returns their hashes.
+func generateBlocks(s *Server, numBlocks uint32, payAddr util.Address, closeChan <-chan struct{}) (interface{}, error) {
+	if !s.dag.Params.EnableGenerate {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCMisc,
+			Message: fmt.Sprintf("Block generation is not supported on %s", s.dag.Params.Name),
+		}
+	}
+
+	blockHashes := make([]string, 0, numBlocks)
+	for i := uint32(0); i < numBlocks; i++ {
+		extraNonce, err := random.Uint64()
+		if err != nil {
+			return nil, internalRPCError(err.Error(), "Could not generate an extra nonce")
+		}
+		template, err := s.blockTemplateGenerator.NewBlockTemplate(payAddr, extraNonce)
+		if err != nil {
+			return nil, internalRPCError(err.Error(), "Could not create a block template")
+		}
+
+		solved, err := solveBlock(template.Block, closeChan)
+		if err != nil {
+			return nil, err
+		}
+		if !solved {
+			return nil, &model.RPCError{
+				Code:    model.ErrRPCMisc,
+				Message: fmt.Sprintf("Could not solve a block after generating %d blocks", len(blockHashes)),
+			}
+		}
+
+		block := util.NewBlock(template.Block)
+		err = s.protocolManager.AddBlock(block, blockdag.BFDisallowDelay|blockdag.BFDisallowOrphans)
+		if err != nil {
+			return nil, &model.RPCError{
+				Code:    model.ErrRPCVerify,
+				Message: fmt.Sprintf("Generated block rejected. Reason: %s", err),
+			}
+		}
+		log.Debugf("Generated block %s", block.Hash())
+		blockHashes = append(blockHashes, block.Hash().String())
+	}
+
+	return blockHashes, nil
+}
+
+// solveBlock searches for a nonce that satisfies the target difficulty of
+// the block, and sets it in its header. It returns false if the whole nonce
+// space was searched without finding one. ErrClientQuit is returned if the
+// client disconnects before the block is solved.
+func solveBlock(msgBlock *domainmessage.MsgBlock, closeChan <-chan struct{}) (bool, error) {
+	target := util.CompactToBig(msgBlock.Header.Bits)
+	header := &msgBlock.Header
+
+	// Check whether the client has disconnected only once every
+	// closeCheckInterval nonces, since on the networks that support
+	// generation most blocks are solved after a handful of tries.
+	const closeCheckInterval = 1 << 16
+	for nonce := uint64(0); ; nonce++ {
+		if nonce%closeCheckInterval == 0 {
+			select {
+			case <-closeChan:
+				return false, ErrClientQuit
+			default:
+			}
+		}
+
+		header.Nonce = nonce
+		if daghash.HashToBig(header.BlockHash()).Cmp(target) <= 0 {
+			return true, nil
+		}
+		if nonce == ^uint64(0) {
+			return false, nil
+		}
+	}
+}
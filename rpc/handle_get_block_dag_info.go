@@ -3,7 +3,6 @@ package rpc
 import (
 	"fmt"
 	"github.com/kaspanet/kaspad/blockdag"
-	"github.com/kaspanet/kaspad/dagconfig"
 	"github.com/kaspanet/kaspad/rpc/model"
 	"github.com/kaspanet/kaspad/util/daghash"
 	"github.com/pkg/errors"
@@ -31,21 +30,6 @@ func handleGetBlockDAGInfo(s *Server, cmd interface{}, closeChan <-chan struct{}
 	// Finally, query the BIP0009 version bits state for all currently
 	// defined BIP0009 soft-fork deployments.
 	for deployment, deploymentDetails := range params.Deployments {
-		// Map the integer deployment ID into a human readable
-		// fork-name.
-		var forkName string
-		switch deployment {
-		case dagconfig.DeploymentTestDummy:
-			forkName = "dummy"
-
-		default:
-			return nil, &model.RPCError{
-				Code: model.ErrRPCInternal.Code,
-				Message: fmt.Sprintf("Unknown deployment %d "+
-					"detected", deployment),
-			}
-		}
-
 		// Query the dag for the current status of the deployment as
 		// identified by its deployment ID.
 		deploymentStatus, err := dag.ThresholdState(uint32(deployment))
@@ -68,7 +52,7 @@ func handleGetBlockDAGInfo(s *Server, cmd interface{}, closeChan <-chan struct{}
 
 		// Finally, populate the soft-fork description with all the
 		// information gathered above.
-		dagInfo.Bip9SoftForks[forkName] = &model.Bip9SoftForkDescription{
+		dagInfo.Bip9SoftForks[deploymentDetails.Name] = &model.Bip9SoftForkDescription{
 			Status:    strings.ToLower(statusString),
 			Bit:       deploymentDetails.BitNumber,
 			StartTime: int64(deploymentDetails.StartTime),
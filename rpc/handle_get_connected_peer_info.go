@@ -1,14 +1,19 @@
 package rpc
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/rpc/model"
 )
 
 // handleGetConnectedPeerInfo implements the getConnectedPeerInfo command.
 func handleGetConnectedPeerInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	peers := s.protocolManager.Peers()
+	evictionProtections := s.connectionManager.EvictionProtections()
 	infos := make([]*model.GetConnectedPeerInfoResult, 0, len(peers))
 	for _, peer := range peers {
+		trafficStats := peer.Connection().TrafficStats()
+		bytesSent, bytesReceived := trafficStats.Totals()
 		info := &model.GetConnectedPeerInfoResult{
 			ID:                        peer.ID().String(),
 			Address:                   peer.Address(),
@@ -16,12 +21,31 @@ func handleGetConnectedPeerInfo(s *Server, cmd interface{}, closeChan <-chan str
 			SelectedTipHash:           peer.SelectedTipHash().String(),
 			IsSyncNode:                peer == s.protocolManager.IBDPeer(),
 			IsOutbound:                peer.IsOutbound(),
+			IsEncrypted:               peer.Connection().IsEncrypted(),
 			TimeOffset:                peer.TimeOffset().Milliseconds(),
 			UserAgent:                 peer.UserAgent(),
 			AdvertisedProtocolVersion: peer.AdvertisedProtocolVersion(),
 			TimeConnected:             peer.TimeConnected().Milliseconds(),
+			NetGroup:                  s.addressManager.GroupKey(peer.Connection().NetAddress()),
+			LastNovelBlockTime:        unixMilliseconds(peer.LastNovelBlockTime()),
+			LastNovelTransactionTime:  unixMilliseconds(peer.LastNovelTransactionTime()),
+			BytesSent:                 bytesSent,
+			BytesRecv:                 bytesReceived,
+			MessageStats:              messageStatsResults(trafficStats),
+		}
+		if evictionProtection, ok := evictionProtections[peer.Connection()]; ok {
+			info.EvictionProtection = string(evictionProtection)
 		}
 		infos = append(infos, info)
 	}
 	return infos, nil
 }
+
+// unixMilliseconds returns the given time in milliseconds
+// since the epoch, or 0 for the zero time
+func unixMilliseconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
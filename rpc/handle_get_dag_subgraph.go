@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/rpc/model"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxNodesInGetDAGSubgraphResult is the max amount of blocks that
+	// are allowed in a GetDAGSubgraphResult.
+	maxNodesInGetDAGSubgraphResult = 2000
+)
+
+// handleGetDAGSubgraph implements the getDAGSubgraph command.
+func handleGetDAGSubgraph(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.GetDAGSubgraphCmd)
+
+	lowHash, err := daghash.NewHashFromStr(c.LowHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.LowHash)
+	}
+	highHash, err := daghash.NewHashFromStr(c.HighHash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.HighHash)
+	}
+
+	for _, hash := range []*daghash.Hash{lowHash, highHash} {
+		if !s.dag.IsInDAG(hash) {
+			return nil, &model.RPCError{
+				Code:    model.ErrRPCBlockNotFound,
+				Message: "Block not found: " + hash.String(),
+			}
+		}
+	}
+
+	subgraph, err := s.dag.Subgraph(lowHash, highHash, maxNodesInGetDAGSubgraphResult)
+	if err != nil {
+		if errors.Is(err, blockdag.ErrInvalidParameter) {
+			return nil, &model.RPCError{
+				Code:    model.ErrRPCInvalidParameter,
+				Message: err.Error(),
+			}
+		}
+		context := "Failed to obtain the DAG subgraph"
+		return nil, internalRPCError(err.Error(), context)
+	}
+
+	result := &model.GetDAGSubgraphResult{
+		Nodes: make([]*model.DAGSubgraphNodeResult, len(subgraph)),
+	}
+	for i, node := range subgraph {
+		parentHashes := make([]string, len(node.ParentHashes))
+		for j, parentHash := range node.ParentHashes {
+			parentHashes[j] = parentHash.String()
+		}
+		var selectedParentHash string
+		if node.SelectedParentHash != nil {
+			selectedParentHash = node.SelectedParentHash.String()
+		}
+		result.Nodes[i] = &model.DAGSubgraphNodeResult{
+			Hash:                      node.Hash.String(),
+			ParentHashes:              parentHashes,
+			SelectedParentHash:        selectedParentHash,
+			BlueScore:                 node.BlueScore,
+			IsBlue:                    node.IsBlue,
+			IsChainBlock:              node.IsChainBlock,
+			ReachabilityIntervalStart: node.ReachabilityIntervalStart,
+			ReachabilityIntervalEnd:   node.ReachabilityIntervalEnd,
+			IsFinalityPoint:           node.IsFinalityPoint,
+			IsTip:                     node.IsTip,
+			IsVirtualSelectedParent:   node.IsVirtualSelectedParent,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,41 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/rpc/model"
+)
+
+// handleGetDeploymentInfo implements the getDeploymentInfo command.
+func handleGetDeploymentInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	deploymentInfos, err := s.dag.DeploymentInfos()
+	if err != nil {
+		context := "Failed to obtain deployment info"
+		return nil, internalRPCError(err.Error(), context)
+	}
+
+	result := &model.GetDeploymentInfoResult{
+		Deployments: make([]*model.DeploymentInfoResult, len(deploymentInfos)),
+	}
+	for i, info := range deploymentInfos {
+		statusString, err := softForkStatus(info.State)
+		if err != nil {
+			return nil, &model.RPCError{
+				Code:    model.ErrRPCInternal.Code,
+				Message: fmt.Sprintf("unknown deployment status: %d", info.State),
+			}
+		}
+		result.Deployments[i] = &model.DeploymentInfoResult{
+			Name:             info.Deployment.Name,
+			Bit:              info.Deployment.BitNumber,
+			StartTime:        int64(info.Deployment.StartTime),
+			ExpireTime:       int64(info.Deployment.ExpireTime),
+			Status:           statusString,
+			WindowSize:       info.WindowSize,
+			Threshold:        info.Threshold,
+			WindowBlocks:     info.WindowBlocks,
+			SignallingBlocks: info.SignallingBlocks,
+		}
+	}
+	return result, nil
+}
@@ -9,6 +9,7 @@ import (
 // that are not related to wallet functionality.
 func handleGetInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	ret := &model.InfoDAGResult{
+		ID:              s.protocolManager.NodeID().String(),
 		Version:         version.Version(),
 		ProtocolVersion: int32(maxProtocolVersion),
 		Blocks:          s.dag.BlockCount(),
@@ -26,8 +26,17 @@ func handleGetMempoolEntry(s *Server, cmd interface{}, closeChan <-chan struct{}
 	}
 
 	return &model.GetMempoolEntryResult{
-		Fee:   txDesc.Fee,
-		Time:  txDesc.Added.UnixMilliseconds(),
-		RawTx: *rawTx,
+		Fee:             txDesc.Fee,
+		FeeDelta:        txDesc.FeeDelta,
+		ModifiedFee:     txDesc.ModifiedFee(),
+		Mass:            txDesc.Mass,
+		Time:            txDesc.Added.UnixMilliseconds(),
+		AncestorCount:   txDesc.AncestorCount,
+		AncestorFee:     txDesc.AncestorFee,
+		AncestorMass:    txDesc.AncestorMass,
+		DescendantCount: txDesc.DescendantCount,
+		DescendantFee:   txDesc.DescendantFee,
+		DescendantMass:  txDesc.DescendantMass,
+		RawTx:           *rawTx,
 	}, nil
 }
@@ -11,9 +11,14 @@ func handleGetMempoolInfo(s *Server, cmd interface{}, closeChan <-chan struct{})
 		numBytes += int64(txD.Tx.MsgTx().SerializeSize())
 	}
 
+	policy := s.txMempool.Policy()
 	ret := &model.GetMempoolInfoResult{
-		Size:  int64(len(mempoolTxns)),
-		Bytes: numBytes,
+		Size:               int64(len(mempoolTxns)),
+		Bytes:              numBytes,
+		MaxAncestorCount:   policy.MaxAncestorCount,
+		MaxAncestorMass:    policy.MaxAncestorMass,
+		MaxDescendantCount: policy.MaxDescendantCount,
+		MaxDescendantMass:  policy.MaxDescendantMass,
 	}
 
 	return ret, nil
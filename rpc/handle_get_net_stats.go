@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/netadapter/server"
+	"github.com/kaspanet/kaspad/rpc/model"
+)
+
+// handleGetNetStats implements the getNetStats command.
+func handleGetNetStats(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	trafficStats := s.protocolManager.TrafficStats()
+	bytesSent, bytesReceived := trafficStats.Totals()
+
+	ibdUploadStats := s.protocolManager.IBDUploadStats()
+	timeFrameEnd := ibdUploadStats.TimeFrameStart.Add(ibdUploadStats.TimeFrame)
+
+	return &model.GetNetStatsResult{
+		TotalBytesSent: bytesSent,
+		TotalBytesRecv: bytesReceived,
+		MessageStats:   messageStatsResults(trafficStats),
+		IBDUpload: &model.IBDUploadResult{
+			MaxBytes:       ibdUploadStats.MaxBytes,
+			BytesUsed:      ibdUploadStats.BytesUsed,
+			TimeFrameStart: unixMilliseconds(ibdUploadStats.TimeFrameStart),
+			TimeFrameEnd:   unixMilliseconds(timeFrameEnd),
+		},
+	}, nil
+}
+
+// messageStatsResults returns the traffic of every message
+// command in the given stats, sorted by command name
+func messageStatsResults(trafficStats *server.TrafficStats) []*model.MessageStatsResult {
+	results := make(map[domainmessage.MessageCommand]*model.MessageStatsResult)
+	resultFor := func(command domainmessage.MessageCommand) *model.MessageStatsResult {
+		result, ok := results[command]
+		if !ok {
+			result = &model.MessageStatsResult{Command: command.String()}
+			results[command] = result
+		}
+		return result
+	}
+
+	for command, traffic := range trafficStats.Sent() {
+		result := resultFor(command)
+		result.MessagesSent = traffic.Messages
+		result.BytesSent = traffic.Bytes
+	}
+	for command, traffic := range trafficStats.Received() {
+		result := resultFor(command)
+		result.MessagesRecv = traffic.Messages
+		result.BytesRecv = traffic.Bytes
+	}
+
+	sortedResults := make([]*model.MessageStatsResult, 0, len(results))
+	for _, result := range results {
+		sortedResults = append(sortedResults, result)
+	}
+	sort.Slice(sortedResults, func(i, j int) bool {
+		return sortedResults[i].Command < sortedResults[j].Command
+	})
+	return sortedResults
+}
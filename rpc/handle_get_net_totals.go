@@ -7,10 +7,10 @@ import (
 
 // handleGetNetTotals implements the getNetTotals command.
 func handleGetNetTotals(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
-	// TODO(libp2p): fill this up with real values
+	bytesSent, bytesReceived := s.protocolManager.TrafficStats().Totals()
 	reply := &model.GetNetTotalsResult{
-		TotalBytesRecv: 0,
-		TotalBytesSent: 0,
+		TotalBytesRecv: bytesReceived,
+		TotalBytesSent: bytesSent,
 		TimeMillis:     time.Now().UTC().UnixNano() / int64(time.Millisecond),
 	}
 	return reply, nil
@@ -38,10 +38,17 @@ func rawMempoolVerbose(s *Server) map[string]*model.GetRawMempoolVerboseResult {
 		tx := desc.Tx
 
 		mpd := &model.GetRawMempoolVerboseResult{
-			Size:    int32(tx.MsgTx().SerializeSize()),
-			Fee:     util.Amount(desc.Fee).ToKAS(),
-			Time:    desc.Added.UnixMilliseconds(),
-			Depends: make([]string, 0),
+			Size:            int32(tx.MsgTx().SerializeSize()),
+			Fee:             util.Amount(desc.Fee).ToKAS(),
+			Mass:            desc.Mass,
+			Time:            desc.Added.UnixMilliseconds(),
+			AncestorCount:   desc.AncestorCount,
+			AncestorFee:     desc.AncestorFee,
+			AncestorMass:    desc.AncestorMass,
+			DescendantCount: desc.DescendantCount,
+			DescendantFee:   desc.DescendantFee,
+			DescendantMass:  desc.DescendantMass,
+			Depends:         make([]string, 0),
 		}
 		for _, txIn := range tx.MsgTx().TxIn {
 			txID := &txIn.PreviousOutpoint.TxID
@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/rpc/model"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// handleInvalidateBlock implements the invalidateBlock command.
+func handleInvalidateBlock(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.InvalidateBlockCmd)
+
+	hash, err := daghash.NewHashFromStr(c.Hash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.Hash)
+	}
+	if !s.dag.IsInDAG(hash) {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+
+	err = s.dag.InvalidateBlock(hash)
+	if err != nil {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCMisc,
+			Message: err.Error(),
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kaspanet/kaspad/rpc/model"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// handleSetMockTime implements the setMockTime command.
+func handleSetMockTime(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.SetMockTimeCmd)
+
+	err := checkMockTimeAllowed(s, "setMockTime")
+	if err != nil {
+		return nil, err
+	}
+	if c.Timestamp < 0 {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCInvalidParameter,
+			Message: "The timestamp must not be negative",
+		}
+	}
+
+	// A timestamp of 0 makes the node go back to the local time.
+	var mockTime mstime.Time
+	if c.Timestamp != 0 {
+		mockTime = mstime.UnixMilliseconds(c.Timestamp)
+	}
+	s.dag.TimeSource().SetMockTime(mockTime)
+
+	return nil, processDueDelayedBlocks(s)
+}
+
+// handleAdvanceMockTime implements the advanceMockTime command.
+func handleAdvanceMockTime(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.AdvanceMockTimeCmd)
+
+	err := checkMockTimeAllowed(s, "advanceMockTime")
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.dag.TimeSource().AdvanceMockTime(time.Duration(c.Milliseconds) * time.Millisecond)
+	if err != nil {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCMisc,
+			Message: fmt.Sprintf("Could not advance the mock time: %s", err),
+		}
+	}
+
+	return nil, processDueDelayedBlocks(s)
+}
+
+// checkMockTimeAllowed returns an error if the mock time can't be set on the
+// network the node is running on.
+func checkMockTimeAllowed(s *Server, command string) error {
+	if !s.dag.Params.EnableMockTime {
+		return &model.RPCError{
+			Code:    model.ErrRPCMisc,
+			Message: fmt.Sprintf("%s is not supported on %s", command, s.dag.Params.Name),
+		}
+	}
+	return nil
+}
+
+// processDueDelayedBlocks processes the delayed blocks whose time arrived
+// after the mock time was changed.
+func processDueDelayedBlocks(s *Server) error {
+	err := s.dag.ProcessDelayedBlocks()
+	if err != nil {
+		return internalRPCError(err.Error(), "Could not process delayed blocks")
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/rpc/model"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// handlePrioritiseTransaction implements the prioritiseTransaction command.
+func handlePrioritiseTransaction(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.PrioritiseTransactionCmd)
+	txID, err := daghash.NewTxIDFromStr(c.TxID)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.TxID)
+	}
+
+	err = s.txMempool.PrioritiseTransaction(txID, c.FeeDelta)
+	if err != nil {
+		return nil, internalRPCError(err.Error(), "Could not prioritise transaction")
+	}
+	return nil, nil
+}
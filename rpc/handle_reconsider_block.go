@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"github.com/kaspanet/kaspad/rpc/model"
+	"github.com/kaspanet/kaspad/util/daghash"
+)
+
+// handleReconsiderBlock implements the reconsiderBlock command.
+func handleReconsiderBlock(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.ReconsiderBlockCmd)
+
+	hash, err := daghash.NewHashFromStr(c.Hash)
+	if err != nil {
+		return nil, rpcDecodeHexError(c.Hash)
+	}
+	if !s.dag.IsInDAG(hash) {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+
+	err = s.dag.ReconsiderBlock(hash)
+	if err != nil {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCMisc,
+			Message: err.Error(),
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/mempool"
+	"github.com/kaspanet/kaspad/rpc/model"
+	"github.com/kaspanet/kaspad/util"
+)
+
+// handleTestMempoolAccept implements the testMempoolAccept command.
+func handleTestMempoolAccept(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	c := cmd.(*model.TestMempoolAcceptCmd)
+	if len(c.RawTxs) == 0 {
+		return nil, &model.RPCError{
+			Code:    model.ErrRPCInvalidParameter,
+			Message: "At least one transaction is required",
+		}
+	}
+
+	txs := make([]*util.Tx, len(c.RawTxs))
+	for i, hexStr := range c.RawTxs {
+		serializedTx, err := hex.DecodeString(hexStr)
+		if err != nil {
+			return nil, rpcDecodeHexError(hexStr)
+		}
+		var msgTx domainmessage.MsgTx
+		err = msgTx.Deserialize(bytes.NewReader(serializedTx))
+		if err != nil {
+			return nil, &model.RPCError{
+				Code:    model.ErrRPCDeserialization,
+				Message: "TX decode failed: " + err.Error(),
+			}
+		}
+		txs[i] = util.NewTx(&msgTx)
+	}
+
+	acceptanceResults, err := s.txMempool.CheckAcceptance(txs)
+	if err != nil {
+		return nil, internalRPCError(err.Error(), "Could not check transaction acceptance")
+	}
+
+	results := make([]model.TestMempoolAcceptResult, len(acceptanceResults))
+	for i, acceptanceResult := range acceptanceResults {
+		result := model.TestMempoolAcceptResult{
+			TxID: acceptanceResult.Tx.ID().String(),
+		}
+		if acceptanceResult.Err != nil {
+			rejectCode, reason := mempool.ErrToRejectErr(acceptanceResult.Err)
+			result.RejectCode = rejectCode.String()
+			result.RejectReason = reason
+		} else {
+			result.Allowed = true
+			result.Fee = acceptanceResult.Fee
+			result.Mass = acceptanceResult.Mass
+		}
+		results[i] = result
+	}
+	return results, nil
+}
@@ -47,6 +47,34 @@ type TransactionInput struct {
 	Vout uint32 `json:"vout"`
 }
 
+// GenerateCmd defines the generate JSON-RPC command.
+type GenerateCmd struct {
+	NumBlocks uint32
+}
+
+// NewGenerateCmd returns a new instance which can be used to issue a
+// generate JSON-RPC command.
+func NewGenerateCmd(numBlocks uint32) *GenerateCmd {
+	return &GenerateCmd{
+		NumBlocks: numBlocks,
+	}
+}
+
+// GenerateToAddressCmd defines the generateToAddress JSON-RPC command.
+type GenerateToAddressCmd struct {
+	NumBlocks uint32
+	Address   string
+}
+
+// NewGenerateToAddressCmd returns a new instance which can be used to issue a
+// generateToAddress JSON-RPC command.
+func NewGenerateToAddressCmd(numBlocks uint32, address string) *GenerateToAddressCmd {
+	return &GenerateToAddressCmd{
+		NumBlocks: numBlocks,
+		Address:   address,
+	}
+}
+
 // GetSelectedTipHashCmd defines the getSelectedTipHash JSON-RPC command.
 type GetSelectedTipHashCmd struct{}
 
@@ -104,6 +132,30 @@ func NewGetBlockDAGInfoCmd() *GetBlockDAGInfoCmd {
 	return &GetBlockDAGInfoCmd{}
 }
 
+// GetDeploymentInfoCmd defines the getDeploymentInfo JSON-RPC command.
+type GetDeploymentInfoCmd struct{}
+
+// NewGetDeploymentInfoCmd returns a new instance which can be used to issue a
+// getDeploymentInfo JSON-RPC command.
+func NewGetDeploymentInfoCmd() *GetDeploymentInfoCmd {
+	return &GetDeploymentInfoCmd{}
+}
+
+// GetDAGSubgraphCmd defines the getDAGSubgraph JSON-RPC command.
+type GetDAGSubgraphCmd struct {
+	LowHash  string
+	HighHash string
+}
+
+// NewGetDAGSubgraphCmd returns a new instance which can be used to issue a
+// getDAGSubgraph JSON-RPC command.
+func NewGetDAGSubgraphCmd(lowHash string, highHash string) *GetDAGSubgraphCmd {
+	return &GetDAGSubgraphCmd{
+		LowHash:  lowHash,
+		HighHash: highHash,
+	}
+}
+
 // GetBlockCountCmd defines the getBlockCount JSON-RPC command.
 type GetBlockCountCmd struct{}
 
@@ -307,6 +359,15 @@ func NewGetNetTotalsCmd() *GetNetTotalsCmd {
 	return &GetNetTotalsCmd{}
 }
 
+// GetNetStatsCmd defines the getNetStats JSON-RPC command.
+type GetNetStatsCmd struct{}
+
+// NewGetNetStatsCmd returns a new instance which can be used to issue a
+// getNetStats JSON-RPC command.
+func NewGetNetStatsCmd() *GetNetStatsCmd {
+	return &GetNetStatsCmd{}
+}
+
 // GetConnectedPeerInfoCmd defines the getConnectedPeerInfo JSON-RPC command.
 type GetConnectedPeerInfoCmd struct{}
 
@@ -399,6 +460,21 @@ func NewPingCmd() *PingCmd {
 	return &PingCmd{}
 }
 
+// PrioritiseTransactionCmd defines the prioritiseTransaction JSON-RPC command.
+type PrioritiseTransactionCmd struct {
+	TxID     string
+	FeeDelta int64
+}
+
+// NewPrioritiseTransactionCmd returns a new instance which can be used to
+// issue a prioritiseTransaction JSON-RPC command.
+func NewPrioritiseTransactionCmd(txID string, feeDelta int64) *PrioritiseTransactionCmd {
+	return &PrioritiseTransactionCmd{
+		TxID:     txID,
+		FeeDelta: feeDelta,
+	}
+}
+
 // SendRawTransactionCmd defines the sendRawTransaction JSON-RPC command.
 type SendRawTransactionCmd struct {
 	HexTx         string
@@ -417,6 +493,19 @@ func NewSendRawTransactionCmd(hexTx string, allowHighFees *bool) *SendRawTransac
 	}
 }
 
+// TestMempoolAcceptCmd defines the testMempoolAccept JSON-RPC command.
+type TestMempoolAcceptCmd struct {
+	RawTxs []string
+}
+
+// NewTestMempoolAcceptCmd returns a new instance which can be used to issue a
+// testMempoolAccept JSON-RPC command.
+func NewTestMempoolAcceptCmd(rawTxs []string) *TestMempoolAcceptCmd {
+	return &TestMempoolAcceptCmd{
+		RawTxs: rawTxs,
+	}
+}
+
 // StopCmd defines the stop JSON-RPC command.
 type StopCmd struct{}
 
@@ -588,15 +677,86 @@ type GetPeerAddressesCmd struct {
 // getPeerAddresses command.
 func NewGetPeerAddressesCmd() *GetPeerAddressesCmd { return new(GetPeerAddressesCmd) }
 
+// InvalidateBlockCmd defines the invalidateBlock JSON-RPC command.
+type InvalidateBlockCmd struct {
+	Hash string
+}
+
+// NewInvalidateBlockCmd returns a new instance which can be used to issue a
+// invalidateBlock JSON-RPC command.
+func NewInvalidateBlockCmd(hash string) *InvalidateBlockCmd {
+	return &InvalidateBlockCmd{
+		Hash: hash,
+	}
+}
+
+// ReconsiderBlockCmd defines the reconsiderBlock JSON-RPC command.
+type ReconsiderBlockCmd struct {
+	Hash string
+}
+
+// NewReconsiderBlockCmd returns a new instance which can be used to issue a
+// reconsiderBlock JSON-RPC command.
+func NewReconsiderBlockCmd(hash string) *ReconsiderBlockCmd {
+	return &ReconsiderBlockCmd{
+		Hash: hash,
+	}
+}
+
+// BackupDatabaseCmd defines the backupDatabase JSON-RPC command.
+type BackupDatabaseCmd struct {
+	DestDir string
+}
+
+// NewBackupDatabaseCmd returns a new instance which can be used to issue a
+// backupDatabase JSON-RPC command.
+func NewBackupDatabaseCmd(destDir string) *BackupDatabaseCmd {
+	return &BackupDatabaseCmd{
+		DestDir: destDir,
+	}
+}
+
+// SetMockTimeCmd defines the setMockTime JSON-RPC command.
+type SetMockTimeCmd struct {
+	Timestamp int64
+}
+
+// NewSetMockTimeCmd returns a new instance which can be used to issue a
+// setMockTime JSON-RPC command.
+func NewSetMockTimeCmd(timestamp int64) *SetMockTimeCmd {
+	return &SetMockTimeCmd{
+		Timestamp: timestamp,
+	}
+}
+
+// AdvanceMockTimeCmd defines the advanceMockTime JSON-RPC command.
+type AdvanceMockTimeCmd struct {
+	Milliseconds int64
+}
+
+// NewAdvanceMockTimeCmd returns a new instance which can be used to issue an
+// advanceMockTime JSON-RPC command.
+func NewAdvanceMockTimeCmd(milliseconds int64) *AdvanceMockTimeCmd {
+	return &AdvanceMockTimeCmd{
+		Milliseconds: milliseconds,
+	}
+}
+
 func init() {
 	// No special flags for commands in this file.
 	flags := UsageFlag(0)
 
+	MustRegisterCommand("advanceMockTime", (*AdvanceMockTimeCmd)(nil), flags)
+	MustRegisterCommand("backupDatabase", (*BackupDatabaseCmd)(nil), flags)
 	MustRegisterCommand("connect", (*ConnectCmd)(nil), flags)
+	MustRegisterCommand("generate", (*GenerateCmd)(nil), flags)
+	MustRegisterCommand("generateToAddress", (*GenerateToAddressCmd)(nil), flags)
 	MustRegisterCommand("getSelectedTipHash", (*GetSelectedTipHashCmd)(nil), flags)
 	MustRegisterCommand("getBlock", (*GetBlockCmd)(nil), flags)
 	MustRegisterCommand("getBlocks", (*GetBlocksCmd)(nil), flags)
 	MustRegisterCommand("getBlockDagInfo", (*GetBlockDAGInfoCmd)(nil), flags)
+	MustRegisterCommand("getDeploymentInfo", (*GetDeploymentInfoCmd)(nil), flags)
+	MustRegisterCommand("getDAGSubgraph", (*GetDAGSubgraphCmd)(nil), flags)
 	MustRegisterCommand("getBlockCount", (*GetBlockCountCmd)(nil), flags)
 	MustRegisterCommand("getBlockHeader", (*GetBlockHeaderCmd)(nil), flags)
 	MustRegisterCommand("getBlockTemplate", (*GetBlockTemplateCmd)(nil), flags)
@@ -609,6 +769,7 @@ func init() {
 	MustRegisterCommand("getMempoolInfo", (*GetMempoolInfoCmd)(nil), flags)
 	MustRegisterCommand("getNetworkInfo", (*GetNetworkInfoCmd)(nil), flags)
 	MustRegisterCommand("getNetTotals", (*GetNetTotalsCmd)(nil), flags)
+	MustRegisterCommand("getNetStats", (*GetNetStatsCmd)(nil), flags)
 	MustRegisterCommand("getConnectedPeerInfo", (*GetConnectedPeerInfoCmd)(nil), flags)
 	MustRegisterCommand("getPeerAddresses", (*GetPeerAddressesCmd)(nil), flags)
 	MustRegisterCommand("getRawMempool", (*GetRawMempoolCmd)(nil), flags)
@@ -616,9 +777,14 @@ func init() {
 	MustRegisterCommand("getTxOut", (*GetTxOutCmd)(nil), flags)
 	MustRegisterCommand("getTxOutSetInfo", (*GetTxOutSetInfoCmd)(nil), flags)
 	MustRegisterCommand("help", (*HelpCmd)(nil), flags)
+	MustRegisterCommand("invalidateBlock", (*InvalidateBlockCmd)(nil), flags)
 	MustRegisterCommand("ping", (*PingCmd)(nil), flags)
 	MustRegisterCommand("disconnect", (*DisconnectCmd)(nil), flags)
+	MustRegisterCommand("reconsiderBlock", (*ReconsiderBlockCmd)(nil), flags)
+	MustRegisterCommand("prioritiseTransaction", (*PrioritiseTransactionCmd)(nil), flags)
 	MustRegisterCommand("sendRawTransaction", (*SendRawTransactionCmd)(nil), flags)
+	MustRegisterCommand("setMockTime", (*SetMockTimeCmd)(nil), flags)
+	MustRegisterCommand("testMempoolAccept", (*TestMempoolAcceptCmd)(nil), flags)
 	MustRegisterCommand("stop", (*StopCmd)(nil), flags)
 	MustRegisterCommand("submitBlock", (*SubmitBlockCmd)(nil), flags)
 	MustRegisterCommand("uptime", (*UptimeCmd)(nil), flags)
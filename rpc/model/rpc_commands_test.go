@@ -42,6 +42,31 @@ func TestRPCServerCommands(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"connect","params":["127.0.0.1"],"id":1}`,
 			unmarshalled: &model.ConnectCmd{Address: "127.0.0.1", IsPermanent: pointers.Bool(false)},
 		},
+		{
+			name: "generate",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("generate", 10)
+			},
+			staticCmd: func() interface{} {
+				return model.NewGenerateCmd(10)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"generate","params":[10],"id":1}`,
+			unmarshalled: &model.GenerateCmd{NumBlocks: 10},
+		},
+		{
+			name: "generateToAddress",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("generateToAddress", 10, "kaspasim:qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq6nh65a26")
+			},
+			staticCmd: func() interface{} {
+				return model.NewGenerateToAddressCmd(10, "kaspasim:qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq6nh65a26")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"generateToAddress","params":[10,"kaspasim:qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq6nh65a26"],"id":1}`,
+			unmarshalled: &model.GenerateToAddressCmd{
+				NumBlocks: 10,
+				Address:   "kaspasim:qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq6nh65a26",
+			},
+		},
 		{
 			name: "getSelectedTipHash",
 			newCmd: func() (interface{}, error) {
@@ -144,6 +169,31 @@ func TestRPCServerCommands(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getBlockDagInfo","params":[],"id":1}`,
 			unmarshalled: &model.GetBlockDAGInfoCmd{},
 		},
+		{
+			name: "getDeploymentInfo",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("getDeploymentInfo")
+			},
+			staticCmd: func() interface{} {
+				return model.NewGetDeploymentInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getDeploymentInfo","params":[],"id":1}`,
+			unmarshalled: &model.GetDeploymentInfoCmd{},
+		},
+		{
+			name: "getDAGSubgraph",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("getDAGSubgraph", "123", "456")
+			},
+			staticCmd: func() interface{} {
+				return model.NewGetDAGSubgraphCmd("123", "456")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getDAGSubgraph","params":["123","456"],"id":1}`,
+			unmarshalled: &model.GetDAGSubgraphCmd{
+				LowHash:  "123",
+				HighHash: "456",
+			},
+		},
 		{
 			name: "getBlockCount",
 			newCmd: func() (interface{}, error) {
@@ -356,6 +406,17 @@ func TestRPCServerCommands(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getNetTotals","params":[],"id":1}`,
 			unmarshalled: &model.GetNetTotalsCmd{},
 		},
+		{
+			name: "getNetStats",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("getNetStats")
+			},
+			staticCmd: func() interface{} {
+				return model.NewGetNetStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getNetStats","params":[],"id":1}`,
+			unmarshalled: &model.GetNetStatsCmd{},
+		},
 		{
 			name: "getConnectedPeerInfo",
 			newCmd: func() (interface{}, error) {
@@ -473,6 +534,61 @@ func TestRPCServerCommands(t *testing.T) {
 				Command: pointers.String("getBlock"),
 			},
 		},
+		{
+			name: "invalidateBlock",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("invalidateBlock", "123")
+			},
+			staticCmd: func() interface{} {
+				return model.NewInvalidateBlockCmd("123")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"invalidateBlock","params":["123"],"id":1}`,
+			unmarshalled: &model.InvalidateBlockCmd{Hash: "123"},
+		},
+		{
+			name: "backupDatabase",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("backupDatabase", "/tmp/backup")
+			},
+			staticCmd: func() interface{} {
+				return model.NewBackupDatabaseCmd("/tmp/backup")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"backupDatabase","params":["/tmp/backup"],"id":1}`,
+			unmarshalled: &model.BackupDatabaseCmd{DestDir: "/tmp/backup"},
+		},
+		{
+			name: "setMockTime",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("setMockTime", 1600000000000)
+			},
+			staticCmd: func() interface{} {
+				return model.NewSetMockTimeCmd(1600000000000)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"setMockTime","params":[1600000000000],"id":1}`,
+			unmarshalled: &model.SetMockTimeCmd{Timestamp: 1600000000000},
+		},
+		{
+			name: "advanceMockTime",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("advanceMockTime", 60000)
+			},
+			staticCmd: func() interface{} {
+				return model.NewAdvanceMockTimeCmd(60000)
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"advanceMockTime","params":[60000],"id":1}`,
+			unmarshalled: &model.AdvanceMockTimeCmd{Milliseconds: 60000},
+		},
+		{
+			name: "reconsiderBlock",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("reconsiderBlock", "123")
+			},
+			staticCmd: func() interface{} {
+				return model.NewReconsiderBlockCmd("123")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"reconsiderBlock","params":["123"],"id":1}`,
+			unmarshalled: &model.ReconsiderBlockCmd{Hash: "123"},
+		},
 		{
 			name: "ping",
 			newCmd: func() (interface{}, error) {
@@ -495,6 +611,20 @@ func TestRPCServerCommands(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"disconnect","params":["127.0.0.1"],"id":1}`,
 			unmarshalled: &model.DisconnectCmd{Address: "127.0.0.1"},
 		},
+		{
+			name: "prioritiseTransaction",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("prioritiseTransaction", "123", -1000)
+			},
+			staticCmd: func() interface{} {
+				return model.NewPrioritiseTransactionCmd("123", -1000)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"prioritiseTransaction","params":["123",-1000],"id":1}`,
+			unmarshalled: &model.PrioritiseTransactionCmd{
+				TxID:     "123",
+				FeeDelta: -1000,
+			},
+		},
 		{
 			name: "sendRawTransaction",
 			newCmd: func() (interface{}, error) {
@@ -523,6 +653,19 @@ func TestRPCServerCommands(t *testing.T) {
 				AllowHighFees: pointers.Bool(false),
 			},
 		},
+		{
+			name: "testMempoolAccept",
+			newCmd: func() (interface{}, error) {
+				return model.NewCommand("testMempoolAccept", []string{"1122", "3344"})
+			},
+			staticCmd: func() interface{} {
+				return model.NewTestMempoolAcceptCmd([]string{"1122", "3344"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"testMempoolAccept","params":[["1122","3344"]],"id":1}`,
+			unmarshalled: &model.TestMempoolAcceptCmd{
+				RawTxs: []string{"1122", "3344"},
+			},
+		},
 		{
 			name: "stop",
 			newCmd: func() (interface{}, error) {
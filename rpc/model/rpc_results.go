@@ -89,6 +89,48 @@ type Bip9SoftForkDescription struct {
 	Since     int32  `json:"since"`
 }
 
+// GetDeploymentInfoResult models the data returned from the
+// getDeploymentInfo command.
+type GetDeploymentInfoResult struct {
+	Deployments []*DeploymentInfoResult `json:"deployments"`
+}
+
+// DeploymentInfoResult describes the state of a rule change deployment,
+// and the signalling for it in the current confirmation window.
+type DeploymentInfoResult struct {
+	Name             string `json:"name"`
+	Bit              uint8  `json:"bit"`
+	StartTime        int64  `json:"startTime"`
+	ExpireTime       int64  `json:"expireTime"`
+	Status           string `json:"status"`
+	WindowSize       uint64 `json:"windowSize"`
+	Threshold        uint64 `json:"threshold"`
+	WindowBlocks     uint64 `json:"windowBlocks"`
+	SignallingBlocks uint64 `json:"signallingBlocks"`
+}
+
+// GetDAGSubgraphResult models the data returned from the getDAGSubgraph
+// command.
+type GetDAGSubgraphResult struct {
+	Nodes []*DAGSubgraphNodeResult `json:"nodes"`
+}
+
+// DAGSubgraphNodeResult describes a single block of a DAG subgraph, along
+// with its GHOSTDAG coloring and its position in the DAG.
+type DAGSubgraphNodeResult struct {
+	Hash                      string   `json:"hash"`
+	ParentHashes              []string `json:"parentHashes"`
+	SelectedParentHash        string   `json:"selectedParentHash,omitempty"`
+	BlueScore                 uint64   `json:"blueScore"`
+	IsBlue                    bool     `json:"isBlue"`
+	IsChainBlock              bool     `json:"isChainBlock"`
+	ReachabilityIntervalStart uint64   `json:"reachabilityIntervalStart"`
+	ReachabilityIntervalEnd   uint64   `json:"reachabilityIntervalEnd"`
+	IsFinalityPoint           bool     `json:"isFinalityPoint"`
+	IsTip                     bool     `json:"isTip"`
+	IsVirtualSelectedParent   bool     `json:"isVirtualSelectedParent"`
+}
+
 // GetBlockDAGInfoResult models the data returned from the getblockdaginfo
 // command.
 type GetBlockDAGInfoResult struct {
@@ -156,16 +198,40 @@ type GetBlockTemplateResult struct {
 // GetMempoolEntryResult models the data returned from the getMempoolEntry
 // command.
 type GetMempoolEntryResult struct {
-	Fee   uint64      `json:"fee"`
-	Time  int64       `json:"time"`
-	RawTx TxRawResult `json:"rawTx"`
+	Fee             uint64      `json:"fee"`
+	FeeDelta        int64       `json:"feeDelta"`
+	ModifiedFee     uint64      `json:"modifiedFee"`
+	Mass            uint64      `json:"mass"`
+	Time            int64       `json:"time"`
+	AncestorCount   uint64      `json:"ancestorCount"`
+	AncestorFee     uint64      `json:"ancestorFee"`
+	AncestorMass    uint64      `json:"ancestorMass"`
+	DescendantCount uint64      `json:"descendantCount"`
+	DescendantFee   uint64      `json:"descendantFee"`
+	DescendantMass  uint64      `json:"descendantMass"`
+	RawTx           TxRawResult `json:"rawTx"`
+}
+
+// TestMempoolAcceptResult models the data returned for each transaction
+// from the testMempoolAccept command.
+type TestMempoolAcceptResult struct {
+	TxID         string `json:"txId"`
+	Allowed      bool   `json:"allowed"`
+	RejectCode   string `json:"rejectCode,omitempty"`
+	RejectReason string `json:"rejectReason,omitempty"`
+	Fee          uint64 `json:"fee,omitempty"`
+	Mass         uint64 `json:"mass,omitempty"`
 }
 
 // GetMempoolInfoResult models the data returned from the getmempoolinfo
 // command.
 type GetMempoolInfoResult struct {
-	Size  int64 `json:"size"`
-	Bytes int64 `json:"bytes"`
+	Size               int64  `json:"size"`
+	Bytes              int64  `json:"bytes"`
+	MaxAncestorCount   uint64 `json:"maxAncestorCount"`
+	MaxAncestorMass    uint64 `json:"maxAncestorMass"`
+	MaxDescendantCount uint64 `json:"maxDescendantCount"`
+	MaxDescendantMass  uint64 `json:"maxDescendantMass"`
 }
 
 // NetworksResult models the networks data from the getnetworkinfo command.
@@ -205,16 +271,24 @@ type GetNetworkInfoResult struct {
 
 // GetConnectedPeerInfoResult models the data returned from the getConnectedPeerInfo command.
 type GetConnectedPeerInfoResult struct {
-	ID                        string `json:"id"`
-	Address                   string `json:"address"`
-	LastPingDuration          int64  `json:"lastPingDuration"`
-	SelectedTipHash           string `json:"selectedTipHash"`
-	IsSyncNode                bool   `json:"isSyncNode"`
-	IsOutbound                bool   `json:"isOutbound"`
-	TimeOffset                int64  `json:"timeOffset"`
-	UserAgent                 string `json:"userAgent"`
-	AdvertisedProtocolVersion uint32 `json:"advertisedProtocolVersion"`
-	TimeConnected             int64  `json:"timeConnected"`
+	ID                        string                `json:"id"`
+	Address                   string                `json:"address"`
+	LastPingDuration          int64                 `json:"lastPingDuration"`
+	SelectedTipHash           string                `json:"selectedTipHash"`
+	IsSyncNode                bool                  `json:"isSyncNode"`
+	IsOutbound                bool                  `json:"isOutbound"`
+	IsEncrypted               bool                  `json:"isEncrypted"`
+	TimeOffset                int64                 `json:"timeOffset"`
+	UserAgent                 string                `json:"userAgent"`
+	AdvertisedProtocolVersion uint32                `json:"advertisedProtocolVersion"`
+	TimeConnected             int64                 `json:"timeConnected"`
+	NetGroup                  string                `json:"netGroup"`
+	LastNovelBlockTime        int64                 `json:"lastNovelBlockTime"`
+	LastNovelTransactionTime  int64                 `json:"lastNovelTransactionTime"`
+	EvictionProtection        string                `json:"evictionProtection,omitempty"`
+	BytesSent                 uint64                `json:"bytesSent"`
+	BytesRecv                 uint64                `json:"bytesRecv"`
+	MessageStats              []*MessageStatsResult `json:"messageStats"`
 }
 
 // GetPeerAddressesResult models the data returned from the getPeerAddresses command.
@@ -251,10 +325,17 @@ type GetPeerAddressesTriedBucketResult [addressmanager.TriedBucketCount][]string
 // command when the verbose flag is set. When the verbose flag is not set,
 // getrawmempool returns an array of transaction hashes.
 type GetRawMempoolVerboseResult struct {
-	Size    int32    `json:"size"`
-	Fee     float64  `json:"fee"`
-	Time    int64    `json:"time"`
-	Depends []string `json:"depends"`
+	Size            int32    `json:"size"`
+	Fee             float64  `json:"fee"`
+	Mass            uint64   `json:"mass"`
+	Time            int64    `json:"time"`
+	AncestorCount   uint64   `json:"ancestorCount"`
+	AncestorFee     uint64   `json:"ancestorFee"`
+	AncestorMass    uint64   `json:"ancestorMass"`
+	DescendantCount uint64   `json:"descendantCount"`
+	DescendantFee   uint64   `json:"descendantFee"`
+	DescendantMass  uint64   `json:"descendantMass"`
+	Depends         []string `json:"depends"`
 }
 
 // ScriptPubKeyResult models the scriptPubKey data of a tx script. It is
@@ -288,6 +369,32 @@ type GetNetTotalsResult struct {
 	TimeMillis     int64  `json:"timeMillis"`
 }
 
+// GetNetStatsResult models the data returned from the getNetStats command.
+type GetNetStatsResult struct {
+	TotalBytesSent uint64                `json:"totalBytesSent"`
+	TotalBytesRecv uint64                `json:"totalBytesRecv"`
+	MessageStats   []*MessageStatsResult `json:"messageStats"`
+	IBDUpload      *IBDUploadResult      `json:"ibdUpload"`
+}
+
+// MessageStatsResult models the traffic of a single message command.
+type MessageStatsResult struct {
+	Command      string `json:"command"`
+	MessagesSent uint64 `json:"messagesSent"`
+	BytesSent    uint64 `json:"bytesSent"`
+	MessagesRecv uint64 `json:"messagesRecv"`
+	BytesRecv    uint64 `json:"bytesRecv"`
+}
+
+// IBDUploadResult models the state of the cap on the blocks
+// served to syncing peers.
+type IBDUploadResult struct {
+	MaxBytes       uint64 `json:"maxBytes"`
+	BytesUsed      uint64 `json:"bytesUsed"`
+	TimeFrameStart int64  `json:"timeFrameStart"`
+	TimeFrameEnd   int64  `json:"timeFrameEnd"`
+}
+
 // ScriptSig models a signature script. It is defined separately since it only
 // applies to non-coinbase. Therefore the field in the Vin structure needs
 // to be a pointer.
@@ -387,6 +494,7 @@ type GetWorkResult struct {
 
 // InfoDAGResult models the data returned by the kaspa rpc server getinfo command.
 type InfoDAGResult struct {
+	ID              string  `json:"id"`
 	Version         string  `json:"version"`
 	ProtocolVersion int32   `json:"protocolVersion"`
 	Blocks          uint64  `json:"blocks"`
@@ -33,6 +33,7 @@ import (
 	"github.com/kaspanet/kaspad/blockdag"
 	"github.com/kaspanet/kaspad/blockdag/indexers"
 	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/dbaccess"
 	"github.com/kaspanet/kaspad/mempool"
 	"github.com/kaspanet/kaspad/mining"
 	"github.com/kaspanet/kaspad/rpc/model"
@@ -57,38 +58,50 @@ type commandHandler func(*Server, interface{}, <-chan struct{}) (interface{}, er
 // a dependency loop.
 var rpcHandlers map[string]commandHandler
 var rpcHandlersBeforeInit = map[string]commandHandler{
-	"connect":              handleConnect,
-	"debugLevel":           handleDebugLevel,
-	"getSelectedTip":       handleGetSelectedTip,
-	"getSelectedTipHash":   handleGetSelectedTipHash,
-	"getBlock":             handleGetBlock,
-	"getBlocks":            handleGetBlocks,
-	"getBlockDagInfo":      handleGetBlockDAGInfo,
-	"getBlockCount":        handleGetBlockCount,
-	"getBlockHeader":       handleGetBlockHeader,
-	"getBlockTemplate":     handleGetBlockTemplate,
-	"getChainFromBlock":    handleGetChainFromBlock,
-	"getConnectionCount":   handleGetConnectionCount,
-	"getCurrentNet":        handleGetCurrentNet,
-	"getDifficulty":        handleGetDifficulty,
-	"getHeaders":           handleGetHeaders,
-	"getTopHeaders":        handleGetTopHeaders,
-	"getInfo":              handleGetInfo,
-	"getMempoolInfo":       handleGetMempoolInfo,
-	"getMempoolEntry":      handleGetMempoolEntry,
-	"getNetTotals":         handleGetNetTotals,
-	"getConnectedPeerInfo": handleGetConnectedPeerInfo,
-	"getPeerAddresses":     handleGetPeerAddresses,
-	"getRawMempool":        handleGetRawMempool,
-	"getSubnetwork":        handleGetSubnetwork,
-	"getTxOut":             handleGetTxOut,
-	"help":                 handleHelp,
-	"disconnect":           handleDisconnect,
-	"sendRawTransaction":   handleSendRawTransaction,
-	"stop":                 handleStop,
-	"submitBlock":          handleSubmitBlock,
-	"uptime":               handleUptime,
-	"version":              handleVersion,
+	"advanceMockTime":       handleAdvanceMockTime,
+	"connect":               handleConnect,
+	"debugLevel":            handleDebugLevel,
+	"generate":              handleGenerate,
+	"generateToAddress":     handleGenerateToAddress,
+	"getSelectedTip":        handleGetSelectedTip,
+	"getSelectedTipHash":    handleGetSelectedTipHash,
+	"getBlock":              handleGetBlock,
+	"getBlocks":             handleGetBlocks,
+	"getBlockDagInfo":       handleGetBlockDAGInfo,
+	"getDeploymentInfo":     handleGetDeploymentInfo,
+	"getDAGSubgraph":        handleGetDAGSubgraph,
+	"getBlockCount":         handleGetBlockCount,
+	"getBlockHeader":        handleGetBlockHeader,
+	"getBlockTemplate":      handleGetBlockTemplate,
+	"getChainFromBlock":     handleGetChainFromBlock,
+	"getConnectionCount":    handleGetConnectionCount,
+	"getCurrentNet":         handleGetCurrentNet,
+	"getDifficulty":         handleGetDifficulty,
+	"getHeaders":            handleGetHeaders,
+	"getTopHeaders":         handleGetTopHeaders,
+	"getInfo":               handleGetInfo,
+	"getMempoolInfo":        handleGetMempoolInfo,
+	"getMempoolEntry":       handleGetMempoolEntry,
+	"getNetTotals":          handleGetNetTotals,
+	"getNetStats":           handleGetNetStats,
+	"getConnectedPeerInfo":  handleGetConnectedPeerInfo,
+	"getPeerAddresses":      handleGetPeerAddresses,
+	"getRawMempool":         handleGetRawMempool,
+	"getSubnetwork":         handleGetSubnetwork,
+	"getTxOut":              handleGetTxOut,
+	"help":                  handleHelp,
+	"invalidateBlock":       handleInvalidateBlock,
+	"disconnect":            handleDisconnect,
+	"reconsiderBlock":       handleReconsiderBlock,
+	"backupDatabase":        handleBackupDatabase,
+	"prioritiseTransaction": handlePrioritiseTransaction,
+	"sendRawTransaction":    handleSendRawTransaction,
+	"setMockTime":           handleSetMockTime,
+	"stop":                  handleStop,
+	"testMempoolAccept":     handleTestMempoolAccept,
+	"submitBlock":           handleSubmitBlock,
+	"uptime":                handleUptime,
+	"version":               handleVersion,
 }
 
 // Commands that are currently unimplemented, but should ultimately be.
@@ -126,14 +139,18 @@ var rpcLimited = map[string]struct{}{
 	"getBlockHeader":       {},
 	"getChainFromBlock":    {},
 	"getCurrentNet":        {},
+	"getDeploymentInfo":    {},
+	"getDAGSubgraph":       {},
 	"getDifficulty":        {},
 	"getHeaders":           {},
 	"getInfo":              {},
 	"getNetTotals":         {},
+	"getNetStats":          {},
 	"getRawMempool":        {},
 	"getTxOut":             {},
 	"sendRawTransaction":   {},
 	"submitBlock":          {},
+	"testMempoolAccept":    {},
 	"uptime":               {},
 	"validateAddress":      {},
 	"version":              {},
@@ -164,6 +181,7 @@ type Server struct {
 	requestProcessShutdown chan struct{}
 	quit                   chan int
 
+	databaseContext        *dbaccess.DatabaseContext
 	dag                    *blockdag.BlockDAG
 	txMempool              *mempool.TxPool
 	acceptanceIndex        *indexers.AcceptanceIndex
@@ -690,6 +708,7 @@ func setupRPCListeners(appCfg *config.Config) ([]net.Listener, error) {
 // NewRPCServer returns a new instance of the rpcServer struct.
 func NewRPCServer(
 	cfg *config.Config,
+	databaseContext *dbaccess.DatabaseContext,
 	dag *blockdag.BlockDAG,
 	txMempool *mempool.TxPool,
 	acceptanceIndex *indexers.AcceptanceIndex,
@@ -718,6 +737,7 @@ func NewRPCServer(
 		requestProcessShutdown: make(chan struct{}),
 		quit:                   make(chan int),
 
+		databaseContext:        databaseContext,
 		dag:                    dag,
 		txMempool:              txMempool,
 		acceptanceIndex:        acceptanceIndex,
@@ -95,6 +95,19 @@ var helpDescsEnUS = map[string]string{
 	"getSelectedTipResult-hash":   "Hex-encoded bytes of the best block hash",
 	"getSelectedTipResult-height": "Height of the best block",
 
+	// GenerateCmd help.
+	"generate--synopsis": "Mines the given number of blocks in-process, one after the other, and submits them to the DAG. " +
+		"The blocks pay to an anyone-can-spend address. Only available on networks with a low difficulty, such as regtest, simnet and devnet.",
+	"generate-numBlocks": "The number of blocks to generate",
+	"generate--result0":  "The hashes of the generated blocks",
+
+	// GenerateToAddressCmd help.
+	"generateToAddress--synopsis": "Mines the given number of blocks that pay to the given address in-process, one after the other, and submits them to the DAG. " +
+		"Only available on networks with a low difficulty, such as regtest, simnet and devnet.",
+	"generateToAddress-numBlocks": "The number of blocks to generate",
+	"generateToAddress-address":   "The address the generated blocks pay to",
+	"generateToAddress--result0":  "The hashes of the generated blocks",
+
 	// GetSelectedTipCmd help.
 	"getSelectedTip--synopsis":   "Returns information about the selected tip of the blockDAG.",
 	"getSelectedTip-verbose":     "Specifies the block is returned as a JSON object instead of hex-encoded string",
@@ -152,6 +165,44 @@ var helpDescsEnUS = map[string]string{
 	"getBlockDagInfoResult-bip9SoftForks--value": "An object describing a particular BIP009 deployment",
 	"getBlockDagInfoResult-bip9SoftForks--desc":  "The status of any defined BIP0009 soft-fork deployments",
 
+	// GetDeploymentInfoCmd help.
+	"getDeploymentInfo--synopsis": "Returns the state of every rule change deployment of the network, and the signalling for it in the current confirmation window.",
+
+	// GetDeploymentInfoResult help.
+	"getDeploymentInfoResult-deployments": "The rule change deployments, ordered by deployment ID",
+
+	// GetDAGSubgraphCmd help.
+	"getDAGSubgraph--synopsis": "Returns the blocks in the past of highHash that aren't in the past of lowHash, annotated with their GHOSTDAG coloring and position in the DAG. The result may contain up to 2000 blocks.",
+	"getDAGSubgraph-lowHash":   "The hash of the lowest block of the subgraph",
+	"getDAGSubgraph-highHash":  "The hash of the highest block of the subgraph",
+
+	// GetDAGSubgraphResult help.
+	"getDagSubgraphResult-nodes": "The blocks of the subgraph, ordered by blue score",
+
+	// DAGSubgraphNodeResult help.
+	"dagSubgraphNodeResult-hash":                      "The hash of the block",
+	"dagSubgraphNodeResult-parentHashes":              "The hashes of the parents of the block",
+	"dagSubgraphNodeResult-selectedParentHash":        "The hash of the selected parent of the block",
+	"dagSubgraphNodeResult-blueScore":                 "The blue score of the block",
+	"dagSubgraphNodeResult-isBlue":                    "Whether the block is blue in the view of the selected parent chain",
+	"dagSubgraphNodeResult-isChainBlock":              "Whether the block is in the selected parent chain",
+	"dagSubgraphNodeResult-reachabilityIntervalStart": "The start of the block's interval in the reachability tree",
+	"dagSubgraphNodeResult-reachabilityIntervalEnd":   "The end of the block's interval in the reachability tree",
+	"dagSubgraphNodeResult-isFinalityPoint":           "Whether the block is the last finality point",
+	"dagSubgraphNodeResult-isTip":                     "Whether the block is a tip of the DAG",
+	"dagSubgraphNodeResult-isVirtualSelectedParent":   "Whether the block is the selected parent of the virtual block",
+
+	// DeploymentInfoResult help.
+	"deploymentInfoResult-name":             "The name of the deployment",
+	"deploymentInfoResult-bit":              "The bit of the block version that signals for the deployment",
+	"deploymentInfoResult-startTime":        "The median block time, in milliseconds since the epoch, after which voting on the deployment starts",
+	"deploymentInfoResult-expireTime":       "The median block time, in milliseconds since the epoch, after which the deployment fails if it isn't locked in",
+	"deploymentInfoResult-status":           "The status of the deployment for the next block (defined, started, lockedin, active or failed)",
+	"deploymentInfoResult-windowSize":       "The number of blocks in each confirmation window",
+	"deploymentInfoResult-threshold":        "The number of blocks in a confirmation window that must signal for the deployment in order to lock it in",
+	"deploymentInfoResult-windowBlocks":     "The number of blocks in the current confirmation window so far",
+	"deploymentInfoResult-signallingBlocks": "The number of blocks in the current confirmation window that signal for the deployment",
+
 	// SoftForkDescription help.
 	"softForkDescription-reject":  "The current activation status of the softfork",
 	"softForkDescription-version": "The block version that signals enforcement of this softfork",
@@ -315,6 +366,7 @@ var helpDescsEnUS = map[string]string{
 	"getDifficulty--result0":  "The difficulty",
 
 	// InfoDAGResult help.
+	"infoDagResult-id":              "The ID of the node in the P2P network",
 	"infoDagResult-version":         "The version of the server",
 	"infoDagResult-protocolVersion": "The latest supported protocol version",
 	"infoDagResult-blocks":          "The number of blocks processed",
@@ -346,16 +398,29 @@ var helpDescsEnUS = map[string]string{
 	"getMempoolEntry-txId":      "The transaction ID",
 
 	// getMempoolEntryResult help.
-	"getMempoolEntryResult-fee":   "Transaction fee in sompis",
-	"getMempoolEntryResult-time":  "Local time transaction entered pool in seconds since 1 Jan 1970 GMT",
-	"getMempoolEntryResult-rawTx": "The transaction as a JSON object",
+	"getMempoolEntryResult-fee":             "Transaction fee in sompis",
+	"getMempoolEntryResult-feeDelta":        "The fee delta in sompis the transaction was prioritised by",
+	"getMempoolEntryResult-modifiedFee":     "Transaction fee in sompis with the fee delta applied",
+	"getMempoolEntryResult-mass":            "Transaction mass",
+	"getMempoolEntryResult-time":            "Local time transaction entered pool in seconds since 1 Jan 1970 GMT",
+	"getMempoolEntryResult-ancestorCount":   "The number of in-mempool ancestor transactions, including this one",
+	"getMempoolEntryResult-ancestorFee":     "The total fee in sompis of in-mempool ancestors, including this one",
+	"getMempoolEntryResult-ancestorMass":    "The total mass of in-mempool ancestors, including this one",
+	"getMempoolEntryResult-descendantCount": "The number of in-mempool descendant transactions, including this one",
+	"getMempoolEntryResult-descendantFee":   "The total fee in sompis of in-mempool descendants, including this one",
+	"getMempoolEntryResult-descendantMass":  "The total mass of in-mempool descendants, including this one",
+	"getMempoolEntryResult-rawTx":           "The transaction as a JSON object",
 
 	// GetMempoolInfoCmd help.
 	"getMempoolInfo--synopsis": "Returns memory pool information",
 
 	// GetMempoolInfoResult help.
-	"getMempoolInfoResult-bytes": "Size in bytes of the mempool",
-	"getMempoolInfoResult-size":  "Number of transactions in the mempool",
+	"getMempoolInfoResult-bytes":              "Size in bytes of the mempool",
+	"getMempoolInfoResult-size":               "Number of transactions in the mempool",
+	"getMempoolInfoResult-maxAncestorCount":   "Max number of unconfirmed ancestors of a transaction, including itself (0 for no limit)",
+	"getMempoolInfoResult-maxAncestorMass":    "Max total mass of the unconfirmed ancestors of a transaction, including itself (0 for no limit)",
+	"getMempoolInfoResult-maxDescendantCount": "Max number of unconfirmed descendants of a transaction, including itself (0 for no limit)",
+	"getMempoolInfoResult-maxDescendantMass":  "Max total mass of the unconfirmed descendants of a transaction, including itself (0 for no limit)",
 
 	// GetNetTotalsCmd help.
 	"getNetTotals--synopsis": "Returns a JSON object containing network traffic statistics.",
@@ -365,6 +430,28 @@ var helpDescsEnUS = map[string]string{
 	"getNetTotalsResult-totalBytesSent": "Total bytes sent",
 	"getNetTotalsResult-timeMillis":     "Number of milliseconds since 1 Jan 1970 GMT",
 
+	// GetNetStatsCmd help.
+	"getNetStats--synopsis": "Returns network traffic statistics broken down by message type, and the state of the cap on the blocks served to syncing peers.",
+
+	// GetNetStatsResult help.
+	"getNetStatsResult-totalBytesSent": "Total bytes sent",
+	"getNetStatsResult-totalBytesRecv": "Total bytes received",
+	"getNetStatsResult-messageStats":   "The traffic of every message type",
+	"getNetStatsResult-ibdUpload":      "The state of the cap on the blocks served to syncing peers (see --maxibdupload)",
+
+	// MessageStatsResult help.
+	"messageStatsResult-command":      "The message type",
+	"messageStatsResult-messagesSent": "Number of messages sent",
+	"messageStatsResult-bytesSent":    "Number of bytes sent",
+	"messageStatsResult-messagesRecv": "Number of messages received",
+	"messageStatsResult-bytesRecv":    "Number of bytes received",
+
+	// IBDUploadResult help.
+	"ibdUploadResult-maxBytes":       "The maximum number of bytes of blocks served to syncing peers in a time frame, or 0 if unlimited",
+	"ibdUploadResult-bytesUsed":      "The number of bytes of blocks served to syncing peers in the current time frame",
+	"ibdUploadResult-timeFrameStart": "The start of the current time frame in milliseconds since the epoch",
+	"ibdUploadResult-timeFrameEnd":   "The end of the current time frame in milliseconds since the epoch",
+
 	// GetConnectedPeerInfoResult help.
 	"getConnectedPeerInfoResult-id":                        "A unique node ID",
 	"getConnectedPeerInfoResult-address":                   "The ip address and port of the peer",
@@ -372,10 +459,18 @@ var helpDescsEnUS = map[string]string{
 	"getConnectedPeerInfoResult-lastPingDuration":          "The duration of the last ping to the peer in milliseconds",
 	"getConnectedPeerInfoResult-isSyncNode":                "Whether or not the peer is the sync peer",
 	"getConnectedPeerInfoResult-isOutbound":                "Whether the peer is inbound or outbound",
+	"getConnectedPeerInfoResult-isEncrypted":               "Whether the connection to the peer is encrypted",
 	"getConnectedPeerInfoResult-timeOffset":                "The time difference between this node and the peer",
 	"getConnectedPeerInfoResult-userAgent":                 "The user agent of the peer",
 	"getConnectedPeerInfoResult-advertisedProtocolVersion": "The advertised p2p protocol version of the peer",
 	"getConnectedPeerInfoResult-timeConnected":             "The timestamp of when the peer connected to this node",
+	"getConnectedPeerInfoResult-netGroup":                  "The network group of the peer",
+	"getConnectedPeerInfoResult-lastNovelBlockTime":        "The time the peer last relayed a new valid block to this node in milliseconds since the epoch, or 0 if it never has",
+	"getConnectedPeerInfoResult-lastNovelTransactionTime":  "The time the peer last relayed a new transaction that was accepted to the mempool in milliseconds since the epoch, or 0 if it never has",
+	"getConnectedPeerInfoResult-evictionProtection":        "Inbound peers only: the reason the peer is protected from eviction (netgroup, ping, transactions, blocks, uptime), or none if it may be evicted",
+	"getConnectedPeerInfoResult-bytesSent":                 "Number of bytes sent to the peer",
+	"getConnectedPeerInfoResult-bytesRecv":                 "Number of bytes received from the peer",
+	"getConnectedPeerInfoResult-messageStats":              "The traffic with the peer of every message type",
 
 	// GetConnectedPeerInfoCmd help.
 	"getConnectedPeerInfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
@@ -411,7 +506,14 @@ var helpDescsEnUS = map[string]string{
 	// GetRawMempoolVerboseResult help.
 	"getRawMempoolVerboseResult-size":             "Transaction size in bytes",
 	"getRawMempoolVerboseResult-fee":              "Transaction fee in kaspa",
+	"getRawMempoolVerboseResult-mass":             "Transaction mass",
 	"getRawMempoolVerboseResult-time":             "Local time transaction entered pool in seconds since 1 Jan 1970 GMT",
+	"getRawMempoolVerboseResult-ancestorCount":    "The number of in-mempool ancestor transactions, including this one",
+	"getRawMempoolVerboseResult-ancestorFee":      "The total fee in sompis of in-mempool ancestors, including this one",
+	"getRawMempoolVerboseResult-ancestorMass":     "The total mass of in-mempool ancestors, including this one",
+	"getRawMempoolVerboseResult-descendantCount":  "The number of in-mempool descendant transactions, including this one",
+	"getRawMempoolVerboseResult-descendantFee":    "The total fee in sompis of in-mempool descendants, including this one",
+	"getRawMempoolVerboseResult-descendantMass":   "The total mass of in-mempool descendants, including this one",
 	"getRawMempoolVerboseResult-height":           "Block height when transaction entered the pool",
 	"getRawMempoolVerboseResult-startingPriority": "Priority when transaction entered the pool",
 	"getRawMempoolVerboseResult-currentPriority":  "Current priority",
@@ -454,6 +556,38 @@ var helpDescsEnUS = map[string]string{
 	"help--result0":    "List of commands",
 	"help--result1":    "Help for specified command",
 
+	// InvalidateBlockCmd help.
+	"invalidateBlock--synopsis": "Permanently marks a block as invalid, as if it violated a consensus rule. " +
+		"All the blocks in its future are marked as having an invalid ancestor, and are removed from the DAG's tips and virtual UTXO set. " +
+		"Transactions of the invalidated blocks are not returned to the mempool.",
+	"invalidateBlock-hash": "The hash of the block to invalidate",
+
+	// ReconsiderBlockCmd help.
+	"reconsiderBlock--synopsis": "Removes the invalidity status of a block, its ancestors and the blocks in its future, and validates them again. " +
+		"This reverses the effects of invalidateBlock.",
+	"reconsiderBlock-hash": "The hash of the block to reconsider",
+
+	// BackupDatabaseCmd help.
+	"backupDatabase--synopsis": "Writes a consistent copy of the database into the given directory on the node's host while the node keeps running. " +
+		"The directory must either not exist or be empty. The copy can be restored by starting kaspad with --restore-db.",
+	"backupDatabase-destDir": "The directory to write the copy of the database into",
+
+	// PrioritiseTransactionCmd help.
+	"prioritiseTransaction--synopsis": "Sets a virtual fee delta that is added to the fee of a transaction when it's selected for mining and when its ancestor and descendant fees are calculated. " +
+		"The transaction doesn't have to be in the memory pool. The fee delta is kept until the transaction is mined, including across restarts, and a fee delta of 0 removes it.",
+	"prioritiseTransaction-txId":     "The ID of the transaction",
+	"prioritiseTransaction-feeDelta": "The fee delta in sompis, which may be negative",
+
+	// SetMockTimeCmd help.
+	"setMockTime--synopsis": "Makes the node use the given time instead of the local time for the DAG, the memory pool, mining and pinging peers. " +
+		"Delayed blocks whose time arrived are processed. Only available on networks that enable mock time, such as regtest and simnet.",
+	"setMockTime-timestamp": "The mock time as a Unix timestamp in milliseconds, or 0 to go back to the local time",
+
+	// AdvanceMockTimeCmd help.
+	"advanceMockTime--synopsis": "Moves the mock time that was set with setMockTime forward. " +
+		"Delayed blocks whose time arrived are processed. Only available on networks that enable mock time, such as regtest and simnet.",
+	"advanceMockTime-milliseconds": "The number of milliseconds to move the mock time forward by",
+
 	// PingCmd help.
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getConnectedPeerInfo via the pingtime and pingwait fields.",
@@ -468,6 +602,19 @@ var helpDescsEnUS = map[string]string{
 	"sendRawTransaction-allowHighFees": "Whether or not to allow insanely high fees (kaspad does not yet implement this parameter, so it has no effect)",
 	"sendRawTransaction--result0":      "The hash of the transaction",
 
+	// TestMempoolAcceptCmd help.
+	"testMempoolAccept--synopsis": "Checks whether the memory pool would accept the serialized, hex-encoded transactions, without adding them to it or relaying them. " +
+		"The transactions are checked in order as a package, so a transaction may spend the outputs of the transactions before it.",
+	"testMempoolAccept-rawTxs": "Serialized, hex-encoded signed transactions",
+
+	// TestMempoolAcceptResult help.
+	"testMempoolAcceptResult-txId":         "The ID of the transaction",
+	"testMempoolAcceptResult-allowed":      "Whether the memory pool would accept the transaction",
+	"testMempoolAcceptResult-rejectCode":   "The reject code, if the transaction would be rejected",
+	"testMempoolAcceptResult-rejectReason": "The reason the transaction would be rejected",
+	"testMempoolAcceptResult-fee":          "The fee of the transaction in sompis, if it would be accepted",
+	"testMempoolAcceptResult-mass":         "The mass of the transaction, if it would be accepted",
+
 	// StopCmd help.
 	"stop--synopsis": "Shutdown kaspad.",
 	"stop--result0":  "The string 'kaspad stopping.'",
@@ -559,41 +706,53 @@ var helpDescsEnUS = map[string]string{
 // This information is used to generate the help. Each result type must be a
 // pointer to the type (or nil to indicate no return value).
 var rpcResultTypes = map[string][]interface{}{
-	"connect":              nil,
-	"debugLevel":           {(*string)(nil), (*string)(nil)},
-	"getSelectedTip":       {(*model.GetBlockVerboseResult)(nil)},
-	"getSelectedTipHash":   {(*string)(nil)},
-	"getBlock":             {(*string)(nil), (*model.GetBlockVerboseResult)(nil)},
-	"getBlocks":            {(*model.GetBlocksResult)(nil)},
-	"getBlockCount":        {(*int64)(nil)},
-	"getBlockHeader":       {(*string)(nil), (*model.GetBlockHeaderVerboseResult)(nil)},
-	"getBlockTemplate":     {(*model.GetBlockTemplateResult)(nil), (*string)(nil), nil},
-	"getBlockDagInfo":      {(*model.GetBlockDAGInfoResult)(nil)},
-	"getChainFromBlock":    {(*model.GetChainFromBlockResult)(nil)},
-	"getConnectionCount":   {(*int32)(nil)},
-	"getCurrentNet":        {(*uint32)(nil)},
-	"getDifficulty":        {(*float64)(nil)},
-	"getTopHeaders":        {(*[]string)(nil)},
-	"getHeaders":           {(*[]string)(nil)},
-	"getInfo":              {(*model.InfoDAGResult)(nil)},
-	"getMempoolInfo":       {(*model.GetMempoolInfoResult)(nil)},
-	"getMempoolEntry":      {(*model.GetMempoolEntryResult)(nil)},
-	"getNetTotals":         {(*model.GetNetTotalsResult)(nil)},
-	"getConnectedPeerInfo": {(*[]model.GetConnectedPeerInfoResult)(nil)},
-	"getPeerAddresses":     {(*[]model.GetPeerAddressesResult)(nil)},
-	"getRawMempool":        {(*[]string)(nil), (*model.GetRawMempoolVerboseResult)(nil)},
-	"getSubnetwork":        {(*model.GetSubnetworkResult)(nil)},
-	"getTxOut":             {(*model.GetTxOutResult)(nil)},
-	"node":                 nil,
-	"help":                 {(*string)(nil), (*string)(nil)},
-	"ping":                 nil,
-	"disconnect":           nil,
-	"sendRawTransaction":   {(*string)(nil)},
-	"stop":                 {(*string)(nil)},
-	"submitBlock":          {nil, (*string)(nil)},
-	"uptime":               {(*int64)(nil)},
-	"validateAddress":      {(*model.ValidateAddressResult)(nil)},
-	"version":              {(*map[string]model.VersionResult)(nil)},
+	"advanceMockTime":       nil,
+	"connect":               nil,
+	"debugLevel":            {(*string)(nil), (*string)(nil)},
+	"generate":              {(*[]string)(nil)},
+	"generateToAddress":     {(*[]string)(nil)},
+	"getSelectedTip":        {(*model.GetBlockVerboseResult)(nil)},
+	"getSelectedTipHash":    {(*string)(nil)},
+	"getBlock":              {(*string)(nil), (*model.GetBlockVerboseResult)(nil)},
+	"getBlocks":             {(*model.GetBlocksResult)(nil)},
+	"getBlockCount":         {(*int64)(nil)},
+	"getBlockHeader":        {(*string)(nil), (*model.GetBlockHeaderVerboseResult)(nil)},
+	"getBlockTemplate":      {(*model.GetBlockTemplateResult)(nil), (*string)(nil), nil},
+	"getBlockDagInfo":       {(*model.GetBlockDAGInfoResult)(nil)},
+	"getDeploymentInfo":     {(*model.GetDeploymentInfoResult)(nil)},
+	"getDAGSubgraph":        {(*model.GetDAGSubgraphResult)(nil)},
+	"getChainFromBlock":     {(*model.GetChainFromBlockResult)(nil)},
+	"getConnectionCount":    {(*int32)(nil)},
+	"getCurrentNet":         {(*uint32)(nil)},
+	"getDifficulty":         {(*float64)(nil)},
+	"getTopHeaders":         {(*[]string)(nil)},
+	"getHeaders":            {(*[]string)(nil)},
+	"getInfo":               {(*model.InfoDAGResult)(nil)},
+	"getMempoolInfo":        {(*model.GetMempoolInfoResult)(nil)},
+	"getMempoolEntry":       {(*model.GetMempoolEntryResult)(nil)},
+	"getNetTotals":          {(*model.GetNetTotalsResult)(nil)},
+	"getNetStats":           {(*model.GetNetStatsResult)(nil)},
+	"getConnectedPeerInfo":  {(*[]model.GetConnectedPeerInfoResult)(nil)},
+	"getPeerAddresses":      {(*[]model.GetPeerAddressesResult)(nil)},
+	"getRawMempool":         {(*[]string)(nil), (*model.GetRawMempoolVerboseResult)(nil)},
+	"getSubnetwork":         {(*model.GetSubnetworkResult)(nil)},
+	"getTxOut":              {(*model.GetTxOutResult)(nil)},
+	"node":                  nil,
+	"help":                  {(*string)(nil), (*string)(nil)},
+	"invalidateBlock":       nil,
+	"reconsiderBlock":       nil,
+	"backupDatabase":        nil,
+	"prioritiseTransaction": nil,
+	"ping":                  nil,
+	"disconnect":            nil,
+	"sendRawTransaction":    {(*string)(nil)},
+	"setMockTime":           nil,
+	"stop":                  {(*string)(nil)},
+	"testMempoolAccept":     {(*[]model.TestMempoolAcceptResult)(nil)},
+	"submitBlock":           {nil, (*string)(nil)},
+	"uptime":                {(*int64)(nil)},
+	"validateAddress":       {(*model.ValidateAddressResult)(nil)},
+	"version":               {(*map[string]model.VersionResult)(nil)},
 
 	// Websocket commands.
 	"loadTxFilter":              nil,
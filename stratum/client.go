@@ -0,0 +1,276 @@
+package stratum
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domainmessage"
+)
+
+const (
+	// maxJobsPerClient is the number of the latest jobs of a connection
+	// that shares are accepted for. Since blocks that don't point at the
+	// latest tips are still valid, shares of older jobs are accepted too
+	// as long as they're kept.
+	maxJobsPerClient = 16
+
+	// maxRequestLength is the maximum length of a request line.
+	maxRequestLength = 4096
+
+	// clientIdleTimeout is how long a connection may go without sending
+	// a request before it's closed.
+	clientIdleTimeout = 10 * time.Minute
+
+	// writeTimeout is how long writing a message to a connection may take.
+	writeTimeout = 10 * time.Second
+)
+
+// client is a connection of a worker to the server.
+type client struct {
+	server     *Server
+	conn       net.Conn
+	extraNonce uint64
+
+	writeLock sync.Mutex
+
+	lock           sync.Mutex
+	subscribed     bool
+	authorized     bool
+	workerName     string
+	varDiff        *varDiff
+	sentDifficulty float64
+	jobs           map[string]*job
+	jobIDs         []string
+	nextJobID      uint64
+	acceptedShares uint64
+	rejectedShares uint64
+}
+
+func newClient(server *Server, conn net.Conn, extraNonce uint64) *client {
+	return &client{
+		server:     server,
+		conn:       conn,
+		extraNonce: extraNonce,
+		workerName: conn.RemoteAddr().String(),
+		varDiff:    newVarDiff(server.cfg.StratumDifficulty, server.cfg.StratumShareTime, time.Now()),
+		jobs:       make(map[string]*job),
+	}
+}
+
+// handle reads and handles the requests of the client until the connection
+// is closed.
+func (c *client) handle() {
+	defer c.server.removeClient(c)
+	defer c.conn.Close()
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, maxRequestLength), maxRequestLength)
+	for {
+		err := c.conn.SetReadDeadline(time.Now().Add(clientIdleTimeout))
+		if err != nil {
+			log.Debugf("Error setting the read deadline of %s: %s", c.conn.RemoteAddr(), err)
+			return
+		}
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				log.Debugf("Error reading from %s: %s", c.conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		var req request
+		err = json.Unmarshal(scanner.Bytes(), &req)
+		if err != nil {
+			log.Debugf("Malformed request from %s: %s", c.conn.RemoteAddr(), err)
+			return
+		}
+		result, stratumErr := c.handleRequest(&req)
+		err = c.send(&response{ID: req.ID, Result: result, Error: stratumErr})
+		if err != nil {
+			log.Debugf("Error writing to %s: %s", c.conn.RemoteAddr(), err)
+			return
+		}
+
+		// Newly authorized workers start working on the current
+		// template right away.
+		if req.Method == "mining.authorize" && stratumErr == nil {
+			err := c.server.sendCurrentJob(c)
+			if err != nil {
+				log.Debugf("Error sending a job to %s: %s", c.conn.RemoteAddr(), err)
+				return
+			}
+		}
+	}
+}
+
+// handleRequest handles a request and returns its result.
+func (c *client) handleRequest(req *request) (interface{}, *stratumError) {
+	switch req.Method {
+	case "mining.subscribe":
+		return c.handleSubscribe(req)
+	case "mining.authorize":
+		return c.handleAuthorize(req)
+	case "mining.submit":
+		return c.handleSubmit(req)
+	case "mining.extranonce.subscribe":
+		// The extra nonce of a connection never changes, so
+		// there's nothing to subscribe to.
+		return true, nil
+	default:
+		return nil, newError(errCodeOther, fmt.Sprintf("Unknown method %s", req.Method))
+	}
+}
+
+func (c *client) handleSubscribe(req *request) (interface{}, *stratumError) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.subscribed = true
+	subscriptionID := fmt.Sprintf("%016x", c.extraNonce)
+	subscriptions := [][]string{
+		{"mining.set_difficulty", subscriptionID},
+		{"mining.notify", subscriptionID},
+	}
+	return []interface{}{subscriptions, subscriptionID, 0}, nil
+}
+
+func (c *client) handleAuthorize(req *request) (interface{}, *stratumError) {
+	var workerName string
+	if err := req.parseParams(&workerName); err != nil {
+		return nil, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.subscribed {
+		return nil, newError(errCodeNotSubscribed, "Not subscribed")
+	}
+	c.authorized = true
+	if workerName != "" {
+		c.workerName = workerName
+	}
+	log.Infof("Worker %s connected from %s", c.workerName, c.conn.RemoteAddr())
+	return true, nil
+}
+
+func (c *client) handleSubmit(req *request) (interface{}, *stratumError) {
+	var workerName, jobID, nonceStr string
+	if err := req.parseParams(&workerName, &jobID, &nonceStr); err != nil {
+		return nil, err
+	}
+	nonce, err := strconv.ParseUint(strings.TrimPrefix(nonceStr, "0x"), 16, 64)
+	if err != nil {
+		return nil, newError(errCodeOther, fmt.Sprintf("Invalid nonce %s", nonceStr))
+	}
+
+	c.lock.Lock()
+	if !c.authorized {
+		c.lock.Unlock()
+		return nil, newError(errCodeUnauthorized, "Unauthorized worker")
+	}
+	j, exists := c.jobs[jobID]
+	if !exists {
+		c.rejectedShares++
+		c.lock.Unlock()
+		return nil, newError(errCodeJobNotFound, "Job not found")
+	}
+	block, stratumErr := j.checkShare(nonce)
+	if stratumErr != nil {
+		c.rejectedShares++
+		c.lock.Unlock()
+		log.Debugf("Rejected share of worker %s for job %s: %s", c.workerName, jobID, stratumErr)
+		return nil, stratumErr
+	}
+	c.acceptedShares++
+	c.varDiff.addShare(time.Now())
+	log.Debugf("Accepted share of worker %s for job %s (%d accepted, %d rejected)",
+		c.workerName, jobID, c.acceptedShares, c.rejectedShares)
+	workerName = c.workerName
+	c.lock.Unlock()
+
+	if block != nil {
+		c.server.submitBlock(block, workerName)
+	}
+	return true, nil
+}
+
+// sendJob sends a new job for the passed block template to the client, if
+// it's authorized and its latest job isn't already for that template. The
+// job is preceded by the current share difficulty of the client if it
+// changed since the previous job.
+func (c *client) sendJob(template *domainmessage.MsgBlock, cleanJobs bool) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if !c.authorized {
+		return nil
+	}
+	if len(c.jobIDs) > 0 && c.jobs[c.jobIDs[len(c.jobIDs)-1]].template == template {
+		return nil
+	}
+
+	c.varDiff.retarget(time.Now())
+	difficulty := c.varDiff.difficulty
+	c.nextJobID++
+	j, err := newJob(strconv.FormatUint(c.nextJobID, 16), template, c.extraNonce, difficulty)
+	if err != nil {
+		return err
+	}
+	serializedHeader, err := j.serializedHeader()
+	if err != nil {
+		return err
+	}
+
+	c.jobs[j.id] = j
+	c.jobIDs = append(c.jobIDs, j.id)
+	if len(c.jobIDs) > maxJobsPerClient {
+		delete(c.jobs, c.jobIDs[0])
+		c.jobIDs = c.jobIDs[1:]
+	}
+
+	if difficulty != c.sentDifficulty {
+		err := c.send(&notification{Method: "mining.set_difficulty", Params: []interface{}{difficulty}})
+		if err != nil {
+			return err
+		}
+		c.sentDifficulty = difficulty
+		log.Debugf("Set the share difficulty of worker %s to %g", c.workerName, difficulty)
+	}
+	return c.send(&notification{
+		Method: "mining.notify",
+		Params: []interface{}{j.id, hex.EncodeToString(serializedHeader), cleanJobs},
+	})
+}
+
+// send writes a message to the client as a line of JSON.
+func (c *client) send(message interface{}) error {
+	serializedMessage, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	serializedMessage = append(serializedMessage, '\n')
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	err = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err != nil {
+		return err
+	}
+	_, err = c.conn.Write(serializedMessage)
+	return err
+}
+
+func (c *client) isAuthorized() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.authorized
+}
@@ -0,0 +1,40 @@
+/*
+Package stratum implements a Stratum mining server that lets external mining
+hardware mine blocks on the node directly, without going through the
+getBlockTemplate and submitBlock RPCs.
+
+The server speaks Stratum v1 (line-delimited JSON-RPC over TCP) with a job
+format adapted to Kaspa blocks:
+
+	mining.subscribe -> [[["mining.set_difficulty", <id>], ["mining.notify", <id>]], <extranonce>, 0]
+
+Every connection is assigned an 8-byte extra nonce, returned as a hex string,
+which the server writes into the payload of the coinbase transaction of the
+jobs it sends to that connection. Since the server rolls the extra nonce
+itself, the size of the miner's extra nonce is 0.
+
+	mining.authorize [<worker name>, <password>] -> true
+
+The worker name is only used to identify the worker in the logs, and the
+password is ignored. All the blocks mined through the server pay to the
+address given by --stratumpayaddr.
+
+	mining.set_difficulty [<difficulty>]
+	mining.notify [<job id>, <header>, <clean jobs>]
+
+The header is the hex-encoded serialized block header of the job without its
+trailing 8-byte nonce. To try a nonce, the miner appends it to the header as
+a little-endian uint64 and double-SHA256 hashes the result. A share is valid
+if the hash, read as a little-endian number, is at most the share target,
+which is 0xffff * 2^208 divided by the difficulty that was set before the
+job was sent. A new difficulty only applies to the jobs sent after it.
+
+	mining.submit [<worker name>, <job id>, <nonce>] -> true
+
+The nonce is a hex-encoded uint64. Shares whose hash also meets the target
+of the block are submitted to the DAG as blocks.
+
+The share difficulty of every connection is adjusted (vardiff) so that it
+submits a share every --stratumsharetime on average.
+*/
+package stratum
@@ -0,0 +1,123 @@
+package stratum
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/mining"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/coinbasepayload"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/pkg/errors"
+)
+
+// nonceLength is the length of the nonce at the end of a serialized block
+// header.
+const nonceLength = 8
+
+// job is a block template that was sent to a connection with the extra
+// nonce of the connection in its coinbase, along with the share target the
+// connection was asked to meet when it was sent.
+type job struct {
+	id          string
+	template    *domainmessage.MsgBlock
+	block       *domainmessage.MsgBlock
+	shareTarget *big.Int
+
+	// nonces are the nonces of the shares that were already submitted
+	// for the job
+	nonces map[uint64]struct{}
+}
+
+// newJob returns a new job with the given ID for the passed block template,
+// with the passed extra nonce in its coinbase.
+func newJob(id string, template *domainmessage.MsgBlock, extraNonce uint64, difficulty float64) (*job, error) {
+	block, err := blockWithExtraNonce(template, extraNonce)
+	if err != nil {
+		return nil, err
+	}
+	return &job{
+		id:          id,
+		template:    template,
+		block:       block,
+		shareTarget: difficultyToTarget(difficulty),
+		nonces:      make(map[uint64]struct{}),
+	}, nil
+}
+
+// blockWithExtraNonce returns a copy of the passed block template whose
+// coinbase payload has the passed extra nonce in its extra data, and whose
+// hash merkle root is updated accordingly. Only the coinbase transaction is
+// copied: the other transactions are shared with the template.
+func blockWithExtraNonce(template *domainmessage.MsgBlock, extraNonce uint64) (*domainmessage.MsgBlock, error) {
+	if len(template.Transactions) == 0 {
+		return nil, errors.New("block template has no coinbase transaction")
+	}
+	templateCoinbase := template.Transactions[util.CoinbaseTransactionIndex]
+	blueScore, scriptPubKey, _, err := coinbasepayload.DeserializeCoinbasePayload(templateCoinbase)
+	if err != nil {
+		return nil, err
+	}
+	extraData, err := blockdag.CoinbasePayloadExtraData(extraNonce, mining.CoinbaseFlags)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := coinbasepayload.SerializeCoinbasePayload(blueScore, scriptPubKey, extraData)
+	if err != nil {
+		return nil, err
+	}
+	coinbase := templateCoinbase.Copy()
+	coinbase.Payload = payload
+	coinbase.PayloadHash = daghash.DoubleHashP(payload)
+
+	block := &domainmessage.MsgBlock{
+		Header:       template.Header,
+		Transactions: make([]*domainmessage.MsgTx, len(template.Transactions)),
+	}
+	copy(block.Transactions, template.Transactions)
+	block.Transactions[util.CoinbaseTransactionIndex] = coinbase
+
+	hashMerkleTree := blockdag.BuildHashMerkleTreeStore(util.NewBlock(block).Transactions())
+	block.Header.HashMerkleRoot = hashMerkleTree.Root()
+	block.Header.Nonce = 0
+	return block, nil
+}
+
+// serializedHeader returns the serialized header of the job's block without
+// its nonce, as it's sent to miners.
+func (j *job) serializedHeader() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, j.block.Header.SerializeSize()))
+	err := j.block.Header.Serialize(buf)
+	if err != nil {
+		return nil, err
+	}
+	serializedHeader := buf.Bytes()
+	return serializedHeader[:len(serializedHeader)-nonceLength], nil
+}
+
+// checkShare checks the share of the job with the passed nonce. It returns
+// the solved block if the share also meets the target of the block, or nil
+// if it only meets the share target.
+func (j *job) checkShare(nonce uint64) (*util.Block, *stratumError) {
+	if _, exists := j.nonces[nonce]; exists {
+		return nil, newError(errCodeDuplicateShare, "Duplicate share")
+	}
+
+	header := j.block.Header
+	header.Nonce = nonce
+	hashNum := daghash.HashToBig(header.BlockHash())
+	isBlock := hashNum.Cmp(util.CompactToBig(header.Bits)) <= 0
+	if !isBlock && hashNum.Cmp(j.shareTarget) > 0 {
+		return nil, newError(errCodeLowDifficultyShare, "Low difficulty share")
+	}
+	j.nonces[nonce] = struct{}{}
+
+	if !isBlock {
+		return nil, nil
+	}
+	block := *j.block
+	block.Header = header
+	return util.NewBlock(&block), nil
+}
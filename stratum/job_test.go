@@ -0,0 +1,188 @@
+package stratum
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/mining"
+	"github.com/kaspanet/kaspad/txscript"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/coinbasepayload"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/kaspanet/kaspad/util/subnetworkid"
+)
+
+// newTestTemplate returns a block template with a coinbase that pays to
+// OP_TRUE and one other transaction.
+func newTestTemplate(t *testing.T, bits uint32) *domainmessage.MsgBlock {
+	extraData, err := blockdag.CoinbasePayloadExtraData(0, mining.CoinbaseFlags)
+	if err != nil {
+		t.Fatalf("CoinbasePayloadExtraData: %s", err)
+	}
+	payload, err := coinbasepayload.SerializeCoinbasePayload(5, []byte{txscript.OpTrue}, extraData)
+	if err != nil {
+		t.Fatalf("SerializeCoinbasePayload: %s", err)
+	}
+	coinbase := domainmessage.NewSubnetworkMsgTx(domainmessage.TxVersion, []*domainmessage.TxIn{},
+		[]*domainmessage.TxOut{domainmessage.NewTxOut(1000, []byte{txscript.OpTrue})},
+		subnetworkid.SubnetworkIDCoinbase, 0, payload)
+	tx := domainmessage.NewNativeMsgTx(domainmessage.TxVersion,
+		[]*domainmessage.TxIn{domainmessage.NewTxIn(domainmessage.NewOutpoint(&daghash.TxID{1}, 0), nil)},
+		[]*domainmessage.TxOut{domainmessage.NewTxOut(500, []byte{txscript.OpTrue})})
+
+	block := &domainmessage.MsgBlock{
+		Header: *domainmessage.NewBlockHeader(1, []*daghash.Hash{{2}}, &daghash.Hash{},
+			&daghash.Hash{3}, &daghash.Hash{4}, bits, 0),
+		Transactions: []*domainmessage.MsgTx{coinbase, tx},
+	}
+	block.Header.HashMerkleRoot = blockdag.BuildHashMerkleTreeStore(util.NewBlock(block).Transactions()).Root()
+	return block
+}
+
+func TestBlockWithExtraNonce(t *testing.T) {
+	template := newTestTemplate(t, 0x207fffff)
+	templateCoinbaseHash := template.Transactions[0].TxHash()
+	templateMerkleRoot := template.Header.HashMerkleRoot
+
+	const extraNonce = 0x0123456789abcdef
+	block, err := blockWithExtraNonce(template, extraNonce)
+	if err != nil {
+		t.Fatalf("blockWithExtraNonce: %s", err)
+	}
+
+	blueScore, scriptPubKey, extraData, err := coinbasepayload.DeserializeCoinbasePayload(block.Transactions[0])
+	if err != nil {
+		t.Fatalf("DeserializeCoinbasePayload: %s", err)
+	}
+	expectedExtraData, err := blockdag.CoinbasePayloadExtraData(extraNonce, mining.CoinbaseFlags)
+	if err != nil {
+		t.Fatalf("CoinbasePayloadExtraData: %s", err)
+	}
+	if blueScore != 5 || !bytes.Equal(scriptPubKey, []byte{txscript.OpTrue}) ||
+		!bytes.Equal(extraData, expectedExtraData) {
+		t.Errorf("unexpected coinbase payload: blue score %d, script pub key %x, extra data %x",
+			blueScore, scriptPubKey, extraData)
+	}
+	if !block.Transactions[0].PayloadHash.IsEqual(daghash.DoubleHashP(block.Transactions[0].Payload)) {
+		t.Errorf("the payload hash of the coinbase doesn't match its payload")
+	}
+	expectedMerkleRoot := blockdag.BuildHashMerkleTreeStore(util.NewBlock(block).Transactions()).Root()
+	if !block.Header.HashMerkleRoot.IsEqual(expectedMerkleRoot) {
+		t.Errorf("expected hash merkle root %s but got %s", expectedMerkleRoot, block.Header.HashMerkleRoot)
+	}
+	if block.Header.HashMerkleRoot.IsEqual(templateMerkleRoot) {
+		t.Errorf("expected the hash merkle root to change with the extra nonce")
+	}
+	if block.Transactions[1] != template.Transactions[1] {
+		t.Errorf("expected the non-coinbase transactions to be shared with the template")
+	}
+
+	// The template itself must not change.
+	if !template.Transactions[0].TxHash().IsEqual(templateCoinbaseHash) ||
+		!template.Header.HashMerkleRoot.IsEqual(templateMerkleRoot) {
+		t.Errorf("blockWithExtraNonce modified the template")
+	}
+
+	// Different extra nonces give different work.
+	otherBlock, err := blockWithExtraNonce(template, extraNonce+1)
+	if err != nil {
+		t.Fatalf("blockWithExtraNonce: %s", err)
+	}
+	if otherBlock.Header.HashMerkleRoot.IsEqual(block.Header.HashMerkleRoot) {
+		t.Errorf("expected different extra nonces to give different hash merkle roots")
+	}
+}
+
+func TestSerializedHeader(t *testing.T) {
+	j, err := newJob("1", newTestTemplate(t, 0x207fffff), 1, 1)
+	if err != nil {
+		t.Fatalf("newJob: %s", err)
+	}
+	serializedHeader, err := j.serializedHeader()
+	if err != nil {
+		t.Fatalf("serializedHeader: %s", err)
+	}
+
+	// Appending a nonce to the serialized header and hashing it must give
+	// the hash of the block with that nonce.
+	const nonce = 0x1122334455667788
+	header := j.block.Header
+	header.Nonce = nonce
+	nonceBytes := []byte{0x88, 0x77, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11}
+	hash := daghash.DoubleHashP(append(serializedHeader, nonceBytes...))
+	if !hash.IsEqual(header.BlockHash()) {
+		t.Errorf("expected hash %s but got %s", header.BlockHash(), hash)
+	}
+}
+
+// findNonce returns the first nonce for which the hash of the header
+// satisfies the passed condition.
+func findNonce(header domainmessage.BlockHeader, condition func(hash *daghash.Hash) bool) uint64 {
+	for nonce := uint64(0); ; nonce++ {
+		header.Nonce = nonce
+		if condition(header.BlockHash()) {
+			return nonce
+		}
+	}
+}
+
+func TestCheckShare(t *testing.T) {
+	// A hard block target, so that shares don't solve the block.
+	template := newTestTemplate(t, 0x1d00ffff)
+	j, err := newJob("1", template, 1, minDifficulty)
+	if err != nil {
+		t.Fatalf("newJob: %s", err)
+	}
+
+	lowNonce := findNonce(j.block.Header, func(hash *daghash.Hash) bool {
+		return daghash.HashToBig(hash).Cmp(j.shareTarget) > 0
+	})
+	_, stratumErr := j.checkShare(lowNonce)
+	if stratumErr == nil || stratumErr.code != errCodeLowDifficultyShare {
+		t.Errorf("expected a low difficulty share error but got %v", stratumErr)
+	}
+
+	shareNonce := findNonce(j.block.Header, func(hash *daghash.Hash) bool {
+		return daghash.HashToBig(hash).Cmp(j.shareTarget) <= 0
+	})
+	block, stratumErr := j.checkShare(shareNonce)
+	if stratumErr != nil {
+		t.Fatalf("checkShare: unexpected error %s", stratumErr)
+	}
+	if block != nil {
+		t.Errorf("expected the share not to solve the block")
+	}
+	_, stratumErr = j.checkShare(shareNonce)
+	if stratumErr == nil || stratumErr.code != errCodeDuplicateShare {
+		t.Errorf("expected a duplicate share error but got %v", stratumErr)
+	}
+
+	// With an easy block target and a hard share target, a share that
+	// solves the block is accepted even if it doesn't meet the share
+	// target.
+	template = newTestTemplate(t, 0x207fffff)
+	j, err = newJob("2", template, 1, 1<<32)
+	if err != nil {
+		t.Fatalf("newJob: %s", err)
+	}
+	blockTarget := util.CompactToBig(template.Header.Bits)
+	blockNonce := findNonce(j.block.Header, func(hash *daghash.Hash) bool {
+		return daghash.HashToBig(hash).Cmp(blockTarget) <= 0
+	})
+	block, stratumErr = j.checkShare(blockNonce)
+	if stratumErr != nil {
+		t.Fatalf("checkShare: unexpected error %s", stratumErr)
+	}
+	if block == nil {
+		t.Fatalf("expected the share to solve the block")
+	}
+	if block.MsgBlock().Header.Nonce != blockNonce ||
+		daghash.HashToBig(block.Hash()).Cmp(blockTarget) > 0 {
+		t.Errorf("the returned block isn't solved")
+	}
+	if j.block.Header.Nonce != 0 {
+		t.Errorf("checkShare modified the block of the job")
+	}
+}
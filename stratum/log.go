@@ -0,0 +1,11 @@
+package stratum
+
+import (
+	"github.com/kaspanet/kaspad/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var (
+	log, _ = logger.Get(logger.SubsystemTags.STRM)
+	spawn  = panics.GoroutineWrapperFunc(log)
+)
@@ -0,0 +1,73 @@
+package stratum
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Stratum error codes
+const (
+	errCodeOther              = 20
+	errCodeJobNotFound        = 21
+	errCodeDuplicateShare     = 22
+	errCodeLowDifficultyShare = 23
+	errCodeUnauthorized       = 24
+	errCodeNotSubscribed      = 25
+)
+
+// stratumError is an error that's returned to a Stratum client. It's
+// encoded as [<code>, <message>, null].
+type stratumError struct {
+	code    int
+	message string
+}
+
+func newError(code int, message string) *stratumError {
+	return &stratumError{code: code, message: message}
+}
+
+func (e *stratumError) Error() string {
+	return fmt.Sprintf("%d: %s", e.code, e.message)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e *stratumError) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{e.code, e.message, nil})
+}
+
+// request is a request from a Stratum client.
+type request struct {
+	ID     interface{}       `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// response is the response of the server to a request.
+type response struct {
+	ID     interface{}   `json:"id"`
+	Result interface{}   `json:"result"`
+	Error  *stratumError `json:"error"`
+}
+
+// notification is a message the server sends to a client on its own
+// initiative. Its ID is always null.
+type notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// parseParams unmarshals the params of the request into the passed
+// pointers, in order. Missing params are left untouched.
+func (r *request) parseParams(params ...interface{}) *stratumError {
+	if len(r.Params) < len(params) {
+		params = params[:len(r.Params)]
+	}
+	for i, param := range params {
+		err := json.Unmarshal(r.Params[i], param)
+		if err != nil {
+			return newError(errCodeOther, fmt.Sprintf("Invalid parameter %d: %s", i, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package stratum
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/blockdag"
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/domainmessage"
+	"github.com/kaspanet/kaspad/mining"
+	"github.com/kaspanet/kaspad/protocol"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/daghash"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/kaspanet/kaspad/util/network"
+	"github.com/kaspanet/kaspad/util/random"
+	"github.com/pkg/errors"
+)
+
+const (
+	// templatePollInterval is how often the server checks whether the
+	// block template should be regenerated, in addition to whenever a
+	// block is added to the DAG.
+	templatePollInterval = time.Second
+
+	// templateRegenerateInterval is the time that must pass before a new
+	// block template is generated when the DAG tips haven't changed but
+	// the transactions in the mempool have.
+	templateRegenerateInterval = 30 * time.Second
+)
+
+// Server is a Stratum mining server. It sends jobs built from block
+// templates to the workers that connect to it, validates the shares they
+// submit, and submits the shares that solve a block to the DAG.
+type Server struct {
+	cfg             *config.Config
+	dag             *blockdag.BlockDAG
+	generator       *mining.BlkTmplGenerator
+	protocolManager *protocol.Manager
+	listeners       []net.Listener
+
+	nextExtraNonce uint64
+	templateUpdate chan struct{}
+
+	// The following fields are only accessed by templateLoop
+	tipHashes     []*daghash.Hash
+	lastTxUpdate  mstime.Time
+	lastGenerated mstime.Time
+
+	templateLock sync.RWMutex
+	template     *domainmessage.MsgBlock
+
+	clientsLock sync.Mutex
+	clients     map[*client]struct{}
+
+	started, shutdown int32
+	quit              chan struct{}
+	wg                sync.WaitGroup
+}
+
+// NewServer returns a new Stratum server that listens on the addresses
+// given by --stratumlisten.
+func NewServer(cfg *config.Config, dag *blockdag.BlockDAG, generator *mining.BlkTmplGenerator,
+	protocolManager *protocol.Manager) (*Server, error) {
+
+	netAddrs, err := network.ParseListeners(cfg.StratumListeners)
+	if err != nil {
+		return nil, err
+	}
+	listeners := make([]net.Listener, 0, len(netAddrs))
+	for _, addr := range netAddrs {
+		listener, err := net.Listen(addr.Network(), addr.String())
+		if err != nil {
+			log.Warnf("Can't listen on %s: %s", addr, err)
+			continue
+		}
+		listeners = append(listeners, listener)
+	}
+	if len(listeners) == 0 {
+		return nil, errors.New("STRM: No valid listen address")
+	}
+
+	// Extra nonces are assigned to connections sequentially, starting
+	// from a random one so that they don't repeat across restarts.
+	firstExtraNonce, err := random.Uint64()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		cfg:             cfg,
+		dag:             dag,
+		generator:       generator,
+		protocolManager: protocolManager,
+		listeners:       listeners,
+		nextExtraNonce:  firstExtraNonce,
+		templateUpdate:  make(chan struct{}, 1),
+		clients:         make(map[*client]struct{}),
+		quit:            make(chan struct{}),
+	}
+	dag.Subscribe(s.handleBlockDAGNotification)
+	return s, nil
+}
+
+// Start starts accepting connections and sending jobs to workers.
+func (s *Server) Start() {
+	if atomic.AddInt32(&s.started, 1) != 1 {
+		return
+	}
+
+	log.Trace("Starting Stratum server")
+	for _, listener := range s.listeners {
+		s.wg.Add(1)
+		listener := listener
+		spawn("Server.Start-acceptConnections", func() {
+			defer s.wg.Done()
+			s.acceptConnections(listener)
+		})
+	}
+	s.wg.Add(1)
+	spawn("Server.Start-templateLoop", func() {
+		defer s.wg.Done()
+		s.templateLoop()
+	})
+}
+
+// Stop closes the listeners and the connections of the server, and waits
+// for it to stop.
+func (s *Server) Stop() error {
+	if atomic.AddInt32(&s.shutdown, 1) != 1 {
+		log.Infof("Stratum server is already in the process of shutting down")
+		return nil
+	}
+	log.Warnf("Stratum server shutting down")
+
+	close(s.quit)
+	for _, listener := range s.listeners {
+		err := listener.Close()
+		if err != nil {
+			log.Errorf("Problem shutting down the Stratum server: %s", err)
+			return err
+		}
+	}
+	s.clientsLock.Lock()
+	for c := range s.clients {
+		c.conn.Close()
+	}
+	s.clientsLock.Unlock()
+
+	s.wg.Wait()
+	log.Infof("Stratum server shutdown complete")
+	return nil
+}
+
+func (s *Server) acceptConnections(listener net.Listener) {
+	log.Infof("Stratum server listening on %s", listener.Addr())
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.quit:
+			default:
+				log.Errorf("Error accepting Stratum connections on %s: %s", listener.Addr(), err)
+			}
+			return
+		}
+
+		c := newClient(s, conn, atomic.AddUint64(&s.nextExtraNonce, 1))
+		s.clientsLock.Lock()
+		s.clients[c] = struct{}{}
+		s.clientsLock.Unlock()
+
+		log.Debugf("New Stratum connection from %s", conn.RemoteAddr())
+		s.wg.Add(1)
+		spawn("Server.acceptConnections-handle", func() {
+			defer s.wg.Done()
+			c.handle()
+		})
+	}
+}
+
+func (s *Server) removeClient(c *client) {
+	s.clientsLock.Lock()
+	defer s.clientsLock.Unlock()
+	delete(s.clients, c)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.authorized {
+		log.Infof("Worker %s disconnected (%d accepted shares, %d rejected shares)",
+			c.workerName, c.acceptedShares, c.rejectedShares)
+	}
+}
+
+// authorizedClients returns the connections whose workers are authorized.
+func (s *Server) authorizedClients() []*client {
+	s.clientsLock.Lock()
+	defer s.clientsLock.Unlock()
+
+	clients := make([]*client, 0, len(s.clients))
+	for c := range s.clients {
+		if c.isAuthorized() {
+			clients = append(clients, c)
+		}
+	}
+	return clients
+}
+
+// handleBlockDAGNotification requests a new block template whenever a
+// block is added to the DAG.
+func (s *Server) handleBlockDAGNotification(notification *blockdag.Notification) {
+	if notification.Type == blockdag.NTBlockAdded {
+		s.requestTemplateUpdate()
+	}
+}
+
+// requestTemplateUpdate makes templateLoop check whether the block template
+// should be regenerated without waiting for templatePollInterval.
+func (s *Server) requestTemplateUpdate() {
+	select {
+	case s.templateUpdate <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Server) templateLoop() {
+	ticker := time.NewTicker(templatePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-s.templateUpdate:
+		case <-ticker.C:
+		}
+		err := s.updateTemplate()
+		if err != nil {
+			log.Errorf("Error updating the block template: %s", err)
+		}
+	}
+}
+
+// updateTemplate generates a new block template and sends jobs for it to
+// all the authorized workers when the DAG tips have changed, or when the
+// transactions in the mempool have changed and it has been at least
+// templateRegenerateInterval since the last template was generated. No
+// templates are generated while no workers are connected.
+func (s *Server) updateTemplate() error {
+	clients := s.authorizedClients()
+	if len(clients) == 0 {
+		s.tipHashes = nil
+		s.setTemplate(nil)
+		return nil
+	}
+
+	tipHashes := s.dag.TipHashes()
+	lastTxUpdate := s.generator.TxSource().LastUpdated()
+	tipsChanged := s.tipHashes == nil || !daghash.AreEqual(s.tipHashes, tipHashes)
+	if !tipsChanged && (lastTxUpdate == s.lastTxUpdate ||
+		mstime.Now().Before(s.lastGenerated.Add(templateRegenerateInterval))) {
+
+		return nil
+	}
+
+	// The extra nonce of the template is replaced with the extra nonce
+	// of each connection when its job is built.
+	template, err := s.generator.NewBlockTemplate(s.cfg.StratumPayAddress, 0)
+	if err != nil {
+		s.tipHashes = nil
+		return errors.Wrap(err, "failed to create new block template")
+	}
+	s.tipHashes = tipHashes
+	s.lastTxUpdate = lastTxUpdate
+	s.lastGenerated = mstime.Now()
+	s.setTemplate(template.Block)
+
+	log.Debugf("Sending jobs for a new block template with %d transactions to %d workers",
+		len(template.Block.Transactions), len(clients))
+	for _, c := range clients {
+		err := c.sendJob(template.Block, tipsChanged)
+		if err != nil {
+			log.Debugf("Error sending a job to %s: %s", c.conn.RemoteAddr(), err)
+			c.conn.Close()
+		}
+	}
+	return nil
+}
+
+func (s *Server) setTemplate(template *domainmessage.MsgBlock) {
+	s.templateLock.Lock()
+	defer s.templateLock.Unlock()
+	s.template = template
+}
+
+// sendCurrentJob sends a job for the current block template to the passed
+// client, or requests a new template if there's none.
+func (s *Server) sendCurrentJob(c *client) error {
+	s.templateLock.RLock()
+	template := s.template
+	s.templateLock.RUnlock()
+
+	if template == nil {
+		s.requestTemplateUpdate()
+		return nil
+	}
+	return c.sendJob(template, true)
+}
+
+// submitBlock submits a block that was found by the passed worker to the
+// DAG and relays it.
+func (s *Server) submitBlock(block *util.Block, workerName string) {
+	err := s.protocolManager.AddBlock(block, blockdag.BFDisallowDelay|blockdag.BFDisallowOrphans)
+	if err != nil {
+		log.Warnf("Block %s found by worker %s was rejected: %s", block.Hash(), workerName, err)
+		return
+	}
+	log.Infof("Accepted block %s found by worker %s", block.Hash(), workerName)
+}
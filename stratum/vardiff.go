@@ -0,0 +1,90 @@
+package stratum
+
+import (
+	"math"
+	"math/big"
+	"time"
+)
+
+const (
+	// minDifficulty is the lowest share difficulty a connection can be
+	// set to, which is about 65536 hashes per share.
+	minDifficulty = 1.0 / (1 << 16)
+
+	// varDiffRetargetShares is the number of shares after which the
+	// share difficulty of a connection is retargeted. It's also
+	// retargeted if it doesn't submit that many shares within
+	// varDiffRetargetShares times the target share time.
+	varDiffRetargetShares = 10
+
+	// varDiffMaxChange is the largest factor the share difficulty can be
+	// multiplied or divided by in a single retarget.
+	varDiffMaxChange = 4
+
+	// varDiffTolerance is the smallest relative change of the share
+	// difficulty that is applied, so that connections with a steady hash
+	// rate aren't sent a new difficulty on every retarget.
+	varDiffTolerance = 0.1
+)
+
+// diff1Target is the share target of difficulty 1, as in Bitcoin's Stratum.
+var diff1Target = new(big.Int).Lsh(big.NewInt(0xffff), 208)
+
+// difficultyToTarget returns the share target of the passed difficulty.
+func difficultyToTarget(difficulty float64) *big.Int {
+	target, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(diff1Target), big.NewFloat(difficulty)).Int(nil)
+	return target
+}
+
+// varDiff adjusts the share difficulty of a connection so that it submits
+// a share every targetShareTime on average.
+type varDiff struct {
+	difficulty      float64
+	targetShareTime time.Duration
+
+	windowStart  time.Time
+	windowShares int
+}
+
+func newVarDiff(difficulty float64, targetShareTime time.Duration, now time.Time) *varDiff {
+	return &varDiff{
+		difficulty:      math.Max(difficulty, minDifficulty),
+		targetShareTime: targetShareTime,
+		windowStart:     now,
+	}
+}
+
+// addShare records a share that was submitted at the passed time, and
+// retargets the share difficulty if needed. It returns whether the share
+// difficulty changed.
+func (v *varDiff) addShare(now time.Time) bool {
+	v.windowShares++
+	return v.retarget(now)
+}
+
+// retarget adjusts the share difficulty to the share rate since the last
+// retarget, once enough shares were submitted or enough time has passed.
+// It returns whether the share difficulty changed.
+func (v *varDiff) retarget(now time.Time) bool {
+	elapsed := now.Sub(v.windowStart)
+	if v.windowShares < varDiffRetargetShares && elapsed < varDiffRetargetShares*v.targetShareTime {
+		return false
+	}
+
+	factor := float64(varDiffMaxChange)
+	if elapsed > 0 {
+		factor = float64(v.windowShares) * float64(v.targetShareTime) / float64(elapsed)
+	}
+	factor = math.Max(math.Min(factor, varDiffMaxChange), 1.0/varDiffMaxChange)
+	newDifficulty := math.Max(v.difficulty*factor, minDifficulty)
+
+	v.windowStart = now
+	v.windowShares = 0
+
+	if math.Abs(newDifficulty/v.difficulty-1) < varDiffTolerance {
+		return false
+	}
+	v.difficulty = newDifficulty
+	return true
+}
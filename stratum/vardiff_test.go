@@ -0,0 +1,104 @@
+package stratum
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVarDiff(t *testing.T) {
+	const targetShareTime = 10 * time.Second
+	start := time.Unix(1000, 0)
+
+	tests := []struct {
+		name               string
+		shares             int
+		elapsed            time.Duration
+		expectedChanged    bool
+		expectedDifficulty float64
+	}{
+		{
+			name:               "shares at the target rate",
+			shares:             varDiffRetargetShares,
+			elapsed:            varDiffRetargetShares * targetShareTime,
+			expectedChanged:    false,
+			expectedDifficulty: 8,
+		},
+		{
+			name:               "shares twice as fast as the target rate",
+			shares:             varDiffRetargetShares,
+			elapsed:            varDiffRetargetShares * targetShareTime / 2,
+			expectedChanged:    true,
+			expectedDifficulty: 16,
+		},
+		{
+			name:               "shares much faster than the target rate",
+			shares:             varDiffRetargetShares,
+			elapsed:            time.Second,
+			expectedChanged:    true,
+			expectedDifficulty: 8 * varDiffMaxChange,
+		},
+		{
+			name:               "shares at half the target rate",
+			shares:             varDiffRetargetShares / 2,
+			elapsed:            varDiffRetargetShares * targetShareTime,
+			expectedChanged:    true,
+			expectedDifficulty: 4,
+		},
+		{
+			name:               "no shares",
+			shares:             0,
+			elapsed:            varDiffRetargetShares * targetShareTime,
+			expectedChanged:    true,
+			expectedDifficulty: 8 / varDiffMaxChange,
+		},
+		{
+			name:               "not enough shares or time to retarget",
+			shares:             varDiffRetargetShares - 1,
+			elapsed:            time.Second,
+			expectedChanged:    false,
+			expectedDifficulty: 8,
+		},
+	}
+
+	for _, test := range tests {
+		v := newVarDiff(8, targetShareTime, start)
+		changed := false
+		for i := 0; i < test.shares; i++ {
+			shareTime := start.Add(test.elapsed * time.Duration(i+1) / time.Duration(test.shares))
+			changed = v.addShare(shareTime) || changed
+		}
+		if test.shares == 0 {
+			changed = v.retarget(start.Add(test.elapsed))
+		}
+		if changed != test.expectedChanged {
+			t.Errorf("%s: expected changed to be %t but got %t", test.name, test.expectedChanged, changed)
+		}
+		if v.difficulty != test.expectedDifficulty {
+			t.Errorf("%s: expected difficulty %g but got %g", test.name, test.expectedDifficulty, v.difficulty)
+		}
+	}
+}
+
+func TestVarDiffMinDifficulty(t *testing.T) {
+	start := time.Unix(1000, 0)
+	v := newVarDiff(minDifficulty*2, time.Second, start)
+	if !v.retarget(start.Add(varDiffRetargetShares * time.Second)) {
+		t.Fatalf("expected the difficulty to change")
+	}
+	if v.difficulty != minDifficulty {
+		t.Errorf("expected the difficulty to be clamped to %g but got %g", minDifficulty, v.difficulty)
+	}
+	if v.retarget(start.Add(2 * varDiffRetargetShares * time.Second)) {
+		t.Errorf("expected the difficulty not to go below %g", minDifficulty)
+	}
+}
+
+func TestDifficultyToTarget(t *testing.T) {
+	if difficultyToTarget(1).Cmp(diff1Target) != 0 {
+		t.Errorf("expected the target of difficulty 1 to be %x", diff1Target)
+	}
+	doubled := difficultyToTarget(0.5)
+	if doubled.Rsh(doubled, 1).Cmp(diff1Target) != 0 {
+		t.Errorf("expected the target of difficulty 0.5 to be twice that of difficulty 1")
+	}
+}
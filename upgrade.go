@@ -4,8 +4,15 @@
 
 package main
 
+import (
+	"github.com/kaspanet/kaspad/config"
+	"github.com/kaspanet/kaspad/dbaccess"
+)
+
 // doUpgrades performs upgrades to kaspad as new versions require it.
-// currently it's a placeholder we got from kaspad upstream, that does nothing
-func doUpgrades() error {
-	return nil
+// Currently, these are the migrations of the database schema. If
+// --dry-run-migration is set, the migrations are rolled back once
+// they're done.
+func doUpgrades(cfg *config.Config, databaseContext *dbaccess.DatabaseContext) error {
+	return databaseContext.MigrateSchema(cfg.DryRunMigration)
 }
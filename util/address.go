@@ -5,6 +5,8 @@
 package util
 
 import (
+	"sync"
+
 	"github.com/pkg/errors"
 
 	"github.com/kaspanet/kaspad/util/bech32"
@@ -57,6 +59,7 @@ const (
 )
 
 // Map from strings to Bech32 address prefix constants for parsing purposes.
+// Prefixes of custom networks are added to it by RegisterBech32Prefix.
 var stringsToBech32Prefixes = map[string]Bech32Prefix{
 	"kaspa":     Bech32PrefixKaspa,
 	"kaspadev":  Bech32PrefixKaspaDev,
@@ -65,8 +68,64 @@ var stringsToBech32Prefixes = map[string]Bech32Prefix{
 	"kaspasim":  Bech32PrefixKaspaSim,
 }
 
+// nextCustomBech32Prefix is the Bech32Prefix that is assigned to the next
+// prefix registered by RegisterBech32Prefix.
+var nextCustomBech32Prefix = Bech32PrefixKaspaSim + 1
+
+// customBech32PrefixNetworks maps the prefixes registered by
+// RegisterBech32Prefix to the names of the networks that registered them.
+var customBech32PrefixNetworks = make(map[string]string)
+
+// bech32PrefixesLock protects stringsToBech32Prefixes,
+// nextCustomBech32Prefix and customBech32PrefixNetworks.
+var bech32PrefixesLock sync.RWMutex
+
+// RegisterBech32Prefix registers the human-readable prefix of the addresses
+// of the custom network with the given name, and returns the Bech32Prefix
+// assigned to it, so that different networks can't use the same prefix.
+// Registering a prefix again under the same network name returns its
+// existing Bech32Prefix, and registering the prefix of a default network
+// or registering a prefix under a different network name returns an error.
+func RegisterBech32Prefix(prefixString string, networkName string) (Bech32Prefix, error) {
+	if prefixString == "" {
+		return Bech32PrefixUnknown, errors.New("the prefix must not be empty")
+	}
+	for i := 0; i < len(prefixString); i++ {
+		// The prefix must be in the lowercase form of the characters
+		// allowed in a Bech32 string, and mustn't contain the
+		// separator.
+		c := prefixString[i]
+		if c < 33 || c > 126 || (c >= 'A' && c <= 'Z') || c == ':' {
+			return Bech32PrefixUnknown, errors.Errorf("invalid character in prefix %s: '%c'", prefixString, c)
+		}
+	}
+
+	bech32PrefixesLock.Lock()
+	defer bech32PrefixesLock.Unlock()
+
+	if prefix, ok := stringsToBech32Prefixes[prefixString]; ok {
+		existingNetworkName, isCustom := customBech32PrefixNetworks[prefixString]
+		if !isCustom {
+			return Bech32PrefixUnknown, errors.Errorf("address prefix %s is used by a default network", prefixString)
+		}
+		if existingNetworkName != networkName {
+			return Bech32PrefixUnknown, errors.Errorf("address prefix %s is already used by %s",
+				prefixString, existingNetworkName)
+		}
+		return prefix, nil
+	}
+	prefix := nextCustomBech32Prefix
+	stringsToBech32Prefixes[prefixString] = prefix
+	customBech32PrefixNetworks[prefixString] = networkName
+	nextCustomBech32Prefix++
+	return prefix, nil
+}
+
 // ParsePrefix attempts to parse a Bech32 address prefix.
 func ParsePrefix(prefixString string) (Bech32Prefix, error) {
+	bech32PrefixesLock.RLock()
+	defer bech32PrefixesLock.RUnlock()
+
 	prefix, ok := stringsToBech32Prefixes[prefixString]
 	if !ok {
 		return Bech32PrefixUnknown, errors.Errorf("could not parse prefix %s", prefixString)
@@ -77,6 +136,9 @@ func ParsePrefix(prefixString string) (Bech32Prefix, error) {
 
 // Converts from Bech32 address prefixes to their string values
 func (prefix Bech32Prefix) String() string {
+	bech32PrefixesLock.RLock()
+	defer bech32PrefixesLock.RUnlock()
+
 	for key, value := range stringsToBech32Prefixes {
 		if prefix == value {
 			return key
@@ -403,3 +403,73 @@ func TestPrefixToString(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterBech32Prefix(t *testing.T) {
+	prefix, err := util.RegisterBech32Prefix("kaspapriv", "privnet")
+	if err != nil {
+		t.Fatalf("RegisterBech32Prefix: %s", err)
+	}
+	if prefix == util.Bech32PrefixUnknown || prefix == util.Bech32PrefixKaspaSim {
+		t.Fatalf("RegisterBech32Prefix: unexpected prefix %d", prefix)
+	}
+	if prefix.String() != "kaspapriv" {
+		t.Errorf("expected the prefix string to be kaspapriv but got %s", prefix)
+	}
+	parsedPrefix, err := util.ParsePrefix("kaspapriv")
+	if err != nil {
+		t.Fatalf("ParsePrefix: %s", err)
+	}
+	if parsedPrefix != prefix {
+		t.Errorf("ParsePrefix: expected %d but got %d", prefix, parsedPrefix)
+	}
+
+	// Registering a prefix again under the same network name returns the
+	// same Bech32Prefix.
+	prefixAgain, err := util.RegisterBech32Prefix("kaspapriv", "privnet")
+	if err != nil {
+		t.Fatalf("RegisterBech32Prefix: %s", err)
+	}
+	if prefixAgain != prefix {
+		t.Errorf("expected registering a prefix again to return %d but got %d", prefix, prefixAgain)
+	}
+
+	// The prefix of another network can't be registered.
+	_, err = util.RegisterBech32Prefix("kaspapriv", "otherprivnet")
+	if err == nil {
+		t.Errorf("RegisterBech32Prefix: expected an error when registering a used prefix under a different name")
+	}
+	for _, defaultPrefix := range []string{"kaspa", "kaspadev", "kaspareg", "kaspatest", "kaspasim"} {
+		_, err := util.RegisterBech32Prefix(defaultPrefix, "privnet")
+		if err == nil {
+			t.Errorf("RegisterBech32Prefix: expected an error when registering the prefix %s of a default network",
+				defaultPrefix)
+		}
+	}
+
+	// Addresses of the custom prefix can be encoded and decoded.
+	address, err := util.NewAddressPubKeyHash(make([]byte, ripemd160.Size), prefix)
+	if err != nil {
+		t.Fatalf("NewAddressPubKeyHash: %s", err)
+	}
+	if !strings.HasPrefix(address.EncodeAddress(), "kaspapriv:") {
+		t.Errorf("unexpected encoded address %s", address.EncodeAddress())
+	}
+	decoded, err := util.DecodeAddress(address.EncodeAddress(), prefix)
+	if err != nil {
+		t.Fatalf("DecodeAddress: %s", err)
+	}
+	if !reflect.DeepEqual(decoded, address) {
+		t.Errorf("expected the decoded address to be %s but got %s", address, decoded)
+	}
+	_, err = util.DecodeAddress(address.EncodeAddress(), util.Bech32PrefixKaspaSim)
+	if err == nil {
+		t.Errorf("DecodeAddress: expected an error when decoding an address of another network")
+	}
+
+	for _, invalidPrefix := range []string{"", "Kaspa", "kas:pa", "kas pa"} {
+		_, err := util.RegisterBech32Prefix(invalidPrefix, "invalidnet")
+		if err == nil {
+			t.Errorf("RegisterBech32Prefix: expected an error for the prefix %q", invalidPrefix)
+		}
+	}
+}